@@ -106,6 +106,11 @@ func main() {
 	userHandler := handlers.NewUserHandler(db, cfg)
 	dogHandler := handlers.NewDogHandler(db, cfg)
 	bookingHandler := handlers.NewBookingHandler(db, cfg)
+	bookingSeriesHandler := handlers.NewBookingSeriesHandler(db, cfg)
+	waitlistHandler := handlers.NewWaitlistHandler(db, cfg)
+	bookingTransferHandler := handlers.NewBookingTransferHandler(db, cfg)
+	availabilityHandler := handlers.NewAvailabilityHandler(db, cfg)
+	calendarHandler := handlers.NewCalendarHandler(db, cfg)
 	blockedDateHandler := handlers.NewBlockedDateHandler(db, cfg)
 	settingsHandler := handlers.NewSettingsHandler(db, cfg)
 	experienceHandler := handlers.NewExperienceRequestHandler(db, cfg)
@@ -113,6 +118,9 @@ func main() {
 	dashboardHandler := handlers.NewDashboardHandler(db, cfg)
 	healthHandler := handlers.NewHealthHandler()
 	walkReportHandler := handlers.NewWalkReportHandler(db, cfg)
+	dogCareHandler := handlers.NewDogCareHandler(db, cfg)
+	dogImportHandler := handlers.NewDogImportHandler(db, cfg)
+	publicDogHandler := handlers.NewPublicDogHandler(db, cfg)
 	colorCategoryHandler := handlers.NewColorCategoryHandler(db, cfg)
 	colorRequestHandler := handlers.NewColorRequestHandler(db, cfg)
 	userColorHandler := handlers.NewUserColorHandler(db, cfg)
@@ -162,6 +170,10 @@ func main() {
 	// Featured dogs (public - for homepage)
 	router.HandleFunc("/api/dogs/featured", dogHandler.GetFeaturedDogs).Methods("GET")
 
+	// Public dog profiles (public - shareable pages of dogs marked public)
+	router.HandleFunc("/api/public/dogs/{slug}", publicDogHandler.GetPublicDog).Methods("GET")
+	router.HandleFunc("/hunde/{slug}", publicDogHandler.GetPublicDogPage).Methods("GET")
+
 	// Color categories (public - for filters)
 	router.HandleFunc("/api/colors", colorCategoryHandler.ListColors).Methods("GET")
 
@@ -171,6 +183,10 @@ func main() {
 	// WhatsApp group settings (public - for displaying join button)
 	router.HandleFunc("/api/settings/whatsapp", settingsHandler.GetWhatsAppSettings).Methods("GET")
 
+	// Calendar feeds (public - the secret token in the URL authenticates calendar apps)
+	router.HandleFunc("/api/calendar/admin/{token:[0-9a-f]+}.ics", calendarHandler.GetAdminFeed).Methods("GET")
+	router.HandleFunc("/api/calendar/{token:[0-9a-f]+}.ics", calendarHandler.GetUserFeed).Methods("GET")
+
 	// Protected routes (authenticated users)
 	protected := router.PathPrefix("/api").Subrouter()
 	protected.Use(middleware.AuthMiddleware(cfg.JWTSecret))
@@ -183,6 +199,8 @@ func main() {
 	protected.HandleFunc("/users/me", userHandler.UpdateMe).Methods("PUT")
 	protected.HandleFunc("/users/me/photo", userHandler.UploadPhoto).Methods("POST")
 	protected.HandleFunc("/users/me", userHandler.DeleteAccount).Methods("DELETE")
+	protected.HandleFunc("/users/me/calendar", calendarHandler.GetMyCalendar).Methods("GET")
+	protected.HandleFunc("/users/me/calendar/regenerate", calendarHandler.RegenerateMyCalendar).Methods("POST")
 
 	// Dogs (read-only for authenticated users)
 	protected.HandleFunc("/dogs", dogHandler.ListDogs).Methods("GET")
@@ -192,11 +210,32 @@ func main() {
 	// Bookings (authenticated users)
 	protected.HandleFunc("/bookings", bookingHandler.ListBookings).Methods("GET")
 	protected.HandleFunc("/bookings", bookingHandler.CreateBooking).Methods("POST")
+	protected.HandleFunc("/bookings/series", bookingSeriesHandler.ListSeries).Methods("GET")
+	protected.HandleFunc("/bookings/series", bookingSeriesHandler.CreateSeries).Methods("POST")
+	protected.HandleFunc("/bookings/series/{id}/cancel", bookingSeriesHandler.CancelSeries).Methods("PUT")
 	protected.HandleFunc("/bookings/{id}", bookingHandler.GetBooking).Methods("GET")
+	protected.HandleFunc("/bookings/{id}/history", bookingHandler.GetBookingHistory).Methods("GET")
 	protected.HandleFunc("/bookings/{id}/cancel", bookingHandler.CancelBooking).Methods("PUT")
 	protected.HandleFunc("/bookings/{id}/notes", bookingHandler.AddNotes).Methods("PUT")
+	protected.HandleFunc("/bookings/{id}/checkin", bookingHandler.CheckIn).Methods("POST")
+	protected.HandleFunc("/bookings/{id}/checkout", bookingHandler.CheckOut).Methods("POST")
 	protected.HandleFunc("/bookings/calendar/{year}/{month}", bookingHandler.GetCalendarData).Methods("GET")
 
+	// Waitlist (authenticated users)
+	protected.HandleFunc("/waitlist", waitlistHandler.ListWaitlist).Methods("GET")
+	protected.HandleFunc("/waitlist", waitlistHandler.JoinWaitlist).Methods("POST")
+	protected.HandleFunc("/waitlist/{id}", waitlistHandler.LeaveWaitlist).Methods("DELETE")
+
+	// Booking transfers (authenticated users)
+	protected.HandleFunc("/bookings/{id}/transfer", bookingTransferHandler.OfferTransfer).Methods("POST")
+	protected.HandleFunc("/bookings/{id}/transfer", bookingTransferHandler.WithdrawTransfer).Methods("DELETE")
+	protected.HandleFunc("/bookings/{id}/transfers", bookingTransferHandler.ListBookingTransfers).Methods("GET")
+	protected.HandleFunc("/transfers", bookingTransferHandler.ListOffers).Methods("GET")
+	protected.HandleFunc("/transfers/{id}/accept", bookingTransferHandler.AcceptTransfer).Methods("POST")
+
+	// Availability search (authenticated users)
+	protected.HandleFunc("/availability", availabilityHandler.GetAvailability).Methods("GET")
+
 	// Blocked dates (read-only for authenticated users)
 	protected.HandleFunc("/blocked-dates", blockedDateHandler.ListBlockedDates).Methods("GET")
 
@@ -218,6 +257,7 @@ func main() {
 	protected.HandleFunc("/walk-reports/{id}/photos", walkReportHandler.UploadPhoto).Methods("POST")
 	protected.HandleFunc("/walk-reports/{id}/photos/{photoId}", walkReportHandler.DeletePhoto).Methods("DELETE")
 	protected.HandleFunc("/dogs/{id}/walk-reports", walkReportHandler.GetDogWalkReports).Methods("GET")
+	protected.HandleFunc("/dogs/{id}/care", dogCareHandler.ListCareEntries).Methods("GET")
 
 	// Admin-only routes
 	admin := protected.PathPrefix("").Subrouter()
@@ -228,8 +268,30 @@ func main() {
 	admin.HandleFunc("/dogs/{id}", dogHandler.UpdateDog).Methods("PUT")
 	admin.HandleFunc("/dogs/{id}", dogHandler.DeleteDog).Methods("DELETE")
 	admin.HandleFunc("/dogs/{id}/photo", dogHandler.UploadDogPhoto).Methods("POST")
+	admin.HandleFunc("/dogs/{id}/photos", dogHandler.AddDogPhotos).Methods("POST")
+	admin.HandleFunc("/dogs/{id}/photos/order", dogHandler.ReorderDogPhotos).Methods("PUT")
+	admin.HandleFunc("/dogs/{id}/photos/from-walk-report", dogHandler.PromoteWalkReportPhoto).Methods("POST")
+	admin.HandleFunc("/dogs/{id}/photos/{photoId}/cover", dogHandler.SetDogCoverPhoto).Methods("PUT")
+	admin.HandleFunc("/dogs/{id}/photos/{photoId}", dogHandler.DeleteDogPhoto).Methods("DELETE")
 	admin.HandleFunc("/dogs/{id}/availability", dogHandler.ToggleAvailability).Methods("PUT")
+	admin.HandleFunc("/dogs/{id}/status", dogHandler.ChangeDogStatus).Methods("PUT")
+	admin.HandleFunc("/dogs/{id}/status-history", dogHandler.GetDogStatusHistory).Methods("GET")
+	admin.HandleFunc("/dogs/{id}/restore", dogHandler.RestoreDog).Methods("POST")
 	admin.HandleFunc("/dogs/{id}/featured", dogHandler.SetFeatured).Methods("PUT")
+	admin.HandleFunc("/dogs/{id}/public", dogHandler.SetPublic).Methods("PUT")
+	admin.HandleFunc("/dogs/{id}/pair-walks", dogHandler.GetPairWalks).Methods("GET")
+	admin.HandleFunc("/dogs/{id}/pair-walks", dogHandler.AddPairWalk).Methods("POST")
+	admin.HandleFunc("/dogs/{id}/pair-walks/{partnerId}", dogHandler.RemovePairWalk).Methods("DELETE")
+	admin.HandleFunc("/dogs/{id}/walker-rules", dogHandler.GetWalkerRules).Methods("GET")
+	admin.HandleFunc("/dogs/{id}/walker-rules", dogHandler.AddWalkerRule).Methods("POST")
+	admin.HandleFunc("/dogs/{id}/walker-rules/{ruleId}", dogHandler.RemoveWalkerRule).Methods("DELETE")
+	admin.HandleFunc("/dogs/{id}/care", dogCareHandler.CreateCareEntry).Methods("POST")
+	admin.HandleFunc("/dogs/{id}/care/{entryId}", dogCareHandler.UpdateCareEntry).Methods("PUT")
+	admin.HandleFunc("/dogs/{id}/care/{entryId}", dogCareHandler.DeleteCareEntry).Methods("DELETE")
+
+	// Bulk dog import and export (admin only)
+	admin.HandleFunc("/admin/dogs/export", dogImportHandler.ExportDogs).Methods("GET")
+	admin.HandleFunc("/admin/dogs/import", dogImportHandler.ImportDogs).Methods("POST")
 
 	// Blocked dates management (admin only)
 	admin.HandleFunc("/blocked-dates", blockedDateHandler.CreateBlockedDate).Methods("POST")
@@ -237,6 +299,7 @@ func main() {
 
 	// Booking management (admin only)
 	admin.HandleFunc("/bookings/{id}/move", bookingHandler.MoveBooking).Methods("PUT")
+	admin.HandleFunc("/admin/bookings/export", bookingHandler.ExportBookings).Methods("GET")
 
 	// System settings (admin only)
 	admin.HandleFunc("/settings", settingsHandler.GetAllSettings).Methods("GET")
@@ -265,6 +328,7 @@ func main() {
 	admin.HandleFunc("/users/{id}", userHandler.AdminUpdateUser).Methods("PUT")
 	admin.HandleFunc("/users/{id}/activate", userHandler.ActivateUser).Methods("PUT")
 	admin.HandleFunc("/users/{id}/deactivate", userHandler.DeactivateUser).Methods("PUT")
+	admin.HandleFunc("/users/{id}/strikes/{strikeId}/forgive", userHandler.ForgiveStrike).Methods("PUT")
 	admin.HandleFunc("/users/{id}", userHandler.AdminDeleteUser).Methods("DELETE") // Super-admin only
 
 	// Reactivation requests management (admin only)
@@ -291,6 +355,9 @@ func main() {
 	admin.HandleFunc("/bookings/pending-approvals", bookingHandler.GetPendingApprovals).Methods("GET")
 	admin.HandleFunc("/bookings/{id}/approve", bookingHandler.ApprovePendingBooking).Methods("PUT")
 	admin.HandleFunc("/bookings/{id}/reject", bookingHandler.RejectPendingBooking).Methods("PUT")
+	admin.HandleFunc("/transfers/pending", bookingTransferHandler.ListPendingTransfers).Methods("GET")
+	admin.HandleFunc("/transfers/{id}/approve", bookingTransferHandler.ApproveTransfer).Methods("PUT")
+	admin.HandleFunc("/transfers/{id}/reject", bookingTransferHandler.RejectTransfer).Methods("PUT")
 
 	// DONE: Phase 4 - Super Admin routes (authenticated + admin + super admin)
 	superAdmin := admin.PathPrefix("").Subrouter()
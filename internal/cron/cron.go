@@ -8,18 +8,25 @@ import (
 	"time"
 
 	"github.com/tranmh/gassigeher/internal/config"
+	"github.com/tranmh/gassigeher/internal/models"
 	"github.com/tranmh/gassigeher/internal/repository"
 	"github.com/tranmh/gassigeher/internal/services"
 )
 
 // CronService handles scheduled tasks
 type CronService struct {
-	db           *sql.DB
-	bookingRepo  *repository.BookingRepository
-	userRepo     *repository.UserRepository
-	settingsRepo *repository.SettingsRepository
-	emailService *services.EmailService
-	stopChan     chan bool
+	db              *sql.DB
+	bookingRepo     *repository.BookingRepository
+	userRepo        *repository.UserRepository
+	dogRepo         *repository.DogRepository
+	settingsRepo    *repository.SettingsRepository
+	seriesRepo      *repository.BookingSeriesRepository
+	dogCareRepo     *repository.DogCareRepository
+	seriesService   *services.BookingSeriesService
+	waitlistService *services.WaitlistService
+	strikeService   *services.StrikeService
+	emailService    *services.EmailService
+	stopChan        chan bool
 }
 
 // NewCronService creates a new cron service
@@ -34,13 +41,33 @@ func NewCronService(db *sql.DB, cfg *config.Config) *CronService {
 		}
 	}
 
+	bookingRepo := repository.NewBookingRepository(db)
+	dogRepo := repository.NewDogRepository(db)
+	settingsRepo := repository.NewSettingsRepository(db)
+	seriesRepo := repository.NewBookingSeriesRepository(db)
+	userRepo := repository.NewUserRepository(db)
+	strikeService := services.NewStrikeService(repository.NewStrikeRepository(db), userRepo, settingsRepo, emailService)
+	waitlistService := services.NewWaitlistService(repository.NewWaitlistRepository(db), bookingRepo, userRepo,
+		dogRepo, settingsRepo, emailService)
+	holidayService := services.NewHolidayService(repository.NewHolidayRepository(db), settingsRepo)
+	bookingTimeService := services.NewBookingTimeService(repository.NewBookingTimeRepository(db), holidayService, settingsRepo)
+
 	return &CronService{
 		db:           db,
-		bookingRepo:  repository.NewBookingRepository(db),
-		userRepo:     repository.NewUserRepository(db),
-		settingsRepo: repository.NewSettingsRepository(db),
-		emailService: emailService,
-		stopChan:     make(chan bool),
+		bookingRepo:  bookingRepo,
+		userRepo:     userRepo,
+		dogRepo:      dogRepo,
+		settingsRepo: settingsRepo,
+		seriesRepo:   seriesRepo,
+		dogCareRepo:  repository.NewDogCareRepository(db),
+		seriesService: services.NewBookingSeriesService(seriesRepo, bookingRepo, dogRepo, userRepo,
+			repository.NewUserColorRepository(db), repository.NewDogWalkerRuleRepository(db), repository.NewBlockedDateRepository(db),
+			settingsRepo, bookingTimeService, services.NewBookingQuotaService(bookingRepo, settingsRepo), strikeService,
+			waitlistService),
+		waitlistService: waitlistService,
+		strikeService:   strikeService,
+		emailService:    emailService,
+		stopChan:        make(chan bool),
 	}
 }
 
@@ -51,11 +78,30 @@ func (s *CronService) Start() {
 	// Run auto-complete job every 15 minutes (allows users to add notes quickly after completion)
 	go s.runPeriodically("Auto-complete bookings", 15*time.Minute, s.autoCompleteBookings)
 
+	// Mark bookings never checked in as no-shows every 15 minutes
+	go s.runPeriodically("Mark no-shows", 15*time.Minute, s.markNoShows)
+
+	// Reject bookings still waiting for approval once their walk is due, every 15 minutes
+	go s.runPeriodically("Expire pending approvals", 15*time.Minute, s.expirePendingApprovals)
+
 	// Run auto-deactivation job daily at 3am (also runs once on startup)
 	go s.runDaily("Auto-deactivate inactive users", 3, 0, s.autoDeactivateInactiveUsers)
 
 	// Run booking reminder job every 15 minutes
 	go s.runPeriodically("Send booking reminders", 15*time.Minute, s.sendBookingReminders)
+
+	// Extend recurring booking series daily at 00:15 as the booking horizon moves forward
+	go s.runDaily("Extend booking series", 0, 15, s.extendBookingSeries)
+
+	// Start and end scheduled dog unavailability daily at 00:05 (also runs once on startup)
+	go s.runDaily("Apply dog unavailability windows", 0, 5, s.applyDogUnavailability)
+
+	// Send admins the dogs below their weekly walk target daily at 07:00 (not on startup,
+	// so restarts do not mail the digest again)
+	go s.scheduleDaily("Send under-walked dog digest", 7, 0, s.sendUnderWalkedDigest)
+
+	// Expire unclaimed waitlist offers every 15 minutes and pass the slots on
+	go s.runPeriodically("Expire waitlist offers", 15*time.Minute, s.expireWaitlistOffers)
 }
 
 // Stop stops all cron jobs
@@ -84,7 +130,7 @@ func (s *CronService) runPeriodically(name string, interval time.Duration, fn fu
 	}
 }
 
-// autoCompleteBookings marks past scheduled bookings as completed
+// autoCompleteBookings completes past walks that were checked in but not checked out
 func (s *CronService) autoCompleteBookings() {
 	count, err := s.bookingRepo.AutoComplete()
 	if err != nil {
@@ -99,6 +145,69 @@ func (s *CronService) autoCompleteBookings() {
 	}
 }
 
+// markNoShows marks bookings that were not checked in within no_show_grace_minutes as no-shows
+// and records a strike for each
+func (s *CronService) markNoShows() {
+	graceMinutes := s.settingsRepo.GetInt("no_show_grace_minutes", 60)
+
+	noShows, err := s.bookingRepo.MarkNoShows(graceMinutes)
+	if err != nil {
+		log.Printf("Error marking no-shows: %v", err)
+		return
+	}
+
+	for _, booking := range noShows {
+		bookingID := booking.ID
+		if _, err := s.strikeService.AddStrike(booking.UserID, &bookingID, models.StrikeReasonNoShow, time.Now()); err != nil {
+			log.Printf("Error recording no-show strike for booking %d: %v", booking.ID, err)
+		}
+	}
+
+	if len(noShows) > 0 {
+		log.Printf("Marked %d booking(s) as no-show", len(noShows))
+	}
+}
+
+// expirePendingApprovals rejects bookings no admin approved before their scheduled time and
+// tells the walkers
+func (s *CronService) expirePendingApprovals() {
+	expired, err := s.bookingRepo.ExpirePendingApprovals()
+	if err != nil {
+		log.Printf("Error expiring pending approvals: %v", err)
+		return
+	}
+
+	if s.emailService != nil {
+		for _, expiredBooking := range expired {
+			booking, err := s.bookingRepo.FindByID(expiredBooking.ID)
+			if err != nil || booking == nil || booking.User == nil || booking.User.Email == nil || booking.Dog == nil {
+				continue
+			}
+			if err := s.emailService.SendBookingRejected(*booking.User.Email, booking.User.FirstName, booking.Dog.Name,
+				booking.Date, booking.ScheduledTime, models.BookingApprovalExpiredReason); err != nil {
+				log.Printf("Error sending expired approval email for booking %d: %v", booking.ID, err)
+			}
+		}
+	}
+
+	if len(expired) > 0 {
+		log.Printf("Rejected %d booking(s) still pending approval at their walk time", len(expired))
+	}
+}
+
+// expireWaitlistOffers expires unclaimed waitlist offers and offers the slots to the next users in line
+func (s *CronService) expireWaitlistOffers() {
+	count, err := s.waitlistService.ExpireOffers(time.Now())
+	if err != nil {
+		log.Printf("Error expiring waitlist offers: %v", err)
+		return
+	}
+
+	if count > 0 {
+		log.Printf("Expired %d waitlist offer(s)", count)
+	}
+}
+
 // sendBookingReminders sends reminders for upcoming bookings (1-2 hours before)
 func (s *CronService) sendBookingReminders() {
 	// Check if email service is available
@@ -141,13 +250,22 @@ func (s *CronService) sendBookingReminders() {
 			formattedDate = t.Format("02.01.2006")
 		}
 
+		// Medication due during the walk is listed in the reminder; without it the reminder
+		// is still sent, just without the medication section
+		medications, err := s.dogCareRepo.MedicationDuringWalk(booking.DogID, booking.Date, booking.ScheduledTime)
+		if err != nil {
+			log.Printf("Error getting medication for booking %d: %v", booking.ID, err)
+			medications = nil
+		}
+
 		// Send reminder email
-		err := s.emailService.SendBookingReminder(
+		err = s.emailService.SendBookingReminder(
 			*booking.User.Email,
 			booking.User.FirstName,
 			dogName,
 			formattedDate,
 			booking.ScheduledTime,
+			medications,
 		)
 
 		if err != nil {
@@ -172,6 +290,11 @@ func (s *CronService) runDaily(name string, hour, minute int, fn func()) {
 	log.Printf("Running daily job on startup: %s", name)
 	fn()
 
+	s.scheduleDaily(name, hour, minute, fn)
+}
+
+// scheduleDaily runs a function daily at a specific time, without a run on startup
+func (s *CronService) scheduleDaily(name string, hour, minute int, fn func()) {
 	for {
 		now := time.Now()
 		next := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, now.Location())
@@ -241,3 +364,127 @@ func (s *CronService) autoDeactivateInactiveUsers() {
 		}
 	}
 }
+
+// applyDogUnavailability makes dogs unavailable when their scheduled window starts and
+// available again once its end date has passed
+func (s *CronService) applyDogUnavailability() {
+	today := time.Now().Format("2006-01-02")
+	started, ended, err := s.dogRepo.ApplyUnavailabilityWindows(today)
+	if err != nil {
+		log.Printf("Error applying dog unavailability windows: %v", err)
+		return
+	}
+
+	if started > 0 || ended > 0 {
+		log.Printf("Dog unavailability: %d dog(s) now unavailable, %d dog(s) available again", started, ended)
+	} else {
+		log.Println("Dog unavailability check: no changes")
+	}
+}
+
+// sendUnderWalkedDigest emails all active admins the dogs that got fewer completed walks in
+// the last 7 days than their weekly target
+func (s *CronService) sendUnderWalkedDigest() {
+	if s.emailService == nil {
+		log.Println("Under-walked dog check: email service not configured, skipping")
+		return
+	}
+
+	dogs, err := s.dogRepo.FindUnderWalked(time.Now())
+	if err != nil {
+		log.Printf("Error finding under-walked dogs: %v", err)
+		return
+	}
+
+	if len(dogs) == 0 {
+		log.Println("Under-walked dog check: all dogs reached their walk target")
+		return
+	}
+
+	activeOnly := true
+	users, err := s.userRepo.FindAll(&activeOnly)
+	if err != nil {
+		log.Printf("Error getting admins for under-walked dog digest: %v", err)
+		return
+	}
+
+	sent := 0
+	for _, user := range users {
+		if (!user.IsAdmin && !user.IsSuperAdmin) || user.Email == nil {
+			continue
+		}
+		if err := s.emailService.SendUnderWalkedDogsDigest(*user.Email, user.FirstName, dogs); err != nil {
+			log.Printf("Error sending under-walked dog digest to user %d: %v", user.ID, err)
+			continue
+		}
+		sent++
+	}
+
+	log.Printf("Sent under-walked dog digest (%d dog(s)) to %d admin(s)", len(dogs), sent)
+}
+
+// extendBookingSeries books the next occurrences of all active series up to the booking horizon
+func (s *CronService) extendBookingSeries() {
+	seriesList, err := s.seriesRepo.FindActive()
+	if err != nil {
+		log.Printf("Error finding active booking series: %v", err)
+		return
+	}
+
+	if len(seriesList) == 0 {
+		log.Println("Booking series check: no active series")
+		return
+	}
+
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	for _, series := range seriesList {
+		// Stop series of users who were deactivated in the meantime
+		user, err := s.userRepo.FindByID(series.UserID)
+		if err != nil {
+			log.Printf("Error getting user %d for booking series %d: %v", series.UserID, series.ID, err)
+			continue
+		}
+		if user == nil || !user.IsActive {
+			if err := s.seriesRepo.Deactivate(series.ID); err != nil {
+				log.Printf("Error deactivating booking series %d: %v", series.ID, err)
+			}
+			continue
+		}
+
+		result, err := s.seriesService.Extend(series, today)
+		if err != nil {
+			log.Printf("Error extending booking series %d: %v", series.ID, err)
+			continue
+		}
+
+		// Walkers who lost the dog's color or are restricted by strikes get no new occurrences;
+		// the series stays active and continues once they may book again
+		if result.PausedReason != "" {
+			log.Printf("Booking series %d paused: %s", series.ID, result.PausedReason)
+			continue
+		}
+
+		for _, skipped := range result.Skipped {
+			log.Printf("Booking series %d: skipped %s (%s)", series.ID, skipped.Date, skipped.Reason)
+		}
+
+		if len(result.Bookings) > 0 {
+			log.Printf("Booking series %d: created %d booking(s)", series.ID, len(result.Bookings))
+
+			if s.emailService != nil && user.Email != nil {
+				dogName := "Unbekannter Hund"
+				dog, err := s.dogRepo.FindByID(series.DogID)
+				if err == nil && dog != nil {
+					dogName = dog.Name
+				} else {
+					dog = nil
+				}
+				for _, booking := range result.Bookings {
+					go s.emailService.SendBookingConfirmation(*user.Email, user.FirstName, dogName, booking.Date, booking.ScheduledTime, services.BookingInvite(booking, dog))
+				}
+			}
+		}
+	}
+}
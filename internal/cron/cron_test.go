@@ -17,14 +17,16 @@ func TestCronService_AutoCompleteBookings(t *testing.T) {
 	userID := testutil.SeedTestUser(t, db, "test@example.com", "Test User", "green")
 	dogID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
 
-	t.Run("complete past bookings", func(t *testing.T) {
-		// Create booking from yesterday
+	t.Run("complete past checked-in bookings", func(t *testing.T) {
+		// Create checked-in booking from yesterday
 		yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
-		testutil.SeedTestBooking(t, db, userID, dogID, yesterday, "09:00", "scheduled")
+		yesterdayID := testutil.SeedTestBooking(t, db, userID, dogID, yesterday, "09:00", "scheduled")
 
-		// Create booking from last week
+		// Create checked-in booking from last week
 		lastWeek := time.Now().AddDate(0, 0, -7).Format("2006-01-02")
-		testutil.SeedTestBooking(t, db, userID, dogID, lastWeek, "15:00", "scheduled")
+		lastWeekID := testutil.SeedTestBooking(t, db, userID, dogID, lastWeek, "15:00", "scheduled")
+
+		db.Exec("UPDATE bookings SET checked_in_at = ? WHERE id IN (?, ?)", time.Now().AddDate(0, 0, -1), yesterdayID, lastWeekID)
 
 		// Create future booking (should not be completed)
 		tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
@@ -52,6 +54,19 @@ func TestCronService_AutoCompleteBookings(t *testing.T) {
 		}
 	})
 
+	t.Run("skip bookings never checked in", func(t *testing.T) {
+		past := time.Now().AddDate(0, 0, -2).Format("2006-01-02")
+		bookingID := testutil.SeedTestBooking(t, db, userID, dogID, past, "11:00", "scheduled")
+
+		cronService.autoCompleteBookings()
+
+		var status string
+		db.QueryRow("SELECT status FROM bookings WHERE id = ?", bookingID).Scan(&status)
+		if status != "scheduled" {
+			t.Errorf("Booking without check-in should not be completed, got status: %s", status)
+		}
+	})
+
 	t.Run("skip already completed bookings", func(t *testing.T) {
 		// Create already completed booking from past
 		past := time.Now().AddDate(0, 0, -5).Format("2006-01-02")
@@ -90,6 +105,98 @@ func TestCronService_AutoCompleteBookings(t *testing.T) {
 	})
 }
 
+// TestCronService_MarkNoShows tests that bookings never checked in become no-shows after the grace period
+func TestCronService_MarkNoShows(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	cronService := NewCronService(db, nil)
+
+	userID := testutil.SeedTestUser(t, db, "test@example.com", "Test User", "green")
+	dogID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+
+	yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+	missedID := testutil.SeedTestBooking(t, db, userID, dogID, yesterday, "09:00", "scheduled")
+	walkedID := testutil.SeedTestBooking(t, db, userID, dogID, yesterday, "15:00", "scheduled")
+	db.Exec("UPDATE bookings SET checked_in_at = ? WHERE id = ?", time.Now().AddDate(0, 0, -1), walkedID)
+
+	// Started 30 minutes ago: still inside the default 60 minute grace period
+	recent := time.Now().Add(-30 * time.Minute)
+	recentID := testutil.SeedTestBooking(t, db, userID, dogID, recent.Format("2006-01-02"), recent.Format("15:04"), "scheduled")
+
+	cronService.markNoShows()
+
+	expected := map[int]string{missedID: "no_show", walkedID: "scheduled", recentID: "scheduled"}
+	for id, want := range expected {
+		var status string
+		db.QueryRow("SELECT status FROM bookings WHERE id = ?", id).Scan(&status)
+		if status != want {
+			t.Errorf("Booking %d: expected status %s, got %s", id, want, status)
+		}
+	}
+
+	var strikeBookingID int
+	var reason string
+	db.QueryRow("SELECT booking_id, reason FROM user_strikes WHERE user_id = ?", userID).Scan(&strikeBookingID, &reason)
+	if strikeBookingID != missedID || reason != "no_show" {
+		t.Errorf("Expected no_show strike for booking %d, got booking %d reason %q", missedID, strikeBookingID, reason)
+	}
+}
+
+// TestCronService_ExpirePendingApprovals tests that unapproved bookings are rejected once they are due
+func TestCronService_ExpirePendingApprovals(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	cronService := NewCronService(db, nil)
+
+	userID := testutil.SeedTestUser(t, db, "test@example.com", "Test User", "green")
+	dogID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+
+	yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+	pendingID := testutil.SeedTestBooking(t, db, userID, dogID, yesterday, "07:00", "scheduled")
+	db.Exec("UPDATE bookings SET requires_approval = 1, approval_status = 'pending' WHERE id = ?", pendingID)
+
+	cronService.expirePendingApprovals()
+
+	var status, approvalStatus string
+	db.QueryRow("SELECT status, approval_status FROM bookings WHERE id = ?", pendingID).Scan(&status, &approvalStatus)
+	if status != "cancelled" || approvalStatus != "rejected" {
+		t.Errorf("Expected cancelled/rejected, got %s/%s", status, approvalStatus)
+	}
+
+	// Rejected bookings are not no-shows
+	cronService.markNoShows()
+	var strikes int
+	db.QueryRow("SELECT COUNT(*) FROM user_strikes WHERE user_id = ?", userID).Scan(&strikes)
+	if strikes != 0 {
+		t.Errorf("Expected no strike for an expired approval, got %d", strikes)
+	}
+}
+
+// TestCronService_ApplyDogUnavailability tests re-enabling dogs after their unavailability window
+func TestCronService_ApplyDogUnavailability(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	cronService := NewCronService(db, nil)
+
+	yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+	today := time.Now().Format("2006-01-02")
+	recoveredID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+	sickID := testutil.SeedTestDog(t, db, "Max", "Beagle", "green")
+	db.Exec(`UPDATE dogs SET is_available = 0, unavailable_reason = 'Tierarzt', unavailable_from = ?, unavailable_until = ?
+		WHERE id = ?`, yesterday, yesterday, recoveredID)
+	db.Exec(`UPDATE dogs SET unavailable_reason = 'Tierarzt', unavailable_from = ?, unavailable_until = ? WHERE id = ?`,
+		today, today, sickID)
+
+	cronService.applyDogUnavailability()
+
+	var recoveredAvailable, sickAvailable bool
+	db.QueryRow("SELECT is_available FROM dogs WHERE id = ?", recoveredID).Scan(&recoveredAvailable)
+	db.QueryRow("SELECT is_available FROM dogs WHERE id = ?", sickID).Scan(&sickAvailable)
+	if !recoveredAvailable {
+		t.Error("Expected dog to be available again after its window")
+	}
+	if sickAvailable {
+		t.Error("Expected dog to be unavailable on the first day of its window")
+	}
+}
+
 // DONE: TestCronService_AutoDeactivateInactiveUsers tests automatic user deactivation
 func TestCronService_AutoDeactivateInactiveUsers(t *testing.T) {
 	db := testutil.SetupTestDB(t)
@@ -280,3 +387,16 @@ func TestCronService_NewCronService(t *testing.T) {
 		t.Error("Stop channel should be initialized")
 	}
 }
+
+// TestCronService_SendUnderWalkedDigest tests that the digest is skipped without email service
+func TestCronService_SendUnderWalkedDigest(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	cronService := NewCronService(db, nil)
+
+	testutil.SeedTestUser(t, db, "admin@example.com", "Admin", "orange")
+	dogID := testutil.SeedTestDog(t, db, "Max", "Beagle", "green")
+	db.Exec("UPDATE dogs SET target_walks_per_week = 3 WHERE id = ?", dogID)
+
+	// Should not panic even though no email service is configured
+	cronService.sendUnderWalkedDigest()
+}
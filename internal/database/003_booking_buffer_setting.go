@@ -0,0 +1,26 @@
+package database
+
+func init() {
+	RegisterMigration(&Migration{
+		ID:          "003_booking_buffer_setting",
+		Description: "Add buffer setting for duration-aware booking overlap detection",
+		Up: map[string]string{
+			"sqlite": `
+-- Minutes kept free between two walks of the same dog
+INSERT OR IGNORE INTO system_settings (key, value) VALUES
+  ('booking_buffer_minutes', '0');
+`,
+			"mysql": `
+-- Minutes kept free between two walks of the same dog
+INSERT IGNORE INTO system_settings (` + "`key`" + `, value) VALUES
+  ('booking_buffer_minutes', '0');
+`,
+			"postgres": `
+-- Minutes kept free between two walks of the same dog
+INSERT INTO system_settings (key, value) VALUES
+  ('booking_buffer_minutes', '0')
+ON CONFLICT (key) DO NOTHING;
+`,
+		},
+	})
+}
@@ -0,0 +1,55 @@
+package database
+
+func init() {
+	RegisterMigration(&Migration{
+		ID:          "004_dog_pair_walks",
+		Description: "Add dog pairs that one walker may take out together",
+		Up: map[string]string{
+			"sqlite": `
+-- Dog pairs approved by an admin for pair walks (dog_id < partner_dog_id)
+CREATE TABLE IF NOT EXISTS dog_pair_walks (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  dog_id INTEGER NOT NULL,
+  partner_dog_id INTEGER NOT NULL,
+  created_by INTEGER NOT NULL,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (dog_id) REFERENCES dogs(id) ON DELETE CASCADE,
+  FOREIGN KEY (partner_dog_id) REFERENCES dogs(id) ON DELETE CASCADE,
+  FOREIGN KEY (created_by) REFERENCES users(id),
+  UNIQUE(dog_id, partner_dog_id)
+);
+CREATE INDEX IF NOT EXISTS idx_dog_pair_walks_partner ON dog_pair_walks (partner_dog_id);
+`,
+			"mysql": `
+-- Dog pairs approved by an admin for pair walks (dog_id < partner_dog_id)
+CREATE TABLE IF NOT EXISTS dog_pair_walks (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  dog_id INT NOT NULL,
+  partner_dog_id INT NOT NULL,
+  created_by INT NOT NULL,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (dog_id) REFERENCES dogs(id) ON DELETE CASCADE,
+  FOREIGN KEY (partner_dog_id) REFERENCES dogs(id) ON DELETE CASCADE,
+  FOREIGN KEY (created_by) REFERENCES users(id),
+  UNIQUE KEY unique_dog_pair (dog_id, partner_dog_id),
+  INDEX idx_dog_pair_walks_partner (partner_dog_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+`,
+			"postgres": `
+-- Dog pairs approved by an admin for pair walks (dog_id < partner_dog_id)
+CREATE TABLE IF NOT EXISTS dog_pair_walks (
+  id SERIAL PRIMARY KEY,
+  dog_id INTEGER NOT NULL,
+  partner_dog_id INTEGER NOT NULL,
+  created_by INTEGER NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (dog_id) REFERENCES dogs(id) ON DELETE CASCADE,
+  FOREIGN KEY (partner_dog_id) REFERENCES dogs(id) ON DELETE CASCADE,
+  FOREIGN KEY (created_by) REFERENCES users(id),
+  UNIQUE(dog_id, partner_dog_id)
+);
+CREATE INDEX IF NOT EXISTS idx_dog_pair_walks_partner ON dog_pair_walks (partner_dog_id);
+`,
+		},
+	})
+}
@@ -0,0 +1,77 @@
+package database
+
+func init() {
+	RegisterMigration(&Migration{
+		ID:          "005_booking_series",
+		Description: "Add recurring booking series",
+		Up: map[string]string{
+			"sqlite": `
+-- Weekly or bi-weekly recurring bookings of one dog by one user
+CREATE TABLE IF NOT EXISTS booking_series (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  user_id INTEGER NOT NULL,
+  dog_id INTEGER NOT NULL,
+  scheduled_time TEXT NOT NULL,
+  interval_weeks INTEGER NOT NULL DEFAULT 1 CHECK(interval_weeks IN (1, 2)),
+  start_date DATE NOT NULL,
+  last_generated_date DATE NOT NULL,
+  is_active INTEGER DEFAULT 1,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+  FOREIGN KEY (dog_id) REFERENCES dogs(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_booking_series_user ON booking_series(user_id);
+CREATE INDEX IF NOT EXISTS idx_booking_series_active ON booking_series(is_active);
+
+ALTER TABLE bookings ADD COLUMN series_id INTEGER REFERENCES booking_series(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_bookings_series ON bookings(series_id);
+`,
+			"mysql": `
+-- Weekly or bi-weekly recurring bookings of one dog by one user
+CREATE TABLE IF NOT EXISTS booking_series (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  user_id INT NOT NULL,
+  dog_id INT NOT NULL,
+  scheduled_time VARCHAR(10) NOT NULL,
+  interval_weeks INT NOT NULL DEFAULT 1 CHECK(interval_weeks IN (1, 2)),
+  start_date DATE NOT NULL,
+  last_generated_date DATE NOT NULL,
+  is_active TINYINT(1) DEFAULT 1,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+  FOREIGN KEY (dog_id) REFERENCES dogs(id) ON DELETE CASCADE,
+  INDEX idx_booking_series_user (user_id),
+  INDEX idx_booking_series_active (is_active)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+ALTER TABLE bookings ADD COLUMN series_id INT NULL;
+ALTER TABLE bookings ADD INDEX idx_bookings_series (series_id);
+ALTER TABLE bookings ADD CONSTRAINT fk_bookings_series FOREIGN KEY (series_id) REFERENCES booking_series(id) ON DELETE SET NULL;
+`,
+			"postgres": `
+-- Weekly or bi-weekly recurring bookings of one dog by one user
+CREATE TABLE IF NOT EXISTS booking_series (
+  id SERIAL PRIMARY KEY,
+  user_id INTEGER NOT NULL,
+  dog_id INTEGER NOT NULL,
+  scheduled_time VARCHAR(10) NOT NULL,
+  interval_weeks INTEGER NOT NULL DEFAULT 1 CHECK(interval_weeks IN (1, 2)),
+  start_date DATE NOT NULL,
+  last_generated_date DATE NOT NULL,
+  is_active BOOLEAN DEFAULT TRUE,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+  FOREIGN KEY (dog_id) REFERENCES dogs(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_booking_series_user ON booking_series(user_id);
+CREATE INDEX IF NOT EXISTS idx_booking_series_active ON booking_series(is_active);
+
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS series_id INTEGER REFERENCES booking_series(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_bookings_series ON bookings(series_id);
+`,
+		},
+	})
+}
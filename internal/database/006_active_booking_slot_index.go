@@ -0,0 +1,70 @@
+package database
+
+func init() {
+	RegisterMigration(&Migration{
+		ID:          "006_active_booking_slot_index",
+		Description: "Only keep booking slots unique for scheduled bookings so cancelled slots can be rebooked",
+		Up: map[string]string{
+			// SQLite cannot drop a table constraint, so the bookings table is rebuilt.
+			// Foreign keys are switched off so dropping the old table does not cascade to walk_reports;
+			// the migration runner restores the previous setting.
+			"sqlite": `
+PRAGMA foreign_keys = OFF;
+
+CREATE TABLE bookings_new (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  user_id INTEGER NOT NULL,
+  dog_id INTEGER NOT NULL,
+  date DATE NOT NULL,
+  scheduled_time TEXT NOT NULL,
+  status TEXT DEFAULT 'scheduled' CHECK(status IN ('scheduled', 'completed', 'cancelled')),
+  completed_at TIMESTAMP,
+  user_notes TEXT,
+  admin_cancellation_reason TEXT,
+  requires_approval INTEGER DEFAULT 0,
+  approval_status TEXT DEFAULT 'approved',
+  approved_by INTEGER,
+  approved_at TIMESTAMP,
+  rejection_reason TEXT,
+  reminder_sent_at TIMESTAMP,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  series_id INTEGER REFERENCES booking_series(id) ON DELETE SET NULL,
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+  FOREIGN KEY (dog_id) REFERENCES dogs(id) ON DELETE CASCADE,
+  FOREIGN KEY (approved_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+INSERT INTO bookings_new (id, user_id, dog_id, date, scheduled_time, status, completed_at, user_notes,
+  admin_cancellation_reason, requires_approval, approval_status, approved_by, approved_at, rejection_reason,
+  reminder_sent_at, created_at, updated_at, series_id)
+SELECT id, user_id, dog_id, date, scheduled_time, status, completed_at, user_notes,
+  admin_cancellation_reason, requires_approval, approval_status, approved_by, approved_at, rejection_reason,
+  reminder_sent_at, created_at, updated_at, series_id
+FROM bookings;
+
+DROP TABLE bookings;
+ALTER TABLE bookings_new RENAME TO bookings;
+
+CREATE INDEX IF NOT EXISTS idx_bookings_user ON bookings(user_id);
+CREATE INDEX IF NOT EXISTS idx_bookings_dog ON bookings(dog_id);
+CREATE INDEX IF NOT EXISTS idx_bookings_date ON bookings(date);
+CREATE INDEX IF NOT EXISTS idx_bookings_status ON bookings(status);
+CREATE INDEX IF NOT EXISTS idx_bookings_approval_status ON bookings(approval_status);
+CREATE INDEX IF NOT EXISTS idx_bookings_series ON bookings(series_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bookings_active_slot ON bookings(dog_id, date, scheduled_time) WHERE status = 'scheduled';
+`,
+			// MySQL has no partial indexes: the generated column is NULL for inactive bookings,
+			// and NULLs never collide in a unique index
+			"mysql": `
+ALTER TABLE bookings ADD COLUMN active_dog_id INT AS (CASE WHEN status = 'scheduled' THEN dog_id END) STORED;
+ALTER TABLE bookings ADD UNIQUE KEY idx_bookings_active_slot (active_dog_id, date, scheduled_time);
+ALTER TABLE bookings DROP INDEX unique_dog_date_time;
+`,
+			"postgres": `
+ALTER TABLE bookings DROP CONSTRAINT IF EXISTS bookings_dog_id_date_scheduled_time_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bookings_active_slot ON bookings(dog_id, date, scheduled_time) WHERE status = 'scheduled';
+`,
+		},
+	})
+}
@@ -0,0 +1,80 @@
+package database
+
+func init() {
+	RegisterMigration(&Migration{
+		ID:          "007_booking_waitlist",
+		Description: "Add waitlist for taken dog slots",
+		Up: map[string]string{
+			"sqlite": `
+-- Users queueing for a taken dog slot; the first waiting user gets a time-limited offer when it frees up
+CREATE TABLE IF NOT EXISTS booking_waitlist (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  user_id INTEGER NOT NULL,
+  dog_id INTEGER NOT NULL,
+  date DATE NOT NULL,
+  scheduled_time TEXT NOT NULL,
+  status TEXT DEFAULT 'waiting' CHECK(status IN ('waiting', 'offered', 'claimed', 'expired', 'cancelled')),
+  offered_at TIMESTAMP,
+  offer_expires_at TIMESTAMP,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+  FOREIGN KEY (dog_id) REFERENCES dogs(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_booking_waitlist_slot ON booking_waitlist(dog_id, date, scheduled_time, status);
+CREATE INDEX IF NOT EXISTS idx_booking_waitlist_user ON booking_waitlist(user_id);
+
+-- Hours a waitlisted user has to claim a freed slot
+INSERT OR IGNORE INTO system_settings (key, value) VALUES
+  ('waitlist_claim_hours', '2');
+`,
+			"mysql": `
+-- Users queueing for a taken dog slot; the first waiting user gets a time-limited offer when it frees up
+CREATE TABLE IF NOT EXISTS booking_waitlist (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  user_id INT NOT NULL,
+  dog_id INT NOT NULL,
+  date DATE NOT NULL,
+  scheduled_time VARCHAR(10) NOT NULL,
+  status VARCHAR(20) DEFAULT 'waiting' CHECK(status IN ('waiting', 'offered', 'claimed', 'expired', 'cancelled')),
+  offered_at DATETIME,
+  offer_expires_at DATETIME,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+  FOREIGN KEY (dog_id) REFERENCES dogs(id) ON DELETE CASCADE,
+  INDEX idx_booking_waitlist_slot (dog_id, date, scheduled_time, status),
+  INDEX idx_booking_waitlist_user (user_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Hours a waitlisted user has to claim a freed slot
+INSERT IGNORE INTO system_settings (` + "`key`" + `, value) VALUES
+  ('waitlist_claim_hours', '2');
+`,
+			"postgres": `
+-- Users queueing for a taken dog slot; the first waiting user gets a time-limited offer when it frees up
+CREATE TABLE IF NOT EXISTS booking_waitlist (
+  id SERIAL PRIMARY KEY,
+  user_id INTEGER NOT NULL,
+  dog_id INTEGER NOT NULL,
+  date DATE NOT NULL,
+  scheduled_time VARCHAR(10) NOT NULL,
+  status VARCHAR(20) DEFAULT 'waiting' CHECK(status IN ('waiting', 'offered', 'claimed', 'expired', 'cancelled')),
+  offered_at TIMESTAMP WITH TIME ZONE,
+  offer_expires_at TIMESTAMP WITH TIME ZONE,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+  FOREIGN KEY (dog_id) REFERENCES dogs(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_booking_waitlist_slot ON booking_waitlist(dog_id, date, scheduled_time, status);
+CREATE INDEX IF NOT EXISTS idx_booking_waitlist_user ON booking_waitlist(user_id);
+
+-- Hours a waitlisted user has to claim a freed slot
+INSERT INTO system_settings (key, value) VALUES
+  ('waitlist_claim_hours', '2')
+ON CONFLICT (key) DO NOTHING;
+`,
+		},
+	})
+}
@@ -0,0 +1,84 @@
+package database
+
+func init() {
+	RegisterMigration(&Migration{
+		ID:          "008_walk_check_in",
+		Description: "Add walk check-in/check-out times and no_show booking status",
+		Up: map[string]string{
+			// The status CHECK constraint cannot be altered in SQLite, so the bookings table is rebuilt
+			// (see 006_active_booking_slot_index for why foreign keys are switched off)
+			"sqlite": `
+PRAGMA foreign_keys = OFF;
+
+CREATE TABLE bookings_new (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  user_id INTEGER NOT NULL,
+  dog_id INTEGER NOT NULL,
+  date DATE NOT NULL,
+  scheduled_time TEXT NOT NULL,
+  status TEXT DEFAULT 'scheduled' CHECK(status IN ('scheduled', 'completed', 'cancelled', 'no_show')),
+  completed_at TIMESTAMP,
+  user_notes TEXT,
+  admin_cancellation_reason TEXT,
+  requires_approval INTEGER DEFAULT 0,
+  approval_status TEXT DEFAULT 'approved',
+  approved_by INTEGER,
+  approved_at TIMESTAMP,
+  rejection_reason TEXT,
+  reminder_sent_at TIMESTAMP,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  series_id INTEGER REFERENCES booking_series(id) ON DELETE SET NULL,
+  checked_in_at TIMESTAMP,
+  checked_out_at TIMESTAMP,
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+  FOREIGN KEY (dog_id) REFERENCES dogs(id) ON DELETE CASCADE,
+  FOREIGN KEY (approved_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+INSERT INTO bookings_new (id, user_id, dog_id, date, scheduled_time, status, completed_at, user_notes,
+  admin_cancellation_reason, requires_approval, approval_status, approved_by, approved_at, rejection_reason,
+  reminder_sent_at, created_at, updated_at, series_id)
+SELECT id, user_id, dog_id, date, scheduled_time, status, completed_at, user_notes,
+  admin_cancellation_reason, requires_approval, approval_status, approved_by, approved_at, rejection_reason,
+  reminder_sent_at, created_at, updated_at, series_id
+FROM bookings;
+
+DROP TABLE bookings;
+ALTER TABLE bookings_new RENAME TO bookings;
+
+CREATE INDEX IF NOT EXISTS idx_bookings_user ON bookings(user_id);
+CREATE INDEX IF NOT EXISTS idx_bookings_dog ON bookings(dog_id);
+CREATE INDEX IF NOT EXISTS idx_bookings_date ON bookings(date);
+CREATE INDEX IF NOT EXISTS idx_bookings_status ON bookings(status);
+CREATE INDEX IF NOT EXISTS idx_bookings_approval_status ON bookings(approval_status);
+CREATE INDEX IF NOT EXISTS idx_bookings_series ON bookings(series_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bookings_active_slot ON bookings(dog_id, date, scheduled_time) WHERE status = 'scheduled';
+
+-- Minutes after the scheduled time without check-in before a booking becomes a no-show
+INSERT OR IGNORE INTO system_settings (key, value) VALUES
+  ('no_show_grace_minutes', '60');
+`,
+			// The unnamed column CHECK from 001_create_tables is the first check of the table
+			"mysql": `
+ALTER TABLE bookings DROP CHECK bookings_chk_1;
+ALTER TABLE bookings ADD CONSTRAINT chk_bookings_status CHECK (status IN ('scheduled', 'completed', 'cancelled', 'no_show'));
+ALTER TABLE bookings ADD COLUMN checked_in_at DATETIME;
+ALTER TABLE bookings ADD COLUMN checked_out_at DATETIME;
+
+INSERT IGNORE INTO system_settings (` + "`key`" + `, value) VALUES
+  ('no_show_grace_minutes', '60');
+`,
+			"postgres": `
+ALTER TABLE bookings DROP CONSTRAINT IF EXISTS bookings_status_check;
+ALTER TABLE bookings ADD CONSTRAINT bookings_status_check CHECK (status IN ('scheduled', 'completed', 'cancelled', 'no_show'));
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS checked_in_at TIMESTAMP;
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS checked_out_at TIMESTAMP;
+
+INSERT INTO system_settings (key, value) VALUES
+  ('no_show_grace_minutes', '60')
+ON CONFLICT (key) DO NOTHING;
+`,
+		},
+	})
+}
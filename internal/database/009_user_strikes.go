@@ -0,0 +1,77 @@
+package database
+
+func init() {
+	RegisterMigration(&Migration{
+		ID:          "009_user_strikes",
+		Description: "Add strikes for no-shows and late cancellations",
+		Up: map[string]string{
+			"sqlite": `
+-- Strikes count towards booking restrictions until they leave the strike window or are forgiven
+CREATE TABLE IF NOT EXISTS user_strikes (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  user_id INTEGER NOT NULL,
+  booking_id INTEGER,
+  reason TEXT NOT NULL CHECK(reason IN ('no_show', 'late_cancellation')),
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  forgiven_at TIMESTAMP,
+  forgiven_by INTEGER,
+  forgive_reason TEXT,
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+  FOREIGN KEY (booking_id) REFERENCES bookings(id) ON DELETE SET NULL,
+  FOREIGN KEY (forgiven_by) REFERENCES users(id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS idx_user_strikes_user ON user_strikes(user_id, created_at);
+
+INSERT OR IGNORE INTO system_settings (key, value) VALUES
+  ('strike_window_days', '90'),
+  ('strike_warning_threshold', '2'),
+  ('strike_restriction_threshold', '3');
+`,
+			"mysql": `
+-- Strikes count towards booking restrictions until they leave the strike window or are forgiven
+CREATE TABLE IF NOT EXISTS user_strikes (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  user_id INT NOT NULL,
+  booking_id INT,
+  reason VARCHAR(20) NOT NULL CHECK(reason IN ('no_show', 'late_cancellation')),
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  forgiven_at DATETIME,
+  forgiven_by INT,
+  forgive_reason TEXT,
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+  FOREIGN KEY (booking_id) REFERENCES bookings(id) ON DELETE SET NULL,
+  FOREIGN KEY (forgiven_by) REFERENCES users(id) ON DELETE SET NULL,
+  INDEX idx_user_strikes_user (user_id, created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+INSERT IGNORE INTO system_settings (` + "`key`" + `, value) VALUES
+  ('strike_window_days', '90'),
+  ('strike_warning_threshold', '2'),
+  ('strike_restriction_threshold', '3');
+`,
+			"postgres": `
+-- Strikes count towards booking restrictions until they leave the strike window or are forgiven
+CREATE TABLE IF NOT EXISTS user_strikes (
+  id SERIAL PRIMARY KEY,
+  user_id INTEGER NOT NULL,
+  booking_id INTEGER,
+  reason VARCHAR(20) NOT NULL CHECK(reason IN ('no_show', 'late_cancellation')),
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  forgiven_at TIMESTAMP WITH TIME ZONE,
+  forgiven_by INTEGER,
+  forgive_reason TEXT,
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+  FOREIGN KEY (booking_id) REFERENCES bookings(id) ON DELETE SET NULL,
+  FOREIGN KEY (forgiven_by) REFERENCES users(id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS idx_user_strikes_user ON user_strikes(user_id, created_at);
+
+INSERT INTO system_settings (key, value) VALUES
+  ('strike_window_days', '90'),
+  ('strike_warning_threshold', '2'),
+  ('strike_restriction_threshold', '3')
+ON CONFLICT (key) DO NOTHING;
+`,
+		},
+	})
+}
@@ -0,0 +1,35 @@
+package database
+
+func init() {
+	RegisterMigration(&Migration{
+		ID:          "010_booking_quotas",
+		Description: "Add per-user booking quota settings",
+		Up: map[string]string{
+			"sqlite": `
+-- Per-user booking limits (0 = unlimited) and the hour the newest bookable day opens (0 = midnight)
+INSERT OR IGNORE INTO system_settings (key, value) VALUES
+  ('max_future_bookings', '0'),
+  ('max_bookings_per_day', '0'),
+  ('max_bookings_per_dog_per_week', '0'),
+  ('booking_release_hour', '0');
+`,
+			"mysql": `
+-- Per-user booking limits (0 = unlimited) and the hour the newest bookable day opens (0 = midnight)
+INSERT IGNORE INTO system_settings (` + "`key`" + `, value) VALUES
+  ('max_future_bookings', '0'),
+  ('max_bookings_per_day', '0'),
+  ('max_bookings_per_dog_per_week', '0'),
+  ('booking_release_hour', '0');
+`,
+			"postgres": `
+-- Per-user booking limits (0 = unlimited) and the hour the newest bookable day opens (0 = midnight)
+INSERT INTO system_settings (key, value) VALUES
+  ('max_future_bookings', '0'),
+  ('max_bookings_per_day', '0'),
+  ('max_bookings_per_dog_per_week', '0'),
+  ('booking_release_hour', '0')
+ON CONFLICT (key) DO NOTHING;
+`,
+		},
+	})
+}
@@ -0,0 +1,92 @@
+package database
+
+func init() {
+	RegisterMigration(&Migration{
+		ID:          "011_booking_transfers",
+		Description: "Add booking hand-over between volunteers",
+		Up: map[string]string{
+			"sqlite": `
+-- Booking hand-overs: the owner offers a booking, another walker takes it over.
+-- Rows are kept as the booking's transfer history.
+CREATE TABLE IF NOT EXISTS booking_transfers (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  booking_id INTEGER NOT NULL,
+  from_user_id INTEGER NOT NULL,
+  to_user_id INTEGER,
+  status TEXT DEFAULT 'offered' CHECK(status IN ('offered', 'pending_approval', 'completed', 'withdrawn', 'rejected')),
+  note TEXT,
+  accepted_at TIMESTAMP,
+  decided_by INTEGER,
+  decided_at TIMESTAMP,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (booking_id) REFERENCES bookings(id) ON DELETE CASCADE,
+  FOREIGN KEY (from_user_id) REFERENCES users(id) ON DELETE CASCADE,
+  FOREIGN KEY (to_user_id) REFERENCES users(id) ON DELETE SET NULL,
+  FOREIGN KEY (decided_by) REFERENCES users(id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS idx_booking_transfers_booking ON booking_transfers(booking_id);
+CREATE INDEX IF NOT EXISTS idx_booking_transfers_status ON booking_transfers(status);
+
+-- Whether an admin has to approve a hand-over before the booking changes owner
+INSERT OR IGNORE INTO system_settings (key, value) VALUES
+  ('booking_transfer_requires_approval', 'false');
+`,
+			"mysql": `
+-- Booking hand-overs: the owner offers a booking, another walker takes it over.
+-- Rows are kept as the booking's transfer history.
+CREATE TABLE IF NOT EXISTS booking_transfers (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  booking_id INT NOT NULL,
+  from_user_id INT NOT NULL,
+  to_user_id INT,
+  status VARCHAR(20) DEFAULT 'offered' CHECK(status IN ('offered', 'pending_approval', 'completed', 'withdrawn', 'rejected')),
+  note TEXT,
+  accepted_at DATETIME,
+  decided_by INT,
+  decided_at DATETIME,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  FOREIGN KEY (booking_id) REFERENCES bookings(id) ON DELETE CASCADE,
+  FOREIGN KEY (from_user_id) REFERENCES users(id) ON DELETE CASCADE,
+  FOREIGN KEY (to_user_id) REFERENCES users(id) ON DELETE SET NULL,
+  FOREIGN KEY (decided_by) REFERENCES users(id) ON DELETE SET NULL,
+  INDEX idx_booking_transfers_booking (booking_id),
+  INDEX idx_booking_transfers_status (status)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Whether an admin has to approve a hand-over before the booking changes owner
+INSERT IGNORE INTO system_settings (` + "`key`" + `, value) VALUES
+  ('booking_transfer_requires_approval', 'false');
+`,
+			"postgres": `
+-- Booking hand-overs: the owner offers a booking, another walker takes it over.
+-- Rows are kept as the booking's transfer history.
+CREATE TABLE IF NOT EXISTS booking_transfers (
+  id SERIAL PRIMARY KEY,
+  booking_id INTEGER NOT NULL,
+  from_user_id INTEGER NOT NULL,
+  to_user_id INTEGER,
+  status VARCHAR(20) DEFAULT 'offered' CHECK(status IN ('offered', 'pending_approval', 'completed', 'withdrawn', 'rejected')),
+  note TEXT,
+  accepted_at TIMESTAMP WITH TIME ZONE,
+  decided_by INTEGER,
+  decided_at TIMESTAMP WITH TIME ZONE,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (booking_id) REFERENCES bookings(id) ON DELETE CASCADE,
+  FOREIGN KEY (from_user_id) REFERENCES users(id) ON DELETE CASCADE,
+  FOREIGN KEY (to_user_id) REFERENCES users(id) ON DELETE SET NULL,
+  FOREIGN KEY (decided_by) REFERENCES users(id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS idx_booking_transfers_booking ON booking_transfers(booking_id);
+CREATE INDEX IF NOT EXISTS idx_booking_transfers_status ON booking_transfers(status);
+
+-- Whether an admin has to approve a hand-over before the booking changes owner
+INSERT INTO system_settings (key, value) VALUES
+  ('booking_transfer_requires_approval', 'false')
+ON CONFLICT (key) DO NOTHING;
+`,
+		},
+	})
+}
@@ -0,0 +1,37 @@
+package database
+
+func init() {
+	RegisterMigration(&Migration{
+		ID:          "012_calendar_tokens",
+		Description: "Add secret tokens for personal iCalendar feeds",
+		Up: map[string]string{
+			"sqlite": `
+-- One secret feed token per user; calendar apps cannot send a login, so the token is the credential
+CREATE TABLE IF NOT EXISTS calendar_tokens (
+  user_id INTEGER PRIMARY KEY,
+  token TEXT NOT NULL UNIQUE,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+`,
+			"mysql": `
+-- One secret feed token per user; calendar apps cannot send a login, so the token is the credential
+CREATE TABLE IF NOT EXISTS calendar_tokens (
+  user_id INT PRIMARY KEY,
+  token VARCHAR(64) NOT NULL UNIQUE,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+`,
+			"postgres": `
+-- One secret feed token per user; calendar apps cannot send a login, so the token is the credential
+CREATE TABLE IF NOT EXISTS calendar_tokens (
+  user_id INTEGER PRIMARY KEY,
+  token VARCHAR(64) NOT NULL UNIQUE,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+`,
+		},
+	})
+}
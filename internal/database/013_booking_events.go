@@ -0,0 +1,55 @@
+package database
+
+func init() {
+	RegisterMigration(&Migration{
+		ID:          "013_booking_events",
+		Description: "Add booking change history",
+		Up: map[string]string{
+			"sqlite": `
+-- Append-only history of booking changes; actor_id is NULL for changes made by the system
+CREATE TABLE IF NOT EXISTS booking_events (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  booking_id INTEGER NOT NULL,
+  event_type TEXT NOT NULL CHECK(event_type IN ('created', 'approved', 'rejected', 'moved', 'cancelled', 'notes', 'completed', 'no_show', 'transferred')),
+  actor_id INTEGER,
+  old_value TEXT,
+  new_value TEXT,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (booking_id) REFERENCES bookings(id) ON DELETE CASCADE,
+  FOREIGN KEY (actor_id) REFERENCES users(id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS idx_booking_events_booking ON booking_events(booking_id, created_at);
+`,
+			"mysql": `
+-- Append-only history of booking changes; actor_id is NULL for changes made by the system
+CREATE TABLE IF NOT EXISTS booking_events (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  booking_id INT NOT NULL,
+  event_type VARCHAR(20) NOT NULL CHECK(event_type IN ('created', 'approved', 'rejected', 'moved', 'cancelled', 'notes', 'completed', 'no_show', 'transferred')),
+  actor_id INT,
+  old_value TEXT,
+  new_value TEXT,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (booking_id) REFERENCES bookings(id) ON DELETE CASCADE,
+  FOREIGN KEY (actor_id) REFERENCES users(id) ON DELETE SET NULL,
+  INDEX idx_booking_events_booking (booking_id, created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+`,
+			"postgres": `
+-- Append-only history of booking changes; actor_id is NULL for changes made by the system
+CREATE TABLE IF NOT EXISTS booking_events (
+  id SERIAL PRIMARY KEY,
+  booking_id INTEGER NOT NULL,
+  event_type VARCHAR(20) NOT NULL CHECK(event_type IN ('created', 'approved', 'rejected', 'moved', 'cancelled', 'notes', 'completed', 'no_show', 'transferred')),
+  actor_id INTEGER,
+  old_value TEXT,
+  new_value TEXT,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (booking_id) REFERENCES bookings(id) ON DELETE CASCADE,
+  FOREIGN KEY (actor_id) REFERENCES users(id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS idx_booking_events_booking ON booking_events(booking_id, created_at);
+`,
+		},
+	})
+}
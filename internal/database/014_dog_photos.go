@@ -0,0 +1,61 @@
+package database
+
+func init() {
+	RegisterMigration(&Migration{
+		ID:          "014_dog_photos",
+		Description: "Add dog photo galleries",
+		Up: map[string]string{
+			"sqlite": `
+-- Ordered photo gallery per dog; the cover photo stays in dogs.photo / dogs.photo_thumbnail
+CREATE TABLE IF NOT EXISTS dog_photos (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  dog_id INTEGER NOT NULL,
+  photo_path TEXT NOT NULL,
+  photo_thumbnail TEXT NOT NULL,
+  display_order INTEGER DEFAULT 0,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (dog_id) REFERENCES dogs(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_dog_photos_dog ON dog_photos(dog_id, display_order);
+
+-- Existing single photos become the first gallery entry
+INSERT INTO dog_photos (dog_id, photo_path, photo_thumbnail, display_order)
+SELECT id, photo, COALESCE(photo_thumbnail, photo), 0 FROM dogs WHERE photo IS NOT NULL AND photo <> '';
+`,
+			"mysql": `
+-- Ordered photo gallery per dog; the cover photo stays in dogs.photo / dogs.photo_thumbnail
+CREATE TABLE IF NOT EXISTS dog_photos (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  dog_id INT NOT NULL,
+  photo_path VARCHAR(255) NOT NULL,
+  photo_thumbnail VARCHAR(255) NOT NULL,
+  display_order INT DEFAULT 0,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (dog_id) REFERENCES dogs(id) ON DELETE CASCADE,
+  INDEX idx_dog_photos_dog (dog_id, display_order)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Existing single photos become the first gallery entry
+INSERT INTO dog_photos (dog_id, photo_path, photo_thumbnail, display_order)
+SELECT id, photo, COALESCE(photo_thumbnail, photo), 0 FROM dogs WHERE photo IS NOT NULL AND photo <> '';
+`,
+			"postgres": `
+-- Ordered photo gallery per dog; the cover photo stays in dogs.photo / dogs.photo_thumbnail
+CREATE TABLE IF NOT EXISTS dog_photos (
+  id SERIAL PRIMARY KEY,
+  dog_id INTEGER NOT NULL,
+  photo_path VARCHAR(255) NOT NULL,
+  photo_thumbnail VARCHAR(255) NOT NULL,
+  display_order INTEGER DEFAULT 0,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (dog_id) REFERENCES dogs(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_dog_photos_dog ON dog_photos(dog_id, display_order);
+
+-- Existing single photos become the first gallery entry
+INSERT INTO dog_photos (dog_id, photo_path, photo_thumbnail, display_order)
+SELECT id, photo, COALESCE(photo_thumbnail, photo), 0 FROM dogs WHERE photo IS NOT NULL AND photo <> '';
+`,
+		},
+	})
+}
@@ -0,0 +1,82 @@
+package database
+
+func init() {
+	RegisterMigration(&Migration{
+		ID:          "015_dog_care",
+		Description: "Add dog care log (vaccinations, medication, vet appointments, injuries)",
+		Up: map[string]string{
+			"sqlite": `
+-- Care log per dog. schedule_times holds the daily dose times of a medication ("08:00,18:00");
+-- blocked_date_id is the dog block created for a vet appointment
+CREATE TABLE IF NOT EXISTS dog_care_entries (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  dog_id INTEGER NOT NULL,
+  entry_type TEXT NOT NULL CHECK(entry_type IN ('vaccination', 'medication', 'vet_appointment', 'injury')),
+  title TEXT NOT NULL,
+  notes TEXT,
+  start_date DATE NOT NULL,
+  end_date DATE,
+  appointment_time TEXT,
+  dosage TEXT,
+  schedule_times TEXT,
+  blocked_date_id INTEGER,
+  created_by INTEGER,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (dog_id) REFERENCES dogs(id) ON DELETE CASCADE,
+  FOREIGN KEY (blocked_date_id) REFERENCES blocked_dates(id) ON DELETE SET NULL,
+  FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS idx_dog_care_entries_dog ON dog_care_entries(dog_id, entry_type, start_date);
+`,
+			"mysql": `
+-- Care log per dog. schedule_times holds the daily dose times of a medication ("08:00,18:00");
+-- blocked_date_id is the dog block created for a vet appointment
+CREATE TABLE IF NOT EXISTS dog_care_entries (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  dog_id INT NOT NULL,
+  entry_type VARCHAR(20) NOT NULL CHECK(entry_type IN ('vaccination', 'medication', 'vet_appointment', 'injury')),
+  title VARCHAR(200) NOT NULL,
+  notes TEXT,
+  start_date DATE NOT NULL,
+  end_date DATE,
+  appointment_time VARCHAR(5),
+  dosage VARCHAR(200),
+  schedule_times VARCHAR(255),
+  blocked_date_id INT,
+  created_by INT,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  FOREIGN KEY (dog_id) REFERENCES dogs(id) ON DELETE CASCADE,
+  FOREIGN KEY (blocked_date_id) REFERENCES blocked_dates(id) ON DELETE SET NULL,
+  FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL,
+  INDEX idx_dog_care_entries_dog (dog_id, entry_type, start_date)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+`,
+			"postgres": `
+-- Care log per dog. schedule_times holds the daily dose times of a medication ("08:00,18:00");
+-- blocked_date_id is the dog block created for a vet appointment
+CREATE TABLE IF NOT EXISTS dog_care_entries (
+  id SERIAL PRIMARY KEY,
+  dog_id INTEGER NOT NULL,
+  entry_type VARCHAR(20) NOT NULL CHECK(entry_type IN ('vaccination', 'medication', 'vet_appointment', 'injury')),
+  title VARCHAR(200) NOT NULL,
+  notes TEXT,
+  start_date DATE NOT NULL,
+  end_date DATE,
+  appointment_time VARCHAR(5),
+  dosage VARCHAR(200),
+  schedule_times VARCHAR(255),
+  blocked_date_id INTEGER,
+  created_by INTEGER,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (dog_id) REFERENCES dogs(id) ON DELETE CASCADE,
+  FOREIGN KEY (blocked_date_id) REFERENCES blocked_dates(id) ON DELETE SET NULL,
+  FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS idx_dog_care_entries_dog ON dog_care_entries(dog_id, entry_type, start_date);
+`,
+		},
+	})
+}
@@ -0,0 +1,67 @@
+package database
+
+func init() {
+	RegisterMigration(&Migration{
+		ID:          "016_dog_walker_rules",
+		Description: "Add per-dog walker allowlists and denylists",
+		Up: map[string]string{
+			"sqlite": `
+-- Walkers an admin explicitly allowed ('allow') or forbade ('deny') to walk a dog.
+-- expires_at is the last day the rule applies (NULL = no expiry)
+CREATE TABLE IF NOT EXISTS dog_walker_rules (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  dog_id INTEGER NOT NULL,
+  user_id INTEGER NOT NULL,
+  rule_type TEXT NOT NULL CHECK(rule_type IN ('allow', 'deny')),
+  reason TEXT NOT NULL,
+  expires_at DATE,
+  created_by INTEGER,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (dog_id) REFERENCES dogs(id) ON DELETE CASCADE,
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+  FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL,
+  UNIQUE(dog_id, user_id)
+);
+CREATE INDEX IF NOT EXISTS idx_dog_walker_rules_user ON dog_walker_rules (user_id);
+`,
+			"mysql": `
+-- Walkers an admin explicitly allowed ('allow') or forbade ('deny') to walk a dog.
+-- expires_at is the last day the rule applies (NULL = no expiry)
+CREATE TABLE IF NOT EXISTS dog_walker_rules (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  dog_id INT NOT NULL,
+  user_id INT NOT NULL,
+  rule_type VARCHAR(10) NOT NULL CHECK(rule_type IN ('allow', 'deny')),
+  reason TEXT NOT NULL,
+  expires_at DATE,
+  created_by INT,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (dog_id) REFERENCES dogs(id) ON DELETE CASCADE,
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+  FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL,
+  UNIQUE KEY unique_dog_walker_rule (dog_id, user_id),
+  INDEX idx_dog_walker_rules_user (user_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+`,
+			"postgres": `
+-- Walkers an admin explicitly allowed ('allow') or forbade ('deny') to walk a dog.
+-- expires_at is the last day the rule applies (NULL = no expiry)
+CREATE TABLE IF NOT EXISTS dog_walker_rules (
+  id SERIAL PRIMARY KEY,
+  dog_id INTEGER NOT NULL,
+  user_id INTEGER NOT NULL,
+  rule_type VARCHAR(10) NOT NULL CHECK(rule_type IN ('allow', 'deny')),
+  reason TEXT NOT NULL,
+  expires_at DATE,
+  created_by INTEGER,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (dog_id) REFERENCES dogs(id) ON DELETE CASCADE,
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+  FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL,
+  UNIQUE(dog_id, user_id)
+);
+CREATE INDEX IF NOT EXISTS idx_dog_walker_rules_user ON dog_walker_rules (user_id);
+`,
+		},
+	})
+}
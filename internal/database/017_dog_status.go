@@ -0,0 +1,73 @@
+package database
+
+func init() {
+	RegisterMigration(&Migration{
+		ID:          "017_dog_status",
+		Description: "Add dog status lifecycle (in shelter, reserved, adopted, deceased, transferred)",
+		Up: map[string]string{
+			"sqlite": `
+-- Dogs that left the shelter (adopted, deceased, transferred) are archived instead of deleted
+ALTER TABLE dogs ADD COLUMN status TEXT NOT NULL DEFAULT 'in_shelter';
+ALTER TABLE dogs ADD COLUMN status_date DATE;
+CREATE INDEX IF NOT EXISTS idx_dogs_status ON dogs(status);
+
+-- Every status transition with its effective date and note
+CREATE TABLE IF NOT EXISTS dog_status_events (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  dog_id INTEGER NOT NULL,
+  previous_status TEXT NOT NULL,
+  status TEXT NOT NULL CHECK(status IN ('in_shelter', 'reserved', 'adopted', 'deceased', 'transferred')),
+  effective_date DATE NOT NULL,
+  note TEXT NOT NULL,
+  created_by INTEGER,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (dog_id) REFERENCES dogs(id) ON DELETE CASCADE,
+  FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS idx_dog_status_events_dog ON dog_status_events(dog_id);
+`,
+			"mysql": `
+-- Dogs that left the shelter (adopted, deceased, transferred) are archived instead of deleted
+ALTER TABLE dogs ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'in_shelter';
+ALTER TABLE dogs ADD COLUMN status_date DATE;
+CREATE INDEX idx_dogs_status ON dogs(status);
+
+-- Every status transition with its effective date and note
+CREATE TABLE IF NOT EXISTS dog_status_events (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  dog_id INT NOT NULL,
+  previous_status VARCHAR(20) NOT NULL,
+  status VARCHAR(20) NOT NULL CHECK(status IN ('in_shelter', 'reserved', 'adopted', 'deceased', 'transferred')),
+  effective_date DATE NOT NULL,
+  note TEXT NOT NULL,
+  created_by INT,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (dog_id) REFERENCES dogs(id) ON DELETE CASCADE,
+  FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL,
+  INDEX idx_dog_status_events_dog (dog_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+`,
+			"postgres": `
+-- Dogs that left the shelter (adopted, deceased, transferred) are archived instead of deleted
+ALTER TABLE dogs ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'in_shelter';
+ALTER TABLE dogs ADD COLUMN IF NOT EXISTS status_date DATE;
+CREATE INDEX IF NOT EXISTS idx_dogs_status ON dogs(status);
+
+-- Every status transition with its effective date and note
+CREATE TABLE IF NOT EXISTS dog_status_events (
+  id SERIAL PRIMARY KEY,
+  dog_id INTEGER NOT NULL,
+  previous_status VARCHAR(20) NOT NULL,
+  status VARCHAR(20) NOT NULL CHECK(status IN ('in_shelter', 'reserved', 'adopted', 'deceased', 'transferred')),
+  effective_date DATE NOT NULL,
+  note TEXT NOT NULL,
+  created_by INTEGER,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (dog_id) REFERENCES dogs(id) ON DELETE CASCADE,
+  FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS idx_dog_status_events_dog ON dog_status_events(dog_id);
+`,
+		},
+	})
+}
@@ -0,0 +1,25 @@
+package database
+
+func init() {
+	RegisterMigration(&Migration{
+		ID:          "018_dog_walk_limits",
+		Description: "Add per-dog daily walk limit and minimum rest between walks",
+		Up: map[string]string{
+			"sqlite": `
+-- NULL = no limit. The rest is counted from the end of one walk to the start of the next
+ALTER TABLE dogs ADD COLUMN max_walks_per_day INTEGER;
+ALTER TABLE dogs ADD COLUMN min_rest_minutes INTEGER;
+`,
+			"mysql": `
+-- NULL = no limit. The rest is counted from the end of one walk to the start of the next
+ALTER TABLE dogs ADD COLUMN max_walks_per_day INT;
+ALTER TABLE dogs ADD COLUMN min_rest_minutes INT;
+`,
+			"postgres": `
+-- NULL = no limit. The rest is counted from the end of one walk to the start of the next
+ALTER TABLE dogs ADD COLUMN IF NOT EXISTS max_walks_per_day INTEGER;
+ALTER TABLE dogs ADD COLUMN IF NOT EXISTS min_rest_minutes INTEGER;
+`,
+		},
+	})
+}
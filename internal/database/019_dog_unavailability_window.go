@@ -0,0 +1,25 @@
+package database
+
+func init() {
+	RegisterMigration(&Migration{
+		ID:          "019_dog_unavailability_window",
+		Description: "Add scheduled unavailability windows for dogs",
+		Up: map[string]string{
+			"sqlite": `
+-- First and last day the dog is unavailable. NULL until = until made available again by hand
+ALTER TABLE dogs ADD COLUMN unavailable_from DATE;
+ALTER TABLE dogs ADD COLUMN unavailable_until DATE;
+`,
+			"mysql": `
+-- First and last day the dog is unavailable. NULL until = until made available again by hand
+ALTER TABLE dogs ADD COLUMN unavailable_from DATE;
+ALTER TABLE dogs ADD COLUMN unavailable_until DATE;
+`,
+			"postgres": `
+-- First and last day the dog is unavailable. NULL until = until made available again by hand
+ALTER TABLE dogs ADD COLUMN IF NOT EXISTS unavailable_from DATE;
+ALTER TABLE dogs ADD COLUMN IF NOT EXISTS unavailable_until DATE;
+`,
+		},
+	})
+}
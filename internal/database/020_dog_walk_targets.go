@@ -0,0 +1,22 @@
+package database
+
+func init() {
+	RegisterMigration(&Migration{
+		ID:          "020_dog_walk_targets",
+		Description: "Add weekly walk targets for dogs",
+		Up: map[string]string{
+			"sqlite": `
+-- Completed walks a dog should get per 7 days; NULL = no target
+ALTER TABLE dogs ADD COLUMN target_walks_per_week INTEGER;
+`,
+			"mysql": `
+-- Completed walks a dog should get per 7 days; NULL = no target
+ALTER TABLE dogs ADD COLUMN target_walks_per_week INT;
+`,
+			"postgres": `
+-- Completed walks a dog should get per 7 days; NULL = no target
+ALTER TABLE dogs ADD COLUMN IF NOT EXISTS target_walks_per_week INTEGER;
+`,
+		},
+	})
+}
@@ -0,0 +1,24 @@
+package database
+
+func init() {
+	RegisterMigration(&Migration{
+		ID:          "021_dog_shelter_id",
+		Description: "Add external shelter IDs for dog import and export",
+		Up: map[string]string{
+			"sqlite": `
+-- ID of the dog in the shelter's own records; bulk imports update the dog with the same ID
+ALTER TABLE dogs ADD COLUMN shelter_id TEXT;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_dogs_shelter_id ON dogs(shelter_id);
+`,
+			"mysql": `
+-- ID of the dog in the shelter's own records; bulk imports update the dog with the same ID
+ALTER TABLE dogs ADD COLUMN shelter_id VARCHAR(100), ADD UNIQUE KEY idx_dogs_shelter_id (shelter_id);
+`,
+			"postgres": `
+-- ID of the dog in the shelter's own records; bulk imports update the dog with the same ID
+ALTER TABLE dogs ADD COLUMN IF NOT EXISTS shelter_id VARCHAR(100);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_dogs_shelter_id ON dogs(shelter_id);
+`,
+		},
+	})
+}
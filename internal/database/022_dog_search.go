@@ -0,0 +1,52 @@
+package database
+
+import "strings"
+
+// dogSearchColumns are the dog columns covered by the full-text index
+// The repository searches exactly these columns (see DogRepository.FindAll)
+var dogSearchColumns = []string{"name", "breed", "special_needs", "special_instructions", "walk_route"}
+
+func init() {
+	columns := strings.Join(dogSearchColumns, ", ")
+	newColumns := "new." + strings.Join(dogSearchColumns, ", new.")
+	oldColumns := "old." + strings.Join(dogSearchColumns, ", old.")
+
+	RegisterMigration(&Migration{
+		ID:          "022_dog_search",
+		Description: "Add full-text search index for dogs",
+		Up: map[string]string{
+			"sqlite": `
+-- External content FTS5 table over the dogs table, kept in sync by triggers
+-- remove_diacritics lets "Schaferhund" find "Schäferhund"
+CREATE VIRTUAL TABLE IF NOT EXISTS dogs_fts USING fts5(
+  ` + columns + `,
+  content='dogs',
+  content_rowid='id',
+  tokenize='unicode61 remove_diacritics 2'
+);
+
+CREATE TRIGGER IF NOT EXISTS dogs_fts_insert AFTER INSERT ON dogs BEGIN
+  INSERT INTO dogs_fts(rowid, ` + columns + `) VALUES (new.id, ` + newColumns + `);
+END;
+
+CREATE TRIGGER IF NOT EXISTS dogs_fts_delete AFTER DELETE ON dogs BEGIN
+  INSERT INTO dogs_fts(dogs_fts, rowid, ` + columns + `) VALUES ('delete', old.id, ` + oldColumns + `);
+END;
+
+CREATE TRIGGER IF NOT EXISTS dogs_fts_update AFTER UPDATE ON dogs BEGIN
+  INSERT INTO dogs_fts(dogs_fts, rowid, ` + columns + `) VALUES ('delete', old.id, ` + oldColumns + `);
+  INSERT INTO dogs_fts(rowid, ` + columns + `) VALUES (new.id, ` + newColumns + `);
+END;
+
+-- Index the dogs that already exist
+INSERT INTO dogs_fts(dogs_fts) VALUES ('rebuild');
+`,
+			"mysql": `
+ALTER TABLE dogs ADD FULLTEXT INDEX idx_dogs_search (` + columns + `);
+`,
+			"postgres": `
+CREATE INDEX IF NOT EXISTS idx_dogs_search ON dogs USING GIN ((` + postgresSearchDocument(dogSearchColumns) + `));
+`,
+		},
+	})
+}
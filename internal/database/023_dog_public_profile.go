@@ -0,0 +1,22 @@
+package database
+
+func init() {
+	RegisterMigration(&Migration{
+		ID:          "023_dog_public_profile",
+		Description: "Add public profile pages for adoptable dogs",
+		Up: map[string]string{
+			"sqlite": `
+-- Public dogs get a shareable profile page at /hunde/{slug}
+ALTER TABLE dogs ADD COLUMN is_public INTEGER DEFAULT 0;
+`,
+			"mysql": `
+-- Public dogs get a shareable profile page at /hunde/{slug}
+ALTER TABLE dogs ADD COLUMN is_public TINYINT(1) DEFAULT 0;
+`,
+			"postgres": `
+-- Public dogs get a shareable profile page at /hunde/{slug}
+ALTER TABLE dogs ADD COLUMN IF NOT EXISTS is_public BOOLEAN DEFAULT FALSE;
+`,
+		},
+	})
+}
@@ -3,6 +3,7 @@ package database
 import (
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	_ "github.com/go-sql-driver/mysql"
@@ -70,7 +71,7 @@ func InitializeWithConfig(config *DBConfig) (*sql.DB, Dialect, error) {
 		if dsn == "" {
 			dsn = "./gassigeher.db"
 		}
-		db, err = sql.Open(dialect.GetDriverName(), dsn)
+		db, err = sql.Open(dialect.GetDriverName(), buildSQLiteDSN(dsn))
 
 	case "mysql":
 		dsn := config.ConnectionString
@@ -112,6 +113,16 @@ func InitializeWithConfig(config *DBConfig) (*sql.DB, Dialect, error) {
 	return db, dialect, nil
 }
 
+// buildSQLiteDSN adds connection options to a SQLite path unless it already has some.
+// Transactions take the write lock when they begin and wait up to 5 seconds for another
+// writer instead of failing with "database is locked", so concurrent bookings are serialized.
+func buildSQLiteDSN(path string) string {
+	if strings.Contains(path, "?") {
+		return path
+	}
+	return path + "?_txlock=immediate&_pragma=busy_timeout(5000)"
+}
+
 // buildMySQLDSN builds a MySQL connection string
 // Format: username:password@tcp(host:port)/database?parseTime=true&charset=utf8mb4
 func buildMySQLDSN(config *DBConfig) string {
@@ -1,6 +1,10 @@
 package database
 
-import "database/sql"
+import (
+	"database/sql"
+	"strings"
+	"unicode"
+)
 
 // Dialect defines database-specific SQL syntax and behaviors
 // This interface allows the application to work with SQLite, MySQL, and PostgreSQL
@@ -109,6 +113,23 @@ type Dialect interface {
 	// Used when inserting timestamps from Go code
 	// All databases handle this via driver, but allows customization
 	ConvertGoTime(goTime string) string
+
+	// FullTextSearch returns a WHERE condition (and its arguments) matching the rows of
+	// tableName whose columns contain every word of query as a word prefix, using the
+	// full-text index over exactly these columns (see 022_dog_search.go)
+	// Returns an empty condition if the index cannot answer the query; callers fall back to LIKE
+	// SQLite: "dogs.id IN (SELECT rowid FROM dogs_fts WHERE dogs_fts MATCH ?)" (FTS5)
+	// MySQL: "MATCH(cols) AGAINST(? IN BOOLEAN MODE)" (FULLTEXT, words of 3+ characters)
+	// PostgreSQL: "to_tsvector('simple', cols) @@ to_tsquery('simple', ?)" (GIN index)
+	FullTextSearch(tableName string, columns []string, query string) (string, []interface{})
+}
+
+// searchWords splits a search query into words of letters and digits
+// Everything else is dropped, so the words are safe inside any full-text query syntax
+func searchWords(query string) []string {
+	return strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
 }
 
 // GetDialect returns the appropriate dialect for a database type
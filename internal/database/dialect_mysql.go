@@ -4,8 +4,12 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"unicode/utf8"
 )
 
+// mysqlMinTokenSize is InnoDB's default innodb_ft_min_token_size; shorter words are not indexed
+const mysqlMinTokenSize = 3
+
 // MySQLDialect implements the Dialect interface for MySQL
 type MySQLDialect struct{}
 
@@ -141,3 +145,24 @@ func (d *MySQLDialect) QuoteIdentifier(identifier string) string {
 func (d *MySQLDialect) ConvertGoTime(goTime string) string {
 	return goTime // Driver handles conversion
 }
+
+// FullTextSearch returns a boolean-mode MATCH ... AGAINST condition
+// +word* requires every word as a prefix. Words shorter than the minimum token size are
+// not in the FULLTEXT index, so such queries fall back to LIKE
+func (d *MySQLDialect) FullTextSearch(tableName string, columns []string, query string) (string, []interface{}) {
+	words := searchWords(query)
+	if len(words) == 0 {
+		return "", nil
+	}
+
+	terms := make([]string, len(words))
+	for i, word := range words {
+		if utf8.RuneCountInString(word) < mysqlMinTokenSize {
+			return "", nil
+		}
+		terms[i] = "+" + word + "*"
+	}
+
+	return fmt.Sprintf("MATCH(%s) AGAINST(? IN BOOLEAN MODE)", strings.Join(columns, ", ")),
+		[]interface{}{strings.Join(terms, " ")}
+}
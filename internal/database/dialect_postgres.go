@@ -148,3 +148,32 @@ func (d *PostgreSQLDialect) QuoteIdentifier(identifier string) string {
 func (d *PostgreSQLDialect) ConvertGoTime(goTime string) string {
 	return goTime // Driver handles conversion
 }
+
+// FullTextSearch returns a tsvector @@ tsquery condition
+// The document expression must match the GIN index expression for the index to be used;
+// postgresSearchDocument builds both. word:* matches word prefixes
+func (d *PostgreSQLDialect) FullTextSearch(tableName string, columns []string, query string) (string, []interface{}) {
+	words := searchWords(query)
+	if len(words) == 0 {
+		return "", nil
+	}
+
+	terms := make([]string, len(words))
+	for i, word := range words {
+		terms[i] = word + ":*"
+	}
+
+	return postgresSearchDocument(columns) + " @@ to_tsquery('simple', ?)",
+		[]interface{}{strings.Join(terms, " & ")}
+}
+
+// postgresSearchDocument returns the tsvector expression over the given columns
+// The 'simple' configuration neither stems nor drops stop words, so German and English
+// texts are searched alike
+func postgresSearchDocument(columns []string) string {
+	parts := make([]string, len(columns))
+	for i, column := range columns {
+		parts[i] = fmt.Sprintf("coalesce(%s, '')", column)
+	}
+	return fmt.Sprintf("to_tsvector('simple', %s)", strings.Join(parts, " || ' ' || "))
+}
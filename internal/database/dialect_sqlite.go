@@ -130,3 +130,22 @@ func (d *SQLiteDialect) QuoteIdentifier(identifier string) string {
 func (d *SQLiteDialect) ConvertGoTime(goTime string) string {
 	return goTime // Driver handles conversion
 }
+
+// FullTextSearch returns an FTS5 condition on the <tableName>_fts table
+// The column filter limits the match to the given columns, "word"* matches word prefixes
+func (d *SQLiteDialect) FullTextSearch(tableName string, columns []string, query string) (string, []interface{}) {
+	words := searchWords(query)
+	if len(words) == 0 {
+		return "", nil
+	}
+
+	terms := make([]string, len(words))
+	for i, word := range words {
+		terms[i] = `"` + word + `"*`
+	}
+	match := fmt.Sprintf("{%s} : (%s)", strings.Join(columns, " "), strings.Join(terms, " AND "))
+
+	ftsTable := tableName + "_fts"
+	return fmt.Sprintf("%s.id IN (SELECT rowid FROM %s WHERE %s MATCH ?)", tableName, ftsTable, ftsTable),
+		[]interface{}{match}
+}
@@ -514,6 +514,44 @@ func TestDialect_AddColumnSyntax(t *testing.T) {
 	}
 }
 
+// TestDialect_FullTextSearch tests full-text search conditions
+func TestDialect_FullTextSearch(t *testing.T) {
+	columns := []string{"name", "walk_route"}
+
+	testCases := []struct {
+		name      string
+		dialect   Dialect
+		condition string
+		arg       string
+	}{
+		{"SQLite", NewSQLiteDialect(), "dogs.id IN (SELECT rowid FROM dogs_fts WHERE dogs_fts MATCH ?)",
+			`{name walk_route} : ("schäfer"* AND "park"*)`},
+		{"MySQL", NewMySQLDialect(), "MATCH(name, walk_route) AGAINST(? IN BOOLEAN MODE)",
+			"+schäfer* +park*"},
+		{"PostgreSQL", NewPostgreSQLDialect(),
+			"to_tsvector('simple', coalesce(name, '') || ' ' || coalesce(walk_route, '')) @@ to_tsquery('simple', ?)",
+			"schäfer:* & park:*"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Punctuation and operators of the full-text syntax are dropped
+			condition, args := tc.dialect.FullTextSearch("dogs", columns, ` Schäfer "-Park*" `)
+			assert.Equal(t, tc.condition, condition)
+			assert.Equal(t, []interface{}{tc.arg}, args)
+
+			condition, args = tc.dialect.FullTextSearch("dogs", columns, `"*" -`)
+			assert.Empty(t, condition, "Query without words should fall back to LIKE")
+			assert.Nil(t, args)
+		})
+	}
+
+	t.Run("MySQL_ShortWordFallsBack", func(t *testing.T) {
+		condition, _ := NewMySQLDialect().FullTextSearch("dogs", columns, "Rex am See")
+		assert.Empty(t, condition)
+	})
+}
+
 // TestDialect_TableCreationSuffix tests table creation suffixes
 func TestDialect_TableCreationSuffix(t *testing.T) {
 	testCases := []struct {
@@ -207,6 +207,12 @@ func TestBuildMySQLDSN(t *testing.T) {
 	}
 }
 
+// TestBuildSQLiteDSN tests SQLite connection options
+func TestBuildSQLiteDSN(t *testing.T) {
+	assert.Equal(t, "./gassigeher.db?_txlock=immediate&_pragma=busy_timeout(5000)", buildSQLiteDSN("./gassigeher.db"))
+	assert.Equal(t, "file:test.db?mode=ro", buildSQLiteDSN("file:test.db?mode=ro"), "explicit options are kept")
+}
+
 // TestBuildPostgreSQLDSN tests PostgreSQL connection string builder
 func TestBuildPostgreSQLDSN(t *testing.T) {
 	testCases := []struct {
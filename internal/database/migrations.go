@@ -51,6 +51,15 @@ func RunMigrationsWithDialect(db *sql.DB, dialect Dialect) error {
 		return fmt.Errorf("failed to get applied migrations: %w", err)
 	}
 
+	// SQLite migrations that rebuild tables switch foreign keys off (otherwise dropping
+	// the old table cascades to child tables); restore the connection's setting afterwards
+	if dialect.Name() == "sqlite" {
+		var foreignKeys int
+		if err := db.QueryRow("PRAGMA foreign_keys").Scan(&foreignKeys); err == nil {
+			defer db.Exec(fmt.Sprintf("PRAGMA foreign_keys = %d", foreignKeys))
+		}
+	}
+
 	// Get all registered migrations
 	allMigrations := GetAllMigrations()
 
@@ -14,8 +14,8 @@ import (
 func TestMigrationRegistry(t *testing.T) {
 	migrations := GetAllMigrations()
 
-	t.Run("All_2_migrations_registered", func(t *testing.T) {
-		assert.Len(t, migrations, 2, "Should have 2 migrations (consolidated schema)")
+	t.Run("All_5_migrations_registered", func(t *testing.T) {
+		assert.Len(t, migrations, 23, "Should have 23 migrations (consolidated schema)")
 	})
 
 	t.Run("Migrations_have_unique_IDs", func(t *testing.T) {
@@ -74,13 +74,14 @@ func TestRunMigrations_SQLite(t *testing.T) {
 	var count int
 	err = db.QueryRow("SELECT COUNT(*) FROM schema_migrations").Scan(&count)
 	assert.NoError(t, err)
-	assert.Equal(t, 2, count, "Should have 2 applied migrations")
+	assert.Equal(t, 23, count, "Should have 23 applied migrations")
 
 	// Verify all tables created
 	tables := []string{
 		"users", "dogs", "bookings", "blocked_dates",
 		"experience_requests", "system_settings", "reactivation_requests",
-		"walk_reports", "walk_report_photos",
+		"walk_reports", "walk_report_photos", "dog_pair_walks", "booking_series", "booking_waitlist",
+		"user_strikes", "booking_transfers", "calendar_tokens", "booking_events", "dog_photos", "dog_care_entries", "dog_walker_rules", "dog_status_events",
 	}
 
 	for _, table := range tables {
@@ -91,7 +92,7 @@ func TestRunMigrations_SQLite(t *testing.T) {
 	// Verify default settings inserted (3 from migration 008 + 5 from migration 012 + 1 from migration 017 + 1 from migration 018 + 2 from migration 021 + 1 from migration 028)
 	err = db.QueryRow("SELECT COUNT(*) FROM system_settings").Scan(&count)
 	assert.NoError(t, err)
-	assert.Equal(t, 13, count, "Should have 13 default settings")
+	assert.Equal(t, 24, count, "Should have 24 default settings")
 
 	// Verify photo_thumbnail column exists in dogs table
 	err = db.QueryRow(`
@@ -120,16 +121,16 @@ func TestRunMigrations_Idempotent(t *testing.T) {
 	var count int
 	err = db.QueryRow("SELECT COUNT(*) FROM schema_migrations").Scan(&count)
 	assert.NoError(t, err)
-	assert.Equal(t, 2, count)
+	assert.Equal(t, 23, count)
 
 	// Run migrations second time (should be idempotent)
 	err = RunMigrationsWithDialect(db, dialect)
 	assert.NoError(t, err, "Second migration run should succeed (idempotent)")
 
-	// Count should still be 2 (no duplicates)
+	// Count should still be 23 (no duplicates)
 	err = db.QueryRow("SELECT COUNT(*) FROM schema_migrations").Scan(&count)
 	assert.NoError(t, err)
-	assert.Equal(t, 2, count, "Should still have 2 migrations (no duplicates)")
+	assert.Equal(t, 23, count, "Should still have 23 migrations (no duplicates)")
 }
 
 // TestGetMigrationStatus tests migration status reporting
@@ -147,7 +148,7 @@ func TestGetMigrationStatus(t *testing.T) {
 	applied, pending, err := GetMigrationStatus(db, dialect)
 	assert.NoError(t, err)
 	assert.Equal(t, 0, applied)
-	assert.Equal(t, 2, pending)
+	assert.Equal(t, 23, pending)
 
 	// After migrations
 	err = RunMigrationsWithDialect(db, dialect)
@@ -155,7 +156,7 @@ func TestGetMigrationStatus(t *testing.T) {
 
 	applied, pending, err = GetMigrationStatus(db, dialect)
 	assert.NoError(t, err)
-	assert.Equal(t, 2, applied)
+	assert.Equal(t, 23, applied)
 	assert.Equal(t, 0, pending)
 }
 
@@ -353,6 +354,27 @@ func TestMigrationOrder(t *testing.T) {
 	expectedOrder := []string{
 		"001_create_tables",
 		"002_insert_default_data",
+		"003_booking_buffer_setting",
+		"004_dog_pair_walks",
+		"005_booking_series",
+		"006_active_booking_slot_index",
+		"007_booking_waitlist",
+		"008_walk_check_in",
+		"009_user_strikes",
+		"010_booking_quotas",
+		"011_booking_transfers",
+		"012_calendar_tokens",
+		"013_booking_events",
+		"014_dog_photos",
+		"015_dog_care",
+		"016_dog_walker_rules",
+		"017_dog_status",
+		"018_dog_walk_limits",
+		"019_dog_unavailability_window",
+		"020_dog_walk_targets",
+		"021_dog_shelter_id",
+		"022_dog_search",
+		"023_dog_public_profile",
 	}
 
 	assert.Len(t, migrations, len(expectedOrder))
@@ -402,7 +424,7 @@ func TestMigrationRunner_PartialApplication(t *testing.T) {
 	var count int
 	err = db.QueryRow("SELECT COUNT(*) FROM schema_migrations").Scan(&count)
 	assert.NoError(t, err)
-	assert.Equal(t, 2, count, "Should have 2 migrations applied")
+	assert.Equal(t, 23, count, "Should have 23 migrations applied")
 }
 
 // TestIsAlreadyExistsError tests error detection for different databases
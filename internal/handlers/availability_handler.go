@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/tranmh/gassigeher/internal/config"
+	"github.com/tranmh/gassigeher/internal/middleware"
+	"github.com/tranmh/gassigeher/internal/repository"
+	"github.com/tranmh/gassigeher/internal/services"
+)
+
+var timeOfDayPattern = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+
+// AvailabilityHandler handles availability search requests
+type AvailabilityHandler struct {
+	db                  *sql.DB
+	cfg                 *config.Config
+	settingsRepo        *repository.SettingsRepository
+	availabilityService *services.AvailabilityService
+}
+
+// NewAvailabilityHandler creates a new availability handler
+func NewAvailabilityHandler(db *sql.DB, cfg *config.Config) *AvailabilityHandler {
+	settingsRepo := repository.NewSettingsRepository(db)
+	bookingRepo := repository.NewBookingRepository(db)
+	dogRepo := repository.NewDogRepository(db)
+	holidayService := services.NewHolidayService(repository.NewHolidayRepository(db), settingsRepo)
+	bookingTimeService := services.NewBookingTimeService(repository.NewBookingTimeRepository(db), holidayService, settingsRepo)
+
+	return &AvailabilityHandler{
+		db:           db,
+		cfg:          cfg,
+		settingsRepo: settingsRepo,
+		availabilityService: services.NewAvailabilityService(
+			bookingTimeService,
+			bookingRepo,
+			dogRepo,
+			repository.NewBlockedDateRepository(db),
+			repository.NewUserColorRepository(db),
+			settingsRepo,
+			// Only used to check waitlist reservations, so no emails are sent
+			services.NewWaitlistService(repository.NewWaitlistRepository(db), bookingRepo,
+				repository.NewUserRepository(db), dogRepo, settingsRepo, nil),
+		),
+	}
+}
+
+// GetAvailability lists the dogs the caller may walk with their free start times
+// GET /api/availability?date=YYYY-MM-DD&from=HH:MM&to=HH:MM
+func (h *AvailabilityHandler) GetAvailability(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(middleware.UserIDKey).(int)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	isAdmin, _ := r.Context().Value(middleware.IsAdminKey).(bool)
+	isSuperAdmin, _ := r.Context().Value(middleware.IsSuperAdminKey).(bool)
+
+	query := r.URL.Query()
+	date := query.Get("date")
+	from := query.Get("from")
+	to := query.Get("to")
+
+	if date == "" {
+		respondError(w, http.StatusBadRequest, "date parameter required")
+		return
+	}
+	bookingDate, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid date format")
+		return
+	}
+	if from != "" && !timeOfDayPattern.MatchString(from) {
+		respondError(w, http.StatusBadRequest, "Invalid from time (expected HH:MM)")
+		return
+	}
+	if to != "" && !timeOfDayPattern.MatchString(to) {
+		respondError(w, http.StatusBadRequest, "Invalid to time (expected HH:MM)")
+		return
+	}
+	if from != "" && to != "" && from > to {
+		respondError(w, http.StatusBadRequest, "from must not be after to")
+		return
+	}
+
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if bookingDate.Before(today) {
+		respondError(w, http.StatusBadRequest, "Cannot search dates in the past")
+		return
+	}
+	advanceDays := h.settingsRepo.GetInt("booking_advance_days", 14)
+	if bookingDate.After(today.AddDate(0, 0, advanceDays)) {
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("Cannot book more than %d days in advance", advanceDays))
+		return
+	}
+
+	dogs, err := h.availabilityService.FindFreeSlots(userID, isAdmin || isSuperAdmin, date, from, to, time.Now())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get availability")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"date": date,
+		"dogs": dogs,
+	})
+}
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/tranmh/gassigeher/internal/config"
+	"github.com/tranmh/gassigeher/internal/models"
+	"github.com/tranmh/gassigeher/internal/testutil"
+)
+
+// TestAvailabilityHandler_GetAvailability tests the availability search endpoint
+func TestAvailabilityHandler_GetAvailability(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	db.Exec("UPDATE system_settings SET value = 'false' WHERE key = 'use_feiertage_api'")
+	cfg := &config.Config{JWTSecret: "test-secret"}
+	handler := NewAvailabilityHandler(db, cfg)
+
+	userID := testutil.SeedTestUser(t, db, "walker@example.com", "Walker", "green")
+	adminID := testutil.SeedTestUser(t, db, "admin@example.com", "Admin", "blue")
+	testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+	testutil.SeedTestDog(t, db, "Rex", "Schäferhund", "blue")
+
+	date := time.Now().AddDate(0, 0, 2).Format("2006-01-02")
+
+	call := func(query string, asUser int, isAdmin bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/api/availability?"+query, nil)
+		req = req.WithContext(contextWithUser(req.Context(), asUser, "", isAdmin))
+		rec := httptest.NewRecorder()
+		handler.GetAvailability(rec, req)
+		return rec
+	}
+
+	decode := func(t *testing.T, rec *httptest.ResponseRecorder) []models.DogAvailability {
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+		var response struct {
+			Dogs []models.DogAvailability `json:"dogs"`
+		}
+		json.Unmarshal(rec.Body.Bytes(), &response)
+		return response.Dogs
+	}
+
+	t.Run("user sees dogs of their colors", func(t *testing.T) {
+		dogs := decode(t, call("date="+date, userID, false))
+		if len(dogs) != 1 || dogs[0].DogName != "Bella" {
+			t.Fatalf("Expected only Bella, got %+v", dogs)
+		}
+		if len(dogs[0].FreeSlots) == 0 {
+			t.Error("Expected free slots for Bella")
+		}
+	})
+
+	t.Run("admin sees all dogs", func(t *testing.T) {
+		if dogs := decode(t, call("date="+date, adminID, true)); len(dogs) != 2 {
+			t.Errorf("Expected 2 dogs, got %d", len(dogs))
+		}
+	})
+
+	t.Run("time window limits slots", func(t *testing.T) {
+		dogs := decode(t, call("date="+date+"&from=09:00&to=09:30", userID, false))
+		if len(dogs) != 1 {
+			t.Fatalf("Expected 1 dog, got %d", len(dogs))
+		}
+		for _, slot := range dogs[0].FreeSlots {
+			if slot < "09:00" || slot > "09:30" {
+				t.Errorf("Slot %s outside of requested window", slot)
+			}
+		}
+	})
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"missing date", ""},
+		{"invalid date", "date=09.01.2030"},
+		{"past date", "date=" + time.Now().AddDate(0, 0, -1).Format("2006-01-02")},
+		{"beyond advance days", "date=" + time.Now().AddDate(0, 0, 60).Format("2006-01-02")},
+		{"invalid from", "date=" + date + "&from=9"},
+		{"from after to", "date=" + date + "&from=12:00&to=09:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if rec := call(tt.query, userID, false); rec.Code != http.StatusBadRequest {
+				t.Errorf("Expected status 400, got %d", rec.Code)
+			}
+		})
+	}
+}
@@ -90,25 +90,7 @@ func (h *BlockedDateHandler) CreateBlockedDate(w http.ResponseWriter, r *http.Re
 		dogName = dog.Name
 	}
 
-	// Create blocked date
-	blockedDate := &models.BlockedDate{
-		Date:      req.Date,
-		DogID:     req.DogID,
-		Reason:    req.Reason,
-		CreatedBy: userID,
-	}
-
-	if err := h.blockedDateRepo.Create(blockedDate); err != nil {
-		errStr := err.Error()
-		if errStr == "this dog is already blocked for this date" || errStr == "this date is already globally blocked" {
-			respondError(w, http.StatusConflict, errStr)
-			return
-		}
-		respondError(w, http.StatusInternalServerError, "Failed to create blocked date")
-		return
-	}
-
-	// Find bookings to cancel based on block type
+	// Find scheduled bookings affected by the block
 	status := "scheduled"
 	filter := &models.BookingFilterRequest{
 		DateFrom: &req.Date,
@@ -117,19 +99,27 @@ func (h *BlockedDateHandler) CreateBlockedDate(w http.ResponseWriter, r *http.Re
 	}
 
 	if req.DogID != nil {
-		// Dog-specific block: only cancel bookings for this dog
+		// Dog-specific block: only bookings for this dog
 		filter.DogID = req.DogID
 	}
-	// For global block (req.DogID == nil): cancel ALL bookings on this date
+	// For global block (req.DogID == nil): ALL bookings on this date
 
 	bookings, err := h.bookingRepo.FindAll(filter)
 	if err != nil {
-		fmt.Printf("Warning: Failed to find bookings for date %s: %v\n", req.Date, err)
-		// Continue even if we can't find bookings - at least the date is blocked
+		respondError(w, http.StatusInternalServerError, "Failed to find affected bookings")
+		return
+	}
+
+	// Attach walker and dog so the admin can review the affected bookings
+	for _, booking := range bookings {
+		if user, err := h.userRepo.FindByID(booking.UserID); err == nil && user != nil {
+			booking.User = user
+		}
+		if dog, err := h.dogRepo.FindByID(booking.DogID); err == nil && dog != nil {
+			booking.Dog = dog
+		}
 	}
 
-	// Cancel each booking and notify users
-	cancelledCount := 0
 	var cancellationReason string
 	if req.DogID != nil {
 		cancellationReason = fmt.Sprintf("Hund '%s' wurde für dieses Datum gesperrt: %s", dogName, req.Reason)
@@ -137,35 +127,82 @@ func (h *BlockedDateHandler) CreateBlockedDate(w http.ResponseWriter, r *http.Re
 		cancellationReason = fmt.Sprintf("Datum wurde durch Administration gesperrt: %s", req.Reason)
 	}
 
-	for _, booking := range bookings {
-		// Cancel the booking
-		if err := h.bookingRepo.Cancel(booking.ID, &cancellationReason); err != nil {
-			fmt.Printf("Warning: Failed to cancel booking %d: %v\n", booking.ID, err)
-			continue
+	// Dry run: report what would happen without blocking or cancelling anything
+	if req.DryRun {
+		existing, err := h.blockedDateRepo.FindByDateAndDog(req.Date, req.DogID)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to check blocked dates")
+			return
+		}
+		if existing != nil {
+			if req.DogID != nil {
+				respondError(w, http.StatusConflict, "this dog is already blocked for this date")
+			} else {
+				respondError(w, http.StatusConflict, "this date is already globally blocked")
+			}
+			return
 		}
-		cancelledCount++
 
-		// Get user details for email
-		user, err := h.userRepo.FindByID(booking.UserID)
-		if err != nil || user == nil {
-			fmt.Printf("Warning: Failed to get user %d for cancellation email: %v\n", booking.UserID, err)
-			continue
+		respondJSON(w, http.StatusOK, map[string]interface{}{
+			"dry_run":             true,
+			"affected_bookings":   bookings,
+			"cancellation_reason": cancellationReason,
+		})
+		return
+	}
+
+	// Create blocked date
+	blockedDate := &models.BlockedDate{
+		Date:      req.Date,
+		DogID:     req.DogID,
+		Reason:    req.Reason,
+		CreatedBy: userID,
+	}
+
+	// Block the date and cancel the affected bookings in one transaction
+	bookingIDs := []int{}
+	if !req.KeepBookings {
+		for _, booking := range bookings {
+			bookingIDs = append(bookingIDs, booking.ID)
 		}
+	}
 
-		// Get dog details for email
-		dog, err := h.dogRepo.FindByID(booking.DogID)
-		if err != nil || dog == nil {
-			fmt.Printf("Warning: Failed to get dog %d for cancellation email: %v\n", booking.DogID, err)
-			continue
+	cancelledIDs, err := h.blockedDateRepo.CreateWithCancellations(blockedDate, bookingIDs, cancellationReason)
+	if err != nil {
+		errStr := err.Error()
+		if errStr == "this dog is already blocked for this date" || errStr == "this date is already globally blocked" {
+			respondError(w, http.StatusConflict, errStr)
+			return
 		}
+		respondError(w, http.StatusInternalServerError, "Failed to create blocked date")
+		return
+	}
 
-		// Send cancellation email (in goroutine, don't block)
-		if h.emailService != nil && user.Email != nil {
-			go func(userEmail, userName, dogName, date, scheduledTime, reason string) {
-				if err := h.emailService.SendAdminCancellation(userEmail, userName, dogName, date, scheduledTime, reason); err != nil {
+	// Only bookings still scheduled inside the transaction were cancelled
+	cancelledSet := map[int]bool{}
+	for _, id := range cancelledIDs {
+		cancelledSet[id] = true
+	}
+	cancelled := []*models.Booking{}
+	for _, booking := range bookings {
+		if cancelledSet[booking.ID] {
+			cancelled = append(cancelled, booking)
+		}
+	}
+
+	// Notify affected walkers
+	if h.emailService != nil {
+		for _, booking := range cancelled {
+			if booking.User == nil || booking.User.Email == nil || booking.Dog == nil {
+				continue
+			}
+
+			// Send cancellation email (in goroutine, don't block)
+			go func(userEmail, userName, dogName, date, scheduledTime, reason string, invite *services.ICalEvent) {
+				if err := h.emailService.SendAdminCancellation(userEmail, userName, dogName, date, scheduledTime, reason, invite); err != nil {
 					fmt.Printf("Warning: Failed to send cancellation email to %s: %v\n", userEmail, err)
 				}
-			}(*user.Email, user.FirstName, dog.Name, booking.Date, booking.ScheduledTime, cancellationReason)
+			}(*booking.User.Email, booking.User.FirstName, booking.Dog.Name, booking.Date, booking.ScheduledTime, cancellationReason, services.BookingInvite(booking, booking.Dog))
 		}
 	}
 
@@ -177,7 +214,10 @@ func (h *BlockedDateHandler) CreateBlockedDate(w http.ResponseWriter, r *http.Re
 	// Return response with cancellation count
 	response := map[string]interface{}{
 		"blocked_date":       blockedDate,
-		"cancelled_bookings": cancelledCount,
+		"cancelled_bookings": len(cancelled),
+	}
+	if !req.KeepBookings {
+		response["affected_bookings"] = cancelled
 	}
 
 	respondJSON(w, http.StatusCreated, response)
@@ -517,3 +517,94 @@ func TestBlockedDateHandler_ListBlockedDatesWithDogInfo(t *testing.T) {
 		}
 	})
 }
+
+// TestBlockedDateHandler_CancelAffectedBookings tests the dry run and the bulk cancellation when blocking a date
+func TestBlockedDateHandler_CancelAffectedBookings(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	cfg := &config.Config{JWTSecret: "test-secret"}
+	handler := NewBlockedDateHandler(db, cfg)
+
+	adminID := testutil.SeedTestUser(t, db, "admin@example.com", "Admin", "orange")
+	userID := testutil.SeedTestUser(t, db, "walker@example.com", "Walker", "green")
+	buddyID := testutil.SeedTestDog(t, db, "Buddy", "Labrador", "green")
+	maxID := testutil.SeedTestDog(t, db, "Max", "Beagle", "green")
+
+	buddyBooking := testutil.SeedTestBooking(t, db, userID, buddyID, "2030-12-24", "09:00", "scheduled")
+	maxBooking := testutil.SeedTestBooking(t, db, userID, maxID, "2030-12-24", "14:00", "scheduled")
+
+	block := func(body map[string]interface{}) (*httptest.ResponseRecorder, map[string]interface{}) {
+		data, _ := json.Marshal(body)
+		req := httptest.NewRequest("POST", "/api/blocked-dates", bytes.NewReader(data))
+		req = req.WithContext(contextWithUser(req.Context(), adminID, "admin@example.com", true))
+		rec := httptest.NewRecorder()
+		handler.CreateBlockedDate(rec, req)
+
+		var response map[string]interface{}
+		json.Unmarshal(rec.Body.Bytes(), &response)
+		return rec, response
+	}
+
+	statusOf := func(id int) string {
+		var status string
+		db.QueryRow("SELECT status FROM bookings WHERE id = ?", id).Scan(&status)
+		return status
+	}
+
+	t.Run("dry run lists affected bookings without changes", func(t *testing.T) {
+		rec, response := block(map[string]interface{}{"date": "2030-12-24", "reason": "Betriebsferien", "dry_run": true})
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		affected, _ := response["affected_bookings"].([]interface{})
+		if len(affected) != 2 {
+			t.Fatalf("Expected 2 affected bookings, got %d", len(affected))
+		}
+		first := affected[0].(map[string]interface{})
+		if dog, _ := first["dog"].(map[string]interface{}); dog == nil || dog["name"] != "Buddy" {
+			t.Errorf("Expected dog details in affected booking, got %v", first["dog"])
+		}
+
+		var count int
+		db.QueryRow("SELECT COUNT(*) FROM blocked_dates").Scan(&count)
+		if count != 0 || statusOf(buddyBooking) != "scheduled" {
+			t.Error("Dry run must not block the date or cancel bookings")
+		}
+	})
+
+	t.Run("dog-specific block cancels only that dog", func(t *testing.T) {
+		rec, response := block(map[string]interface{}{"date": "2030-12-24", "reason": "Tierarzt", "dog_id": buddyID})
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("Expected status 201, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+		if response["cancelled_bookings"] != float64(1) {
+			t.Errorf("Expected 1 cancelled booking, got %v", response["cancelled_bookings"])
+		}
+
+		var reason string
+		db.QueryRow("SELECT admin_cancellation_reason FROM bookings WHERE id = ?", buddyBooking).Scan(&reason)
+		if statusOf(buddyBooking) != "cancelled" || reason != "Hund 'Buddy' wurde für dieses Datum gesperrt: Tierarzt" {
+			t.Errorf("Expected Buddy's booking cancelled with block reason, got %s (%q)", statusOf(buddyBooking), reason)
+		}
+		if statusOf(maxBooking) != "scheduled" {
+			t.Error("Other dog's booking should stay scheduled")
+		}
+	})
+
+	t.Run("dry run reports existing block", func(t *testing.T) {
+		rec, _ := block(map[string]interface{}{"date": "2030-12-24", "reason": "Tierarzt", "dog_id": buddyID, "dry_run": true})
+		if rec.Code != http.StatusConflict {
+			t.Errorf("Expected status 409, got %d", rec.Code)
+		}
+	})
+
+	t.Run("keep bookings", func(t *testing.T) {
+		rec, response := block(map[string]interface{}{"date": "2030-12-24", "reason": "Feiertag", "keep_bookings": true})
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("Expected status 201, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+		if response["cancelled_bookings"] != float64(0) || statusOf(maxBooking) != "scheduled" {
+			t.Errorf("Expected no cancellations, got %v", response["cancelled_bookings"])
+		}
+	})
+}
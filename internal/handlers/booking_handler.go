@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"database/sql"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"log"
 	"net/http"
 	"strconv"
 	"strings"
@@ -29,6 +31,13 @@ type BookingHandler struct {
 	settingsRepo         *repository.SettingsRepository
 	bookingTimeService   *services.BookingTimeService
 	emailService         *services.EmailService
+	waitlistService      *services.WaitlistService
+	strikeService        *services.StrikeService
+	quotaService         *services.BookingQuotaService
+	transferRepo         *repository.BookingTransferRepository
+	bookingEventRepo     *repository.BookingEventRepository
+	dogCareRepo          *repository.DogCareRepository
+	walkerRuleRepo       *repository.DogWalkerRuleRepository
 }
 
 // NewBookingHandler creates a new booking handler
@@ -46,17 +55,29 @@ func NewBookingHandler(db *sql.DB, cfg *config.Config) *BookingHandler {
 	holidayService := services.NewHolidayService(holidayRepo, settingsRepo)
 	bookingTimeService := services.NewBookingTimeService(bookingTimeRepo, holidayService, settingsRepo)
 
+	bookingRepo := repository.NewBookingRepository(db)
+	dogRepo := repository.NewDogRepository(db)
+	userRepo := repository.NewUserRepository(db)
+
 	return &BookingHandler{
 		db:                   db,
 		cfg:                  cfg,
-		bookingRepo:          repository.NewBookingRepository(db),
-		dogRepo:              repository.NewDogRepository(db),
-		userRepo:             repository.NewUserRepository(db),
+		bookingRepo:          bookingRepo,
+		dogRepo:              dogRepo,
+		userRepo:             userRepo,
 		userColorRepo:        repository.NewUserColorRepository(db),
 		blockedDateRepo:      repository.NewBlockedDateRepository(db),
 		settingsRepo:         settingsRepo,
 		bookingTimeService:   bookingTimeService,
 		emailService:         emailService,
+		waitlistService: services.NewWaitlistService(repository.NewWaitlistRepository(db), bookingRepo, userRepo,
+			dogRepo, settingsRepo, emailService),
+		strikeService: services.NewStrikeService(repository.NewStrikeRepository(db), userRepo, settingsRepo, emailService),
+		quotaService:  services.NewBookingQuotaService(bookingRepo, settingsRepo),
+		transferRepo:  repository.NewBookingTransferRepository(db),
+		bookingEventRepo: repository.NewBookingEventRepository(db),
+		dogCareRepo:      repository.NewDogCareRepository(db),
+		walkerRuleRepo:   repository.NewDogWalkerRuleRepository(db),
 	}
 }
 
@@ -110,8 +131,8 @@ func (h *BookingHandler) CreateBooking(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Check if dog is available
-	if !dog.IsAvailable {
+	// Check if dog is available on that day (scheduled unavailability included)
+	if !dog.IsBookableOn(req.Date) {
 		respondError(w, http.StatusBadRequest, "Dog is currently unavailable")
 		return
 	}
@@ -138,6 +159,16 @@ func (h *BookingHandler) CreateBooking(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Per-dog allowlists and denylists apply in addition to the colors
+	if !user.IsAdmin && !user.IsSuperAdmin && rejectIfWalkerRuleForbids(w, h.walkerRuleRepo, dog.ID, userID, req.Date) {
+		return
+	}
+
+	// Users who collected too many strikes may not book until the restriction ends
+	if !user.IsAdmin && !user.IsSuperAdmin && rejectIfRestricted(w, h.strikeService, userID) {
+		return
+	}
+
 	// BUGFIX #4: Check if date is in the past with consistent timezone handling
 	// Parse date in UTC to match how dates are stored
 	bookingDate, parseErr := time.Parse("2006-01-02", req.Date)
@@ -171,6 +202,19 @@ func (h *BookingHandler) CreateBooking(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Check per-user booking quotas (admins and super admins bypass them)
+	if !user.IsAdmin && !user.IsSuperAdmin {
+		violation, err := h.quotaService.Check(userID, dog.ID, req.Date, time.Now())
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to check booking limits")
+			return
+		}
+		if violation != nil {
+			respondQuotaViolation(w, violation)
+			return
+		}
+	}
+
 	// Check if date is blocked for this specific dog
 	isBlocked, err := h.blockedDateRepo.IsBlockedForDog(req.Date, req.DogID)
 	if err != nil {
@@ -182,14 +226,36 @@ func (h *BookingHandler) CreateBooking(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Check for double-booking
-	isDoubleBooked, err := h.bookingRepo.CheckDoubleBooking(req.DogID, req.Date, req.ScheduledTime)
+	// Check for overlapping bookings of this dog (walk duration + buffer)
+	conflict, err := h.findDogConflict(dog, req.Date, req.ScheduledTime, 0)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to check availability")
+		return
+	}
+	if conflict != nil {
+		respondBookingConflict(w, conflict)
+		return
+	}
+
+	// Check that the walker is not already out with another dog at this time (pair walks excepted)
+	userConflict, err := h.bookingRepo.FindUserConflictingBooking(userID, dog.ID, req.Date, req.ScheduledTime, dog.GetWalkDuration(), 0)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to check availability")
+		return
+	}
+	if userConflict != nil {
+		respondUserBookingConflict(w, userConflict)
+		return
+	}
+
+	// A freed slot offered to someone on the waitlist is reserved for them until the offer expires
+	reserved, err := h.waitlistService.CheckReservation(userID, dog.ID, req.Date, req.ScheduledTime, dog.GetWalkDuration(), time.Now())
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Failed to check availability")
 		return
 	}
-	if isDoubleBooked {
-		respondError(w, http.StatusConflict, "This dog is already booked for this time")
+	if reserved {
+		respondError(w, http.StatusConflict, "Dieser Termin ist derzeit für eine Person auf der Warteliste reserviert")
 		return
 	}
 
@@ -222,23 +288,43 @@ func (h *BookingHandler) CreateBooking(w http.ResponseWriter, r *http.Request) {
 		booking.ApprovalStatus = "approved"
 	}
 
-	if err := h.bookingRepo.Create(booking); err != nil {
+	// The conflict checks above are repeated inside the insert transaction, so a request that
+	// loses a race for the same dog or walker gets a 409 instead of a double booking. The dog's
+	// walks per day and rest between walks are checked there as well.
+	conflict, walkLimit, err := h.bookingRepo.CreateIfFree(booking, dog, h.getBufferMinutes())
+	if err != nil {
 		// BUGFIX #2: Detect UNIQUE constraint violation (race condition scenario)
-		// SQLite returns error containing "UNIQUE constraint failed" when duplicate booking occurs
-		if strings.Contains(err.Error(), "UNIQUE constraint") || strings.Contains(err.Error(), "unique constraint") {
+		if repository.IsSlotTakenError(err) {
 			respondError(w, http.StatusConflict, "This dog is already booked for this time")
 			return
 		}
 		respondError(w, http.StatusInternalServerError, "Failed to create booking")
 		return
 	}
+	if walkLimit != nil {
+		respondWalkLimitViolation(w, walkLimit)
+		return
+	}
+	if conflict != nil {
+		if conflict.DogID == dog.ID {
+			respondBookingConflict(w, conflict)
+		} else {
+			respondUserBookingConflict(w, conflict)
+		}
+		return
+	}
+
+	// Close the user's waitlist entry for this slot, if any
+	if err := h.waitlistService.Claim(userID, dog.ID, booking.Date, booking.ScheduledTime); err != nil {
+		log.Printf("Failed to mark waitlist entry as claimed: %v", err)
+	}
 
 	// Update user last activity
 	h.userRepo.UpdateLastActivity(userID)
 
 	// Send confirmation email
 	if user.Email != nil && h.emailService != nil {
-		go h.emailService.SendBookingConfirmation(*user.Email, user.FirstName, dog.Name, booking.Date, booking.ScheduledTime)
+		go h.emailService.SendBookingConfirmation(*user.Email, user.FirstName, dog.Name, booking.Date, booking.ScheduledTime, services.BookingInvite(booking, dog))
 	}
 
 	respondJSON(w, http.StatusCreated, booking)
@@ -292,6 +378,165 @@ func (h *BookingHandler) ListBookings(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, bookings)
 }
 
+// bookingExportHeader are the column titles of the admin booking export
+var bookingExportHeader = []string{
+	"Buchung", "Datum", "Uhrzeit", "Hund", "Gassigeher", "Status", "Genehmigung",
+	"Genehmigt/abgelehnt von", "Genehmigt/abgelehnt am", "Ablehnungsgrund", "Stornierungsgrund", "Spaziergangsbericht",
+}
+
+// bookingExportLabels translate status values for the export
+var bookingExportLabels = map[string]string{
+	"scheduled": "Geplant",
+	"completed": "Abgeschlossen",
+	"cancelled": "Storniert",
+	"no_show":   "Nicht erschienen",
+	"approved":  "Genehmigt",
+	"pending":   "Ausstehend",
+	"rejected":  "Abgelehnt",
+}
+
+// ExportBookings streams the bookings matching the filter as CSV or XLSX (admin only)
+// GET /api/admin/bookings/export?format=csv|xlsx
+func (h *BookingHandler) ExportBookings(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	format := query.Get("format")
+	if format == "" {
+		format = "csv"
+	}
+	if format != "csv" && format != "xlsx" {
+		respondError(w, http.StatusBadRequest, "format must be 'csv' or 'xlsx'")
+		return
+	}
+
+	filter := &models.BookingFilterRequest{}
+	for _, param := range []struct {
+		name   string
+		target **int
+	}{{"user_id", &filter.UserID}, {"dog_id", &filter.DogID}, {"year", &filter.Year}, {"month", &filter.Month}} {
+		if value := query.Get(param.name); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				respondError(w, http.StatusBadRequest, "Invalid "+param.name)
+				return
+			}
+			*param.target = &n
+		}
+	}
+	if (filter.Year == nil) != (filter.Month == nil) || (filter.Month != nil && (*filter.Month < 1 || *filter.Month > 12)) {
+		respondError(w, http.StatusBadRequest, "year and month must be given together, month between 1 and 12")
+		return
+	}
+	for _, param := range []struct {
+		name   string
+		target **string
+	}{{"date_from", &filter.DateFrom}, {"date_to", &filter.DateTo}} {
+		if value := query.Get(param.name); value != "" {
+			if _, err := time.Parse("2006-01-02", value); err != nil {
+				respondError(w, http.StatusBadRequest, "Invalid "+param.name+" (expected YYYY-MM-DD)")
+				return
+			}
+			*param.target = &value
+		}
+	}
+	if status := query.Get("status"); status != "" {
+		filter.Status = &status
+	}
+
+	filename := "buchungen-" + time.Now().Format("2006-01-02")
+	if filter.Year != nil {
+		filename = fmt.Sprintf("buchungen-%d-%02d", *filter.Year, *filter.Month)
+	}
+
+	// Rows are written while they are read, so errors after the first row can only be logged
+	var err error
+	if format == "xlsx" {
+		w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+		w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`.xlsx"`)
+
+		var xw *services.XLSXWriter
+		if xw, err = services.NewXLSXWriter(w, "Buchungen"); err == nil {
+			xw.WriteRow(bookingExportHeader)
+			err = h.bookingRepo.ExportRows(filter, func(row *models.BookingExportRow) error {
+				return xw.WriteRow(bookingExportRecord(row))
+			})
+			if closeErr := xw.Close(); err == nil {
+				err = closeErr
+			}
+		}
+	} else {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`.csv"`)
+
+		cw := csv.NewWriter(w)
+		cw.Write(bookingExportHeader)
+		err = h.bookingRepo.ExportRows(filter, func(row *models.BookingExportRow) error {
+			record := bookingExportRecord(row)
+			for i, value := range record {
+				record[i] = csvSafe(value)
+			}
+			return cw.Write(record)
+		})
+		cw.Flush()
+		if err == nil {
+			err = cw.Error()
+		}
+	}
+
+	if err != nil {
+		log.Printf("Booking export failed: %v", err)
+	}
+}
+
+// bookingExportRecord formats an export row in the column order of bookingExportHeader
+func bookingExportRecord(row *models.BookingExportRow) []string {
+	label := func(value string) string {
+		if translated, ok := bookingExportLabels[value]; ok {
+			return translated
+		}
+		return value
+	}
+	optional := func(value *string) string {
+		if value == nil {
+			return ""
+		}
+		return *value
+	}
+
+	approvedAt := ""
+	if row.ApprovedAt != nil {
+		approvedAt = row.ApprovedAt.Local().Format("2006-01-02 15:04")
+	}
+	walkReport := "nein"
+	if row.HasWalkReport {
+		walkReport = "ja"
+	}
+
+	return []string{
+		strconv.Itoa(row.BookingID),
+		row.Date,
+		row.ScheduledTime,
+		row.DogName,
+		row.WalkerName,
+		label(row.Status),
+		label(row.ApprovalStatus),
+		optional(row.ApprovedBy),
+		approvedAt,
+		optional(row.RejectionReason),
+		optional(row.CancellationReason),
+		walkReport,
+	}
+}
+
+// csvSafe keeps spreadsheet programs from evaluating user-provided text (names,
+// reasons) as a formula. XLSX cells are inline strings and need no escaping.
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
 // GetBooking gets a booking by ID
 func (h *BookingHandler) GetBooking(w http.ResponseWriter, r *http.Request) {
 	// Get booking ID from URL
@@ -323,9 +568,57 @@ func (h *BookingHandler) GetBooking(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Medication is only relevant while the walk is still ahead
+	if booking.Status == "scheduled" {
+		doses, err := h.dogCareRepo.MedicationDuringWalk(booking.DogID, booking.Date, booking.ScheduledTime)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to get medication")
+			return
+		}
+		booking.MedicationDue = doses
+	}
+
 	respondJSON(w, http.StatusOK, booking)
 }
 
+// GetBookingHistory returns the change history of a booking, oldest first (booking owner or admin)
+// GET /api/bookings/{id}/history
+func (h *BookingHandler) GetBookingHistory(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid booking ID")
+		return
+	}
+
+	userID, _ := r.Context().Value(middleware.UserIDKey).(int)
+	isAdmin, _ := r.Context().Value(middleware.IsAdminKey).(bool)
+
+	booking, err := h.bookingRepo.FindByID(id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get booking")
+		return
+	}
+	if booking == nil {
+		respondError(w, http.StatusNotFound, "Booking not found")
+		return
+	}
+
+	// Previous owners of a transferred booking lose access like for the booking itself
+	if !isAdmin && booking.UserID != userID {
+		respondError(w, http.StatusForbidden, "Access denied")
+		return
+	}
+
+	events, err := h.bookingEventRepo.FindByBooking(id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get booking history")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, events)
+}
+
 // CancelBooking cancels a booking
 func (h *BookingHandler) CancelBooking(w http.ResponseWriter, r *http.Request) {
 	// Get booking ID from URL
@@ -371,6 +664,8 @@ func (h *BookingHandler) CancelBooking(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// For non-admin users, check cancellation notice period
+	// A late cancellation is only allowed when the user accepts a strike for it
+	lateCancellation := false
 	if !isAdmin {
 		noticeSetting, err := h.settingsRepo.Get("cancellation_notice_hours")
 		if err != nil {
@@ -418,8 +713,11 @@ func (h *BookingHandler) CancelBooking(w http.ResponseWriter, r *http.Request) {
 			bookingTime, now, hoursUntilBooking, noticeHours)
 
 		if hoursUntilBooking < float64(noticeHours) {
-			respondError(w, http.StatusBadRequest, fmt.Sprintf("Buchungen müssen mindestens %d Stunden im Voraus storniert werden. Verbleibende Zeit: %.1f Stunden", noticeHours, hoursUntilBooking))
-			return
+			if !req.AcceptStrike {
+				respondError(w, http.StatusBadRequest, fmt.Sprintf("Buchungen müssen mindestens %d Stunden im Voraus storniert werden. Verbleibende Zeit: %.1f Stunden", noticeHours, hoursUntilBooking))
+				return
+			}
+			lateCancellation = true
 		}
 	}
 
@@ -429,6 +727,25 @@ func (h *BookingHandler) CancelBooking(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	reason := ""
+	if req.Reason != nil {
+		reason = *req.Reason
+	}
+	recordBookingEvent(h.bookingEventRepo, booking.ID, models.BookingEventCancelled, userID, "", reason)
+
+	h.offerFreedSlot(booking.DogID, booking.Date, booking.ScheduledTime)
+
+	// A cancelled booking can no longer be handed over
+	if err := h.transferRepo.WithdrawOpenForBooking(booking.ID); err != nil {
+		log.Printf("Failed to withdraw transfers of booking %d: %v", booking.ID, err)
+	}
+
+	if lateCancellation {
+		if _, err := h.strikeService.AddStrike(booking.UserID, &booking.ID, models.StrikeReasonLateCancellation, time.Now()); err != nil {
+			log.Printf("Error recording late cancellation strike for booking %d: %v", booking.ID, err)
+		}
+	}
+
 	// Update user last activity
 	h.userRepo.UpdateLastActivity(userID)
 
@@ -436,10 +753,10 @@ func (h *BookingHandler) CancelBooking(w http.ResponseWriter, r *http.Request) {
 	if booking.User.Email != nil && h.emailService != nil {
 		if isAdmin && req.Reason != nil {
 			// Admin cancelled
-			go h.emailService.SendAdminCancellation(*booking.User.Email, booking.User.FirstName, booking.Dog.Name, booking.Date, booking.ScheduledTime, *req.Reason)
+			go h.emailService.SendAdminCancellation(*booking.User.Email, booking.User.FirstName, booking.Dog.Name, booking.Date, booking.ScheduledTime, *req.Reason, services.BookingInvite(booking, booking.Dog))
 		} else {
 			// User cancelled
-			go h.emailService.SendBookingCancellation(*booking.User.Email, booking.User.FirstName, booking.Dog.Name, booking.Date, booking.ScheduledTime)
+			go h.emailService.SendBookingCancellation(*booking.User.Email, booking.User.FirstName, booking.Dog.Name, booking.Date, booking.ScheduledTime, services.BookingInvite(booking, booking.Dog))
 		}
 	}
 
@@ -500,6 +817,12 @@ func (h *BookingHandler) AddNotes(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	oldNotes := ""
+	if booking.UserNotes != nil {
+		oldNotes = *booking.UserNotes
+	}
+	recordBookingEvent(h.bookingEventRepo, id, models.BookingEventNotes, userID, oldNotes, req.Notes)
+
 	respondJSON(w, http.StatusOK, map[string]string{"message": "Notes added successfully"})
 }
 
@@ -561,14 +884,58 @@ func (h *BookingHandler) MoveBooking(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Check for double-booking at new time
-	isDoubleBooked, err := h.bookingRepo.CheckDoubleBooking(booking.DogID, req.Date, req.ScheduledTime)
+	// Check for overlapping bookings at new time (ignoring the booking being moved)
+	dog, err := h.dogRepo.FindByID(booking.DogID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get dog")
+		return
+	}
+	if dog == nil {
+		respondError(w, http.StatusNotFound, "Dog not found")
+		return
+	}
+	if !dog.IsBookableOn(req.Date) {
+		respondError(w, http.StatusBadRequest, "Dog is unavailable on the new date")
+		return
+	}
+
+	// The walker must still be allowed to walk the dog on the new date
+	walker, err := h.userRepo.FindByID(booking.UserID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get user")
+		return
+	}
+	if walker != nil && !walker.IsAdmin && !walker.IsSuperAdmin {
+		ruleViolation, err := h.walkerRuleRepo.Violation(dog.ID, walker.ID, req.Date)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to check walker rules")
+			return
+		}
+		if ruleViolation != "" {
+			respondError(w, http.StatusForbidden, "The walker is not allowed to walk this dog on the new date")
+			return
+		}
+	}
+
+	conflict, err := h.findDogConflict(dog, req.Date, req.ScheduledTime, booking.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to check availability")
+		return
+	}
+	if conflict != nil {
+		respondBookingConflict(w, conflict)
+		return
+	}
+	if h.rejectIfWalkLimitExceeded(w, dog, req.Date, req.ScheduledTime, booking.ID) {
+		return
+	}
+	userConflict, err := h.bookingRepo.FindUserConflictingBooking(booking.UserID, dog.ID, req.Date, req.ScheduledTime, dog.GetWalkDuration(), booking.ID)
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Failed to check availability")
 		return
 	}
-	if isDoubleBooked {
-		respondError(w, http.StatusConflict, "Dog is already booked for this time")
+	if userConflict != nil {
+		respondUserBookingConflict(w, userConflict)
 		return
 	}
 
@@ -581,6 +948,11 @@ func (h *BookingHandler) MoveBooking(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	recordBookingEvent(h.bookingEventRepo, booking.ID, models.BookingEventMoved, userID,
+		models.BookingEventSlot(oldDate, oldTime), models.BookingEventSlot(booking.Date, booking.ScheduledTime))
+
+	h.offerFreedSlot(booking.DogID, oldDate, oldTime)
+
 	// Update user last activity
 	h.userRepo.UpdateLastActivity(userID)
 
@@ -595,12 +967,130 @@ func (h *BookingHandler) MoveBooking(w http.ResponseWriter, r *http.Request) {
 			req.Date,
 			req.ScheduledTime,
 			req.Reason,
+			services.BookingInvite(booking, dog),
 		)
 	}
 
 	respondJSON(w, http.StatusOK, map[string]string{"message": "Booking moved successfully"})
 }
 
+// checkInEarlyMinutes is how long before the scheduled time a walk can be checked in
+const checkInEarlyMinutes = 30
+
+// CheckIn records the actual start of a walk (booking owner or admin)
+// POST /api/bookings/{id}/checkin
+func (h *BookingHandler) CheckIn(w http.ResponseWriter, r *http.Request) {
+	booking, ok := h.getOwnBooking(w, r)
+	if !ok {
+		return
+	}
+
+	if booking.Status != "scheduled" {
+		respondError(w, http.StatusBadRequest, "Booking is already "+booking.Status)
+		return
+	}
+	if booking.ApprovalStatus == "pending" {
+		respondError(w, http.StatusBadRequest, "Booking is still awaiting approval")
+		return
+	}
+	if booking.CheckedInAt != nil {
+		respondError(w, http.StatusBadRequest, "Walk is already checked in")
+		return
+	}
+
+	// Check-in is possible from shortly before the scheduled time until the no-show grace period ends
+	start, err := time.ParseInLocation("2006-01-02 15:04", booking.Date+" "+booking.ScheduledTime, time.Local)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to parse booking date/time")
+		return
+	}
+	now := time.Now()
+	if now.Before(start.Add(-checkInEarlyMinutes * time.Minute)) {
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("Check-in ist frühestens %d Minuten vor Beginn möglich", checkInEarlyMinutes))
+		return
+	}
+	graceMinutes := h.settingsRepo.GetInt("no_show_grace_minutes", 60)
+	if now.After(start.Add(time.Duration(graceMinutes) * time.Minute)) {
+		respondError(w, http.StatusBadRequest, "Der Check-in-Zeitraum für diese Buchung ist abgelaufen")
+		return
+	}
+
+	if err := h.bookingRepo.CheckIn(booking.ID, now); err != nil {
+		respondError(w, http.StatusConflict, err.Error())
+		return
+	}
+
+	userID, _ := r.Context().Value(middleware.UserIDKey).(int)
+	h.userRepo.UpdateLastActivity(userID)
+
+	booking.CheckedInAt = &now
+	respondJSON(w, http.StatusOK, booking)
+}
+
+// CheckOut records the actual end of a checked-in walk and completes the booking (booking owner or admin)
+// POST /api/bookings/{id}/checkout
+func (h *BookingHandler) CheckOut(w http.ResponseWriter, r *http.Request) {
+	booking, ok := h.getOwnBooking(w, r)
+	if !ok {
+		return
+	}
+
+	if booking.CheckedInAt == nil {
+		respondError(w, http.StatusBadRequest, "Walk is not checked in")
+		return
+	}
+	if booking.Status != "scheduled" || booking.CheckedOutAt != nil {
+		respondError(w, http.StatusBadRequest, "Walk is already checked out")
+		return
+	}
+
+	now := time.Now()
+	if err := h.bookingRepo.CheckOut(booking.ID, now); err != nil {
+		respondError(w, http.StatusConflict, err.Error())
+		return
+	}
+
+	userID, _ := r.Context().Value(middleware.UserIDKey).(int)
+	recordBookingEvent(h.bookingEventRepo, booking.ID, models.BookingEventCompleted, userID, "", "")
+	h.userRepo.UpdateLastActivity(userID)
+
+	booking.Status = "completed"
+	booking.CheckedOutAt = &now
+	booking.CompletedAt = &now
+	respondJSON(w, http.StatusOK, booking)
+}
+
+// getOwnBooking loads the booking from the URL and checks that it belongs to the
+// current user (admins may access any booking). Writes the error response on failure.
+func (h *BookingHandler) getOwnBooking(w http.ResponseWriter, r *http.Request) (*models.Booking, bool) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid booking ID")
+		return nil, false
+	}
+
+	userID, _ := r.Context().Value(middleware.UserIDKey).(int)
+	isAdmin, _ := r.Context().Value(middleware.IsAdminKey).(bool)
+
+	booking, err := h.bookingRepo.FindByID(id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get booking")
+		return nil, false
+	}
+	if booking == nil {
+		respondError(w, http.StatusNotFound, "Booking not found")
+		return nil, false
+	}
+
+	if !isAdmin && booking.UserID != userID {
+		respondError(w, http.StatusForbidden, "Access denied")
+		return nil, false
+	}
+
+	return booking, true
+}
+
 // GetCalendarData gets calendar data for a specific month
 func (h *BookingHandler) GetCalendarData(w http.ResponseWriter, r *http.Request) {
 	// Get year and month from URL
@@ -616,8 +1106,9 @@ func (h *BookingHandler) GetCalendarData(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// Get user ID from context
+	// Get user ID and admin status from context
 	userID, _ := r.Context().Value(middleware.UserIDKey).(int)
+	isAdmin, _ := r.Context().Value(middleware.IsAdminKey).(bool)
 
 	// Get bookings for the month
 	filter := &models.BookingFilterRequest{
@@ -687,6 +1178,16 @@ func (h *BookingHandler) GetCalendarData(w http.ResponseWriter, r *http.Request)
 		BlockedDates: blockedDates, // Include all blocked dates for frontend to handle dog-specific blocks
 	}
 
+	// Admins see which dogs are out on a walk right now
+	if isAdmin {
+		dogsOut, err := h.bookingRepo.FindCurrentlyOut()
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to get walks in progress")
+			return
+		}
+		response.DogsOut = dogsOut
+	}
+
 	respondJSON(w, http.StatusOK, response)
 }
 
@@ -736,6 +1237,33 @@ func (h *BookingHandler) ApprovePendingBooking(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	// Make sure no overlapping booking was made for this dog while this one was pending
+	pending, err := h.bookingRepo.FindByID(id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get booking")
+		return
+	}
+	if pending == nil {
+		respondError(w, http.StatusNotFound, "Booking not found")
+		return
+	}
+	dog, err := h.dogRepo.FindByID(pending.DogID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get dog")
+		return
+	}
+	if dog != nil && pending.Status == "scheduled" {
+		conflict, err := h.findDogConflict(dog, pending.Date, pending.ScheduledTime, pending.ID)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to check availability")
+			return
+		}
+		if conflict != nil {
+			respondBookingConflict(w, conflict)
+			return
+		}
+	}
+
 	if err := h.bookingRepo.ApproveBooking(id, adminID); err != nil {
 		respondError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -808,6 +1336,10 @@ func (h *BookingHandler) RejectPendingBooking(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	if booking != nil {
+		h.offerFreedSlot(booking.DogID, booking.Date, booking.ScheduledTime)
+	}
+
 	// Send email notification to user with reason
 	if h.emailService != nil && booking != nil && booking.User != nil && booking.User.Email != nil && *booking.User.Email != "" {
 		go h.emailService.SendBookingRejected(
@@ -824,3 +1356,119 @@ func (h *BookingHandler) RejectPendingBooking(w http.ResponseWriter, r *http.Req
 		"message": "Booking rejected successfully",
 	})
 }
+
+// recordBookingEvent appends an event to a booking's history. The change itself has
+// already been saved, so a failure is only logged.
+func recordBookingEvent(repo *repository.BookingEventRepository, bookingID int, eventType string, actorID int, oldValue, newValue string) {
+	if err := repo.Record(bookingID, eventType, &actorID, oldValue, newValue); err != nil {
+		log.Printf("Failed to record %s event for booking %d: %v", eventType, bookingID, err)
+	}
+}
+
+// offerFreedSlot offers a slot that just became free to the next user on its waitlist
+func (h *BookingHandler) offerFreedSlot(dogID int, date, scheduledTime string) {
+	if _, err := h.waitlistService.OfferSlot(dogID, date, scheduledTime, time.Now()); err != nil {
+		log.Printf("Failed to offer freed slot to waitlist (dog %d, %s %s): %v", dogID, date, scheduledTime, err)
+	}
+}
+
+// findDogConflict returns a scheduled booking of the dog overlapping the requested slot,
+// using the dog's walk duration and the configured booking buffer
+func (h *BookingHandler) findDogConflict(dog *models.Dog, date, scheduledTime string, excludeBookingID int) (*models.BookingConflict, error) {
+	return h.bookingRepo.FindConflictingBooking(dog.ID, date, scheduledTime, dog.GetWalkDuration(), h.getBufferMinutes(), excludeBookingID)
+}
+
+// getBufferMinutes returns the booking_buffer_minutes setting (never negative)
+func (h *BookingHandler) getBufferMinutes() int {
+	buffer := h.settingsRepo.GetInt("booking_buffer_minutes", 0)
+	if buffer < 0 {
+		buffer = 0
+	}
+	return buffer
+}
+
+// respondBookingConflict sends a 409 naming the clashing booking so the frontend can suggest the next free slot
+func respondBookingConflict(w http.ResponseWriter, conflict *models.BookingConflict) {
+	respondJSON(w, http.StatusConflict, map[string]interface{}{
+		"error": fmt.Sprintf("Dieser Hund ist bereits von %s bis %s Uhr gebucht. Nächste freie Zeit: %s Uhr",
+			conflict.ScheduledTime, conflict.EndTime, conflict.NextFreeTime),
+		"conflicting_booking": conflict,
+	})
+}
+
+// respondUserBookingConflict sends a 409 naming the walk the user already has booked at that time
+func respondUserBookingConflict(w http.ResponseWriter, conflict *models.BookingConflict) {
+	respondJSON(w, http.StatusConflict, map[string]interface{}{
+		"error": fmt.Sprintf("Du hast bereits von %s bis %s Uhr einen Spaziergang mit %s gebucht",
+			conflict.ScheduledTime, conflict.EndTime, conflict.DogName),
+		"conflicting_booking": conflict,
+	})
+}
+
+// respondQuotaViolation sends a 403 with the exceeded limit as machine-readable reason
+func respondQuotaViolation(w http.ResponseWriter, violation *models.BookingQuotaViolation) {
+	respondJSON(w, http.StatusForbidden, map[string]interface{}{
+		"error": violation.Message(),
+		"quota": violation,
+	})
+}
+
+// rejectIfWalkLimitExceeded writes a 409 explaining the rule and returns true if a walk of dog
+// at scheduledTime would exceed its walks per day or cut its rest between walks short
+func (h *BookingHandler) rejectIfWalkLimitExceeded(w http.ResponseWriter, dog *models.Dog, date, scheduledTime string, excludeBookingID int) bool {
+	if !dog.HasWalkLimits() {
+		return false
+	}
+
+	walkTimes, err := h.bookingRepo.FindDogWalkTimes(dog.ID, date, excludeBookingID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to check walk limits")
+		return true
+	}
+
+	violation := models.CheckDogWalkLimits(dog, walkTimes, scheduledTime)
+	if violation == nil {
+		return false
+	}
+
+	respondWalkLimitViolation(w, violation)
+	return true
+}
+
+// respondWalkLimitViolation sends a 409 with the dog's violated walk limit
+func respondWalkLimitViolation(w http.ResponseWriter, violation *models.DogWalkLimitViolation) {
+	respondJSON(w, http.StatusConflict, map[string]interface{}{
+		"error":      violation.Message(),
+		"walk_limit": violation,
+	})
+}
+
+// rejectIfWalkerRuleForbids writes a 403 and returns true if the dog's allowlist or
+// denylist forbids the user to walk it on date
+func rejectIfWalkerRuleForbids(w http.ResponseWriter, ruleRepo *repository.DogWalkerRuleRepository, dogID, userID int, date string) bool {
+	violation, err := ruleRepo.Violation(dogID, userID, date)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to check walker rules")
+		return true
+	}
+
+	if violation != "" {
+		respondError(w, http.StatusForbidden, models.DogWalkerRuleMessage(violation))
+		return true
+	}
+	return false
+}
+
+// rejectIfRestricted responds with 403 and returns true if the user is currently restricted by strikes
+func rejectIfRestricted(w http.ResponseWriter, strikeService *services.StrikeService, userID int) bool {
+	summary, err := strikeService.Summary(userID, time.Now())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to check strikes")
+		return true
+	}
+	if !summary.IsRestricted {
+		return false
+	}
+	respondError(w, http.StatusForbidden, summary.RestrictionMessage())
+	return true
+}
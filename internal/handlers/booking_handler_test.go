@@ -1,11 +1,14 @@
 package handlers
 
 import (
+	"archive/zip"
 	"bytes"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -163,6 +166,51 @@ func TestBookingHandler_CreateBooking(t *testing.T) {
 		}
 	})
 
+	t.Run("same walker overlapping walk with another dog", func(t *testing.T) {
+		date := time.Now().AddDate(0, 0, 4).Format("2006-01-02")
+		testutil.SeedTestBooking(t, db, userID, dogID, date, "09:00", "scheduled")
+		secondDogID := testutil.SeedTestDog(t, db, "Luna", "Beagle", "green")
+
+		createBooking := func(scheduledTime string) *httptest.ResponseRecorder {
+			reqBody := map[string]interface{}{
+				"dog_id":         secondDogID,
+				"date":           date,
+				"scheduled_time": scheduledTime,
+			}
+
+			body, _ := json.Marshal(reqBody)
+			req := httptest.NewRequest("POST", "/api/bookings", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			ctx := contextWithUser(req.Context(), userID, email, false)
+			req = req.WithContext(ctx)
+
+			rec := httptest.NewRecorder()
+			handler.CreateBooking(rec, req)
+			return rec
+		}
+
+		rec := createBooking("09:30")
+		if rec.Code != http.StatusConflict {
+			t.Errorf("Expected status 409 for overlapping walk of same walker, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		// Admin allows the two dogs to be walked together
+		pairWalkRepo := repository.NewPairWalkRepository(db)
+		if err := pairWalkRepo.Create(&models.PairWalk{DogID: dogID, PartnerDogID: secondDogID, CreatedBy: adminID}); err != nil {
+			t.Fatalf("Failed to create pair walk: %v", err)
+		}
+
+		rec = createBooking("09:30")
+		if rec.Code != http.StatusConflict {
+			t.Errorf("Expected status 409 for pair walk at a different start time, got %d", rec.Code)
+		}
+
+		rec = createBooking("09:00")
+		if rec.Code != http.StatusCreated {
+			t.Errorf("Expected status 201 for allowed pair walk, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+	})
+
 	t.Run("insufficient experience level", func(t *testing.T) {
 		// Create orange dog (requires orange level)
 		orangeDogID := testutil.SeedTestDog(t, db, "Rocky", "Rottweiler", "orange")
@@ -630,6 +678,27 @@ func TestBookingHandler_GetBooking(t *testing.T) {
 		}
 	})
 
+	t.Run("medication due during the walk", func(t *testing.T) {
+		careRepo := repository.NewDogCareRepository(db)
+		careRepo.Create(&models.DogCareEntry{DogID: dogID, EntryType: models.DogCareMedication, Title: "Rimadyl",
+			StartDate: "2025-11-01", ScheduleTimes: []string{"07:00", "09:30", "18:00"}})
+
+		req := httptest.NewRequest("GET", "/api/bookings/"+fmt.Sprintf("%d", bookingID), nil)
+		req = mux.SetURLVars(req, map[string]string{"id": fmt.Sprintf("%d", bookingID)})
+		ctx := contextWithUser(req.Context(), userID, "user@example.com", false)
+		req = req.WithContext(ctx)
+
+		rec := httptest.NewRecorder()
+		handler.GetBooking(rec, req)
+
+		var response models.Booking
+		json.Unmarshal(rec.Body.Bytes(), &response)
+
+		if len(response.MedicationDue) != 1 || response.MedicationDue[0].Time != "09:30" || response.MedicationDue[0].Title != "Rimadyl" {
+			t.Errorf("Expected the 09:30 dose, got %+v", response.MedicationDue)
+		}
+	})
+
 	t.Run("booking not found", func(t *testing.T) {
 		req := httptest.NewRequest("GET", "/api/bookings/99999", nil)
 		req = mux.SetURLVars(req, map[string]string{"id": "99999"})
@@ -659,6 +728,87 @@ func TestBookingHandler_GetBooking(t *testing.T) {
 	})
 }
 
+// TestBookingHandler_GetBookingHistory tests that moves and cancellations show up in the booking history
+func TestBookingHandler_GetBookingHistory(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	cfg := &config.Config{JWTSecret: "test-secret"}
+	handler := NewBookingHandler(db, cfg)
+
+	adminID := testutil.SeedTestUser(t, db, "admin@example.com", "Anna Admin", "orange")
+	userID := testutil.SeedTestUser(t, db, "user@example.com", "Wanda Walker", "green")
+	otherID := testutil.SeedTestUser(t, db, "other@example.com", "Other User", "green")
+	dogID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+	bookingID := testutil.SeedTestBooking(t, db, userID, dogID, "2030-01-09", "09:00", "scheduled")
+	vars := map[string]string{"id": fmt.Sprintf("%d", bookingID)}
+
+	body, _ := json.Marshal(map[string]string{"date": "2030-01-10", "scheduled_time": "16:00", "reason": "Tierarzt"})
+	req := httptest.NewRequest("PUT", "/api/admin/bookings/"+vars["id"]+"/move", bytes.NewReader(body))
+	req = mux.SetURLVars(req, vars)
+	req = req.WithContext(contextWithUser(req.Context(), adminID, "admin@example.com", true))
+	rec := httptest.NewRecorder()
+	handler.MoveBooking(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Move failed: %d %s", rec.Code, rec.Body.String())
+	}
+
+	body, _ = json.Marshal(map[string]string{"reason": "Krank"})
+	req = httptest.NewRequest("PUT", "/api/bookings/"+vars["id"]+"/cancel", bytes.NewReader(body))
+	req = mux.SetURLVars(req, vars)
+	req = req.WithContext(contextWithUser(req.Context(), adminID, "admin@example.com", true))
+	rec = httptest.NewRecorder()
+	handler.CancelBooking(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Cancel failed: %d %s", rec.Code, rec.Body.String())
+	}
+
+	getHistory := func(asUser int, isAdmin bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/api/bookings/"+vars["id"]+"/history", nil)
+		req = mux.SetURLVars(req, vars)
+		req = req.WithContext(contextWithUser(req.Context(), asUser, "", isAdmin))
+		rec := httptest.NewRecorder()
+		handler.GetBookingHistory(rec, req)
+		return rec
+	}
+
+	t.Run("owner sees moves and cancellations with actor", func(t *testing.T) {
+		rec := getHistory(userID, false)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", rec.Code)
+		}
+
+		var events []models.BookingEvent
+		json.Unmarshal(rec.Body.Bytes(), &events)
+		if len(events) != 2 {
+			t.Fatalf("Expected 2 events, got %d: %s", len(events), rec.Body.String())
+		}
+
+		moved := events[0]
+		if moved.EventType != models.BookingEventMoved || *moved.OldValue != "2030-01-09 09:00" || *moved.NewValue != "2030-01-10 16:00" {
+			t.Errorf("Unexpected move event %+v", moved)
+		}
+		if moved.ActorName == nil || *moved.ActorName != "Anna Admin" {
+			t.Errorf("Expected actor 'Anna Admin', got %v", moved.ActorName)
+		}
+
+		cancelled := events[1]
+		if cancelled.EventType != models.BookingEventCancelled || cancelled.NewValue == nil || *cancelled.NewValue != "Krank" {
+			t.Errorf("Unexpected cancel event %+v", cancelled)
+		}
+	})
+
+	t.Run("other users are denied", func(t *testing.T) {
+		if rec := getHistory(otherID, false); rec.Code != http.StatusForbidden {
+			t.Errorf("Expected status 403, got %d", rec.Code)
+		}
+	})
+
+	t.Run("admin can read any history", func(t *testing.T) {
+		if rec := getHistory(adminID, true); rec.Code != http.StatusOK {
+			t.Errorf("Expected status 200, got %d", rec.Code)
+		}
+	})
+}
+
 // DONE: TestBookingHandler_MoveBooking tests moving a booking to new date/time (admin only)
 func TestBookingHandler_MoveBooking(t *testing.T) {
 	db := testutil.SetupTestDB(t)
@@ -847,6 +997,92 @@ func TestBookingHandler_MoveBooking(t *testing.T) {
 	})
 }
 
+// TestBookingHandler_CheckInOut tests recording actual walk times and the admin view of dogs out
+func TestBookingHandler_CheckInOut(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	cfg := &config.Config{JWTSecret: "test-secret"}
+	handler := NewBookingHandler(db, cfg)
+
+	userID := testutil.SeedTestUser(t, db, "walker@example.com", "Walker", "green")
+	otherID := testutil.SeedTestUser(t, db, "other@example.com", "Other", "green")
+	adminID := testutil.SeedTestUser(t, db, "admin@example.com", "Admin", "orange")
+	dogID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+	laterDogID := testutil.SeedTestDog(t, db, "Max", "Beagle", "green")
+
+	start := time.Now().Add(10 * time.Minute)
+	bookingID := testutil.SeedTestBooking(t, db, userID, dogID, start.Format("2006-01-02"), start.Format("15:04"), "scheduled")
+	later := time.Now().Add(3 * time.Hour)
+	laterID := testutil.SeedTestBooking(t, db, userID, laterDogID, later.Format("2006-01-02"), later.Format("15:04"), "scheduled")
+
+	post := func(action string, id, asUser int, isAdmin bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", fmt.Sprintf("/api/bookings/%d/%s", id, action), nil)
+		req = mux.SetURLVars(req, map[string]string{"id": fmt.Sprintf("%d", id)})
+		req = req.WithContext(contextWithUser(req.Context(), asUser, "", isAdmin))
+		rec := httptest.NewRecorder()
+		if action == "checkin" {
+			handler.CheckIn(rec, req)
+		} else {
+			handler.CheckOut(rec, req)
+		}
+		return rec
+	}
+
+	t.Run("other user cannot check in", func(t *testing.T) {
+		if rec := post("checkin", bookingID, otherID, false); rec.Code != http.StatusForbidden {
+			t.Errorf("Expected status 403, got %d", rec.Code)
+		}
+	})
+
+	t.Run("too early to check in", func(t *testing.T) {
+		if rec := post("checkin", laterID, userID, false); rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("check out before check in", func(t *testing.T) {
+		if rec := post("checkout", bookingID, userID, false); rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("check in", func(t *testing.T) {
+		rec := post("checkin", bookingID, userID, false)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+		if rec := post("checkin", bookingID, userID, false); rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 for second check-in, got %d", rec.Code)
+		}
+	})
+
+	t.Run("admin calendar shows dogs out", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/bookings/calendar/2025/12", nil)
+		req = mux.SetURLVars(req, map[string]string{"year": "2025", "month": "12"})
+		req = req.WithContext(contextWithUser(req.Context(), adminID, "", true))
+		rec := httptest.NewRecorder()
+		handler.GetCalendarData(rec, req)
+
+		var response models.CalendarResponse
+		json.Unmarshal(rec.Body.Bytes(), &response)
+		if len(response.DogsOut) != 1 || response.DogsOut[0].DogID != dogID {
+			t.Errorf("Expected Bella to be out on a walk, got %+v", response.DogsOut)
+		}
+	})
+
+	t.Run("check out completes the booking", func(t *testing.T) {
+		rec := post("checkout", bookingID, userID, false)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		var booking models.Booking
+		json.Unmarshal(rec.Body.Bytes(), &booking)
+		if booking.Status != "completed" || booking.CheckedInAt == nil || booking.CheckedOutAt == nil {
+			t.Errorf("Expected completed booking with check-in/out times, got %+v", booking)
+		}
+	})
+}
+
 // DONE: TestBookingHandler_GetCalendarData tests getting calendar data for a month
 func TestBookingHandler_GetCalendarData(t *testing.T) {
 	db := testutil.SetupTestDB(t)
@@ -1473,3 +1709,377 @@ func TestBookingHandler_ColorBasedPermission(t *testing.T) {
 		}
 	})
 }
+
+// TestBookingHandler_Strikes tests late cancellations with a strike and the booking restriction
+func TestBookingHandler_Strikes(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	cfg := &config.Config{JWTSecret: "test-secret"}
+	handler := NewBookingHandler(db, cfg)
+
+	userID := testutil.SeedTestUser(t, db, "walker@example.com", "Walker", "green")
+	adminID := testutil.SeedTestUser(t, db, "admin@example.com", "Admin", "orange")
+	dogID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+
+	// Inside the default 12 hour cancellation notice period
+	soon := time.Now().Add(2 * time.Hour)
+	bookingID := testutil.SeedTestBooking(t, db, userID, dogID, soon.Format("2006-01-02"), soon.Format("15:04"), "scheduled")
+
+	cancel := func(body map[string]interface{}) *httptest.ResponseRecorder {
+		data, _ := json.Marshal(body)
+		req := httptest.NewRequest("PUT", fmt.Sprintf("/api/bookings/%d/cancel", bookingID), bytes.NewReader(data))
+		req = mux.SetURLVars(req, map[string]string{"id": fmt.Sprintf("%d", bookingID)})
+		req = req.WithContext(contextWithUser(req.Context(), userID, "walker@example.com", false))
+		rec := httptest.NewRecorder()
+		handler.CancelBooking(rec, req)
+		return rec
+	}
+
+	t.Run("late cancellation rejected without accepting a strike", func(t *testing.T) {
+		if rec := cancel(map[string]interface{}{}); rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("late cancellation with strike", func(t *testing.T) {
+		rec := cancel(map[string]interface{}{"accept_strike": true})
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		var reason string
+		var strikeBookingID int
+		db.QueryRow("SELECT reason, booking_id FROM user_strikes WHERE user_id = ?", userID).Scan(&reason, &strikeBookingID)
+		if reason != models.StrikeReasonLateCancellation || strikeBookingID != bookingID {
+			t.Errorf("Expected late_cancellation strike for booking %d, got %q for %d", bookingID, reason, strikeBookingID)
+		}
+	})
+
+	strikeRepo := repository.NewStrikeRepository(db)
+	for i := 0; i < 2; i++ {
+		strikeRepo.Create(&models.UserStrike{UserID: userID, Reason: models.StrikeReasonNoShow})
+	}
+
+	create := func(asUser int, isAdmin bool) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(map[string]interface{}{
+			"dog_id":         dogID,
+			"date":           time.Now().AddDate(0, 0, 2).Format("2006-01-02"),
+			"scheduled_time": "09:00",
+		})
+		req := httptest.NewRequest("POST", "/api/bookings", bytes.NewReader(body))
+		req = req.WithContext(contextWithUser(req.Context(), asUser, "", isAdmin))
+		rec := httptest.NewRecorder()
+		handler.CreateBooking(rec, req)
+		return rec
+	}
+
+	t.Run("restricted user cannot book", func(t *testing.T) {
+		if rec := create(userID, false); rec.Code != http.StatusForbidden {
+			t.Errorf("Expected status 403, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("admins are not restricted by their own strikes", func(t *testing.T) {
+		db.Exec("UPDATE users SET is_admin = 1 WHERE id = ?", adminID)
+		for i := 0; i < 3; i++ {
+			strikeRepo.Create(&models.UserStrike{UserID: adminID, Reason: models.StrikeReasonNoShow})
+		}
+		if rec := create(adminID, true); rec.Code == http.StatusForbidden {
+			t.Errorf("Expected admin booking not to be forbidden. Body: %s", rec.Body.String())
+		}
+	})
+}
+
+// TestBookingHandler_Quotas tests that per-user booking limits are enforced with a machine-readable reason
+func TestBookingHandler_Quotas(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	cfg := &config.Config{JWTSecret: "test-secret"}
+	handler := NewBookingHandler(db, cfg)
+
+	userID := testutil.SeedTestUser(t, db, "walker@example.com", "Walker", "green")
+	adminID := testutil.SeedTestUser(t, db, "admin@example.com", "Admin", "orange")
+	db.Exec("UPDATE users SET is_admin = 1 WHERE id = ?", adminID)
+	bellaID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+	maxID := testutil.SeedTestDog(t, db, "Max", "Beagle", "green")
+
+	date := time.Now().AddDate(0, 0, 2).Format("2006-01-02")
+	testutil.SeedTestBooking(t, db, userID, bellaID, date, "09:00", "scheduled")
+	testutil.SeedTestBooking(t, db, adminID, bellaID, date, "14:00", "scheduled")
+	db.Exec("UPDATE system_settings SET value = '1' WHERE key = 'max_bookings_per_day'")
+
+	create := func(asUser int, isAdmin bool) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(map[string]interface{}{
+			"dog_id":         maxID,
+			"date":           date,
+			"scheduled_time": "15:00",
+		})
+		req := httptest.NewRequest("POST", "/api/bookings", bytes.NewReader(body))
+		req = req.WithContext(contextWithUser(req.Context(), asUser, "", isAdmin))
+		rec := httptest.NewRecorder()
+		handler.CreateBooking(rec, req)
+		return rec
+	}
+
+	t.Run("limit reached", func(t *testing.T) {
+		rec := create(userID, false)
+		if rec.Code != http.StatusForbidden {
+			t.Fatalf("Expected status 403, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		var response struct {
+			Quota models.BookingQuotaViolation `json:"quota"`
+		}
+		json.Unmarshal(rec.Body.Bytes(), &response)
+		if response.Quota.Reason != models.QuotaMaxBookingsPerDay || response.Quota.Limit != 1 {
+			t.Errorf("Expected max_bookings_per_day with limit 1, got %+v", response.Quota)
+		}
+	})
+
+	t.Run("admins bypass limits", func(t *testing.T) {
+		if rec := create(adminID, true); rec.Code == http.StatusForbidden {
+			t.Errorf("Expected admin booking not to be forbidden. Body: %s", rec.Body.String())
+		}
+	})
+}
+
+// TestBookingHandler_ExportBookings tests the admin CSV and XLSX booking export
+func TestBookingHandler_ExportBookings(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	cfg := &config.Config{JWTSecret: "test-secret"}
+	handler := NewBookingHandler(db, cfg)
+
+	adminID := testutil.SeedTestUser(t, db, "admin@example.com", "Anna Admin", "orange")
+	userID := testutil.SeedTestUser(t, db, "user@example.com", "=Wanda Walker", "green")
+	dogID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+	bookingID := testutil.SeedTestBooking(t, db, userID, dogID, "2030-01-09", "09:00", "completed")
+	testutil.SeedTestWalkReport(t, db, bookingID, 4, "medium", "")
+	testutil.SeedTestBooking(t, db, userID, dogID, "2030-02-01", "09:00", "scheduled")
+
+	export := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/api/admin/bookings/export?"+query, nil)
+		req = req.WithContext(contextWithUser(req.Context(), adminID, "admin@example.com", true))
+		rec := httptest.NewRecorder()
+		handler.ExportBookings(rec, req)
+		return rec
+	}
+
+	t.Run("csv filtered by month", func(t *testing.T) {
+		rec := export("year=2030&month=1")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if got := rec.Header().Get("Content-Disposition"); got != `attachment; filename="buchungen-2030-01.csv"` {
+			t.Errorf("Unexpected Content-Disposition %q", got)
+		}
+
+		records, err := csv.NewReader(rec.Body).ReadAll()
+		if err != nil {
+			t.Fatalf("Invalid CSV: %v", err)
+		}
+		if len(records) != 2 {
+			t.Fatalf("Expected header and 1 row, got %d records", len(records))
+		}
+		row := records[1]
+		if row[0] != fmt.Sprintf("%d", bookingID) || row[3] != "Bella" || row[5] != "Abgeschlossen" || row[11] != "ja" {
+			t.Errorf("Unexpected row %v", row)
+		}
+		if row[4] != "'=Wanda Walker" {
+			t.Errorf("Expected formula-like name to be escaped, got %q", row[4])
+		}
+	})
+
+	t.Run("xlsx workbook", func(t *testing.T) {
+		rec := export("format=xlsx")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", rec.Code)
+		}
+		body := rec.Body.Bytes()
+		archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+		if err != nil {
+			t.Fatalf("Expected a zip package: %v", err)
+		}
+		found := false
+		for _, f := range archive.File {
+			found = found || f.Name == "xl/worksheets/sheet1.xml"
+		}
+		if !found {
+			t.Error("Expected a worksheet in the workbook")
+		}
+	})
+
+	for _, query := range []string{"format=pdf", "year=2030", "year=2030&month=13", "dog_id=abc", "date_from=09.01.2030"} {
+		t.Run("rejects "+query, func(t *testing.T) {
+			if rec := export(query); rec.Code != http.StatusBadRequest {
+				t.Errorf("Expected status 400, got %d", rec.Code)
+			}
+		})
+	}
+}
+
+// TestBookingHandler_WalkerRules tests that a dog's allowlist and denylist are enforced on top of the color check
+func TestBookingHandler_WalkerRules(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	cfg := &config.Config{JWTSecret: "test-secret"}
+	handler := NewBookingHandler(db, cfg)
+	ruleRepo := repository.NewDogWalkerRuleRepository(db)
+
+	adminID := testutil.SeedTestUser(t, db, "admin@example.com", "Admin", "orange")
+	deniedID := testutil.SeedTestUser(t, db, "denied@example.com", "Denied Walker", "green")
+	trustedID := testutil.SeedTestUser(t, db, "trusted@example.com", "Trusted Walker", "green")
+	otherID := testutil.SeedTestUser(t, db, "other@example.com", "Other Walker", "green")
+	deniedDogID := testutil.SeedTestDog(t, db, "Arko", "Schäferhund", "green")
+	allowlistDogID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+
+	tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+	yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+
+	ruleRepo.Create(&models.DogWalkerRule{DogID: deniedDogID, UserID: deniedID, RuleType: models.DogWalkerRuleDeny, Reason: "Konflikt"})
+	ruleRepo.Create(&models.DogWalkerRule{DogID: allowlistDogID, UserID: trustedID, RuleType: models.DogWalkerRuleAllow, Reason: "Bezugsperson"})
+
+	book := func(userID, dogID int, email, scheduledTime string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(map[string]interface{}{"dog_id": dogID, "date": tomorrow, "scheduled_time": scheduledTime})
+		req := httptest.NewRequest("POST", "/api/bookings", bytes.NewReader(body))
+		req = req.WithContext(contextWithUser(req.Context(), userID, email, false))
+		rec := httptest.NewRecorder()
+		handler.CreateBooking(rec, req)
+		return rec
+	}
+
+	t.Run("denied walker cannot book", func(t *testing.T) {
+		if rec := book(deniedID, deniedDogID, "denied@example.com", "09:00"); rec.Code != http.StatusForbidden {
+			t.Errorf("Expected status 403, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("walker not on allowlist cannot book", func(t *testing.T) {
+		if rec := book(otherID, allowlistDogID, "other@example.com", "09:00"); rec.Code != http.StatusForbidden {
+			t.Errorf("Expected status 403, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("allowlisted walker can book", func(t *testing.T) {
+		if rec := book(trustedID, allowlistDogID, "trusted@example.com", "09:00"); rec.Code != http.StatusCreated {
+			t.Errorf("Expected status 201, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("expired deny no longer applies", func(t *testing.T) {
+		expiredDogID := testutil.SeedTestDog(t, db, "Cleo", "Pudel", "green")
+		ruleRepo.Create(&models.DogWalkerRule{DogID: expiredDogID, UserID: deniedID, RuleType: models.DogWalkerRuleDeny, Reason: "Abgelaufen", ExpiresAt: &yesterday})
+
+		if rec := book(deniedID, expiredDogID, "denied@example.com", "09:00"); rec.Code != http.StatusCreated {
+			t.Errorf("Expected status 201, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("booking cannot be moved onto a denied walker's date", func(t *testing.T) {
+		lastDay := time.Now().AddDate(0, 0, 3).Format("2006-01-02")
+		movedDogID := testutil.SeedTestDog(t, db, "Dino", "Dackel", "green")
+		bookingID := testutil.SeedTestBooking(t, db, deniedID, movedDogID, lastDay, "09:00", "scheduled")
+		ruleRepo.Create(&models.DogWalkerRule{DogID: movedDogID, UserID: deniedID, RuleType: models.DogWalkerRuleDeny, Reason: "Bis Montag", ExpiresAt: &lastDay})
+
+		body, _ := json.Marshal(map[string]string{"date": tomorrow, "scheduled_time": "16:00", "reason": "Tausch"})
+		req := httptest.NewRequest("PUT", fmt.Sprintf("/api/admin/bookings/%d/move", bookingID), bytes.NewReader(body))
+		req = mux.SetURLVars(req, map[string]string{"id": fmt.Sprintf("%d", bookingID)})
+		req = req.WithContext(contextWithUser(req.Context(), adminID, "admin@example.com", true))
+		rec := httptest.NewRecorder()
+		handler.MoveBooking(rec, req)
+
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("Expected status 403, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+}
+
+// TestBookingHandler_WalkLimits tests the per-dog walks per day and rest between walks
+func TestBookingHandler_WalkLimits(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	cfg := &config.Config{JWTSecret: "test-secret"}
+	handler := NewBookingHandler(db, cfg)
+
+	adminID := testutil.SeedTestUser(t, db, "admin@example.com", "Admin", "orange")
+	userID := testutil.SeedTestUser(t, db, "walker@example.com", "Walker", "green")
+	dogID := testutil.SeedTestDog(t, db, "Opa", "Dackel", "green")
+	db.Exec("UPDATE dogs SET max_walks_per_day = 2, min_rest_minutes = 180 WHERE id = ?", dogID)
+
+	tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+	testutil.SeedTestBooking(t, db, adminID, dogID, tomorrow, "09:00", "scheduled")
+
+	book := func(scheduledTime string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(map[string]interface{}{"dog_id": dogID, "date": tomorrow, "scheduled_time": scheduledTime})
+		req := httptest.NewRequest("POST", "/api/bookings", bytes.NewReader(body))
+		req = req.WithContext(contextWithUser(req.Context(), userID, "walker@example.com", false))
+		rec := httptest.NewRecorder()
+		handler.CreateBooking(rec, req)
+		return rec
+	}
+
+	move := func(bookingID int, scheduledTime string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(map[string]string{"date": tomorrow, "scheduled_time": scheduledTime, "reason": "Tausch"})
+		req := httptest.NewRequest("PUT", fmt.Sprintf("/api/admin/bookings/%d/move", bookingID), bytes.NewReader(body))
+		req = mux.SetURLVars(req, map[string]string{"id": fmt.Sprintf("%d", bookingID)})
+		req = req.WithContext(contextWithUser(req.Context(), adminID, "admin@example.com", true))
+		rec := httptest.NewRecorder()
+		handler.MoveBooking(rec, req)
+		return rec
+	}
+
+	limitRule := func(rec *httptest.ResponseRecorder) string {
+		var response struct {
+			Error     string                        `json:"error"`
+			WalkLimit *models.DogWalkLimitViolation `json:"walk_limit"`
+		}
+		json.Unmarshal(rec.Body.Bytes(), &response)
+		if response.WalkLimit == nil || !strings.Contains(response.Error, "Opa") {
+			return ""
+		}
+		return response.WalkLimit.Rule
+	}
+
+	var afternoonID int
+
+	t.Run("walk too soon after the last one is rejected", func(t *testing.T) {
+		rec := book("11:00")
+		if rec.Code != http.StatusConflict {
+			t.Fatalf("Expected status 409, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if rule := limitRule(rec); rule != models.WalkLimitMinRest {
+			t.Errorf("Expected rest rule, got %q: %s", rule, rec.Body.String())
+		}
+	})
+
+	t.Run("walk after the rest is allowed", func(t *testing.T) {
+		rec := book("14:00")
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("Expected status 201, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var booking models.Booking
+		json.Unmarshal(rec.Body.Bytes(), &booking)
+		afternoonID = booking.ID
+	})
+
+	t.Run("third walk of the day is rejected", func(t *testing.T) {
+		rec := book("16:00")
+		if rec.Code != http.StatusConflict {
+			t.Fatalf("Expected status 409, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if rule := limitRule(rec); rule != models.WalkLimitMaxWalksPerDay {
+			t.Errorf("Expected max walks rule, got %q: %s", rule, rec.Body.String())
+		}
+	})
+
+	t.Run("booking cannot be moved too close to another walk", func(t *testing.T) {
+		rec := move(afternoonID, "11:30")
+		if rec.Code != http.StatusConflict {
+			t.Fatalf("Expected status 409, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if rule := limitRule(rec); rule != models.WalkLimitMinRest {
+			t.Errorf("Expected rest rule, got %q: %s", rule, rec.Body.String())
+		}
+	})
+
+	t.Run("moved booking does not count against itself", func(t *testing.T) {
+		if rec := move(afternoonID, "15:00"); rec.Code != http.StatusOK {
+			t.Errorf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+}
@@ -0,0 +1,303 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/tranmh/gassigeher/internal/config"
+	"github.com/tranmh/gassigeher/internal/middleware"
+	"github.com/tranmh/gassigeher/internal/models"
+	"github.com/tranmh/gassigeher/internal/repository"
+	"github.com/tranmh/gassigeher/internal/services"
+)
+
+// BookingSeriesHandler handles recurring booking series HTTP requests
+type BookingSeriesHandler struct {
+	seriesRepo      *repository.BookingSeriesRepository
+	bookingRepo     *repository.BookingRepository
+	dogRepo         *repository.DogRepository
+	userRepo        *repository.UserRepository
+	userColorRepo   *repository.UserColorRepository
+	settingsRepo    *repository.SettingsRepository
+	seriesService   *services.BookingSeriesService
+	strikeService   *services.StrikeService
+	emailService    *services.EmailService
+	eventRepo       *repository.BookingEventRepository
+	ruleRepo        *repository.DogWalkerRuleRepository
+	waitlistService *services.WaitlistService
+}
+
+// NewBookingSeriesHandler creates a new booking series handler
+func NewBookingSeriesHandler(db *sql.DB, cfg *config.Config) *BookingSeriesHandler {
+	emailService, err := services.NewEmailService(services.ConfigToEmailConfig(cfg))
+	if err != nil {
+		fmt.Printf("Warning: Failed to initialize email service in BookingSeriesHandler: %v\n", err)
+	}
+
+	settingsRepo := repository.NewSettingsRepository(db)
+	holidayService := services.NewHolidayService(repository.NewHolidayRepository(db), settingsRepo)
+	bookingTimeService := services.NewBookingTimeService(repository.NewBookingTimeRepository(db), holidayService, settingsRepo)
+
+	seriesRepo := repository.NewBookingSeriesRepository(db)
+	bookingRepo := repository.NewBookingRepository(db)
+	dogRepo := repository.NewDogRepository(db)
+	userRepo := repository.NewUserRepository(db)
+	strikeService := services.NewStrikeService(repository.NewStrikeRepository(db), userRepo, settingsRepo, emailService)
+	waitlistService := services.NewWaitlistService(repository.NewWaitlistRepository(db), bookingRepo, userRepo, dogRepo,
+		settingsRepo, emailService)
+
+	return &BookingSeriesHandler{
+		seriesRepo:    seriesRepo,
+		bookingRepo:   bookingRepo,
+		dogRepo:       dogRepo,
+		userRepo:      userRepo,
+		userColorRepo: repository.NewUserColorRepository(db),
+		settingsRepo:  settingsRepo,
+		seriesService: services.NewBookingSeriesService(seriesRepo, bookingRepo, dogRepo, userRepo,
+			repository.NewUserColorRepository(db), repository.NewDogWalkerRuleRepository(db), repository.NewBlockedDateRepository(db),
+			settingsRepo, bookingTimeService, services.NewBookingQuotaService(bookingRepo, settingsRepo), strikeService,
+			waitlistService),
+		strikeService:   strikeService,
+		emailService:    emailService,
+		eventRepo:       repository.NewBookingEventRepository(db),
+		ruleRepo:        repository.NewDogWalkerRuleRepository(db),
+		waitlistService: waitlistService,
+	}
+}
+
+// CreateSeries handles POST /api/bookings/series - book a dog weekly or bi-weekly up to the booking horizon
+func (h *BookingSeriesHandler) CreateSeries(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(middleware.UserIDKey).(int)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req models.CreateBookingSeriesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	user, err := h.userRepo.FindByID(userID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get user")
+		return
+	}
+	if user == nil {
+		respondError(w, http.StatusNotFound, "User not found")
+		return
+	}
+	if !user.IsActive {
+		respondError(w, http.StatusForbidden, "Your account is deactivated")
+		return
+	}
+
+	dog, err := h.dogRepo.FindByID(req.DogID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get dog")
+		return
+	}
+	if dog == nil {
+		respondError(w, http.StatusNotFound, "Dog not found")
+		return
+	}
+	if !dog.IsAvailable {
+		respondError(w, http.StatusBadRequest, "Dog is currently unavailable")
+		return
+	}
+
+	// Check color-based access (admins and super admins bypass this check)
+	if !user.IsAdmin && !user.IsSuperAdmin {
+		userColorIDs, err := h.userColorRepo.GetUserColorIDs(userID)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to check user permissions")
+			return
+		}
+
+		dogColorID := 0
+		if dog.ColorID != nil {
+			dogColorID = *dog.ColorID
+		}
+
+		if !repository.CanUserAccessDogByColor(userColorIDs, dogColorID) {
+			respondError(w, http.StatusForbidden, "Du hast nicht die erforderliche Farbkategorie für diesen Hund")
+			return
+		}
+	}
+
+	// Per-dog allowlists and denylists apply in addition to the colors
+	if !user.IsAdmin && !user.IsSuperAdmin && rejectIfWalkerRuleForbids(w, h.ruleRepo, dog.ID, userID, req.StartDate) {
+		return
+	}
+
+	// Users who collected too many strikes may not book until the restriction ends
+	if !user.IsAdmin && !user.IsSuperAdmin && rejectIfRestricted(w, h.strikeService, userID) {
+		return
+	}
+
+	// Start date must lie between today and the booking horizon (UTC, like CreateBooking)
+	startDate, _ := time.Parse("2006-01-02", req.StartDate)
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	if startDate.Before(today) {
+		respondError(w, http.StatusBadRequest, "Cannot book dates in the past")
+		return
+	}
+	if horizon := h.seriesService.Horizon(today); startDate.After(horizon) {
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("Series must start before %s", horizon.Format("2006-01-02")))
+		return
+	}
+
+	series := &models.BookingSeries{
+		UserID:        userID,
+		DogID:         req.DogID,
+		ScheduledTime: req.ScheduledTime,
+		IntervalWeeks: req.IntervalWeeks,
+		StartDate:     req.StartDate,
+	}
+
+	result, err := h.seriesService.Create(series, today)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to create booking series")
+		return
+	}
+
+	h.userRepo.UpdateLastActivity(userID)
+
+	// Send one confirmation per booked occurrence, like single bookings
+	if user.Email != nil && h.emailService != nil {
+		for _, booking := range result.Bookings {
+			go h.emailService.SendBookingConfirmation(*user.Email, user.FirstName, dog.Name, booking.Date, booking.ScheduledTime, services.BookingInvite(booking, dog))
+		}
+	}
+
+	respondJSON(w, http.StatusCreated, result)
+}
+
+// ListSeries handles GET /api/bookings/series - list the current user's booking series
+func (h *BookingSeriesHandler) ListSeries(w http.ResponseWriter, r *http.Request) {
+	userID, _ := r.Context().Value(middleware.UserIDKey).(int)
+
+	seriesList, err := h.seriesRepo.FindByUser(userID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get booking series")
+		return
+	}
+
+	for _, series := range seriesList {
+		if dog, err := h.dogRepo.FindByID(series.DogID); err == nil {
+			series.Dog = dog
+		}
+	}
+
+	respondJSON(w, http.StatusOK, seriesList)
+}
+
+// CancelSeries handles PUT /api/bookings/series/:id/cancel - stop a series and cancel its upcoming occurrences.
+// Single occurrences are cancelled through the regular booking cancel endpoint.
+// For non-admin users, occurrences inside the cancellation notice period are kept.
+func (h *BookingSeriesHandler) CancelSeries(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid series ID")
+		return
+	}
+
+	userID, _ := r.Context().Value(middleware.UserIDKey).(int)
+	isAdmin, _ := r.Context().Value(middleware.IsAdminKey).(bool)
+
+	var req models.CancelBookingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		// Allow empty body
+		req = models.CancelBookingRequest{}
+	}
+
+	series, err := h.seriesRepo.FindByID(id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get booking series")
+		return
+	}
+	if series == nil {
+		respondError(w, http.StatusNotFound, "Booking series not found")
+		return
+	}
+	if !isAdmin && series.UserID != userID {
+		respondError(w, http.StatusForbidden, "Access denied")
+		return
+	}
+
+	if err := h.seriesRepo.Deactivate(id); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to cancel booking series")
+		return
+	}
+
+	bookings, err := h.bookingRepo.FindUpcomingBySeries(id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get series bookings")
+		return
+	}
+
+	noticeHours := h.settingsRepo.GetInt("cancellation_notice_hours", 12)
+	noticeDeadline := time.Now().Add(time.Duration(noticeHours) * time.Hour)
+
+	reason := ""
+	if req.Reason != nil {
+		reason = *req.Reason
+	}
+
+	cancelled := []*models.Booking{}
+	kept := []*models.Booking{}
+	for _, booking := range bookings {
+		if !isAdmin {
+			bookingTime, err := time.ParseInLocation("2006-01-02 15:04", booking.Date+" "+booking.ScheduledTime, time.Local)
+			if err == nil && bookingTime.Before(noticeDeadline) {
+				kept = append(kept, booking)
+				continue
+			}
+		}
+
+		if err := h.bookingRepo.Cancel(booking.ID, req.Reason); err != nil {
+			fmt.Printf("Warning: Failed to cancel series booking %d: %v\n", booking.ID, err)
+			continue
+		}
+		booking.Status = "cancelled"
+		cancelled = append(cancelled, booking)
+		recordBookingEvent(h.eventRepo, booking.ID, models.BookingEventCancelled, userID, "", reason)
+
+		if _, err := h.waitlistService.OfferSlot(booking.DogID, booking.Date, booking.ScheduledTime, time.Now()); err != nil {
+			fmt.Printf("Warning: Failed to offer freed slot of series booking %d to waitlist: %v\n", booking.ID, err)
+		}
+	}
+
+	h.userRepo.UpdateLastActivity(userID)
+
+	// Notify the walker when an admin cancels the series with a reason
+	if isAdmin && req.Reason != nil && h.emailService != nil && len(cancelled) > 0 {
+		user, _ := h.userRepo.FindByID(series.UserID)
+		dog, _ := h.dogRepo.FindByID(series.DogID)
+		if user != nil && user.Email != nil && dog != nil {
+			for _, booking := range cancelled {
+				go h.emailService.SendAdminCancellation(*user.Email, user.FirstName, dog.Name, booking.Date, booking.ScheduledTime, *req.Reason, services.BookingInvite(booking, dog))
+			}
+		}
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"message":   "Booking series cancelled successfully",
+		"cancelled": cancelled,
+		"kept":      kept,
+	})
+}
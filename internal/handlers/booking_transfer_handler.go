@@ -0,0 +1,495 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/tranmh/gassigeher/internal/config"
+	"github.com/tranmh/gassigeher/internal/middleware"
+	"github.com/tranmh/gassigeher/internal/models"
+	"github.com/tranmh/gassigeher/internal/repository"
+	"github.com/tranmh/gassigeher/internal/services"
+)
+
+// BookingTransferHandler handles handing bookings over between walkers
+type BookingTransferHandler struct {
+	transferRepo  *repository.BookingTransferRepository
+	bookingRepo   *repository.BookingRepository
+	dogRepo       *repository.DogRepository
+	userRepo      *repository.UserRepository
+	userColorRepo *repository.UserColorRepository
+	settingsRepo  *repository.SettingsRepository
+	strikeService *services.StrikeService
+	quotaService  *services.BookingQuotaService
+	emailService  *services.EmailService
+	ruleRepo      *repository.DogWalkerRuleRepository
+}
+
+// NewBookingTransferHandler creates a new booking transfer handler
+func NewBookingTransferHandler(db *sql.DB, cfg *config.Config) *BookingTransferHandler {
+	emailService, err := services.NewEmailService(services.ConfigToEmailConfig(cfg))
+	if err != nil {
+		fmt.Printf("Warning: Failed to initialize email service in BookingTransferHandler: %v\n", err)
+	}
+
+	bookingRepo := repository.NewBookingRepository(db)
+	userRepo := repository.NewUserRepository(db)
+	settingsRepo := repository.NewSettingsRepository(db)
+
+	return &BookingTransferHandler{
+		transferRepo:  repository.NewBookingTransferRepository(db),
+		bookingRepo:   bookingRepo,
+		dogRepo:       repository.NewDogRepository(db),
+		userRepo:      userRepo,
+		userColorRepo: repository.NewUserColorRepository(db),
+		settingsRepo:  settingsRepo,
+		strikeService: services.NewStrikeService(repository.NewStrikeRepository(db), userRepo, settingsRepo, emailService),
+		quotaService:  services.NewBookingQuotaService(bookingRepo, settingsRepo),
+		emailService:  emailService,
+		ruleRepo:      repository.NewDogWalkerRuleRepository(db),
+	}
+}
+
+// OfferTransfer handles POST /api/bookings/:id/transfer - offer an own booking to other walkers
+func (h *BookingTransferHandler) OfferTransfer(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bookingID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid booking ID")
+		return
+	}
+
+	userID, _ := r.Context().Value(middleware.UserIDKey).(int)
+
+	// Note is optional
+	var req models.OfferTransferRequest
+	json.NewDecoder(r.Body).Decode(&req)
+
+	booking, err := h.bookingRepo.FindByID(bookingID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get booking")
+		return
+	}
+	if booking == nil || booking.UserID != userID {
+		respondError(w, http.StatusNotFound, "Booking not found")
+		return
+	}
+
+	if booking.Status != "scheduled" {
+		respondError(w, http.StatusBadRequest, "Booking is already "+booking.Status)
+		return
+	}
+	if isPastBooking(booking) {
+		respondError(w, http.StatusBadRequest, "Cannot transfer past bookings")
+		return
+	}
+
+	transfer := &models.BookingTransfer{
+		BookingID:  booking.ID,
+		FromUserID: userID,
+		Note:       req.Note,
+	}
+	if err := h.transferRepo.Create(transfer); err != nil {
+		if err.Error() == "transfer already open" {
+			respondError(w, http.StatusConflict, "Diese Buchung wird bereits zur Übernahme angeboten")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to offer booking")
+		return
+	}
+
+	transfer, err = h.transferRepo.FindByID(transfer.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get transfer")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, transfer)
+}
+
+// WithdrawTransfer handles DELETE /api/bookings/:id/transfer - take back an offer nobody accepted yet
+func (h *BookingTransferHandler) WithdrawTransfer(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bookingID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid booking ID")
+		return
+	}
+
+	userID, _ := r.Context().Value(middleware.UserIDKey).(int)
+
+	transfer, err := h.transferRepo.FindOpenByBooking(bookingID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get transfer")
+		return
+	}
+	if transfer == nil || transfer.FromUserID != userID {
+		respondError(w, http.StatusNotFound, "Transfer not found")
+		return
+	}
+	if transfer.Status != models.TransferStatusOffered {
+		respondError(w, http.StatusBadRequest, "Transfer is already accepted and waiting for approval")
+		return
+	}
+
+	if err := h.transferRepo.Withdraw(transfer.ID); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to withdraw transfer")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Transfer withdrawn successfully"})
+}
+
+// ListBookingTransfers handles GET /api/bookings/:id/transfers - transfer history of a booking.
+// Visible to admins and to walkers who owned or took over the booking.
+func (h *BookingTransferHandler) ListBookingTransfers(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bookingID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid booking ID")
+		return
+	}
+
+	userID, _ := r.Context().Value(middleware.UserIDKey).(int)
+	isAdmin, _ := r.Context().Value(middleware.IsAdminKey).(bool)
+
+	booking, err := h.bookingRepo.FindByID(bookingID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get booking")
+		return
+	}
+	if booking == nil {
+		respondError(w, http.StatusNotFound, "Booking not found")
+		return
+	}
+
+	transfers, err := h.transferRepo.FindByBooking(bookingID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get transfers")
+		return
+	}
+
+	if !isAdmin && booking.UserID != userID {
+		involved := false
+		for _, transfer := range transfers {
+			if transfer.FromUserID == userID || (transfer.ToUserID != nil && *transfer.ToUserID == userID) {
+				involved = true
+			}
+		}
+		if !involved {
+			respondError(w, http.StatusForbidden, "Access denied")
+			return
+		}
+	}
+
+	respondJSON(w, http.StatusOK, transfers)
+}
+
+// ListOffers handles GET /api/transfers - open offers the current user may take over
+func (h *BookingTransferHandler) ListOffers(w http.ResponseWriter, r *http.Request) {
+	userID, _ := r.Context().Value(middleware.UserIDKey).(int)
+	isAdmin, _ := r.Context().Value(middleware.IsAdminKey).(bool)
+
+	offers, err := h.transferRepo.FindOffered(time.Now().Format("2006-01-02"))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get transfers")
+		return
+	}
+
+	userColorIDs, err := h.userColorRepo.GetUserColorIDs(userID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to check user permissions")
+		return
+	}
+
+	visible := []*models.BookingTransfer{}
+	for _, offer := range offers {
+		if offer.FromUserID == userID {
+			continue
+		}
+		if !isAdmin {
+			dog, err := h.dogRepo.FindByID(offer.DogID)
+			if err != nil || dog == nil {
+				continue
+			}
+			dogColorID := 0
+			if dog.ColorID != nil {
+				dogColorID = *dog.ColorID
+			}
+			if !repository.CanUserAccessDogByColor(userColorIDs, dogColorID) {
+				continue
+			}
+		}
+		visible = append(visible, offer)
+	}
+
+	respondJSON(w, http.StatusOK, visible)
+}
+
+// AcceptTransfer handles POST /api/transfers/:id/accept - take over an offered booking.
+// The booking changes owner right away unless booking_transfer_requires_approval is set.
+func (h *BookingTransferHandler) AcceptTransfer(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid transfer ID")
+		return
+	}
+
+	userID, _ := r.Context().Value(middleware.UserIDKey).(int)
+
+	transfer, err := h.transferRepo.FindByID(id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get transfer")
+		return
+	}
+	if transfer == nil {
+		respondError(w, http.StatusNotFound, "Transfer not found")
+		return
+	}
+	if transfer.Status != models.TransferStatusOffered {
+		respondError(w, http.StatusBadRequest, "Transfer is already "+transfer.Status)
+		return
+	}
+	if transfer.FromUserID == userID {
+		respondError(w, http.StatusBadRequest, "Du kannst deine eigene Buchung nicht übernehmen")
+		return
+	}
+
+	user, err := h.userRepo.FindByID(userID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get user")
+		return
+	}
+	if user == nil {
+		respondError(w, http.StatusNotFound, "User not found")
+		return
+	}
+	if !h.checkNewWalker(w, transfer, user) {
+		return
+	}
+
+	now := time.Now()
+	if h.getSetting("booking_transfer_requires_approval") == "true" {
+		if err := h.transferRepo.MarkAccepted(transfer.ID, userID, now); err != nil {
+			respondError(w, http.StatusConflict, "Transfer is no longer open")
+			return
+		}
+	} else {
+		if err := h.transferRepo.Complete(transfer, userID, nil, now); err != nil {
+			respondError(w, http.StatusConflict, "Diese Buchung kann nicht mehr übernommen werden")
+			return
+		}
+		h.notifyCompleted(transfer, user)
+	}
+
+	h.userRepo.UpdateLastActivity(userID)
+
+	transfer, err = h.transferRepo.FindByID(transfer.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get transfer")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, transfer)
+}
+
+// ListPendingTransfers handles GET /api/transfers/pending - accepted transfers waiting for approval (admin only)
+func (h *BookingTransferHandler) ListPendingTransfers(w http.ResponseWriter, r *http.Request) {
+	transfers, err := h.transferRepo.FindPendingApproval()
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get transfers")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, transfers)
+}
+
+// ApproveTransfer handles PUT /api/transfers/:id/approve - hand the booking over (admin only)
+func (h *BookingTransferHandler) ApproveTransfer(w http.ResponseWriter, r *http.Request) {
+	transfer, ok := h.getPendingTransfer(w, r)
+	if !ok {
+		return
+	}
+
+	adminID, _ := r.Context().Value(middleware.UserIDKey).(int)
+
+	newWalker, err := h.userRepo.FindByID(*transfer.ToUserID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get user")
+		return
+	}
+	if newWalker == nil {
+		respondError(w, http.StatusNotFound, "User not found")
+		return
+	}
+
+	// Strikes, bookings or rule changes since the walker accepted may rule them out by now
+	if !h.checkNewWalker(w, transfer, newWalker) {
+		return
+	}
+
+	if err := h.transferRepo.Complete(transfer, newWalker.ID, &adminID, time.Now()); err != nil {
+		respondError(w, http.StatusConflict, "Diese Buchung kann nicht mehr übergeben werden")
+		return
+	}
+
+	h.notifyCompleted(transfer, newWalker)
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Transfer approved successfully"})
+}
+
+// RejectTransfer handles PUT /api/transfers/:id/reject - keep the booking with its owner (admin only)
+func (h *BookingTransferHandler) RejectTransfer(w http.ResponseWriter, r *http.Request) {
+	transfer, ok := h.getPendingTransfer(w, r)
+	if !ok {
+		return
+	}
+
+	adminID, _ := r.Context().Value(middleware.UserIDKey).(int)
+
+	if err := h.transferRepo.Reject(transfer.ID, adminID); err != nil {
+		respondError(w, http.StatusConflict, "Transfer is no longer open")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Transfer rejected successfully"})
+}
+
+// getPendingTransfer loads the transfer from the URL and checks it waits for approval.
+// Responds with an error and returns false otherwise.
+func (h *BookingTransferHandler) getPendingTransfer(w http.ResponseWriter, r *http.Request) (*models.BookingTransfer, bool) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid transfer ID")
+		return nil, false
+	}
+
+	transfer, err := h.transferRepo.FindByID(id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get transfer")
+		return nil, false
+	}
+	if transfer == nil {
+		respondError(w, http.StatusNotFound, "Transfer not found")
+		return nil, false
+	}
+	if transfer.Status != models.TransferStatusPendingApproval || transfer.ToUserID == nil {
+		respondError(w, http.StatusBadRequest, "Transfer is not waiting for approval")
+		return nil, false
+	}
+
+	return transfer, true
+}
+
+// checkNewWalker checks that the walker taking over a booking may walk the dog at that time.
+// Responds with an error and returns false otherwise.
+func (h *BookingTransferHandler) checkNewWalker(w http.ResponseWriter, transfer *models.BookingTransfer, user *models.User) bool {
+	if !user.IsActive {
+		respondError(w, http.StatusForbidden, "Your account is deactivated")
+		return false
+	}
+
+	dog, err := h.dogRepo.FindByID(transfer.DogID)
+	if err != nil || dog == nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get dog")
+		return false
+	}
+
+	// Taking over a walk is a booking: same color, strike and quota rules (admins and super admins bypass them)
+	if !user.IsAdmin && !user.IsSuperAdmin {
+		userColorIDs, err := h.userColorRepo.GetUserColorIDs(user.ID)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to check user permissions")
+			return false
+		}
+
+		dogColorID := 0
+		if dog.ColorID != nil {
+			dogColorID = *dog.ColorID
+		}
+
+		if !repository.CanUserAccessDogByColor(userColorIDs, dogColorID) {
+			respondError(w, http.StatusForbidden, "Du hast nicht die erforderliche Farbkategorie für diesen Hund")
+			return false
+		}
+
+		if rejectIfWalkerRuleForbids(w, h.ruleRepo, dog.ID, user.ID, transfer.Date) {
+			return false
+		}
+
+		if rejectIfRestricted(w, h.strikeService, user.ID) {
+			return false
+		}
+
+		violation, err := h.quotaService.Check(user.ID, dog.ID, transfer.Date, time.Now())
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to check booking limits")
+			return false
+		}
+		if violation != nil {
+			respondQuotaViolation(w, violation)
+			return false
+		}
+	}
+
+	// The new walker must not be out with another dog at that time
+	userConflict, err := h.bookingRepo.FindUserConflictingBooking(user.ID, dog.ID, transfer.Date, transfer.ScheduledTime, dog.GetWalkDuration(), 0)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to check availability")
+		return false
+	}
+	if userConflict != nil {
+		respondUserBookingConflict(w, userConflict)
+		return false
+	}
+
+	return true
+}
+
+// notifyCompleted emails both walkers about a completed hand-over
+func (h *BookingTransferHandler) notifyCompleted(transfer *models.BookingTransfer, newWalker *models.User) {
+	if h.emailService == nil {
+		return
+	}
+
+	// The calendar event moves with the booking: cancelled for the previous walker, invited for the new one
+	var invite *services.ICalEvent
+	if booking, err := h.bookingRepo.FindByID(transfer.BookingID); err == nil && booking != nil {
+		dog, _ := h.dogRepo.FindByID(transfer.DogID)
+		invite = services.BookingInvite(booking, dog)
+	}
+
+	if newWalker.Email != nil {
+		go h.emailService.SendBookingTakenOver(*newWalker.Email, newWalker.FirstName, transfer.DogName, transfer.Date, transfer.ScheduledTime, transfer.FromUserName, invite)
+	}
+
+	previousWalker, err := h.userRepo.FindByID(transfer.FromUserID)
+	if err == nil && previousWalker != nil && previousWalker.Email != nil {
+		go h.emailService.SendBookingHandedOver(*previousWalker.Email, previousWalker.FirstName, transfer.DogName, transfer.Date, transfer.ScheduledTime, newWalker.FullName(), invite)
+	}
+}
+
+// getSetting reads a system setting, returning "" if unset
+func (h *BookingTransferHandler) getSetting(key string) string {
+	setting, err := h.settingsRepo.Get(key)
+	if err != nil || setting == nil {
+		return ""
+	}
+	return setting.Value
+}
+
+// isPastBooking reports whether a booking's start time has passed
+func isPastBooking(booking *models.Booking) bool {
+	start, err := time.ParseInLocation("2006-01-02 15:04", booking.Date+" "+booking.ScheduledTime, time.Local)
+	if err != nil {
+		return false
+	}
+	return start.Before(time.Now())
+}
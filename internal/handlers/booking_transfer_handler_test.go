@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/tranmh/gassigeher/internal/config"
+	"github.com/tranmh/gassigeher/internal/models"
+	"github.com/tranmh/gassigeher/internal/testutil"
+)
+
+// TestBookingTransferHandler tests offering, accepting and approving booking hand-overs
+func TestBookingTransferHandler(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	cfg := &config.Config{JWTSecret: "test-secret"}
+	handler := NewBookingTransferHandler(db, cfg)
+
+	ownerID := testutil.SeedTestUser(t, db, "owner@example.com", "Olga Owner", "green")
+	takerID := testutil.SeedTestUser(t, db, "taker@example.com", "Tom Taker", "green")
+	noColorID := testutil.SeedTestUserWithoutColors(t, db, "nocolor@example.com", "No Color", "green")
+	adminID := testutil.SeedTestUser(t, db, "admin@example.com", "Admin", "orange")
+	dogID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+
+	date := time.Now().AddDate(0, 0, 2).Format("2006-01-02")
+	bookingID := testutil.SeedTestBooking(t, db, ownerID, dogID, date, "09:00", "scheduled")
+
+	call := func(fn http.HandlerFunc, method, path string, vars map[string]string, asUser int, isAdmin bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req = mux.SetURLVars(req, vars)
+		req = req.WithContext(contextWithUser(req.Context(), asUser, "", isAdmin))
+		rec := httptest.NewRecorder()
+		fn(rec, req)
+		return rec
+	}
+	bookingVars := map[string]string{"id": fmt.Sprintf("%d", bookingID)}
+
+	t.Run("only the owner can offer", func(t *testing.T) {
+		if rec := call(handler.OfferTransfer, "POST", "/api/bookings/x/transfer", bookingVars, takerID, false); rec.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404, got %d", rec.Code)
+		}
+	})
+
+	var transfer models.BookingTransfer
+	t.Run("offer booking", func(t *testing.T) {
+		rec := call(handler.OfferTransfer, "POST", "/api/bookings/x/transfer", bookingVars, ownerID, false)
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("Expected status 201, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+		json.Unmarshal(rec.Body.Bytes(), &transfer)
+
+		if rec := call(handler.OfferTransfer, "POST", "/api/bookings/x/transfer", bookingVars, ownerID, false); rec.Code != http.StatusConflict {
+			t.Errorf("Expected status 409 for second offer, got %d", rec.Code)
+		}
+	})
+
+	t.Run("offers are listed for walkers with the dog's color", func(t *testing.T) {
+		var offers []models.BookingTransfer
+		rec := call(handler.ListOffers, "GET", "/api/transfers", nil, takerID, false)
+		json.Unmarshal(rec.Body.Bytes(), &offers)
+		if len(offers) != 1 || offers[0].ID != transfer.ID {
+			t.Errorf("Expected the offer to be listed, got %+v", offers)
+		}
+
+		rec = call(handler.ListOffers, "GET", "/api/transfers", nil, noColorID, false)
+		json.Unmarshal(rec.Body.Bytes(), &offers)
+		if len(offers) != 0 {
+			t.Errorf("Expected no offers without the dog's color, got %d", len(offers))
+		}
+	})
+
+	transferVars := map[string]string{"id": fmt.Sprintf("%d", transfer.ID)}
+
+	t.Run("walker without the dog's color cannot accept", func(t *testing.T) {
+		if rec := call(handler.AcceptTransfer, "POST", "/api/transfers/x/accept", transferVars, noColorID, false); rec.Code != http.StatusForbidden {
+			t.Errorf("Expected status 403, got %d", rec.Code)
+		}
+	})
+
+	t.Run("accept with admin approval", func(t *testing.T) {
+		db.Exec("UPDATE system_settings SET value = 'true' WHERE key = 'booking_transfer_requires_approval'")
+		defer db.Exec("UPDATE system_settings SET value = 'false' WHERE key = 'booking_transfer_requires_approval'")
+
+		rec := call(handler.AcceptTransfer, "POST", "/api/transfers/x/accept", transferVars, takerID, false)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		var ownerOfBooking int
+		db.QueryRow("SELECT user_id FROM bookings WHERE id = ?", bookingID).Scan(&ownerOfBooking)
+		if ownerOfBooking != ownerID {
+			t.Error("Booking must not change owner before approval")
+		}
+
+		rec = call(handler.ApproveTransfer, "PUT", "/api/transfers/x/approve", transferVars, adminID, true)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+		db.QueryRow("SELECT user_id FROM bookings WHERE id = ?", bookingID).Scan(&ownerOfBooking)
+		if ownerOfBooking != takerID {
+			t.Errorf("Expected booking owner %d after approval, got %d", takerID, ownerOfBooking)
+		}
+	})
+
+	t.Run("accept without approval hands over right away", func(t *testing.T) {
+		otherID := testutil.SeedTestBooking(t, db, ownerID, dogID, date, "15:00", "scheduled")
+		rec := call(handler.OfferTransfer, "POST", "/api/bookings/x/transfer", map[string]string{"id": fmt.Sprintf("%d", otherID)}, ownerID, false)
+		var offer models.BookingTransfer
+		json.Unmarshal(rec.Body.Bytes(), &offer)
+
+		rec = call(handler.AcceptTransfer, "POST", "/api/transfers/x/accept", map[string]string{"id": fmt.Sprintf("%d", offer.ID)}, takerID, false)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+		var accepted models.BookingTransfer
+		json.Unmarshal(rec.Body.Bytes(), &accepted)
+		if accepted.Status != models.TransferStatusCompleted {
+			t.Errorf("Expected completed transfer, got %s", accepted.Status)
+		}
+	})
+
+	t.Run("approval re-checks the new walker", func(t *testing.T) {
+		db.Exec("UPDATE system_settings SET value = 'true' WHERE key = 'booking_transfer_requires_approval'")
+		defer db.Exec("UPDATE system_settings SET value = 'false' WHERE key = 'booking_transfer_requires_approval'")
+
+		otherID := testutil.SeedTestBooking(t, db, ownerID, dogID, date, "17:00", "scheduled")
+		rec := call(handler.OfferTransfer, "POST", "/api/bookings/x/transfer", map[string]string{"id": fmt.Sprintf("%d", otherID)}, ownerID, false)
+		var offer models.BookingTransfer
+		json.Unmarshal(rec.Body.Bytes(), &offer)
+		offerVars := map[string]string{"id": fmt.Sprintf("%d", offer.ID)}
+
+		if rec := call(handler.AcceptTransfer, "POST", "/api/transfers/x/accept", offerVars, takerID, false); rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		// The walker booked another dog for the same time while waiting for approval
+		otherDogID := testutil.SeedTestDog(t, db, "Max", "Beagle", "green")
+		testutil.SeedTestBooking(t, db, takerID, otherDogID, date, "17:00", "scheduled")
+
+		if rec := call(handler.ApproveTransfer, "PUT", "/api/transfers/x/approve", offerVars, adminID, true); rec.Code != http.StatusConflict {
+			t.Errorf("Expected status 409 for walker conflict, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		db.Exec("UPDATE bookings SET status = 'cancelled' WHERE user_id = ? AND dog_id = ?", takerID, otherDogID)
+		db.Exec("UPDATE users SET is_active = 0 WHERE id = ?", takerID)
+		defer db.Exec("UPDATE users SET is_active = 1 WHERE id = ?", takerID)
+
+		if rec := call(handler.ApproveTransfer, "PUT", "/api/transfers/x/approve", offerVars, adminID, true); rec.Code != http.StatusForbidden {
+			t.Errorf("Expected status 403 for deactivated walker, got %d", rec.Code)
+		}
+
+		var ownerOfBooking int
+		db.QueryRow("SELECT user_id FROM bookings WHERE id = ?", otherID).Scan(&ownerOfBooking)
+		if ownerOfBooking != ownerID {
+			t.Errorf("Booking must stay with its owner, got %d", ownerOfBooking)
+		}
+	})
+
+	t.Run("previous owner sees the history", func(t *testing.T) {
+		rec := call(handler.ListBookingTransfers, "GET", "/api/bookings/x/transfers", bookingVars, ownerID, false)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", rec.Code)
+		}
+		var history []models.BookingTransfer
+		json.Unmarshal(rec.Body.Bytes(), &history)
+		if len(history) != 1 || history[0].DecidedBy == nil || *history[0].DecidedBy != adminID {
+			t.Errorf("Expected one approved transfer, got %+v", history)
+		}
+
+		if rec := call(handler.ListBookingTransfers, "GET", "/api/bookings/x/transfers", bookingVars, noColorID, false); rec.Code != http.StatusForbidden {
+			t.Errorf("Expected status 403 for uninvolved user, got %d", rec.Code)
+		}
+	})
+}
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"database/sql"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/tranmh/gassigeher/internal/config"
+	"github.com/tranmh/gassigeher/internal/middleware"
+	"github.com/tranmh/gassigeher/internal/repository"
+	"github.com/tranmh/gassigeher/internal/services"
+)
+
+// CalendarHandler handles iCalendar feed requests
+type CalendarHandler struct {
+	db              *sql.DB
+	cfg             *config.Config
+	calendarService *services.CalendarService
+}
+
+// NewCalendarHandler creates a new calendar handler
+func NewCalendarHandler(db *sql.DB, cfg *config.Config) *CalendarHandler {
+	return &CalendarHandler{
+		db:  db,
+		cfg: cfg,
+		calendarService: services.NewCalendarService(
+			repository.NewCalendarTokenRepository(db),
+			repository.NewBookingRepository(db),
+			repository.NewDogRepository(db),
+			repository.NewUserRepository(db),
+		),
+	}
+}
+
+// GetMyCalendar returns the current user's feed URLs, creating the feed token on first use
+// GET /api/users/me/calendar
+func (h *CalendarHandler) GetMyCalendar(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(middleware.UserIDKey).(int)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	token, err := h.calendarService.GetOrCreateToken(userID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get calendar feed")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, h.feedURLs(r, token))
+}
+
+// RegenerateMyCalendar replaces the current user's feed token, revoking existing subscriptions
+// POST /api/users/me/calendar/regenerate
+func (h *CalendarHandler) RegenerateMyCalendar(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(middleware.UserIDKey).(int)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	token, err := h.calendarService.RegenerateToken(userID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to regenerate calendar feed")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, h.feedURLs(r, token))
+}
+
+// GetUserFeed serves the bookings of the token's owner as iCalendar (public, the token authenticates)
+// GET /api/calendar/{token}.ics
+func (h *CalendarHandler) GetUserFeed(w http.ResponseWriter, r *http.Request) {
+	user, err := h.calendarService.FindUserByToken(mux.Vars(r)["token"])
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to load calendar")
+		return
+	}
+	if user == nil {
+		respondError(w, http.StatusNotFound, "Calendar not found")
+		return
+	}
+
+	feed, err := h.calendarService.UserFeed(user, time.Now())
+	if err != nil {
+		log.Printf("Failed to build calendar feed for user %d: %v", user.ID, err)
+		respondError(w, http.StatusInternalServerError, "Failed to load calendar")
+		return
+	}
+
+	respondICalendar(w, feed)
+}
+
+// GetAdminFeed serves all bookings as iCalendar, optionally for one dog (?dog_id=).
+// Only tokens of admins are accepted.
+// GET /api/calendar/admin/{token}.ics
+func (h *CalendarHandler) GetAdminFeed(w http.ResponseWriter, r *http.Request) {
+	user, err := h.calendarService.FindUserByToken(mux.Vars(r)["token"])
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to load calendar")
+		return
+	}
+	if user == nil || (!user.IsAdmin && !user.IsSuperAdmin) {
+		respondError(w, http.StatusNotFound, "Calendar not found")
+		return
+	}
+
+	var dogID *int
+	if value := r.URL.Query().Get("dog_id"); value != "" {
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid dog ID")
+			return
+		}
+		dogID = &id
+	}
+
+	feed, err := h.calendarService.AdminFeed(dogID, time.Now())
+	if err != nil {
+		log.Printf("Failed to build admin calendar feed: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to load calendar")
+		return
+	}
+
+	respondICalendar(w, feed)
+}
+
+// feedURLs lists the subscription URLs for token; the admin feed only for admins
+func (h *CalendarHandler) feedURLs(r *http.Request, token string) map[string]string {
+	base := strings.TrimRight(h.cfg.BaseURL, "/") + "/api/calendar/"
+	urls := map[string]string{
+		"token":    token,
+		"feed_url": base + token + ".ics",
+	}
+
+	isAdmin, _ := r.Context().Value(middleware.IsAdminKey).(bool)
+	isSuperAdmin, _ := r.Context().Value(middleware.IsSuperAdminKey).(bool)
+	if isAdmin || isSuperAdmin {
+		urls["admin_feed_url"] = base + "admin/" + token + ".ics"
+	}
+	return urls
+}
+
+// respondICalendar sends an iCalendar document
+func respondICalendar(w http.ResponseWriter, feed string) {
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="gassigeher.ics"`)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(feed))
+}
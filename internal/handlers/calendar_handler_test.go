@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/tranmh/gassigeher/internal/config"
+	"github.com/tranmh/gassigeher/internal/testutil"
+)
+
+// TestCalendarHandler tests feed URLs, token regeneration and the public feed endpoints
+func TestCalendarHandler(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	cfg := &config.Config{JWTSecret: "test-secret", BaseURL: "https://gassi.example.com/"}
+	handler := NewCalendarHandler(db, cfg)
+
+	userID := testutil.SeedTestUser(t, db, "walker@example.com", "Walker", "green")
+	adminID := testutil.SeedTestUser(t, db, "admin@example.com", "Admin", "green")
+	db.Exec("UPDATE users SET is_admin = 1 WHERE id = ?", adminID)
+	dogID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+	date := time.Now().AddDate(0, 0, 2).Format("2006-01-02")
+	testutil.SeedTestBooking(t, db, userID, dogID, date, "09:00", "scheduled")
+
+	getURLs := func(fn http.HandlerFunc, method string, asUser int, isAdmin bool) map[string]string {
+		req := httptest.NewRequest(method, "/api/users/me/calendar", nil)
+		req = req.WithContext(contextWithUser(req.Context(), asUser, "", isAdmin))
+		rec := httptest.NewRecorder()
+		fn(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+		var urls map[string]string
+		json.Unmarshal(rec.Body.Bytes(), &urls)
+		return urls
+	}
+
+	feed := func(fn http.HandlerFunc, token, query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/api/calendar/"+token+".ics"+query, nil)
+		req = mux.SetURLVars(req, map[string]string{"token": token})
+		rec := httptest.NewRecorder()
+		fn(rec, req)
+		return rec
+	}
+
+	userURLs := getURLs(handler.GetMyCalendar, "GET", userID, false)
+	adminURLs := getURLs(handler.GetMyCalendar, "GET", adminID, true)
+
+	t.Run("feed URLs", func(t *testing.T) {
+		if userURLs["feed_url"] != "https://gassi.example.com/api/calendar/"+userURLs["token"]+".ics" {
+			t.Errorf("Unexpected feed URL %q", userURLs["feed_url"])
+		}
+		if _, ok := userURLs["admin_feed_url"]; ok {
+			t.Error("Expected no admin feed URL for walkers")
+		}
+		if adminURLs["admin_feed_url"] != "https://gassi.example.com/api/calendar/admin/"+adminURLs["token"]+".ics" {
+			t.Errorf("Unexpected admin feed URL %q", adminURLs["admin_feed_url"])
+		}
+	})
+
+	t.Run("user feed", func(t *testing.T) {
+		rec := feed(handler.GetUserFeed, userURLs["token"], "")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", rec.Code)
+		}
+		if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/calendar") {
+			t.Errorf("Expected text/calendar, got %q", ct)
+		}
+		if !strings.Contains(rec.Body.String(), "SUMMARY:Gassi mit Bella") {
+			t.Errorf("Expected the booking in the feed, got:\n%s", rec.Body.String())
+		}
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		if rec := feed(handler.GetUserFeed, "deadbeef", ""); rec.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404, got %d", rec.Code)
+		}
+	})
+
+	t.Run("admin feed requires an admin token", func(t *testing.T) {
+		if rec := feed(handler.GetAdminFeed, userURLs["token"], ""); rec.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404 for a walker token, got %d", rec.Code)
+		}
+		rec := feed(handler.GetAdminFeed, adminURLs["token"], "?dog_id=1")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", rec.Code)
+		}
+		if rec := feed(handler.GetAdminFeed, adminURLs["token"], "?dog_id=abc"); rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 for invalid dog ID, got %d", rec.Code)
+		}
+	})
+
+	t.Run("regenerate revokes the old feed", func(t *testing.T) {
+		newURLs := getURLs(handler.RegenerateMyCalendar, "POST", userID, false)
+		if newURLs["token"] == userURLs["token"] {
+			t.Fatal("Expected a new token")
+		}
+		if rec := feed(handler.GetUserFeed, userURLs["token"], ""); rec.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404 for the old token, got %d", rec.Code)
+		}
+		if rec := feed(handler.GetUserFeed, newURLs["token"], ""); rec.Code != http.StatusOK {
+			t.Errorf("Expected status 200 for the new token, got %d", rec.Code)
+		}
+	})
+}
@@ -102,6 +102,12 @@ func (h *DashboardHandler) GetStats(w http.ResponseWriter, r *http.Request) {
 		stats.PendingReactivationReqs = len(pendingReactivationReqs)
 	}
 
+	// Get dogs below their weekly walk target
+	stats.NeedsAttention, err = h.dogRepo.FindUnderWalked(time.Now())
+	if err != nil {
+		stats.NeedsAttention = []*models.UnderWalkedDog{}
+	}
+
 	respondJSON(w, http.StatusOK, stats)
 }
 
@@ -136,6 +142,9 @@ func (h *DashboardHandler) GetRecentActivity(w http.ResponseWriter, r *http.Requ
 			case "cancelled":
 				activityType = "booking_cancelled"
 				message = "Buchung für " + dogName + " storniert"
+			case "no_show":
+				activityType = "booking_no_show"
+				message = "Spaziergang mit " + dogName + " nicht angetreten"
 			}
 
 			activity := &models.ActivityItem{
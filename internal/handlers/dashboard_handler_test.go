@@ -173,3 +173,34 @@ func TestDashboardHandler_XSSPrevention(t *testing.T) {
 		}
 	})
 }
+
+// TestDashboardHandler_GetStatsNeedsAttention tests the list of dogs below their walk target
+func TestDashboardHandler_GetStatsNeedsAttention(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	handler := NewDashboardHandler(db, &config.Config{JWTSecret: "test-secret", JWTExpirationHours: 24})
+
+	adminID := testutil.SeedTestUser(t, db, "admin@example.com", "Admin", "orange")
+	userID := testutil.SeedTestUser(t, db, "user@example.com", "Test User", "green")
+	walkedID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+	shyID := testutil.SeedTestDog(t, db, "Max", "Beagle", "green")
+	db.Exec("UPDATE dogs SET target_walks_per_week = 1 WHERE id IN (?, ?)", walkedID, shyID)
+	testutil.SeedTestBooking(t, db, userID, walkedID, time.Now().Format("2006-01-02"), "09:00", "completed")
+
+	req := httptest.NewRequest("GET", "/api/admin/stats", nil)
+	req = req.WithContext(contextWithUser(req.Context(), adminID, "admin@example.com", true))
+	rec := httptest.NewRecorder()
+	handler.GetStats(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+
+	var stats models.DashboardStats
+	json.Unmarshal(rec.Body.Bytes(), &stats)
+	if len(stats.NeedsAttention) != 1 || stats.NeedsAttention[0].DogID != shyID {
+		t.Fatalf("Expected only Max to need attention, got %+v", stats.NeedsAttention)
+	}
+	if stats.NeedsAttention[0].WalksLast7Days != 0 || stats.NeedsAttention[0].TargetPerWeek != 1 {
+		t.Errorf("Expected 0 of 1 walks, got %+v", stats.NeedsAttention[0])
+	}
+}
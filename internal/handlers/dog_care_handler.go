@@ -0,0 +1,281 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/tranmh/gassigeher/internal/config"
+	"github.com/tranmh/gassigeher/internal/middleware"
+	"github.com/tranmh/gassigeher/internal/models"
+	"github.com/tranmh/gassigeher/internal/repository"
+)
+
+// DogCareHandler handles the care log of dogs (vaccinations, medication, vet appointments, injuries)
+type DogCareHandler struct {
+	db              *sql.DB
+	cfg             *config.Config
+	careRepo        *repository.DogCareRepository
+	dogRepo         *repository.DogRepository
+	blockedDateRepo *repository.BlockedDateRepository
+	bookingRepo     *repository.BookingRepository
+}
+
+// NewDogCareHandler creates a new dog care handler
+func NewDogCareHandler(db *sql.DB, cfg *config.Config) *DogCareHandler {
+	return &DogCareHandler{
+		db:              db,
+		cfg:             cfg,
+		careRepo:        repository.NewDogCareRepository(db),
+		dogRepo:         repository.NewDogRepository(db),
+		blockedDateRepo: repository.NewBlockedDateRepository(db),
+		bookingRepo:     repository.NewBookingRepository(db),
+	}
+}
+
+// ListCareEntries handles GET /api/dogs/:id/care - a dog's care log, newest first
+func (h *DogCareHandler) ListCareEntries(w http.ResponseWriter, r *http.Request) {
+	dog, ok := h.dogFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	entries, err := h.careRepo.FindByDog(dog.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get care entries")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, entries)
+}
+
+// CreateCareEntry handles POST /api/dogs/:id/care (admin only). A vet appointment with
+// block_date also blocks the dog for the appointment day; existing bookings are kept and
+// returned as affected_bookings so the admin can move or cancel them.
+func (h *DogCareHandler) CreateCareEntry(w http.ResponseWriter, r *http.Request) {
+	adminID, _ := r.Context().Value(middleware.UserIDKey).(int)
+
+	dog, ok := h.dogFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.DogCareEntryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entry := &models.DogCareEntry{DogID: dog.ID, CreatedBy: &adminID}
+	applyCareRequest(entry, &req)
+
+	if err := h.syncAppointmentBlock(entry, "", req.BlockDate, adminID); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to block dog")
+		return
+	}
+
+	if err := h.careRepo.Create(entry); err != nil {
+		// Do not leave a block behind for an entry that does not exist
+		if entry.BlockedDateID != nil {
+			h.blockedDateRepo.Delete(*entry.BlockedDateID)
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to create care entry")
+		return
+	}
+
+	h.respondCareEntry(w, http.StatusCreated, entry)
+}
+
+// UpdateCareEntry handles PUT /api/dogs/:id/care/:entryId (admin only). Moving or
+// unblocking a vet appointment removes the block created for it.
+func (h *DogCareHandler) UpdateCareEntry(w http.ResponseWriter, r *http.Request) {
+	adminID, _ := r.Context().Value(middleware.UserIDKey).(int)
+
+	entry, ok := h.entryFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.DogCareEntryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	previousDate := entry.StartDate
+	applyCareRequest(entry, &req)
+
+	if err := h.syncAppointmentBlock(entry, previousDate, req.BlockDate, adminID); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update dog block")
+		return
+	}
+
+	if err := h.careRepo.Update(entry); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update care entry")
+		return
+	}
+
+	h.respondCareEntry(w, http.StatusOK, entry)
+}
+
+// DeleteCareEntry handles DELETE /api/dogs/:id/care/:entryId (admin only). The block
+// created for a vet appointment is removed with it.
+func (h *DogCareHandler) DeleteCareEntry(w http.ResponseWriter, r *http.Request) {
+	entry, ok := h.entryFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.careRepo.Delete(entry.ID); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to delete care entry")
+		return
+	}
+
+	if entry.BlockedDateID != nil {
+		if err := h.blockedDateRepo.Delete(*entry.BlockedDateID); err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to remove dog block")
+			return
+		}
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Care entry deleted successfully"})
+}
+
+// syncAppointmentBlock makes the dog block of a vet appointment match the request:
+// a block on another day (previousDate) or one no longer wanted is deleted, and a missing
+// one is created. An existing block of the dog for that day is left alone and not linked,
+// so deleting the entry never removes a block an admin set by hand.
+func (h *DogCareHandler) syncAppointmentBlock(entry *models.DogCareEntry, previousDate string, blockDate bool, adminID int) error {
+	wanted := blockDate && entry.EntryType == models.DogCareVetAppointment
+
+	if entry.BlockedDateID != nil && (!wanted || previousDate != entry.StartDate) {
+		if err := h.blockedDateRepo.Delete(*entry.BlockedDateID); err != nil {
+			return err
+		}
+		entry.BlockedDateID = nil
+	}
+
+	if !wanted || entry.BlockedDateID != nil {
+		return nil
+	}
+
+	existing, err := h.blockedDateRepo.FindByDateAndDog(entry.StartDate, &entry.DogID)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return nil
+	}
+
+	blockedDate := &models.BlockedDate{
+		Date:      entry.StartDate,
+		DogID:     &entry.DogID,
+		Reason:    fmt.Sprintf("Tierarzttermin: %s", entry.Title),
+		CreatedBy: adminID,
+	}
+	if err := h.blockedDateRepo.Create(blockedDate); err != nil {
+		return err
+	}
+	entry.BlockedDateID = &blockedDate.ID
+
+	return nil
+}
+
+// respondCareEntry writes the entry together with the dog's scheduled bookings on a
+// blocked appointment day (affected_bookings, empty without a block)
+func (h *DogCareHandler) respondCareEntry(w http.ResponseWriter, status int, entry *models.DogCareEntry) {
+	bookings := []*models.Booking{}
+	if entry.BlockedDateID != nil {
+		scheduled := "scheduled"
+		var err error
+		bookings, err = h.bookingRepo.FindAll(&models.BookingFilterRequest{
+			DogID:    &entry.DogID,
+			DateFrom: &entry.StartDate,
+			DateTo:   &entry.StartDate,
+			Status:   &scheduled,
+		})
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to find affected bookings")
+			return
+		}
+	}
+
+	respondJSON(w, status, map[string]interface{}{
+		"entry":             entry,
+		"affected_bookings": bookings,
+	})
+}
+
+// dogFromRequest loads the dog of the {id} route variable, writing the error response on failure
+func (h *DogCareHandler) dogFromRequest(w http.ResponseWriter, r *http.Request) (*models.Dog, bool) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid dog ID")
+		return nil, false
+	}
+
+	dog, err := h.dogRepo.FindByID(id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Database error")
+		return nil, false
+	}
+	if dog == nil {
+		respondError(w, http.StatusNotFound, "Dog not found")
+		return nil, false
+	}
+
+	return dog, true
+}
+
+// entryFromRequest loads the care entry of the {id} and {entryId} route variables,
+// writing the error response on failure
+func (h *DogCareHandler) entryFromRequest(w http.ResponseWriter, r *http.Request) (*models.DogCareEntry, bool) {
+	vars := mux.Vars(r)
+	dogID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid dog ID")
+		return nil, false
+	}
+	entryID, err := strconv.Atoi(vars["entryId"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid care entry ID")
+		return nil, false
+	}
+
+	entry, err := h.careRepo.FindByID(entryID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get care entry")
+		return nil, false
+	}
+	if entry == nil || entry.DogID != dogID {
+		respondError(w, http.StatusNotFound, "Care entry not found")
+		return nil, false
+	}
+
+	return entry, true
+}
+
+// applyCareRequest copies the request fields onto an entry
+func applyCareRequest(entry *models.DogCareEntry, req *models.DogCareEntryRequest) {
+	entry.EntryType = req.EntryType
+	entry.Title = req.Title
+	entry.Notes = req.Notes
+	entry.StartDate = req.StartDate
+	entry.EndDate = req.EndDate
+	entry.AppointmentTime = req.AppointmentTime
+	entry.Dosage = req.Dosage
+	entry.ScheduleTimes = req.ScheduleTimes
+}
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/tranmh/gassigeher/internal/config"
+	"github.com/tranmh/gassigeher/internal/models"
+	"github.com/tranmh/gassigeher/internal/repository"
+	"github.com/tranmh/gassigeher/internal/testutil"
+)
+
+// TestDogCareHandler_CareLog tests creating, listing, updating and deleting care entries,
+// including the dog block of vet appointments
+func TestDogCareHandler_CareLog(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	cfg := &config.Config{JWTSecret: "test-secret"}
+	handler := NewDogCareHandler(db, cfg)
+	blockedDateRepo := repository.NewBlockedDateRepository(db)
+
+	adminID := testutil.SeedTestUser(t, db, "admin@example.com", "Anna Admin", "orange")
+	userID := testutil.SeedTestUser(t, db, "user@example.com", "Wanda Walker", "green")
+	dogID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+	bookingID := testutil.SeedTestBooking(t, db, userID, dogID, "2030-03-04", "09:00", "scheduled")
+
+	send := func(method, body string, vars map[string]string, fn http.HandlerFunc) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/api/dogs/care", bytes.NewBufferString(body))
+		req = mux.SetURLVars(req, vars)
+		req = req.WithContext(contextWithUser(req.Context(), adminID, "admin@example.com", true))
+		rec := httptest.NewRecorder()
+		fn(rec, req)
+		return rec
+	}
+	dogVars := map[string]string{"id": fmt.Sprintf("%d", dogID)}
+	entryVars := func(id int) map[string]string {
+		return map[string]string{"id": fmt.Sprintf("%d", dogID), "entryId": fmt.Sprintf("%d", id)}
+	}
+
+	type careResponse struct {
+		Entry            models.DogCareEntry `json:"entry"`
+		AffectedBookings []models.Booking    `json:"affected_bookings"`
+	}
+
+	var appointment models.DogCareEntry
+
+	t.Run("vet appointment blocks the dog", func(t *testing.T) {
+		rec := send("POST", `{"entry_type":"vet_appointment","title":"Zahnkontrolle","start_date":"2030-03-04","appointment_time":"10:00","block_date":true}`,
+			dogVars, handler.CreateCareEntry)
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("Expected status 201, got %d: %s", rec.Code, rec.Body.String())
+		}
+
+		var response careResponse
+		json.Unmarshal(rec.Body.Bytes(), &response)
+		appointment = response.Entry
+
+		if appointment.BlockedDateID == nil {
+			t.Fatal("Expected the entry to be linked to a blocked date")
+		}
+		blocked, _ := blockedDateRepo.FindByDateAndDog("2030-03-04", &dogID)
+		if blocked == nil || blocked.ID != *appointment.BlockedDateID || blocked.Reason != "Tierarzttermin: Zahnkontrolle" {
+			t.Errorf("Expected a dog block for the appointment, got %+v", blocked)
+		}
+		if len(response.AffectedBookings) != 1 || response.AffectedBookings[0].ID != bookingID {
+			t.Errorf("Expected the existing booking as affected, got %+v", response.AffectedBookings)
+		}
+
+		var status string
+		db.QueryRow(`SELECT status FROM bookings WHERE id = ?`, bookingID).Scan(&status)
+		if status != "scheduled" {
+			t.Errorf("Expected the booking to be kept, got status %s", status)
+		}
+	})
+
+	t.Run("moving the appointment moves the block", func(t *testing.T) {
+		rec := send("PUT", `{"entry_type":"vet_appointment","title":"Zahnkontrolle","start_date":"2030-03-06","block_date":true}`,
+			entryVars(appointment.ID), handler.UpdateCareEntry)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+
+		if old, _ := blockedDateRepo.FindByDateAndDog("2030-03-04", &dogID); old != nil {
+			t.Error("Expected the block on the old date to be removed")
+		}
+		if moved, _ := blockedDateRepo.FindByDateAndDog("2030-03-06", &dogID); moved == nil {
+			t.Error("Expected a block on the new date")
+		}
+	})
+
+	t.Run("existing blocks are not linked", func(t *testing.T) {
+		testutil.SeedTestBlockedDateForDog(t, db, "2030-03-10", "Urlaub", adminID, dogID)
+
+		rec := send("POST", `{"entry_type":"vet_appointment","title":"Impfung","start_date":"2030-03-10","block_date":true}`,
+			dogVars, handler.CreateCareEntry)
+		var response careResponse
+		json.Unmarshal(rec.Body.Bytes(), &response)
+		if rec.Code != http.StatusCreated || response.Entry.BlockedDateID != nil {
+			t.Fatalf("Expected an unlinked entry, got %d: %s", rec.Code, rec.Body.String())
+		}
+
+		send("DELETE", "", entryVars(response.Entry.ID), handler.DeleteCareEntry)
+		if existing, _ := blockedDateRepo.FindByDateAndDog("2030-03-10", &dogID); existing == nil {
+			t.Error("Expected the admin's block to survive deleting the entry")
+		}
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		rec := send("POST", `{"entry_type":"medication","title":"Rimadyl","start_date":"2030-03-01"}`, dogVars, handler.CreateCareEntry)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("list care log", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/dogs/care", nil)
+		req = mux.SetURLVars(req, dogVars)
+		req = req.WithContext(contextWithUser(req.Context(), userID, "user@example.com", false))
+		rec := httptest.NewRecorder()
+		handler.ListCareEntries(rec, req)
+
+		var entries []models.DogCareEntry
+		json.Unmarshal(rec.Body.Bytes(), &entries)
+		if rec.Code != http.StatusOK || len(entries) != 1 || entries[0].ID != appointment.ID {
+			t.Errorf("Expected the appointment, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("deleting the appointment removes its block", func(t *testing.T) {
+		rec := send("DELETE", "", entryVars(appointment.ID), handler.DeleteCareEntry)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if blocked, _ := blockedDateRepo.FindByDateAndDog("2030-03-06", &dogID); blocked != nil {
+			t.Error("Expected the appointment block to be removed")
+		}
+	})
+
+	t.Run("entry of another dog", func(t *testing.T) {
+		otherDogID := testutil.SeedTestDog(t, db, "Max", "Beagle", "green")
+		rec := send("POST", `{"entry_type":"injury","title":"Pfote","start_date":"2030-03-01"}`,
+			map[string]string{"id": fmt.Sprintf("%d", otherDogID)}, handler.CreateCareEntry)
+		var response careResponse
+		json.Unmarshal(rec.Body.Bytes(), &response)
+
+		rec = send("DELETE", "", entryVars(response.Entry.ID), handler.DeleteCareEntry)
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404, got %d", rec.Code)
+		}
+	})
+}
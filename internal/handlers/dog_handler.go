@@ -3,16 +3,18 @@ package handlers
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
-	"os"
-	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/tranmh/gassigeher/internal/config"
+	"github.com/tranmh/gassigeher/internal/database"
+	"github.com/tranmh/gassigeher/internal/middleware"
 	"github.com/tranmh/gassigeher/internal/models"
 	"github.com/tranmh/gassigeher/internal/repository"
 	"github.com/tranmh/gassigeher/internal/services"
@@ -23,6 +25,12 @@ type DogHandler struct {
 	dogRepo      *repository.DogRepository
 	userRepo     *repository.UserRepository
 	bookingRepo  *repository.BookingRepository
+	pairWalkRepo *repository.PairWalkRepository
+	ruleRepo     *repository.DogWalkerRuleRepository
+	waitlistRepo *repository.WaitlistRepository
+	photoRepo    *repository.DogPhotoRepository
+	reportRepo   *repository.WalkReportRepository
+	eventRepo    *repository.BookingEventRepository
 	imageService *services.ImageService
 	emailService *services.EmailService
 	config       *config.Config
@@ -37,9 +45,15 @@ func NewDogHandler(db *sql.DB, cfg *config.Config) *DogHandler {
 	}
 
 	return &DogHandler{
-		dogRepo:      repository.NewDogRepository(db),
+		dogRepo:      repository.NewDogRepositoryWithSearch(db, database.GetDialect(cfg.DBType)),
 		userRepo:     repository.NewUserRepository(db),
 		bookingRepo:  repository.NewBookingRepository(db),
+		pairWalkRepo: repository.NewPairWalkRepository(db),
+		ruleRepo:     repository.NewDogWalkerRuleRepository(db),
+		waitlistRepo: repository.NewWaitlistRepository(db),
+		photoRepo:    repository.NewDogPhotoRepository(db),
+		reportRepo:   repository.NewWalkReportRepository(db),
+		eventRepo:    repository.NewBookingEventRepository(db),
 		imageService: services.NewImageService(cfg.UploadDir),
 		emailService: emailService,
 		config:       cfg,
@@ -89,6 +103,48 @@ func (h *DogHandler) ListDogs(w http.ResponseWriter, r *http.Request) {
 		filter.Search = &search
 	}
 
+	// Sorting and cursor pagination
+	filter.Sort = r.URL.Query().Get("sort")
+	filter.Order = r.URL.Query().Get("order")
+	filter.Cursor = r.URL.Query().Get("cursor")
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 1 {
+			respondError(w, http.StatusBadRequest, "limit: Limit must be between 1 and 100")
+			return
+		}
+		filter.Limit = n
+	}
+	if err := filter.Validate(); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Walkers do not see dogs their allowlist/denylist rules forbid
+	isAdmin, _ := r.Context().Value(middleware.IsAdminKey).(bool)
+	isSuperAdmin, _ := r.Context().Value(middleware.IsSuperAdminKey).(bool)
+
+	// Archive of dogs that left the shelter (admins only)
+	if r.URL.Query().Get("archived") == "true" && (isAdmin || isSuperAdmin) {
+		filter.Archived = true
+	}
+
+	if userID, ok := r.Context().Value(middleware.UserIDKey).(int); ok && !isAdmin && !isSuperAdmin {
+		filter.WalkerID = &userID
+	}
+
+	// With a limit the dogs come in pages: {"dogs": [...], "next_cursor": "..."}
+	if filter.Limit > 0 {
+		page, err := h.dogRepo.FindPage(filter)
+		if err != nil {
+			log.Printf("ERROR: Failed to fetch dogs: %v", err)
+			respondError(w, http.StatusInternalServerError, "Failed to fetch dogs")
+			return
+		}
+		respondJSON(w, http.StatusOK, page)
+		return
+	}
+
 	// Get dogs
 	dogs, err := h.dogRepo.FindAll(filter)
 	if err != nil {
@@ -117,11 +173,21 @@ func (h *DogHandler) GetDog(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if dog == nil {
+	// Dogs that left the shelter are only visible to admins
+	isAdmin, _ := r.Context().Value(middleware.IsAdminKey).(bool)
+	isSuperAdmin, _ := r.Context().Value(middleware.IsSuperAdminKey).(bool)
+	if dog == nil || (dog.IsArchived() && !isAdmin && !isSuperAdmin) {
 		respondError(w, http.StatusNotFound, "Dog not found")
 		return
 	}
 
+	photos, err := h.photoRepo.FindByDog(id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to load photos")
+		return
+	}
+	dog.Photos = photos
+
 	respondJSON(w, http.StatusOK, dog)
 }
 
@@ -133,37 +199,16 @@ func (h *DogHandler) CreateDog(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Validate required fields
-	if strings.TrimSpace(req.Name) == "" {
-		respondError(w, http.StatusBadRequest, "Name is required")
-		return
-	}
-
-	if strings.TrimSpace(req.Breed) == "" {
-		respondError(w, http.StatusBadRequest, "Breed is required")
-		return
-	}
-
-	if req.Size != "small" && req.Size != "medium" && req.Size != "large" {
-		respondError(w, http.StatusBadRequest, "Size must be small, medium, or large")
+	if err := req.Validate(); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	// Validate color_id is provided (new color system)
-	// Category is now legacy - if color_id is provided, category validation is skipped
-	if req.ColorID == nil && req.Category == "" {
-		respondError(w, http.StatusBadRequest, "Color is required")
+	shelterID := models.ShelterIDOrNil(req.ShelterID)
+	if !h.checkShelterIDFree(w, shelterID, 0) {
 		return
 	}
 
-	// If only legacy category is provided, validate it
-	if req.ColorID == nil && req.Category != "" {
-		if req.Category != "green" && req.Category != "blue" && req.Category != "orange" {
-			respondError(w, http.StatusBadRequest, "Category must be green, blue, or orange")
-			return
-		}
-	}
-
 	// Set default category for database CHECK constraint (legacy field)
 	// When using new color system, category is not sent but DB requires valid value
 	category := req.Category
@@ -183,11 +228,16 @@ func (h *DogHandler) CreateDog(w http.ResponseWriter, r *http.Request) {
 		PickupLocation:      req.PickupLocation,
 		WalkRoute:           req.WalkRoute,
 		WalkDuration:        req.WalkDuration,
+		MaxWalksPerDay:      models.WalkLimitOrNil(req.MaxWalksPerDay),
+		MinRestMinutes:      models.WalkLimitOrNil(req.MinRestMinutes),
+		TargetWalksPerWeek:  models.WalkLimitOrNil(req.TargetWalksPerWeek),
 		SpecialInstructions: req.SpecialInstructions,
 		DefaultMorningTime:  req.DefaultMorningTime,
 		DefaultEveningTime:  req.DefaultEveningTime,
 		ExternalLink:        req.ExternalLink,
+		ShelterID:           shelterID,
 		IsAvailable:         true, // Default to available
+		Status:              models.DogStatusInShelter,
 	}
 
 	if err := h.dogRepo.Create(dog); err != nil {
@@ -227,6 +277,19 @@ func (h *DogHandler) UpdateDog(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := models.ValidateDogWalkLimits(req.MaxWalksPerDay, req.MinRestMinutes); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := models.ValidateWalkTarget(req.TargetWalksPerWeek); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := models.ValidateShelterID(req.ShelterID); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	// Update fields if provided
 	if req.Name != nil {
 		// Validate name is not empty
@@ -263,6 +326,15 @@ func (h *DogHandler) UpdateDog(w http.ResponseWriter, r *http.Request) {
 	if req.WalkDuration != nil {
 		dog.WalkDuration = req.WalkDuration
 	}
+	if req.MaxWalksPerDay != nil {
+		dog.MaxWalksPerDay = models.WalkLimitOrNil(req.MaxWalksPerDay)
+	}
+	if req.MinRestMinutes != nil {
+		dog.MinRestMinutes = models.WalkLimitOrNil(req.MinRestMinutes)
+	}
+	if req.TargetWalksPerWeek != nil {
+		dog.TargetWalksPerWeek = models.WalkLimitOrNil(req.TargetWalksPerWeek)
+	}
 	if req.SpecialInstructions != nil {
 		dog.SpecialInstructions = req.SpecialInstructions
 	}
@@ -275,6 +347,12 @@ func (h *DogHandler) UpdateDog(w http.ResponseWriter, r *http.Request) {
 	if req.ExternalLink != nil {
 		dog.ExternalLink = req.ExternalLink
 	}
+	if req.ShelterID != nil {
+		dog.ShelterID = models.ShelterIDOrNil(req.ShelterID)
+		if !h.checkShelterIDFree(w, dog.ShelterID, dog.ID) {
+			return
+		}
+	}
 
 	// Update in database
 	if err := h.dogRepo.Update(dog); err != nil {
@@ -294,6 +372,17 @@ func (h *DogHandler) DeleteDog(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Deleting a dog would lose its walk history; dogs that left the shelter are archived instead
+	hasHistory, err := h.dogRepo.HasWalkHistory(id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to check walk history")
+		return
+	}
+	if hasHistory {
+		respondError(w, http.StatusConflict, "Hund hat bereits Spaziergänge – bitte archivieren statt löschen")
+		return
+	}
+
 	// Check if force delete is requested
 	force := r.URL.Query().Get("force") == "true"
 
@@ -309,44 +398,24 @@ func (h *DogHandler) DeleteDog(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		// Get all future bookings
-		bookings, err := h.dogRepo.GetFutureBookings(id)
+		// Cancel all future bookings
+		adminID, _ := r.Context().Value(middleware.UserIDKey).(int)
+		cancelledCount, err := h.cancelFutureBookings(dog, adminID, fmt.Sprintf("Hund %s wurde aus dem System entfernt", dog.Name))
 		if err != nil {
 			respondError(w, http.StatusInternalServerError, "Failed to fetch bookings")
 			return
 		}
 
-		// Cancel all future bookings
-		cancellationReason := fmt.Sprintf("Hund %s wurde aus dem System entfernt", dog.Name)
-		for _, booking := range bookings {
-			// Cancel the booking
-			err := h.bookingRepo.Cancel(booking.ID, &cancellationReason)
-			if err != nil {
-				log.Printf("ERROR: Failed to cancel booking %d: %v", booking.ID, err)
-				continue
-			}
-
-			// Send cancellation email to user if email service is available and user has email
-			if h.emailService != nil && booking.User != nil && booking.User.Email != nil && *booking.User.Email != "" {
-				go h.emailService.SendBookingCancellation(
-					*booking.User.Email,
-					booking.User.FirstName,
-					dog.Name,
-					booking.Date,
-					booking.ScheduledTime,
-				)
-			}
-		}
-
 		// Now delete the dog
 		if err := h.dogRepo.ForceDelete(id); err != nil {
 			respondError(w, http.StatusInternalServerError, "Failed to delete dog")
 			return
 		}
+		h.deleteDogPhotoFiles(id)
 
 		respondJSON(w, http.StatusOK, map[string]interface{}{
 			"message":          "Hund erfolgreich gelöscht",
-			"cancelled_count":  len(bookings),
+			"cancelled_count":  cancelledCount,
 		})
 		return
 	}
@@ -372,13 +441,62 @@ func (h *DogHandler) DeleteDog(w http.ResponseWriter, r *http.Request) {
 		}
 		return
 	}
+	h.deleteDogPhotoFiles(id)
 
 	respondJSON(w, http.StatusOK, map[string]string{
 		"message": "Hund erfolgreich gelöscht",
 	})
 }
 
-// UploadDogPhoto handles POST /api/dogs/:id/photo - upload dog photo (admin only)
+// cancelFutureBookings cancels the dog's future bookings on behalf of adminID with reason and
+// notifies the walkers. It returns how many bookings were cancelled.
+func (h *DogHandler) cancelFutureBookings(dog *models.Dog, adminID int, reason string) (int, error) {
+	bookings, err := h.dogRepo.GetFutureBookings(dog.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	return h.cancelBookings(dog, bookings, adminID, reason), nil
+}
+
+// cancelBookings cancels bookings of dog on behalf of adminID with reason, records the
+// cancellation in each booking's history, notifies the walkers and returns how many bookings
+// were cancelled
+func (h *DogHandler) cancelBookings(dog *models.Dog, bookings []*models.Booking, adminID int, reason string) int {
+	cancelled := 0
+	for _, booking := range bookings {
+		if err := h.bookingRepo.Cancel(booking.ID, &reason); err != nil {
+			log.Printf("ERROR: Failed to cancel booking %d: %v", booking.ID, err)
+			continue
+		}
+		cancelled++
+		recordBookingEvent(h.eventRepo, booking.ID, models.BookingEventCancelled, adminID, "", reason)
+
+		// Send cancellation email to user if email service is available and user has email
+		if h.emailService != nil && booking.User != nil && booking.User.Email != nil && *booking.User.Email != "" {
+			go h.emailService.SendBookingCancellation(
+				*booking.User.Email,
+				booking.User.FirstName,
+				dog.Name,
+				booking.Date,
+				booking.ScheduledTime,
+				services.BookingInvite(booking, dog),
+			)
+		}
+	}
+
+	return cancelled
+}
+
+// deleteDogPhotoFiles removes the photo files of a deleted dog (the rows cascade)
+func (h *DogHandler) deleteDogPhotoFiles(dogID int) {
+	if err := h.imageService.DeleteDogPhotos(dogID); err != nil {
+		log.Printf("ERROR: Failed to delete photos of dog %d: %v", dogID, err)
+	}
+}
+
+// UploadDogPhoto handles POST /api/dogs/:id/photo - upload a photo and make it the cover (admin only)
+// The photo is added to the dog's gallery; earlier photos stay in the gallery.
 func (h *DogHandler) UploadDogPhoto(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := strconv.Atoi(vars["id"])
@@ -412,46 +530,33 @@ func (h *DogHandler) UploadDogPhoto(w http.ResponseWriter, r *http.Request) {
 	}
 	defer file.Close()
 
-	// Validate file extension (quick validation)
-	ext := strings.ToLower(filepath.Ext(header.Filename))
-	if ext != ".jpg" && ext != ".jpeg" && ext != ".png" {
-		respondError(w, http.StatusBadRequest, "Only JPEG and PNG files are allowed")
-		return
-	}
-
-	// Validate MIME type (magic bytes) to prevent file type spoofing
-	if errMsg, valid := ValidateImageMIMEType(file); !valid {
+	// Validate file extension and MIME type (magic bytes) to prevent file type spoofing
+	if errMsg, valid := ValidateImageFile(header.Filename, file); !valid {
 		respondError(w, http.StatusBadRequest, errMsg)
 		return
 	}
-	// Reset file reader position after MIME check
-	file.Seek(0, 0)
-
-	// Delete old photos if they exist (before processing new ones)
-	if dog.Photo != nil && *dog.Photo != "" {
-		// Use ImageService to delete both full and thumbnail
-		// This handles the new naming scheme (dog_{id}_full.jpg, dog_{id}_thumb.jpg)
-		h.imageService.DeleteDogPhotos(id)
 
-		// Also try to delete old photo with original naming scheme (backward compatibility)
-		oldPath := filepath.Join(h.config.UploadDir, *dog.Photo)
-		os.Remove(oldPath) // Ignore errors if file doesn't exist
+	if !h.checkGallerySpace(w, id, 1) {
+		return
 	}
 
 	// Process the uploaded photo (resize, compress, create thumbnail)
-	fullPath, thumbPath, err := h.imageService.ProcessDogPhoto(file, id)
+	fullPath, thumbPath, err := h.imageService.ProcessDogGalleryPhoto(file, id)
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to process image: %v", err))
 		return
 	}
 
-	// Update dog with new photo paths
-	dog.Photo = &fullPath
-	dog.PhotoThumbnail = &thumbPath
-
-	if err := h.dogRepo.Update(dog); err != nil {
+	photo, err := h.photoRepo.Add(id, fullPath, thumbPath)
+	if err == nil && !photo.IsCover {
+		err = h.photoRepo.SetCover(id, photo.ID)
+	}
+	if err != nil {
 		// If database update fails, clean up the newly created files
-		h.imageService.DeleteDogPhotos(id)
+		if photo != nil {
+			h.photoRepo.Delete(id, photo.ID)
+		}
+		h.imageService.DeleteDogPhoto(fullPath, thumbPath)
 		respondError(w, http.StatusInternalServerError, "Failed to update dog")
 		return
 	}
@@ -463,103 +568,468 @@ func (h *DogHandler) UploadDogPhoto(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-// ToggleAvailability handles PUT /api/dogs/:id/availability - toggle availability (admin only)
-func (h *DogHandler) ToggleAvailability(w http.ResponseWriter, r *http.Request) {
-	vars := mux.Vars(r)
-	id, err := strconv.Atoi(vars["id"])
-	if err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid dog ID")
+// AddDogPhotos handles POST /api/dogs/:id/photos - add one or more photos ("photos" form
+// field) to the end of a dog's gallery (admin only)
+func (h *DogHandler) AddDogPhotos(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.dogIDFromRequest(w, r)
+	if !ok {
 		return
 	}
 
-	var req models.ToggleAvailabilityRequest
+	if err := r.ParseMultipartForm(int64(h.config.MaxUploadSizeMB) << 20); err != nil {
+		respondError(w, http.StatusBadRequest, "File too large or invalid form")
+		return
+	}
+
+	headers := r.MultipartForm.File["photos"]
+	if len(headers) == 0 {
+		respondError(w, http.StatusBadRequest, "No file uploaded")
+		return
+	}
+
+	if !h.checkGallerySpace(w, id, len(headers)) {
+		return
+	}
+
+	// Validate every file before processing any, so a bad file does not leave half an upload
+	for _, header := range headers {
+		file, err := header.Open()
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Failed to read file")
+			return
+		}
+		errMsg, valid := ValidateImageFile(header.Filename, file)
+		file.Close()
+		if !valid {
+			respondError(w, http.StatusBadRequest, fmt.Sprintf("%s: %s", header.Filename, errMsg))
+			return
+		}
+	}
+
+	for _, header := range headers {
+		file, err := header.Open()
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Failed to read file")
+			return
+		}
+
+		fullPath, thumbPath, err := h.imageService.ProcessDogGalleryPhoto(file, id)
+		file.Close()
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to process image %s: %v", header.Filename, err))
+			return
+		}
+
+		if _, err := h.photoRepo.Add(id, fullPath, thumbPath); err != nil {
+			h.imageService.DeleteDogPhoto(fullPath, thumbPath)
+			respondError(w, http.StatusInternalServerError, "Failed to save photo")
+			return
+		}
+	}
+
+	h.respondGallery(w, http.StatusCreated, id)
+}
+
+// ReorderDogPhotos handles PUT /api/dogs/:id/photos/order - reorder a dog's gallery (admin only)
+func (h *DogHandler) ReorderDogPhotos(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.dogIDFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.ReorderDogPhotosRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		respondError(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
-	// If marking as unavailable, reason is optional but recommended
-	if !req.IsAvailable && (req.UnavailableReason == nil || *req.UnavailableReason == "") {
-		defaultReason := "Temporarily unavailable"
-		req.UnavailableReason = &defaultReason
+	if err := req.Validate(); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
 	}
 
-	// Toggle availability
-	if err := h.dogRepo.ToggleAvailability(id, req.IsAvailable, req.UnavailableReason); err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to toggle availability")
+	if err := h.photoRepo.Reorder(id, req.PhotoIDs); err != nil {
+		if errors.Is(err, repository.ErrIncompletePhotoOrder) {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to reorder photos")
 		return
 	}
 
-	// Get updated dog
-	dog, err := h.dogRepo.FindByID(id)
+	h.respondGallery(w, http.StatusOK, id)
+}
+
+// SetDogCoverPhoto handles PUT /api/dogs/:id/photos/:photoId/cover - make a gallery photo
+// the dog's cover photo (admin only)
+func (h *DogHandler) SetDogCoverPhoto(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.dogIDFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	photoID, err := strconv.Atoi(mux.Vars(r)["photoId"])
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to fetch updated dog")
+		respondError(w, http.StatusBadRequest, "Invalid photo ID")
 		return
 	}
 
-	respondJSON(w, http.StatusOK, dog)
+	if err := h.photoRepo.SetCover(id, photoID); err != nil {
+		if err.Error() == "photo not found" {
+			respondError(w, http.StatusNotFound, "Photo not found")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to set cover photo")
+		return
+	}
+
+	h.respondGallery(w, http.StatusOK, id)
 }
 
-// GetBreeds handles GET /api/dogs/breeds - get list of all breeds
-func (h *DogHandler) GetBreeds(w http.ResponseWriter, r *http.Request) {
-	breeds, err := h.dogRepo.GetBreeds()
+// DeleteDogPhoto handles DELETE /api/dogs/:id/photos/:photoId - remove a gallery photo (admin only)
+// If it was the cover, the next photo in the gallery becomes the cover.
+func (h *DogHandler) DeleteDogPhoto(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.dogIDFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	photoID, err := strconv.Atoi(mux.Vars(r)["photoId"])
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to fetch breeds")
+		respondError(w, http.StatusBadRequest, "Invalid photo ID")
 		return
 	}
 
-	respondJSON(w, http.StatusOK, breeds)
+	photo, err := h.photoRepo.FindByID(photoID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get photo")
+		return
+	}
+	if photo == nil || photo.DogID != id {
+		respondError(w, http.StatusNotFound, "Photo not found")
+		return
+	}
+
+	if err := h.photoRepo.Delete(id, photoID); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to delete photo")
+		return
+	}
+
+	if err := h.imageService.DeleteDogPhoto(photo.PhotoPath, photo.PhotoThumbnail); err != nil {
+		log.Printf("ERROR: Failed to delete photo files of dog %d: %v", id, err)
+	}
+
+	h.respondGallery(w, http.StatusOK, id)
 }
 
-// GetFeaturedDogs handles GET /api/dogs/featured - get featured dogs for homepage (public)
-func (h *DogHandler) GetFeaturedDogs(w http.ResponseWriter, r *http.Request) {
-	dogs, err := h.dogRepo.GetFeatured()
+// PromoteWalkReportPhoto handles POST /api/dogs/:id/photos/from-walk-report - copy a photo
+// from a walk report of this dog into its gallery (admin only)
+func (h *DogHandler) PromoteWalkReportPhoto(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.dogIDFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.PromoteWalkReportPhotoRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	reportPhoto, err := h.reportRepo.GetPhotoByID(req.WalkReportPhotoID)
 	if err != nil {
-		log.Printf("Error fetching featured dogs: %v", err)
-		respondError(w, http.StatusInternalServerError, "Failed to fetch featured dogs")
+		respondError(w, http.StatusInternalServerError, "Failed to get photo")
+		return
+	}
+	if reportPhoto == nil {
+		respondError(w, http.StatusNotFound, "Photo not found")
 		return
 	}
 
-	respondJSON(w, http.StatusOK, dogs)
+	// The photo must come from a walk with this dog
+	report, err := h.reportRepo.FindByID(reportPhoto.WalkReportID)
+	if err != nil || report == nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get report")
+		return
+	}
+	booking, err := h.bookingRepo.FindByID(report.BookingID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get booking")
+		return
+	}
+	if booking == nil || booking.DogID != id {
+		respondError(w, http.StatusBadRequest, "Photo does not belong to a walk with this dog")
+		return
+	}
+
+	if !h.checkGallerySpace(w, id, 1) {
+		return
+	}
+
+	fullPath, thumbPath, err := h.imageService.CopyToDogGallery(reportPhoto.PhotoPath, reportPhoto.PhotoThumbnail, id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to copy photo")
+		return
+	}
+
+	if _, err := h.photoRepo.Add(id, fullPath, thumbPath); err != nil {
+		h.imageService.DeleteDogPhoto(fullPath, thumbPath)
+		respondError(w, http.StatusInternalServerError, "Failed to save photo")
+		return
+	}
+
+	h.respondGallery(w, http.StatusCreated, id)
 }
 
-// SetFeatured handles PUT /api/dogs/:id/featured - set featured status (admin only)
-func (h *DogHandler) SetFeatured(w http.ResponseWriter, r *http.Request) {
-	vars := mux.Vars(r)
-	id, err := strconv.Atoi(vars["id"])
+// dogIDFromRequest parses the dog ID from the URL and checks that the dog exists.
+// It writes the error response itself and returns false on failure.
+func (h *DogHandler) dogIDFromRequest(w http.ResponseWriter, r *http.Request) (int, bool) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
 	if err != nil {
 		respondError(w, http.StatusBadRequest, "Invalid dog ID")
+		return 0, false
+	}
+
+	dog, err := h.dogRepo.FindByID(id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Database error")
+		return 0, false
+	}
+	if dog == nil {
+		respondError(w, http.StatusNotFound, "Dog not found")
+		return 0, false
+	}
+
+	return id, true
+}
+
+// checkGallerySpace checks that adding photos would not exceed models.MaxDogPhotos.
+// It writes the error response itself and returns false on failure.
+func (h *DogHandler) checkGallerySpace(w http.ResponseWriter, dogID, adding int) bool {
+	count, err := h.photoRepo.Count(dogID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to count photos")
+		return false
+	}
+	if count+adding > models.MaxDogPhotos {
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("Maximal %d Fotos pro Hund erlaubt", models.MaxDogPhotos))
+		return false
+	}
+	return true
+}
+
+// checkShelterIDFree responds with 409 and returns false if another dog than dogID already
+// has the shelter ID
+func (h *DogHandler) checkShelterIDFree(w http.ResponseWriter, shelterID *string, dogID int) bool {
+	if shelterID == nil {
+		return true
+	}
+	existing, err := h.dogRepo.FindByShelterID(*shelterID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Database error")
+		return false
+	}
+	if existing != nil && existing.ID != dogID {
+		respondError(w, http.StatusConflict, fmt.Sprintf("Shelter ID %s is already used by %s", *shelterID, existing.Name))
+		return false
+	}
+	return true
+}
+
+// respondGallery responds with the dog's current gallery
+func (h *DogHandler) respondGallery(w http.ResponseWriter, status int, dogID int) {
+	photos, err := h.photoRepo.FindByDog(dogID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to load photos")
 		return
 	}
+	respondJSON(w, status, photos)
+}
 
-	// Parse request body
-	var req struct {
-		IsFeatured bool `json:"is_featured"`
+// ToggleAvailability handles PUT /api/dogs/:id/availability - toggle availability (admin only).
+// Marking a dog unavailable may schedule a window; the response lists the scheduled bookings
+// inside it and cancels them (with notification) if cancel_bookings is set.
+func (h *DogHandler) ToggleAvailability(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid dog ID")
+		return
 	}
 
+	var req models.ToggleAvailabilityRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		respondError(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
-	// Check if dog exists
 	dog, err := h.dogRepo.FindByID(id)
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Database error")
 		return
 	}
-
 	if dog == nil {
 		respondError(w, http.StatusNotFound, "Dog not found")
 		return
 	}
+	if dog.IsArchived() {
+		respondError(w, http.StatusConflict, "Archived dogs must be restored before changing their availability")
+		return
+	}
 
-	// Note: No limit on featured dogs - frontend randomly displays 3 from all featured
-	// This gives all featured dogs a chance to be shown to visitors
+	if req.IsAvailable {
+		if err := h.dogRepo.ToggleAvailability(id, true, nil); err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to toggle availability")
+			return
+		}
 
-	// Update featured status
-	if err := h.dogRepo.SetFeatured(id, req.IsFeatured); err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to update featured status")
+		dog, err = h.dogRepo.FindByID(id)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to fetch updated dog")
+			return
+		}
+
+		respondJSON(w, http.StatusOK, &models.DogAvailabilityResponse{Dog: dog, AffectedBookings: []*models.Booking{}})
+		return
+	}
+
+	today := time.Now().Format("2006-01-02")
+	if err := req.Validate(today); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// If marking as unavailable, reason is optional but recommended
+	if req.UnavailableReason == nil || *req.UnavailableReason == "" {
+		defaultReason := "Temporarily unavailable"
+		req.UnavailableReason = &defaultReason
+	}
+
+	// Without a start date the dog is unavailable from today on
+	from := today
+	if req.UnavailableFrom != nil {
+		from = *req.UnavailableFrom
+	}
+
+	if err := h.dogRepo.SetUnavailability(id, from, req.UnavailableUntil, req.UnavailableReason, today); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to toggle availability")
+		return
+	}
+
+	// Get updated dog
+	dog, err = h.dogRepo.FindByID(id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch updated dog")
+		return
+	}
+
+	// List the scheduled bookings inside the window so the admin can move or cancel them
+	futureBookings, err := h.dogRepo.GetFutureBookings(id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to load future bookings")
+		return
+	}
+	response := &models.DogAvailabilityResponse{Dog: dog, AffectedBookings: []*models.Booking{}}
+	for _, booking := range futureBookings {
+		if dog.IsUnavailableOn(booking.Date) {
+			response.AffectedBookings = append(response.AffectedBookings, booking)
+		}
+	}
+
+	if req.CancelBookings && len(response.AffectedBookings) > 0 {
+		reason := fmt.Sprintf("Hund %s ist %s nicht verfügbar (%s)", dog.Name,
+			unavailabilityPeriod(from, req.UnavailableUntil), *req.UnavailableReason)
+		adminID, _ := r.Context().Value(middleware.UserIDKey).(int)
+		response.CancelledCount = h.cancelBookings(dog, response.AffectedBookings, adminID, reason)
+		for _, booking := range response.AffectedBookings {
+			booking.Status = "cancelled"
+		}
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+// unavailabilityPeriod describes an unavailability window in German, e.g. "vom 10.01.2030 bis 14.01.2030"
+func unavailabilityPeriod(from string, until *string) string {
+	if until == nil {
+		return "ab dem " + formatGermanDate(from)
+	}
+	return fmt.Sprintf("vom %s bis %s", formatGermanDate(from), formatGermanDate(*until))
+}
+
+// formatGermanDate formats YYYY-MM-DD as DD.MM.YYYY
+func formatGermanDate(date string) string {
+	if t, err := time.Parse("2006-01-02", date); err == nil {
+		return t.Format("02.01.2006")
+	}
+	return date
+}
+
+// GetBreeds handles GET /api/dogs/breeds - get list of all breeds
+func (h *DogHandler) GetBreeds(w http.ResponseWriter, r *http.Request) {
+	breeds, err := h.dogRepo.GetBreeds()
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch breeds")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, breeds)
+}
+
+// GetFeaturedDogs handles GET /api/dogs/featured - get featured dogs for homepage (public)
+func (h *DogHandler) GetFeaturedDogs(w http.ResponseWriter, r *http.Request) {
+	dogs, err := h.dogRepo.GetFeatured()
+	if err != nil {
+		log.Printf("Error fetching featured dogs: %v", err)
+		respondError(w, http.StatusInternalServerError, "Failed to fetch featured dogs")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dogs)
+}
+
+// SetFeatured handles PUT /api/dogs/:id/featured - set featured status (admin only)
+func (h *DogHandler) SetFeatured(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid dog ID")
+		return
+	}
+
+	// Parse request body
+	var req struct {
+		IsFeatured bool `json:"is_featured"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Check if dog exists
+	dog, err := h.dogRepo.FindByID(id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Database error")
+		return
+	}
+
+	if dog == nil {
+		respondError(w, http.StatusNotFound, "Dog not found")
+		return
+	}
+
+	// Note: No limit on featured dogs - frontend randomly displays 3 from all featured
+	// This gives all featured dogs a chance to be shown to visitors
+
+	// Update featured status
+	if err := h.dogRepo.SetFeatured(id, req.IsFeatured); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update featured status")
 		return
 	}
 
@@ -572,3 +1042,374 @@ func (h *DogHandler) SetFeatured(w http.ResponseWriter, r *http.Request) {
 
 	respondJSON(w, http.StatusOK, dog)
 }
+
+// SetPublic handles PUT /api/dogs/:id/public - show or hide the dog's public profile page (admin only)
+func (h *DogHandler) SetPublic(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid dog ID")
+		return
+	}
+
+	var req struct {
+		IsPublic bool `json:"is_public"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	dog, err := h.dogRepo.FindByID(id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Database error")
+		return
+	}
+
+	if dog == nil {
+		respondError(w, http.StatusNotFound, "Dog not found")
+		return
+	}
+
+	if err := h.dogRepo.SetPublic(id, req.IsPublic); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update public status")
+		return
+	}
+
+	dog, err = h.dogRepo.FindByID(id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch updated dog")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dog)
+}
+
+// GetPairWalks handles GET /api/dogs/:id/pair-walks - list dogs that may be walked together with this dog (admin only)
+func (h *DogHandler) GetPairWalks(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid dog ID")
+		return
+	}
+
+	pairWalks, err := h.pairWalkRepo.FindByDog(id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch pair walks")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, pairWalks)
+}
+
+// AddPairWalk handles POST /api/dogs/:id/pair-walks - allow one walker to take this dog and a partner dog together (admin only)
+func (h *DogHandler) AddPairWalk(w http.ResponseWriter, r *http.Request) {
+	adminID, _ := r.Context().Value(middleware.UserIDKey).(int)
+
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid dog ID")
+		return
+	}
+
+	var req models.CreatePairWalkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if req.PartnerDogID == id {
+		respondError(w, http.StatusBadRequest, "A dog cannot be paired with itself")
+		return
+	}
+
+	// Check that both dogs exist
+	for _, dogID := range []int{id, req.PartnerDogID} {
+		dog, err := h.dogRepo.FindByID(dogID)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Database error")
+			return
+		}
+		if dog == nil {
+			respondError(w, http.StatusNotFound, "Dog not found")
+			return
+		}
+	}
+
+	pairWalk := &models.PairWalk{
+		DogID:        id,
+		PartnerDogID: req.PartnerDogID,
+		CreatedBy:    adminID,
+	}
+
+	if err := h.pairWalkRepo.Create(pairWalk); err != nil {
+		if err.Error() == "pair walk already exists" {
+			respondError(w, http.StatusConflict, "These dogs are already allowed to be walked together")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to create pair walk")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, pairWalk)
+}
+
+// RemovePairWalk handles DELETE /api/dogs/:id/pair-walks/:partnerId - stop allowing a pair walk (admin only)
+func (h *DogHandler) RemovePairWalk(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid dog ID")
+		return
+	}
+	partnerID, err := strconv.Atoi(vars["partnerId"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid partner dog ID")
+		return
+	}
+
+	if err := h.pairWalkRepo.Delete(id, partnerID); err != nil {
+		if err.Error() == "pair walk not found" {
+			respondError(w, http.StatusNotFound, "Pair walk not found")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to delete pair walk")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Pair walk removed successfully"})
+}
+
+// GetWalkerRules handles GET /api/dogs/:id/walker-rules - list the dog's walker allowlist and denylist (admin only)
+func (h *DogHandler) GetWalkerRules(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid dog ID")
+		return
+	}
+
+	rules, err := h.ruleRepo.FindByDog(id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch walker rules")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, rules)
+}
+
+// AddWalkerRule handles POST /api/dogs/:id/walker-rules - allow or forbid a walker to walk this dog (admin only)
+func (h *DogHandler) AddWalkerRule(w http.ResponseWriter, r *http.Request) {
+	adminID, _ := r.Context().Value(middleware.UserIDKey).(int)
+
+	id, ok := h.dogIDFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.CreateDogWalkerRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	user, err := h.userRepo.FindByID(req.UserID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Database error")
+		return
+	}
+	if user == nil {
+		respondError(w, http.StatusNotFound, "User not found")
+		return
+	}
+
+	rule := &models.DogWalkerRule{
+		DogID:     id,
+		UserID:    req.UserID,
+		RuleType:  req.RuleType,
+		Reason:    req.Reason,
+		ExpiresAt: req.ExpiresAt,
+		CreatedBy: &adminID,
+	}
+
+	if err := h.ruleRepo.Create(rule); err != nil {
+		if err.Error() == "walker rule already exists" {
+			respondError(w, http.StatusConflict, "This walker is already on a list of this dog")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to create walker rule")
+		return
+	}
+
+	name := user.FullName()
+	rule.UserName = &name
+
+	respondJSON(w, http.StatusCreated, rule)
+}
+
+// RemoveWalkerRule handles DELETE /api/dogs/:id/walker-rules/:ruleId - remove a walker from the dog's lists (admin only)
+func (h *DogHandler) RemoveWalkerRule(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid dog ID")
+		return
+	}
+
+	ruleID, err := strconv.Atoi(vars["ruleId"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid walker rule ID")
+		return
+	}
+
+	if err := h.ruleRepo.Delete(id, ruleID); err != nil {
+		if err.Error() == "walker rule not found" {
+			respondError(w, http.StatusNotFound, "Walker rule not found")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to delete walker rule")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Walker rule removed successfully"})
+}
+
+// dogLeftShelterPhrases describe in German why an archived dog left the shelter
+var dogLeftShelterPhrases = map[string]string{
+	models.DogStatusAdopted:     "wurde adoptiert",
+	models.DogStatusDeceased:    "ist verstorben",
+	models.DogStatusTransferred: "wurde abgegeben",
+}
+
+// ChangeDogStatus handles PUT /api/dogs/:id/status - move a dog through its lifecycle (admin only)
+// Moving a dog to adopted, deceased or transferred archives it: it can no longer be booked,
+// its future bookings are cancelled and its waitlist entries are closed.
+func (h *DogHandler) ChangeDogStatus(w http.ResponseWriter, r *http.Request) {
+	var req models.ChangeDogStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	h.changeDogStatus(w, r, &req, false)
+}
+
+// RestoreDog handles POST /api/dogs/:id/restore - bring an archived dog back to the shelter (admin only)
+func (h *DogHandler) RestoreDog(w http.ResponseWriter, r *http.Request) {
+	var req models.ChangeDogStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	req.Status = models.DogStatusInShelter
+
+	h.changeDogStatus(w, r, &req, true)
+}
+
+// changeDogStatus applies a status change to the dog in the URL and writes the response.
+// With restore set only archived dogs may be changed.
+func (h *DogHandler) changeDogStatus(w http.ResponseWriter, r *http.Request, req *models.ChangeDogStatusRequest, restore bool) {
+	adminID, _ := r.Context().Value(middleware.UserIDKey).(int)
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid dog ID")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	dog, err := h.dogRepo.FindByID(id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Database error")
+		return
+	}
+	if dog == nil {
+		respondError(w, http.StatusNotFound, "Dog not found")
+		return
+	}
+	if restore && !dog.IsArchived() {
+		respondError(w, http.StatusConflict, "Only archived dogs can be restored")
+		return
+	}
+	if dog.Status == req.Status {
+		respondError(w, http.StatusConflict, "Dog already has this status")
+		return
+	}
+
+	event := &models.DogStatusEvent{
+		DogID:          id,
+		PreviousStatus: dog.Status,
+		Status:         req.Status,
+		EffectiveDate:  req.Date,
+		Note:           req.Note,
+		CreatedBy:      &adminID,
+	}
+
+	archiving := models.IsArchivedDogStatus(req.Status) && !dog.IsArchived()
+	var reason *string
+	if models.IsArchivedDogStatus(req.Status) {
+		label := fmt.Sprintf("Hund %s", dogLeftShelterPhrases[req.Status])
+		reason = &label
+	}
+
+	if err := h.dogRepo.ChangeStatus(event, reason); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to change dog status")
+		return
+	}
+
+	cancelledCount := 0
+	if archiving {
+		cancelledCount, err = h.cancelFutureBookings(dog, adminID, fmt.Sprintf("Hund %s %s", dog.Name, dogLeftShelterPhrases[req.Status]))
+		if err != nil {
+			log.Printf("ERROR: Failed to cancel future bookings of archived dog %d: %v", id, err)
+		}
+		if _, err := h.waitlistRepo.CancelForDog(id); err != nil {
+			log.Printf("ERROR: Failed to cancel waitlist entries of archived dog %d: %v", id, err)
+		}
+	}
+
+	dog, err = h.dogRepo.FindByID(id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch updated dog")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"dog":             dog,
+		"event":           event,
+		"cancelled_count": cancelledCount,
+	})
+}
+
+// GetDogStatusHistory handles GET /api/dogs/:id/status-history - list a dog's status changes (admin only)
+func (h *DogHandler) GetDogStatusHistory(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.dogIDFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	events, err := h.dogRepo.GetStatusHistory(id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch status history")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, events)
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/tranmh/gassigeher/internal/models"
 	"github.com/tranmh/gassigeher/internal/repository"
 	"github.com/tranmh/gassigeher/internal/services"
+	"github.com/tranmh/gassigeher/internal/testutil"
 
 	_ "modernc.org/sqlite"
 )
@@ -48,14 +49,23 @@ func setupTestDB(t *testing.T) *sql.DB {
 			pickup_location TEXT,
 			walk_route TEXT,
 			walk_duration INTEGER,
+			max_walks_per_day INTEGER,
+			min_rest_minutes INTEGER,
+			target_walks_per_week INTEGER,
 			special_instructions TEXT,
 			default_morning_time TEXT,
 			default_evening_time TEXT,
 			is_available INTEGER DEFAULT 1,
 			unavailable_reason TEXT,
 			unavailable_since TIMESTAMP,
+			unavailable_from DATE,
+			unavailable_until DATE,
 			is_featured INTEGER DEFAULT 0,
+			is_public INTEGER DEFAULT 0,
 			external_link TEXT,
+			shelter_id TEXT UNIQUE,
+			status TEXT NOT NULL DEFAULT 'in_shelter',
+			status_date DATE,
 			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 		)
@@ -64,6 +74,21 @@ func setupTestDB(t *testing.T) *sql.DB {
 		t.Fatalf("Failed to create dogs table: %v", err)
 	}
 
+	// Create dog photo gallery table
+	_, err = db.Exec(`
+		CREATE TABLE dog_photos (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			dog_id INTEGER NOT NULL,
+			photo_path TEXT NOT NULL,
+			photo_thumbnail TEXT NOT NULL,
+			display_order INTEGER DEFAULT 0,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)
+	`)
+	if err != nil {
+		t.Fatalf("Failed to create dog_photos table: %v", err)
+	}
+
 	return db
 }
 
@@ -474,3 +499,179 @@ func TestImageService_DeleteDogPhotos_Integration(t *testing.T) {
 		t.Errorf("Second delete should not error: %v", err)
 	}
 }
+
+// TestDogHandler_PhotoGallery_Integration tests gallery upload, ordering, cover, deletion
+// and promoting walk report photos
+func TestDogHandler_PhotoGallery_Integration(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	tempDir := t.TempDir()
+	handler := NewDogHandler(db, &config.Config{UploadDir: tempDir, MaxUploadSizeMB: 10})
+	service := services.NewImageService(tempDir)
+
+	dogID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+	dogVars := map[string]string{"id": fmt.Sprintf("%d", dogID)}
+
+	call := func(fn http.HandlerFunc, method string, vars map[string]string, body *bytes.Buffer, contentType string) *httptest.ResponseRecorder {
+		if body == nil {
+			body = new(bytes.Buffer)
+		}
+		req := httptest.NewRequest(method, "/api/dogs/"+vars["id"]+"/photos", body)
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		req = mux.SetURLVars(req, vars)
+		rec := httptest.NewRecorder()
+		fn(rec, req)
+		return rec
+	}
+	gallery := func(rec *httptest.ResponseRecorder) []models.DogPhoto {
+		var photos []models.DogPhoto
+		if err := json.Unmarshal(rec.Body.Bytes(), &photos); err != nil {
+			t.Fatalf("Failed to parse gallery: %v (%s)", err, rec.Body.String())
+		}
+		return photos
+	}
+
+	// Upload two photos in one request
+	imageData, _ := createTestImageBytes(400, 300, "jpeg")
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	for _, name := range []string{"one.jpg", "two.jpg"} {
+		part, _ := writer.CreateFormFile("photos", name)
+		part.Write(imageData)
+	}
+	writer.Close()
+
+	rec := call(handler.AddDogPhotos, "POST", dogVars, body, writer.FormDataContentType())
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	photos := gallery(rec)
+	if len(photos) != 2 || !photos[0].IsCover || photos[1].IsCover {
+		t.Fatalf("Expected two photos with the first as cover, got %+v", photos)
+	}
+	for _, photo := range photos {
+		if _, err := os.Stat(filepath.Join(tempDir, photo.PhotoThumbnail)); err != nil {
+			t.Errorf("Thumbnail not created: %v", err)
+		}
+	}
+
+	t.Run("rejects invalid file without saving any", func(t *testing.T) {
+		body := new(bytes.Buffer)
+		writer := multipart.NewWriter(body)
+		part, _ := writer.CreateFormFile("photos", "ok.jpg")
+		part.Write(imageData)
+		part, _ = writer.CreateFormFile("photos", "bad.gif")
+		part.Write([]byte("GIF89a"))
+		writer.Close()
+
+		rec := call(handler.AddDogPhotos, "POST", dogVars, body, writer.FormDataContentType())
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", rec.Code)
+		}
+		if count, _ := repository.NewDogPhotoRepository(db).Count(dogID); count != 2 {
+			t.Errorf("Expected gallery to stay at 2 photos, got %d", count)
+		}
+	})
+
+	t.Run("reorder and set cover", func(t *testing.T) {
+		order, _ := json.Marshal(models.ReorderDogPhotosRequest{PhotoIDs: []int{photos[1].ID, photos[0].ID}})
+		rec := call(handler.ReorderDogPhotos, "PUT", dogVars, bytes.NewBuffer(order), "")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if reordered := gallery(rec); reordered[0].ID != photos[1].ID {
+			t.Errorf("Expected second photo first, got %+v", reordered)
+		}
+
+		vars := map[string]string{"id": dogVars["id"], "photoId": fmt.Sprintf("%d", photos[1].ID)}
+		rec = call(handler.SetDogCoverPhoto, "PUT", vars, nil, "")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", rec.Code)
+		}
+		dog, _ := repository.NewDogRepository(db).FindByID(dogID)
+		if dog.Photo == nil || *dog.Photo != photos[1].PhotoPath {
+			t.Errorf("Expected cover to be the second photo, got %v", dog.Photo)
+		}
+
+		order, _ = json.Marshal(models.ReorderDogPhotosRequest{PhotoIDs: []int{photos[0].ID}})
+		if rec := call(handler.ReorderDogPhotos, "PUT", dogVars, bytes.NewBuffer(order), ""); rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 for incomplete order, got %d", rec.Code)
+		}
+	})
+
+	t.Run("delete removes files", func(t *testing.T) {
+		vars := map[string]string{"id": dogVars["id"], "photoId": fmt.Sprintf("%d", photos[0].ID)}
+		rec := call(handler.DeleteDogPhoto, "DELETE", vars, nil, "")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", rec.Code)
+		}
+		if len(gallery(rec)) != 1 {
+			t.Error("Expected one photo left")
+		}
+		if _, err := os.Stat(filepath.Join(tempDir, photos[0].PhotoPath)); !os.IsNotExist(err) {
+			t.Error("Expected photo file to be deleted")
+		}
+
+		if rec := call(handler.DeleteDogPhoto, "DELETE", vars, nil, ""); rec.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404 for deleted photo, got %d", rec.Code)
+		}
+	})
+
+	t.Run("promote walk report photo", func(t *testing.T) {
+		userID := testutil.SeedTestUser(t, db, "walker@example.com", "Wanda Walker", "green")
+		bookingID := testutil.SeedTestBooking(t, db, userID, dogID, "2030-01-09", "09:00", "completed")
+		reportID := testutil.SeedTestWalkReport(t, db, bookingID, 5, "high", "")
+
+		file, _ := os.CreateTemp(tempDir, "upload-*.jpg")
+		file.Write(imageData)
+		fullPath, thumbPath, err := service.ProcessWalkReportPhoto(file, reportID, 1)
+		file.Close()
+		if err != nil {
+			t.Fatalf("Failed to create walk report photo: %v", err)
+		}
+		reportPhoto, _ := repository.NewWalkReportRepository(db).AddPhoto(reportID, fullPath, thumbPath, 0)
+
+		body, _ := json.Marshal(models.PromoteWalkReportPhotoRequest{WalkReportPhotoID: reportPhoto.ID})
+		rec := call(handler.PromoteWalkReportPhoto, "POST", dogVars, bytes.NewBuffer(body), "")
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("Expected status 201, got %d: %s", rec.Code, rec.Body.String())
+		}
+		promoted := gallery(rec)
+		if len(promoted) != 2 || promoted[1].PhotoPath == fullPath {
+			t.Fatalf("Expected a copied photo at the end of the gallery, got %+v", promoted)
+		}
+		if _, err := os.Stat(filepath.Join(tempDir, fullPath)); err != nil {
+			t.Error("Expected the walk report photo to stay in place")
+		}
+
+		otherDogID := testutil.SeedTestDog(t, db, "Max", "Beagle", "green")
+		rec = call(handler.PromoteWalkReportPhoto, "POST", map[string]string{"id": fmt.Sprintf("%d", otherDogID)}, bytes.NewBuffer(body), "")
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 for a photo of another dog, got %d", rec.Code)
+		}
+	})
+
+	t.Run("deleting the dog removes all photo files", func(t *testing.T) {
+		dogID := testutil.SeedTestDog(t, db, "Luna", "Pudel", "green")
+		vars := map[string]string{"id": fmt.Sprintf("%d", dogID)}
+
+		body, contentType, _ := createMultipartUpload("photo", "cover.jpg", imageData)
+		if rec := call(handler.UploadDogPhoto, "POST", vars, body, contentType); rec.Code != http.StatusOK {
+			t.Fatalf("Upload failed: %d %s", rec.Code, rec.Body.String())
+		}
+
+		req := httptest.NewRequest("DELETE", "/api/dogs/"+vars["id"], nil)
+		req = mux.SetURLVars(req, vars)
+		rec := httptest.NewRecorder()
+		handler.DeleteDog(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Delete failed: %d %s", rec.Code, rec.Body.String())
+		}
+
+		matches, _ := filepath.Glob(filepath.Join(tempDir, "dogs", fmt.Sprintf("dog_%d_*", dogID)))
+		if len(matches) != 0 {
+			t.Errorf("Expected no photo files left, got %v", matches)
+		}
+	})
+}
@@ -2,14 +2,19 @@ package handlers
 
 import (
 	"bytes"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/tranmh/gassigeher/internal/config"
+	"github.com/tranmh/gassigeher/internal/models"
+	"github.com/tranmh/gassigeher/internal/repository"
 	"github.com/tranmh/gassigeher/internal/testutil"
 )
 
@@ -804,6 +809,147 @@ func TestDogHandler_UpdateDogCareInfo(t *testing.T) {
 	})
 }
 
+// TestDogHandler_UpdateDogWalkLimits tests setting, validating and clearing the walk limits
+func TestDogHandler_UpdateDogWalkLimits(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	cfg := &config.Config{JWTSecret: "test-secret"}
+	handler := NewDogHandler(db, cfg)
+
+	adminID := testutil.SeedTestUser(t, db, "admin@example.com", "Admin", "orange")
+	dogID := testutil.SeedTestDog(t, db, "Opa", "Dackel", "green")
+
+	update := func(reqBody map[string]interface{}) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(reqBody)
+		req := httptest.NewRequest("PUT", fmt.Sprintf("/api/dogs/%d", dogID), bytes.NewReader(body))
+		req = mux.SetURLVars(req, map[string]string{"id": fmt.Sprintf("%d", dogID)})
+		req = req.WithContext(contextWithUser(req.Context(), adminID, "admin@example.com", true))
+		rec := httptest.NewRecorder()
+		handler.UpdateDog(rec, req)
+		return rec
+	}
+
+	t.Run("set limits", func(t *testing.T) {
+		rec := update(map[string]interface{}{"max_walks_per_day": 2, "min_rest_minutes": 180})
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var dog models.Dog
+		json.Unmarshal(rec.Body.Bytes(), &dog)
+		if dog.MaxWalksPerDay == nil || *dog.MaxWalksPerDay != 2 || dog.MinRestMinutes == nil || *dog.MinRestMinutes != 180 {
+			t.Errorf("Expected 2 walks and 180 minutes rest, got %+v", dog)
+		}
+	})
+
+	t.Run("negative limit is rejected", func(t *testing.T) {
+		if rec := update(map[string]interface{}{"min_rest_minutes": -30}); rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("zero clears a limit", func(t *testing.T) {
+		if rec := update(map[string]interface{}{"max_walks_per_day": 0}); rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var maxWalks, minRest sql.NullInt64
+		db.QueryRow("SELECT max_walks_per_day, min_rest_minutes FROM dogs WHERE id = ?", dogID).Scan(&maxWalks, &minRest)
+		if maxWalks.Valid {
+			t.Errorf("Expected no walk limit, got %d", maxWalks.Int64)
+		}
+		if !minRest.Valid || minRest.Int64 != 180 {
+			t.Errorf("Expected rest to stay 180, got %v", minRest)
+		}
+	})
+}
+
+// TestDogHandler_UnavailabilityWindow tests scheduling unavailability and its overlapping bookings
+func TestDogHandler_UnavailabilityWindow(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	cfg := &config.Config{JWTSecret: "test-secret"}
+	handler := NewDogHandler(db, cfg)
+
+	adminID := testutil.SeedTestUser(t, db, "admin@example.com", "Admin", "orange")
+	userID := testutil.SeedTestUser(t, db, "walker@example.com", "Walker", "green")
+	dogID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+
+	day := func(offset int) string { return time.Now().AddDate(0, 0, offset).Format("2006-01-02") }
+	insideID := testutil.SeedTestBooking(t, db, userID, dogID, day(3), "09:00", "scheduled")
+	outsideID := testutil.SeedTestBooking(t, db, userID, dogID, day(6), "09:00", "scheduled")
+
+	setWindow := func(reqBody map[string]interface{}) (*httptest.ResponseRecorder, models.DogAvailabilityResponse) {
+		body, _ := json.Marshal(reqBody)
+		req := httptest.NewRequest("PUT", fmt.Sprintf("/api/dogs/%d/availability", dogID), bytes.NewReader(body))
+		req = mux.SetURLVars(req, map[string]string{"id": fmt.Sprintf("%d", dogID)})
+		req = req.WithContext(contextWithUser(req.Context(), adminID, "admin@example.com", true))
+		rec := httptest.NewRecorder()
+		handler.ToggleAvailability(rec, req)
+
+		var response models.DogAvailabilityResponse
+		json.Unmarshal(rec.Body.Bytes(), &response)
+		return rec, response
+	}
+
+	bookingStatus := func(id int) string {
+		var status string
+		db.QueryRow("SELECT status FROM bookings WHERE id = ?", id).Scan(&status)
+		return status
+	}
+
+	t.Run("upcoming window lists overlapping bookings", func(t *testing.T) {
+		rec, response := setWindow(map[string]interface{}{
+			"is_available": false, "unavailable_reason": "OP", "unavailable_from": day(2), "unavailable_until": day(4),
+		})
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if response.Dog == nil || !response.IsAvailable || response.UnavailableFrom == nil || *response.UnavailableFrom != day(2) {
+			t.Errorf("Expected available dog with upcoming window, got %s", rec.Body.String())
+		}
+		if len(response.AffectedBookings) != 1 || response.AffectedBookings[0].ID != insideID {
+			t.Errorf("Expected only booking %d to overlap, got %s", insideID, rec.Body.String())
+		}
+		if response.CancelledCount != 0 || bookingStatus(insideID) != "scheduled" {
+			t.Error("Expected bookings to stay without cancel_bookings")
+		}
+	})
+
+	t.Run("overlapping bookings can be cancelled", func(t *testing.T) {
+		rec, response := setWindow(map[string]interface{}{
+			"is_available": false, "unavailable_reason": "OP", "unavailable_from": day(2), "unavailable_until": day(4),
+			"cancel_bookings": true,
+		})
+		if rec.Code != http.StatusOK || response.CancelledCount != 1 {
+			t.Fatalf("Expected 1 cancelled booking, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if bookingStatus(insideID) != "cancelled" || bookingStatus(outsideID) != "scheduled" {
+			t.Error("Expected only the booking inside the window to be cancelled")
+		}
+
+		events, err := repository.NewBookingEventRepository(db).FindByBooking(insideID)
+		if err != nil {
+			t.Fatalf("FindByBooking() failed: %v", err)
+		}
+		if len(events) != 1 || events[0].EventType != models.BookingEventCancelled ||
+			events[0].ActorID == nil || *events[0].ActorID != adminID ||
+			events[0].NewValue == nil || !strings.Contains(*events[0].NewValue, "OP") {
+			t.Errorf("Expected cancellation event by the admin with the reason, got %+v", events)
+		}
+	})
+
+	t.Run("end before start is rejected", func(t *testing.T) {
+		rec, _ := setWindow(map[string]interface{}{"is_available": false, "unavailable_from": day(4), "unavailable_until": day(2)})
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("making the dog available clears the window", func(t *testing.T) {
+		rec, response := setWindow(map[string]interface{}{"is_available": true})
+		if rec.Code != http.StatusOK || response.Dog == nil || response.UnavailableFrom != nil {
+			t.Errorf("Expected dog without window, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+}
+
 // DONE: TestDogHandler_GetBreeds tests getting list of unique breeds
 func TestDogHandler_GetBreeds(t *testing.T) {
 	db := testutil.SetupTestDB(t)
@@ -865,3 +1011,357 @@ func TestDogHandler_GetBreeds(t *testing.T) {
 		}
 	})
 }
+
+// TestDogHandler_WalkerRules tests managing a dog's allowlist and denylist
+func TestDogHandler_WalkerRules(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	cfg := &config.Config{JWTSecret: "test-secret"}
+	handler := NewDogHandler(db, cfg)
+
+	adminID := testutil.SeedTestUser(t, db, "admin@example.com", "Anna Admin", "orange")
+	userID := testutil.SeedTestUser(t, db, "walker@example.com", "Wanda Walker", "green")
+	dogID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+
+	send := func(method, body string, vars map[string]string, fn http.HandlerFunc) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/api/dogs/walker-rules", bytes.NewBufferString(body))
+		req = mux.SetURLVars(req, vars)
+		req = req.WithContext(contextWithUser(req.Context(), adminID, "admin@example.com", true))
+		rec := httptest.NewRecorder()
+		fn(rec, req)
+		return rec
+	}
+	dogVars := map[string]string{"id": fmt.Sprintf("%d", dogID)}
+
+	var rule models.DogWalkerRule
+
+	t.Run("add walker to denylist", func(t *testing.T) {
+		rec := send("POST", fmt.Sprintf(`{"user_id":%d,"rule_type":"deny","reason":"Zieht zu stark"}`, userID), dogVars, handler.AddWalkerRule)
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("Expected status 201, got %d: %s", rec.Code, rec.Body.String())
+		}
+		json.Unmarshal(rec.Body.Bytes(), &rule)
+		if rule.UserName == nil || *rule.UserName != "Wanda Walker" || rule.CreatedBy == nil || *rule.CreatedBy != adminID {
+			t.Errorf("Unexpected rule %+v", rule)
+		}
+	})
+
+	t.Run("walker already on a list", func(t *testing.T) {
+		rec := send("POST", fmt.Sprintf(`{"user_id":%d,"rule_type":"allow","reason":"Doch"}`, userID), dogVars, handler.AddWalkerRule)
+		if rec.Code != http.StatusConflict {
+			t.Errorf("Expected status 409, got %d", rec.Code)
+		}
+	})
+
+	t.Run("unknown walker", func(t *testing.T) {
+		rec := send("POST", `{"user_id":9999,"rule_type":"deny","reason":"Grund"}`, dogVars, handler.AddWalkerRule)
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404, got %d", rec.Code)
+		}
+	})
+
+	t.Run("missing reason", func(t *testing.T) {
+		rec := send("POST", fmt.Sprintf(`{"user_id":%d,"rule_type":"deny"}`, adminID), dogVars, handler.AddWalkerRule)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("denied walker doesn't see the dog", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/dogs", nil)
+		req = req.WithContext(contextWithUser(req.Context(), userID, "walker@example.com", false))
+		rec := httptest.NewRecorder()
+		handler.ListDogs(rec, req)
+
+		var dogs []models.Dog
+		json.Unmarshal(rec.Body.Bytes(), &dogs)
+		if rec.Code != http.StatusOK || len(dogs) != 0 {
+			t.Errorf("Expected no dogs, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("list and remove", func(t *testing.T) {
+		rec := send("GET", "", dogVars, handler.GetWalkerRules)
+		var rules []models.DogWalkerRule
+		json.Unmarshal(rec.Body.Bytes(), &rules)
+		if rec.Code != http.StatusOK || len(rules) != 1 || rules[0].ID != rule.ID {
+			t.Fatalf("Expected the deny rule, got %d: %s", rec.Code, rec.Body.String())
+		}
+
+		ruleVars := map[string]string{"id": fmt.Sprintf("%d", dogID), "ruleId": fmt.Sprintf("%d", rule.ID)}
+		if rec := send("DELETE", "", ruleVars, handler.RemoveWalkerRule); rec.Code != http.StatusOK {
+			t.Errorf("Expected status 200, got %d", rec.Code)
+		}
+		if rec := send("DELETE", "", ruleVars, handler.RemoveWalkerRule); rec.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404 for a removed rule, got %d", rec.Code)
+		}
+	})
+}
+
+// TestDogHandler_StatusLifecycle tests archiving a dog that left the shelter and restoring it
+func TestDogHandler_StatusLifecycle(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	cfg := &config.Config{JWTSecret: "test-secret"}
+	handler := NewDogHandler(db, cfg)
+	waitlistRepo := repository.NewWaitlistRepository(db)
+
+	adminID := testutil.SeedTestUser(t, db, "admin@example.com", "Anna Admin", "orange")
+	userID := testutil.SeedTestUser(t, db, "walker@example.com", "Wanda Walker", "green")
+	dogID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+
+	future := time.Now().AddDate(0, 0, 7).Format("2006-01-02")
+	past := time.Now().AddDate(0, 0, -7).Format("2006-01-02")
+	futureBookingID := testutil.SeedTestBooking(t, db, userID, dogID, future, "09:00", "scheduled")
+	pastBookingID := testutil.SeedTestBooking(t, db, userID, dogID, past, "09:00", "completed")
+	waitlistEntry := &models.WaitlistEntry{UserID: userID, DogID: dogID, Date: future, ScheduledTime: "09:00"}
+	waitlistRepo.Create(waitlistEntry)
+
+	dogVars := map[string]string{"id": fmt.Sprintf("%d", dogID)}
+	send := func(method, body string, userID int, isAdmin bool, query string, fn http.HandlerFunc) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/api/dogs/status"+query, bytes.NewBufferString(body))
+		req = mux.SetURLVars(req, dogVars)
+		req = req.WithContext(contextWithUser(req.Context(), userID, "user@example.com", isAdmin))
+		rec := httptest.NewRecorder()
+		fn(rec, req)
+		return rec
+	}
+
+	t.Run("reserving keeps the dog bookable", func(t *testing.T) {
+		rec := send("PUT", `{"status":"reserved","date":"2030-01-05","note":"Vorvertrag unterschrieben"}`, adminID, true, "", handler.ChangeDogStatus)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var status string
+		db.QueryRow(`SELECT status FROM bookings WHERE id = ?`, futureBookingID).Scan(&status)
+		if status != "scheduled" {
+			t.Errorf("Expected the booking to be kept, got %s", status)
+		}
+	})
+
+	t.Run("walk history prevents deletion", func(t *testing.T) {
+		rec := send("DELETE", "", adminID, true, "?force=true", handler.DeleteDog)
+		if rec.Code != http.StatusConflict {
+			t.Errorf("Expected status 409, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("adoption archives the dog", func(t *testing.T) {
+		rec := send("PUT", `{"status":"adopted","date":"2030-01-10","note":"Familie Müller"}`, adminID, true, "", handler.ChangeDogStatus)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+
+		var response struct {
+			Dog            models.Dog `json:"dog"`
+			CancelledCount int        `json:"cancelled_count"`
+		}
+		json.Unmarshal(rec.Body.Bytes(), &response)
+		if response.Dog.Status != models.DogStatusAdopted || response.Dog.IsAvailable || response.CancelledCount != 1 {
+			t.Errorf("Expected an unavailable adopted dog and 1 cancelled booking, got %+v", response)
+		}
+
+		var futureStatus, pastStatus, waitlistStatus string
+		db.QueryRow(`SELECT status FROM bookings WHERE id = ?`, futureBookingID).Scan(&futureStatus)
+		db.QueryRow(`SELECT status FROM bookings WHERE id = ?`, pastBookingID).Scan(&pastStatus)
+		db.QueryRow(`SELECT status FROM booking_waitlist WHERE id = ?`, waitlistEntry.ID).Scan(&waitlistStatus)
+		if futureStatus != "cancelled" || pastStatus != "completed" || waitlistStatus != "cancelled" {
+			t.Errorf("Expected future booking and waitlist cancelled and history kept, got %s/%s/%s", futureStatus, pastStatus, waitlistStatus)
+		}
+	})
+
+	t.Run("walkers no longer see the dog", func(t *testing.T) {
+		if rec := send("GET", "", userID, false, "", handler.GetDog); rec.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404, got %d", rec.Code)
+		}
+		rec := send("GET", "", userID, false, "?archived=true", handler.ListDogs)
+		var dogs []models.Dog
+		json.Unmarshal(rec.Body.Bytes(), &dogs)
+		if len(dogs) != 0 {
+			t.Errorf("Expected no dogs for the walker, got %d", len(dogs))
+		}
+	})
+
+	t.Run("admins see the archive", func(t *testing.T) {
+		if rec := send("GET", "", adminID, true, "", handler.GetDog); rec.Code != http.StatusOK {
+			t.Errorf("Expected status 200, got %d", rec.Code)
+		}
+		rec := send("GET", "", adminID, true, "?archived=true", handler.ListDogs)
+		var dogs []models.Dog
+		json.Unmarshal(rec.Body.Bytes(), &dogs)
+		if len(dogs) != 1 || dogs[0].ID != dogID {
+			t.Errorf("Expected the adopted dog in the archive, got %s", rec.Body.String())
+		}
+	})
+
+	t.Run("archived dog availability cannot be toggled", func(t *testing.T) {
+		if rec := send("PUT", `{"is_available":true}`, adminID, true, "", handler.ToggleAvailability); rec.Code != http.StatusConflict {
+			t.Errorf("Expected status 409, got %d", rec.Code)
+		}
+	})
+
+	t.Run("restore", func(t *testing.T) {
+		rec := send("POST", `{"date":"2030-02-01","note":"Zurückgegeben"}`, adminID, true, "", handler.RestoreDog)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if rec := send("POST", `{"date":"2030-02-02","note":"Nochmal"}`, adminID, true, "", handler.RestoreDog); rec.Code != http.StatusConflict {
+			t.Errorf("Expected status 409 for a dog in the shelter, got %d", rec.Code)
+		}
+
+		rec = send("GET", "", adminID, true, "", handler.GetDogStatusHistory)
+		var history []models.DogStatusEvent
+		json.Unmarshal(rec.Body.Bytes(), &history)
+		if len(history) != 3 || history[0].Status != models.DogStatusInShelter || history[0].PreviousStatus != models.DogStatusAdopted {
+			t.Errorf("Expected 3 transitions ending with the restore, got %s", rec.Body.String())
+		}
+	})
+}
+
+// TestDogHandler_ShelterID tests that shelter IDs stay unique when dogs are created or edited
+func TestDogHandler_ShelterID(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	handler := NewDogHandler(db, &config.Config{JWTSecret: "test-secret"})
+
+	adminID := testutil.SeedTestUser(t, db, "admin@example.com", "Admin", "orange")
+	takenID := testutil.SeedTestDog(t, db, "Bello", "Mischling", "green")
+	dogID := testutil.SeedTestDog(t, db, "Luna", "Pudel", "green")
+	db.Exec("UPDATE dogs SET shelter_id = 'T-1' WHERE id = ?", takenID)
+
+	send := func(method string, id int, reqBody map[string]interface{}) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(reqBody)
+		req := httptest.NewRequest(method, "/api/dogs", bytes.NewReader(body))
+		req = req.WithContext(contextWithUser(req.Context(), adminID, "admin@example.com", true))
+		rec := httptest.NewRecorder()
+		if method == "POST" {
+			handler.CreateDog(rec, req)
+		} else {
+			req = mux.SetURLVars(req, map[string]string{"id": fmt.Sprintf("%d", id)})
+			handler.UpdateDog(rec, req)
+		}
+		return rec
+	}
+
+	t.Run("create with a taken shelter ID", func(t *testing.T) {
+		rec := send("POST", 0, map[string]interface{}{"name": "Max", "breed": "Beagle", "size": "small", "age": 2, "color_id": 1, "shelter_id": "T-1"})
+		if rec.Code != http.StatusConflict {
+			t.Errorf("Expected status 409, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("update sets a trimmed shelter ID", func(t *testing.T) {
+		rec := send("PUT", dogID, map[string]interface{}{"shelter_id": " T-2 "})
+		var dog models.Dog
+		json.Unmarshal(rec.Body.Bytes(), &dog)
+		if rec.Code != http.StatusOK || dog.ShelterID == nil || *dog.ShelterID != "T-2" {
+			t.Errorf("Expected shelter ID T-2, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("update with another dog's shelter ID", func(t *testing.T) {
+		if rec := send("PUT", dogID, map[string]interface{}{"shelter_id": "T-1"}); rec.Code != http.StatusConflict {
+			t.Errorf("Expected status 409, got %d", rec.Code)
+		}
+	})
+
+	t.Run("keeping the own shelter ID", func(t *testing.T) {
+		if rec := send("PUT", takenID, map[string]interface{}{"shelter_id": "T-1", "age": 8}); rec.Code != http.StatusOK {
+			t.Errorf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+}
+
+// TestDogHandler_ListDogsPaged tests sorted, paged and full-text dog listing
+func TestDogHandler_ListDogsPaged(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	handler := NewDogHandler(db, &config.Config{JWTSecret: "test-secret"})
+
+	userID := testutil.SeedTestUser(t, db, "user@example.com", "User", "green")
+	testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+	maxID := testutil.SeedTestDog(t, db, "Max", "Beagle", "green")
+	testutil.SeedTestDog(t, db, "Rocky", "Mischling", "green")
+	db.Exec("UPDATE dogs SET walk_route = 'Runde durch den Stadtpark' WHERE id = ?", maxID)
+
+	list := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/api/dogs"+query, nil)
+		req = req.WithContext(contextWithUser(req.Context(), userID, "user@example.com", false))
+		rec := httptest.NewRecorder()
+		handler.ListDogs(rec, req)
+		return rec
+	}
+
+	t.Run("pages follow the cursor", func(t *testing.T) {
+		names := []string{}
+		query := "?sort=name&order=desc&limit=2"
+		for pages := 0; pages < 5; pages++ {
+			rec := list(query)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+			}
+			var page models.DogPage
+			json.Unmarshal(rec.Body.Bytes(), &page)
+			for _, dog := range page.Dogs {
+				names = append(names, dog.Name)
+			}
+			if page.NextCursor == "" {
+				break
+			}
+			query = "?sort=name&order=desc&limit=2&cursor=" + page.NextCursor
+		}
+		if strings.Join(names, ",") != "Rocky,Max,Bella" {
+			t.Errorf("Expected Rocky,Max,Bella, got %v", names)
+		}
+	})
+
+	t.Run("search in walk route", func(t *testing.T) {
+		var dogs []models.Dog
+		rec := list("?search=stadtpark")
+		json.Unmarshal(rec.Body.Bytes(), &dogs)
+		if rec.Code != http.StatusOK || len(dogs) != 1 || dogs[0].ID != maxID {
+			t.Errorf("Expected Max, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		for _, query := range []string{"?sort=breed", "?order=up", "?limit=0", "?limit=abc", "?limit=500", "?cursor=abc", "?limit=2&cursor=abc"} {
+			if rec := list(query); rec.Code != http.StatusBadRequest {
+				t.Errorf("%s: expected status 400, got %d", query, rec.Code)
+			}
+		}
+	})
+}
+
+// TestDogHandler_SetPublic tests showing and hiding a dog's public profile page
+func TestDogHandler_SetPublic(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	handler := NewDogHandler(db, &config.Config{JWTSecret: "test-secret"})
+	adminID := testutil.SeedTestUser(t, db, "admin@example.com", "Admin", "orange")
+	dogID := testutil.SeedTestDog(t, db, "Bello", "Mischling", "green")
+
+	setPublic := func(id int, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("PUT", fmt.Sprintf("/api/dogs/%d/public", id), strings.NewReader(body))
+		req = req.WithContext(contextWithUser(req.Context(), adminID, "admin@example.com", true))
+		req = mux.SetURLVars(req, map[string]string{"id": fmt.Sprintf("%d", id)})
+		rec := httptest.NewRecorder()
+		handler.SetPublic(rec, req)
+		return rec
+	}
+
+	rec := setPublic(dogID, `{"is_public": true}`)
+	var dog models.Dog
+	json.Unmarshal(rec.Body.Bytes(), &dog)
+	if rec.Code != http.StatusOK || !dog.IsPublic {
+		t.Fatalf("Expected the dog to be public, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	setPublic(dogID, `{"is_public": false}`)
+	stored, _ := repository.NewDogRepository(db).FindByID(dogID)
+	if stored.IsPublic {
+		t.Error("Expected the dog to be hidden again")
+	}
+
+	if rec := setPublic(9999, `{"is_public": true}`); rec.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for unknown dog, got %d", rec.Code)
+	}
+	if rec := setPublic(dogID, `nope`); rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for invalid body, got %d", rec.Code)
+	}
+}
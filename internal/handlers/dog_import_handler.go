@@ -0,0 +1,660 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tranmh/gassigeher/internal/config"
+	"github.com/tranmh/gassigeher/internal/models"
+	"github.com/tranmh/gassigeher/internal/repository"
+	"github.com/tranmh/gassigeher/internal/services"
+)
+
+// dogTransferColumns are the CSV columns of dog exports and imports, named like the JSON fields
+var dogTransferColumns = []string{
+	"shelter_id", "name", "breed", "size", "age", "color", "category",
+	"special_needs", "pickup_location", "walk_route", "walk_duration",
+	"max_walks_per_day", "min_rest_minutes", "target_walks_per_week",
+	"special_instructions", "default_morning_time", "default_evening_time", "external_link",
+}
+
+// DogImportHandler handles bulk import and export of dogs (admin only)
+type DogImportHandler struct {
+	dogRepo      *repository.DogRepository
+	colorRepo    *repository.ColorCategoryRepository
+	photoRepo    *repository.DogPhotoRepository
+	imageService *services.ImageService
+	config       *config.Config
+}
+
+// NewDogImportHandler creates a new dog import handler
+func NewDogImportHandler(db *sql.DB, cfg *config.Config) *DogImportHandler {
+	return &DogImportHandler{
+		dogRepo:      repository.NewDogRepository(db),
+		colorRepo:    repository.NewColorCategoryRepository(db),
+		photoRepo:    repository.NewDogPhotoRepository(db),
+		imageService: services.NewImageService(cfg.UploadDir),
+		config:       cfg,
+	}
+}
+
+// dogImportInput is a parsed record with the errors found while parsing it
+type dogImportInput struct {
+	record *models.DogImportRecord
+	errors []string
+}
+
+// ExportDogs handles GET /api/admin/dogs/export?format=csv|json - all dogs in the shelter in
+// the import format
+func (h *DogImportHandler) ExportDogs(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "csv"
+	}
+	if format != "csv" && format != "json" {
+		respondError(w, http.StatusBadRequest, "format must be 'csv' or 'json'")
+		return
+	}
+
+	dogs, err := h.dogRepo.FindAll(&models.DogFilterRequest{})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get dogs")
+		return
+	}
+
+	colorNames, err := h.colorNames()
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get colors")
+		return
+	}
+
+	records := make([]*models.DogImportRecord, 0, len(dogs))
+	for _, dog := range dogs {
+		records = append(records, dogExportRecord(dog, colorNames))
+	}
+
+	filename := "hunde-" + time.Now().Format("2006-01-02")
+	if format == "json" {
+		w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`.json"`)
+		respondJSON(w, http.StatusOK, records)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`.csv"`)
+
+	cw := csv.NewWriter(w)
+	cw.Write(dogTransferColumns)
+	for _, record := range records {
+		values := dogRecordValues(record)
+		for i, value := range values {
+			values[i] = csvSafe(value)
+		}
+		cw.Write(values)
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		log.Printf("Dog export failed: %v", err)
+	}
+}
+
+// ImportDogs handles POST /api/admin/dogs/import?dry_run=true - create or update dogs from a
+// CSV or JSON "file", matched by shelter_id. An optional "photos" ZIP adds the entry named
+// after a dog's shelter ID (e.g. T-123.jpg) as its cover photo. Every row is validated on its
+// own; rows with errors are skipped and reported.
+func (h *DogImportHandler) ImportDogs(w http.ResponseWriter, r *http.Request) {
+	dryRun := r.URL.Query().Get("dry_run") == "true"
+
+	maxBytes := int64(h.config.MaxUploadSizeMB) << 20
+	if err := r.ParseMultipartForm(maxBytes); err != nil {
+		respondError(w, http.StatusBadRequest, "File too large or invalid form")
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "No file uploaded")
+		return
+	}
+	defer file.Close()
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(header.Filename)), ".")
+	}
+
+	var inputs []*dogImportInput
+	switch format {
+	case "csv":
+		inputs, err = parseDogImportCSV(file)
+	case "json":
+		inputs, err = parseDogImportJSON(file)
+	default:
+		respondError(w, http.StatusBadRequest, "File must be a .csv or .json file")
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	photos := map[string][]*zip.File{}
+	if photoFile, photoHeader, err := r.FormFile("photos"); err == nil {
+		defer photoFile.Close()
+		archive, err := zip.NewReader(photoFile, photoHeader.Size)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "photos must be a ZIP file")
+			return
+		}
+		photos = photosByShelterID(archive)
+	}
+
+	colors, err := h.colorRepo.FindAll()
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get colors")
+		return
+	}
+
+	result := &models.DogImportResult{DryRun: dryRun, Rows: []*models.DogImportRow{}}
+	seen := map[string]int{}
+	for i, input := range inputs {
+		row := h.importDog(i+1, input, colors, photos, seen, dryRun, maxBytes)
+		switch row.Action {
+		case models.DogImportActionCreate:
+			result.Created++
+		case models.DogImportActionUpdate:
+			result.Updated++
+		default:
+			result.Failed++
+		}
+		result.Rows = append(result.Rows, row)
+	}
+
+	for shelterID, entries := range photos {
+		if _, ok := seen[shelterID]; !ok {
+			for _, entry := range entries {
+				result.UnmatchedPhotos = append(result.UnmatchedPhotos, entry.Name)
+			}
+		}
+	}
+	sort.Strings(result.UnmatchedPhotos)
+
+	respondJSON(w, http.StatusOK, result)
+}
+
+// importDog validates one record and, unless it is a dry run, creates or updates its dog and
+// attaches its photo. seen maps the shelter IDs of earlier rows to their row number.
+func (h *DogImportHandler) importDog(number int, input *dogImportInput, colors []*models.ColorCategory,
+	photos map[string][]*zip.File, seen map[string]int, dryRun bool, maxPhotoBytes int64) *models.DogImportRow {
+	record := input.record
+	row := &models.DogImportRow{Row: number, Name: record.Name, Errors: input.errors}
+
+	shelterID := models.ShelterIDOrNil(record.ShelterID)
+	if shelterID == nil {
+		row.Errors = append(row.Errors, "shelter_id: Shelter ID is required for imports")
+	} else {
+		row.ShelterID = *shelterID
+		record.ShelterID = shelterID
+		if previous, ok := seen[*shelterID]; ok {
+			row.Errors = append(row.Errors, fmt.Sprintf("shelter_id: Shelter ID is already used in row %d", previous))
+		} else {
+			seen[*shelterID] = number
+		}
+	}
+
+	colorMsg := resolveImportColor(record, colors)
+	if colorMsg != "" {
+		row.Errors = append(row.Errors, colorMsg)
+	}
+
+	if err := record.Validate(); err != nil {
+		// A missing color is already reported as unknown color
+		if validationErr, ok := err.(*models.ValidationError); !ok || colorMsg == "" || validationErr.Field != "color_id" {
+			row.Errors = append(row.Errors, err.Error())
+		}
+	}
+
+	var existing *models.Dog
+	if shelterID != nil {
+		var err error
+		if existing, err = h.dogRepo.FindByShelterID(*shelterID); err != nil {
+			row.Errors = append(row.Errors, "Database error")
+		}
+	}
+
+	var photo []byte
+	if shelterID != nil && len(photos[*shelterID]) > 0 {
+		var msg string
+		row.Photo = photos[*shelterID][0].Name
+		if photo, msg = h.readImportPhoto(photos[*shelterID], existing, maxPhotoBytes); msg != "" {
+			row.Errors = append(row.Errors, "photo: "+msg)
+		}
+	}
+
+	if len(row.Errors) > 0 {
+		row.Action = models.DogImportActionError
+		return row
+	}
+
+	row.Action = models.DogImportActionCreate
+	if existing != nil {
+		row.Action = models.DogImportActionUpdate
+		row.DogID = &existing.ID
+	}
+	if dryRun {
+		return row
+	}
+
+	dog := existing
+	if dog == nil {
+		dog = &models.Dog{IsAvailable: true, Status: models.DogStatusInShelter}
+	}
+	applyDogImportRecord(dog, record)
+
+	var err error
+	if existing == nil {
+		err = h.dogRepo.Create(dog)
+	} else {
+		err = h.dogRepo.Update(dog)
+	}
+	if err != nil {
+		log.Printf("ERROR: Failed to import dog %s: %v", row.ShelterID, err)
+		row.Action = models.DogImportActionError
+		row.DogID = nil
+		row.Errors = append(row.Errors, "Failed to save dog")
+		return row
+	}
+	row.DogID = &dog.ID
+
+	if photo != nil {
+		if err := h.addCoverPhoto(dog.ID, photo); err != nil {
+			log.Printf("ERROR: Failed to import photo for dog %d: %v", dog.ID, err)
+			row.Errors = append(row.Errors, "photo: Failed to process image")
+		}
+	}
+
+	return row
+}
+
+// readImportPhoto reads and checks the ZIP entry of one dog; the message is empty if the photo
+// can be added
+func (h *DogImportHandler) readImportPhoto(entries []*zip.File, existing *models.Dog, maxBytes int64) ([]byte, string) {
+	if len(entries) > 1 {
+		return nil, "More than one photo for this shelter ID"
+	}
+
+	// A new cover replaces the current one, so only dogs without a cover gain a photo
+	if existing != nil && (existing.Photo == nil || *existing.Photo == "") {
+		count, err := h.photoRepo.Count(existing.ID)
+		if err != nil {
+			return nil, "Failed to count photos"
+		}
+		if count >= models.MaxDogPhotos {
+			return nil, fmt.Sprintf("Maximal %d Fotos pro Hund erlaubt", models.MaxDogPhotos)
+		}
+	}
+
+	entry, err := entries[0].Open()
+	if err != nil {
+		return nil, "Failed to read file"
+	}
+	defer entry.Close()
+
+	// The ZIP header may lie about the size, so the limit is applied while reading
+	data, err := io.ReadAll(io.LimitReader(entry, maxBytes+1))
+	if err != nil {
+		return nil, "Failed to read file"
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, "File too large"
+	}
+
+	if errMsg, valid := ValidateImageFile(entries[0].Name, bytes.NewReader(data)); !valid {
+		return nil, errMsg
+	}
+	return data, ""
+}
+
+// addCoverPhoto adds an imported photo to the dog's gallery and makes it the cover. The
+// previous cover is removed, so importing the same ZIP again does not duplicate photos.
+func (h *DogImportHandler) addCoverPhoto(dogID int, data []byte) error {
+	gallery, err := h.photoRepo.FindByDog(dogID)
+	if err != nil {
+		return err
+	}
+
+	fullPath, thumbPath, err := h.imageService.ProcessDogGalleryPhoto(memoryFile{bytes.NewReader(data)}, dogID)
+	if err != nil {
+		return err
+	}
+
+	photo, err := h.photoRepo.Add(dogID, fullPath, thumbPath)
+	if err == nil && !photo.IsCover {
+		err = h.photoRepo.SetCover(dogID, photo.ID)
+	}
+	if err != nil {
+		if photo != nil {
+			h.photoRepo.Delete(dogID, photo.ID)
+		}
+		h.imageService.DeleteDogPhoto(fullPath, thumbPath)
+		return err
+	}
+
+	for _, previous := range gallery {
+		if !previous.IsCover {
+			continue
+		}
+		if err := h.photoRepo.Delete(dogID, previous.ID); err != nil {
+			log.Printf("ERROR: Failed to remove replaced cover photo %d of dog %d: %v", previous.ID, dogID, err)
+			break
+		}
+		if err := h.imageService.DeleteDogPhoto(previous.PhotoPath, previous.PhotoThumbnail); err != nil {
+			log.Printf("ERROR: Failed to delete photo files of dog %d: %v", dogID, err)
+		}
+	}
+	return nil
+}
+
+// colorNames maps color IDs to their names
+func (h *DogImportHandler) colorNames() (map[int]string, error) {
+	colors, err := h.colorRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[int]string, len(colors))
+	for _, color := range colors {
+		names[color.ID] = color.Name
+	}
+	return names, nil
+}
+
+// resolveImportColor sets the record's color_id from its color name (case-insensitive) and
+// returns an error message if the color does not exist
+func resolveImportColor(record *models.DogImportRecord, colors []*models.ColorCategory) string {
+	name := strings.TrimSpace(record.Color)
+	if name == "" {
+		if record.ColorID == nil {
+			return ""
+		}
+		for _, color := range colors {
+			if color.ID == *record.ColorID {
+				return ""
+			}
+		}
+		return fmt.Sprintf("color_id: Unknown color ID %d", *record.ColorID)
+	}
+
+	for _, color := range colors {
+		if strings.EqualFold(color.Name, name) {
+			id := color.ID
+			record.ColorID = &id
+			return ""
+		}
+	}
+	return fmt.Sprintf("color: Unknown color %s", name)
+}
+
+// applyDogImportRecord copies all imported fields into the dog. Empty optional fields clear
+// the dog's value, so an import fully replaces what the file covers.
+func applyDogImportRecord(dog *models.Dog, record *models.DogImportRecord) {
+	category := record.Category
+	if category == "" {
+		category = "green" // Default to satisfy CHECK constraint (legacy field)
+	}
+
+	dog.Name = strings.TrimSpace(record.Name)
+	dog.Breed = strings.TrimSpace(record.Breed)
+	dog.Size = record.Size
+	dog.Age = record.Age
+	dog.Category = category
+	dog.ColorID = record.ColorID
+	dog.SpecialNeeds = record.SpecialNeeds
+	dog.PickupLocation = record.PickupLocation
+	dog.WalkRoute = record.WalkRoute
+	dog.WalkDuration = record.WalkDuration
+	dog.MaxWalksPerDay = models.WalkLimitOrNil(record.MaxWalksPerDay)
+	dog.MinRestMinutes = models.WalkLimitOrNil(record.MinRestMinutes)
+	dog.TargetWalksPerWeek = models.WalkLimitOrNil(record.TargetWalksPerWeek)
+	dog.SpecialInstructions = record.SpecialInstructions
+	dog.DefaultMorningTime = record.DefaultMorningTime
+	dog.DefaultEveningTime = record.DefaultEveningTime
+	dog.ExternalLink = record.ExternalLink
+	dog.ShelterID = models.ShelterIDOrNil(record.ShelterID)
+}
+
+// dogExportRecord converts a dog into the import format
+func dogExportRecord(dog *models.Dog, colorNames map[int]string) *models.DogImportRecord {
+	record := &models.DogImportRecord{
+		CreateDogRequest: models.CreateDogRequest{
+			Name:                dog.Name,
+			Breed:               dog.Breed,
+			Size:                dog.Size,
+			Age:                 dog.Age,
+			Category:            dog.Category,
+			SpecialNeeds:        dog.SpecialNeeds,
+			PickupLocation:      dog.PickupLocation,
+			WalkRoute:           dog.WalkRoute,
+			WalkDuration:        dog.WalkDuration,
+			MaxWalksPerDay:      dog.MaxWalksPerDay,
+			MinRestMinutes:      dog.MinRestMinutes,
+			TargetWalksPerWeek:  dog.TargetWalksPerWeek,
+			SpecialInstructions: dog.SpecialInstructions,
+			DefaultMorningTime:  dog.DefaultMorningTime,
+			DefaultEveningTime:  dog.DefaultEveningTime,
+			ExternalLink:        dog.ExternalLink,
+			ShelterID:           dog.ShelterID,
+		},
+	}
+	if dog.ColorID != nil {
+		record.Color = colorNames[*dog.ColorID]
+	}
+	return record
+}
+
+// dogRecordValues formats a record in the column order of dogTransferColumns
+func dogRecordValues(record *models.DogImportRecord) []string {
+	optional := func(value *string) string {
+		if value == nil {
+			return ""
+		}
+		return *value
+	}
+	optionalInt := func(value *int) string {
+		if value == nil {
+			return ""
+		}
+		return strconv.Itoa(*value)
+	}
+
+	return []string{
+		optional(record.ShelterID),
+		record.Name,
+		record.Breed,
+		record.Size,
+		strconv.Itoa(record.Age),
+		record.Color,
+		record.Category,
+		optional(record.SpecialNeeds),
+		optional(record.PickupLocation),
+		optional(record.WalkRoute),
+		optionalInt(record.WalkDuration),
+		optionalInt(record.MaxWalksPerDay),
+		optionalInt(record.MinRestMinutes),
+		optionalInt(record.TargetWalksPerWeek),
+		optional(record.SpecialInstructions),
+		optional(record.DefaultMorningTime),
+		optional(record.DefaultEveningTime),
+		optional(record.ExternalLink),
+	}
+}
+
+// parseDogImportCSV reads a CSV file with a header row of dogTransferColumns names (any order,
+// comma or semicolon separated). Malformed values are reported per row.
+func parseDogImportCSV(file io.Reader) ([]*dogImportInput, error) {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, errors.New("Failed to read file")
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")) // BOM written by spreadsheet programs
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	firstLine, _, _ := strings.Cut(string(data), "\n")
+	if strings.Count(firstLine, ";") > strings.Count(firstLine, ",") {
+		reader.Comma = ';'
+	}
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.New("CSV file has no header row")
+	}
+
+	known := map[string]bool{}
+	for _, column := range dogTransferColumns {
+		known[column] = true
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !known[name] {
+			return nil, fmt.Errorf("Unknown column %q", name)
+		}
+		columns[name] = i
+	}
+	if _, ok := columns["shelter_id"]; !ok {
+		return nil, errors.New("CSV file needs a shelter_id column")
+	}
+
+	inputs := []*dogImportInput{}
+	for {
+		values, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Invalid CSV: %v", err)
+		}
+		inputs = append(inputs, dogImportInputFromCSV(values, columns))
+	}
+	return inputs, nil
+}
+
+// dogImportInputFromCSV converts one CSV row; columns maps column names to their index
+func dogImportInputFromCSV(values []string, columns map[string]int) *dogImportInput {
+	input := &dogImportInput{record: &models.DogImportRecord{}}
+	record := input.record
+
+	value := func(column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(values) {
+			return ""
+		}
+		return csvUnescape(strings.TrimSpace(values[i]))
+	}
+	optional := func(column string) *string {
+		if v := value(column); v != "" {
+			return &v
+		}
+		return nil
+	}
+	optionalInt := func(column string) *int {
+		v := value(column)
+		if v == "" {
+			return nil
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			input.errors = append(input.errors, fmt.Sprintf("%s: %q is not a number", column, v))
+			return nil
+		}
+		return &n
+	}
+
+	record.ShelterID = optional("shelter_id")
+	record.Name = value("name")
+	record.Breed = value("breed")
+	record.Size = strings.ToLower(value("size"))
+	if age := optionalInt("age"); age != nil {
+		record.Age = *age
+	}
+	record.Color = value("color")
+	record.Category = strings.ToLower(value("category"))
+	record.SpecialNeeds = optional("special_needs")
+	record.PickupLocation = optional("pickup_location")
+	record.WalkRoute = optional("walk_route")
+	record.WalkDuration = optionalInt("walk_duration")
+	record.MaxWalksPerDay = optionalInt("max_walks_per_day")
+	record.MinRestMinutes = optionalInt("min_rest_minutes")
+	record.TargetWalksPerWeek = optionalInt("target_walks_per_week")
+	record.SpecialInstructions = optional("special_instructions")
+	record.DefaultMorningTime = optional("default_morning_time")
+	record.DefaultEveningTime = optional("default_evening_time")
+	record.ExternalLink = optional("external_link")
+
+	return input
+}
+
+// parseDogImportJSON reads a JSON array of dogs in the export format
+func parseDogImportJSON(file io.Reader) ([]*dogImportInput, error) {
+	var records []*models.DogImportRecord
+	if err := json.NewDecoder(file).Decode(&records); err != nil {
+		return nil, errors.New("Invalid JSON: expected an array of dogs")
+	}
+
+	inputs := make([]*dogImportInput, 0, len(records))
+	for _, record := range records {
+		if record == nil {
+			record = &models.DogImportRecord{}
+		}
+		inputs = append(inputs, &dogImportInput{record: record})
+	}
+	return inputs, nil
+}
+
+// photosByShelterID groups the image entries of a ZIP by file name without extension.
+// Folders and hidden files (e.g. __MACOSX/._T-1.jpg) are ignored.
+func photosByShelterID(archive *zip.Reader) map[string][]*zip.File {
+	photos := map[string][]*zip.File{}
+	for _, entry := range archive.File {
+		if entry.FileInfo().IsDir() || strings.HasPrefix(entry.Name, "__MACOSX/") {
+			continue
+		}
+		base := path.Base(entry.Name)
+		if strings.HasPrefix(base, ".") {
+			continue
+		}
+		shelterID := strings.TrimSpace(strings.TrimSuffix(base, path.Ext(base)))
+		photos[shelterID] = append(photos[shelterID], entry)
+	}
+	return photos
+}
+
+// csvUnescape removes the quote csvSafe puts in front of values that look like formulas
+func csvUnescape(value string) string {
+	if len(value) > 1 && value[0] == '\'' && strings.ContainsRune("=+-@\t\r", rune(value[1])) {
+		return value[1:]
+	}
+	return value
+}
+
+// memoryFile is an in-memory multipart.File for images that were not uploaded as a form file
+type memoryFile struct {
+	*bytes.Reader
+}
+
+// Close implements multipart.File
+func (memoryFile) Close() error { return nil }
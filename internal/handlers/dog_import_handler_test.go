@@ -0,0 +1,255 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/tranmh/gassigeher/internal/config"
+	"github.com/tranmh/gassigeher/internal/models"
+	"github.com/tranmh/gassigeher/internal/repository"
+	"github.com/tranmh/gassigeher/internal/testutil"
+)
+
+// newDogImportRequest builds a multipart import request with the dog file and optional photos ZIP
+func newDogImportRequest(t *testing.T, query, filename, content string, photos map[string][]byte) *http.Request {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+
+	part, _ := writer.CreateFormFile("file", filename)
+	part.Write([]byte(content))
+
+	if photos != nil {
+		zipPart, _ := writer.CreateFormFile("photos", "fotos.zip")
+		archive := zip.NewWriter(zipPart)
+		for name, data := range photos {
+			entry, _ := archive.Create(name)
+			entry.Write(data)
+		}
+		if err := archive.Close(); err != nil {
+			t.Fatalf("Failed to write ZIP: %v", err)
+		}
+	}
+	writer.Close()
+
+	req := httptest.NewRequest("POST", "/api/admin/dogs/import"+query, body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+// TestDogImportHandler_ImportDogs tests CSV and JSON imports with dry run, upsert and photos
+func TestDogImportHandler_ImportDogs(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	handler := NewDogImportHandler(db, &config.Config{UploadDir: t.TempDir(), MaxUploadSizeMB: 10})
+	dogRepo := repository.NewDogRepository(db)
+
+	existingID := testutil.SeedTestDog(t, db, "Bello", "Mischling", "green")
+	db.Exec("UPDATE dogs SET shelter_id = 'T-1' WHERE id = ?", existingID)
+
+	csvContent := "shelter_id,name,breed,size,age,color,walk_duration,max_walks_per_day\n" +
+		"T-1,Bello,Mischling,large,7,dunkelblau,45,2\n" +
+		"T-2,Luna,Pudel,small,3,Gruen,,\n" +
+		"T-3,Rex,Schäferhund,huge,4,gruen,,\n" +
+		",Kira,Mischling,medium,2,gruen,,\n" +
+		"T-4,Nala,Mischling,medium,zwei,lila,,\n"
+
+	runImport := func(t *testing.T, query string, photos map[string][]byte) *models.DogImportResult {
+		rec := httptest.NewRecorder()
+		handler.ImportDogs(rec, newDogImportRequest(t, query, "hunde.csv", csvContent, photos))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var result models.DogImportResult
+		json.Unmarshal(rec.Body.Bytes(), &result)
+		return &result
+	}
+
+	t.Run("dry run reports rows without writing", func(t *testing.T) {
+		result := runImport(t, "?dry_run=true", nil)
+
+		if !result.DryRun || result.Created != 1 || result.Updated != 1 || result.Failed != 3 {
+			t.Fatalf("Expected 1 create, 1 update, 3 failures, got %+v", result)
+		}
+		if result.Rows[0].Action != models.DogImportActionUpdate || result.Rows[0].DogID == nil || *result.Rows[0].DogID != existingID {
+			t.Errorf("Expected T-1 to update dog %d, got %+v", existingID, result.Rows[0])
+		}
+		if result.Rows[1].Action != models.DogImportActionCreate {
+			t.Errorf("Expected T-2 to be created, got %+v", result.Rows[1])
+		}
+		for i, want := range []string{"size:", "shelter_id:", "age:"} {
+			row := result.Rows[i+2]
+			if row.Action != models.DogImportActionError || !strings.HasPrefix(row.Errors[0], want) {
+				t.Errorf("Expected row %d to fail with %q, got %+v", row.Row, want, row)
+			}
+		}
+		if errors := result.Rows[4].Errors; len(errors) != 2 || !strings.Contains(errors[1], "Unknown color lila") {
+			t.Errorf("Expected number and color errors for T-4, got %v", errors)
+		}
+
+		if dog, _ := dogRepo.FindByShelterID("T-2"); dog != nil {
+			t.Error("Expected dry run not to create dogs")
+		}
+	})
+
+	t.Run("import creates and updates dogs with photos", func(t *testing.T) {
+		png, _ := createTestImageBytes(40, 40, "png")
+		result := runImport(t, "", map[string][]byte{
+			"fotos/T-2.png":            png,
+			"T-9.png":                  png,
+			"__MACOSX/fotos/._T-2.png": []byte("resource fork"),
+		})
+
+		if result.DryRun || result.Created != 1 || result.Updated != 1 {
+			t.Fatalf("Expected 1 create and 1 update, got %+v", result)
+		}
+		if len(result.UnmatchedPhotos) != 1 || result.UnmatchedPhotos[0] != "T-9.png" {
+			t.Errorf("Expected T-9.png to be unmatched, got %v", result.UnmatchedPhotos)
+		}
+
+		updated, _ := dogRepo.FindByID(existingID)
+		if updated.Size != "large" || updated.Age != 7 || updated.ColorID == nil || *updated.ColorID != 5 ||
+			updated.MaxWalksPerDay == nil || *updated.MaxWalksPerDay != 2 {
+			t.Errorf("Expected T-1 to be updated, got %+v", updated)
+		}
+
+		created, _ := dogRepo.FindByShelterID("T-2")
+		if created == nil || created.Name != "Luna" || created.ColorID == nil || *created.ColorID != 1 || !created.IsAvailable {
+			t.Fatalf("Expected Luna to be created, got %+v", created)
+		}
+		if result.Rows[1].DogID == nil || *result.Rows[1].DogID != created.ID || result.Rows[1].Photo != "fotos/T-2.png" {
+			t.Errorf("Expected row to report the new dog and photo, got %+v", result.Rows[1])
+		}
+		if created.Photo == nil {
+			t.Error("Expected the ZIP photo to become the cover photo")
+		}
+	})
+
+	t.Run("re-import replaces the cover photo", func(t *testing.T) {
+		dog, _ := dogRepo.FindByShelterID("T-2")
+		previousCover := *dog.Photo
+
+		png, _ := createTestImageBytes(40, 40, "png")
+		runImport(t, "", map[string][]byte{"T-2.png": png})
+
+		photos, err := repository.NewDogPhotoRepository(db).FindByDog(dog.ID)
+		if err != nil {
+			t.Fatalf("FindByDog() failed: %v", err)
+		}
+		if len(photos) != 1 || !photos[0].IsCover || photos[0].PhotoPath == previousCover {
+			t.Errorf("Expected the new photo to replace the cover, got %+v", photos)
+		}
+	})
+
+	t.Run("JSON import", func(t *testing.T) {
+		content := `[{"shelter_id": "T-2", "name": "Luna", "breed": "Pudel", "size": "small", "age": 4, "color": "orange", "target_walks_per_week": 5}]`
+		rec := httptest.NewRecorder()
+		handler.ImportDogs(rec, newDogImportRequest(t, "", "hunde.json", content, nil))
+
+		var result models.DogImportResult
+		json.Unmarshal(rec.Body.Bytes(), &result)
+		if rec.Code != http.StatusOK || result.Updated != 1 {
+			t.Fatalf("Expected 1 update, got %d: %s", rec.Code, rec.Body.String())
+		}
+
+		dog, _ := dogRepo.FindByShelterID("T-2")
+		if dog.Age != 4 || *dog.ColorID != 3 || dog.TargetWalksPerWeek == nil || *dog.TargetWalksPerWeek != 5 {
+			t.Errorf("Expected Luna to be updated from JSON, got %+v", dog)
+		}
+	})
+
+	t.Run("duplicate shelter ID in file", func(t *testing.T) {
+		content := "shelter_id;name;breed;size;age;color\nT-7;Max;Beagle;small;2;gruen\nT-7;Moritz;Beagle;small;2;gruen\n"
+		rec := httptest.NewRecorder()
+		handler.ImportDogs(rec, newDogImportRequest(t, "?dry_run=true", "hunde.csv", content, nil))
+
+		var result models.DogImportResult
+		json.Unmarshal(rec.Body.Bytes(), &result)
+		if result.Created != 1 || result.Failed != 1 || !strings.Contains(result.Rows[1].Errors[0], "row 1") {
+			t.Errorf("Expected the second T-7 row to fail, got %+v", result)
+		}
+	})
+
+	t.Run("invalid files", func(t *testing.T) {
+		for name, tc := range map[string]struct{ filename, content string }{
+			"unknown column":     {"hunde.csv", "shelter_id,name,farbe\nT-1,Bello,gruen\n"},
+			"missing shelter_id": {"hunde.csv", "name,breed\nBello,Mischling\n"},
+			"invalid JSON":       {"hunde.json", `{"name": "Bello"}`},
+			"unsupported file":   {"hunde.txt", "Bello"},
+		} {
+			rec := httptest.NewRecorder()
+			handler.ImportDogs(rec, newDogImportRequest(t, "", tc.filename, tc.content, nil))
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("%s: expected status 400, got %d", name, rec.Code)
+			}
+		}
+	})
+}
+
+// TestDogImportHandler_ExportDogs tests that exports use the import format
+func TestDogImportHandler_ExportDogs(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	handler := NewDogImportHandler(db, &config.Config{UploadDir: t.TempDir(), MaxUploadSizeMB: 10})
+
+	dogID := testutil.SeedTestDog(t, db, "=Bello", "Mischling", "blue")
+	db.Exec("UPDATE dogs SET shelter_id = 'T-1', max_walks_per_day = 2 WHERE id = ?", dogID)
+
+	t.Run("CSV", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ExportDogs(rec, httptest.NewRequest("GET", "/api/admin/dogs/export", nil))
+
+		if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/csv") {
+			t.Fatalf("Expected CSV export, got %d %s", rec.Code, rec.Header().Get("Content-Type"))
+		}
+		records, err := csv.NewReader(rec.Body).ReadAll()
+		if err != nil || len(records) != 2 {
+			t.Fatalf("Expected header and one dog, got %v (%v)", records, err)
+		}
+		if strings.Join(records[0], ",") != strings.Join(dogTransferColumns, ",") {
+			t.Errorf("Unexpected header %v", records[0])
+		}
+		dog := records[1]
+		if dog[0] != "T-1" || dog[1] != "'=Bello" || dog[5] != "dunkelblau" || dog[11] != "2" {
+			t.Errorf("Unexpected row %v", dog)
+		}
+
+		// The export can be imported again unchanged
+		importRec := httptest.NewRecorder()
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		w.WriteAll(records)
+		handler.ImportDogs(importRec, newDogImportRequest(t, "?dry_run=true", "hunde.csv", buf.String(), nil))
+		var result models.DogImportResult
+		json.Unmarshal(importRec.Body.Bytes(), &result)
+		if result.Updated != 1 || result.Rows[0].Name != "=Bello" {
+			t.Errorf("Expected the export to import as an update, got %+v", result)
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ExportDogs(rec, httptest.NewRequest("GET", "/api/admin/dogs/export?format=json", nil))
+
+		var records []models.DogImportRecord
+		json.Unmarshal(rec.Body.Bytes(), &records)
+		if rec.Code != http.StatusOK || len(records) != 1 {
+			t.Fatalf("Expected one dog, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if records[0].ShelterID == nil || *records[0].ShelterID != "T-1" || records[0].Color != "dunkelblau" || records[0].ColorID != nil {
+			t.Errorf("Expected shelter ID and color name, got %+v", records[0])
+		}
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ExportDogs(rec, httptest.NewRequest("GET", "/api/admin/dogs/export?format=xml", nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", rec.Code)
+		}
+	})
+}
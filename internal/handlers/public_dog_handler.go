@@ -0,0 +1,246 @@
+package handlers
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/tranmh/gassigeher/internal/config"
+	"github.com/tranmh/gassigeher/internal/models"
+	"github.com/tranmh/gassigeher/internal/repository"
+)
+
+// PublicDogHandler serves the public profile pages of adoptable dogs (no login required)
+type PublicDogHandler struct {
+	dogRepo   *repository.DogRepository
+	photoRepo *repository.DogPhotoRepository
+	config    *config.Config
+}
+
+// NewPublicDogHandler creates a new public dog handler
+func NewPublicDogHandler(db *sql.DB, cfg *config.Config) *PublicDogHandler {
+	return &PublicDogHandler{
+		dogRepo:   repository.NewDogRepository(db),
+		photoRepo: repository.NewDogPhotoRepository(db),
+		config:    cfg,
+	}
+}
+
+// GetPublicDog handles GET /api/public/dogs/{slug} - public profile of an adoptable dog
+func (h *PublicDogHandler) GetPublicDog(w http.ResponseWriter, r *http.Request) {
+	dog, ok := h.findPublicDog(r, func(status int, message string) {
+		respondError(w, status, message)
+	})
+	if !ok {
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dog)
+}
+
+// GetPublicDogPage handles GET /hunde/{slug} - server-rendered profile page with OpenGraph
+// and Twitter card tags, so links shared on social media show the dog's name and photo
+func (h *PublicDogHandler) GetPublicDogPage(w http.ResponseWriter, r *http.Request) {
+	dog, ok := h.findPublicDog(r, func(status int, message string) {
+		if status != http.StatusNotFound {
+			http.Error(w, message, status)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(status)
+		publicDogNotFoundTemplate.Execute(w, nil)
+	})
+	if !ok {
+		return
+	}
+
+	// Links with an outdated name part still work, but are redirected to the current slug
+	if mux.Vars(r)["slug"] != dog.Slug {
+		http.Redirect(w, r, "/hunde/"+dog.Slug, http.StatusMovedPermanently)
+		return
+	}
+
+	page := h.pageData(dog)
+	var buf bytes.Buffer
+	if err := publicDogPageTemplate.Execute(&buf, page); err != nil {
+		log.Printf("ERROR: Failed to render public page of dog %s: %v", dog.Slug, err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
+
+// findPublicDog loads the dog of the slug in the URL. Dogs that are not public or left the
+// shelter are reported as not found, so their existence is not revealed.
+func (h *PublicDogHandler) findPublicDog(r *http.Request, fail func(status int, message string)) (*models.PublicDog, bool) {
+	id, ok := models.ParseDogSlug(mux.Vars(r)["slug"])
+	if !ok {
+		fail(http.StatusNotFound, "Dog not found")
+		return nil, false
+	}
+
+	dog, err := h.dogRepo.FindByID(id)
+	if err != nil {
+		log.Printf("ERROR: Failed to load public dog %d: %v", id, err)
+		fail(http.StatusInternalServerError, "Failed to load dog")
+		return nil, false
+	}
+	if dog == nil || !dog.IsPubliclyVisible() {
+		fail(http.StatusNotFound, "Dog not found")
+		return nil, false
+	}
+
+	photos, err := h.photoRepo.FindByDog(id)
+	if err != nil {
+		log.Printf("ERROR: Failed to load photos of public dog %d: %v", id, err)
+		fail(http.StatusInternalServerError, "Failed to load dog")
+		return nil, false
+	}
+
+	return models.NewPublicDog(dog, photos), true
+}
+
+// publicDogPage is the data of the public profile page template
+type publicDogPage struct {
+	Dog         *models.PublicDog
+	URL         string // Absolute URL of the page
+	ImageURL    string // Absolute URL of the cover photo, empty without photo
+	Title       string
+	Description string
+	Size        string
+}
+
+// publicDogSizes are the German size labels of the public page
+var publicDogSizes = map[string]string{"small": "klein", "medium": "mittelgroß", "large": "groß"}
+
+// pageData builds the template data of a public dog page
+func (h *PublicDogHandler) pageData(dog *models.PublicDog) *publicDogPage {
+	baseURL := strings.TrimRight(h.config.BaseURL, "/")
+
+	page := &publicDogPage{
+		Dog:   dog,
+		URL:   baseURL + "/hunde/" + dog.Slug,
+		Title: fmt.Sprintf("%s (%s) sucht ein Zuhause", dog.Name, dog.Breed),
+		Size:  publicDogSizes[dog.Size],
+	}
+	if page.Size == "" {
+		page.Size = dog.Size
+	}
+
+	age := fmt.Sprintf("%d Jahre", dog.Age)
+	if dog.Age == 1 {
+		age = "1 Jahr"
+	}
+	page.Description = fmt.Sprintf("%s, %s, %s. Lerne %s im Tierheim kennen!",
+		dog.Breed, age, page.Size, dog.Name)
+
+	if dog.Photo != nil {
+		page.ImageURL = baseURL + "/uploads/" + *dog.Photo
+	}
+
+	return page
+}
+
+// publicDogPageTemplate renders the public profile page of a dog
+var publicDogPageTemplate = template.Must(template.New("public_dog").Parse(`<!DOCTYPE html>
+<html lang="de">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>{{.Title}} - Gassigeher</title>
+    <meta name="description" content="{{.Description}}">
+    <link rel="canonical" href="{{.URL}}">
+
+    <meta property="og:type" content="website">
+    <meta property="og:site_name" content="Gassigeher">
+    <meta property="og:locale" content="de_DE">
+    <meta property="og:title" content="{{.Title}}">
+    <meta property="og:description" content="{{.Description}}">
+    <meta property="og:url" content="{{.URL}}">
+    {{- if .ImageURL}}
+    <meta property="og:image" content="{{.ImageURL}}">
+    <meta property="og:image:alt" content="Foto von {{.Dog.Name}}">
+    {{- end}}
+
+    <meta name="twitter:card" content="{{if .ImageURL}}summary_large_image{{else}}summary{{end}}">
+    <meta name="twitter:title" content="{{.Title}}">
+    <meta name="twitter:description" content="{{.Description}}">
+    {{- if .ImageURL}}
+    <meta name="twitter:image" content="{{.ImageURL}}">
+    {{- end}}
+
+    <link rel="stylesheet" href="/assets/css/main.css">
+</head>
+<body>
+    <header>
+        <div class="container">
+            <a href="/" class="logo">🐕 Gassigeher</a>
+        </div>
+    </header>
+
+    <main style="padding: 40px 0;">
+        <div class="container-narrow">
+            <div class="card">
+                {{- if .Dog.Photo}}
+                <img src="/uploads/{{.Dog.Photo}}" alt="Foto von {{.Dog.Name}}" style="width: 100%; max-height: 480px; object-fit: cover; border-radius: 8px;">
+                {{- else}}
+                <img src="/assets/images/placeholders/dog-placeholder.svg" alt="" style="width: 100%; max-height: 320px; object-fit: contain;">
+                {{- end}}
+
+                <h1 style="margin-top: 20px;">{{.Dog.Name}}</h1>
+                <p><strong>Rasse:</strong> {{.Dog.Breed}}<br>
+                <strong>Alter:</strong> {{.Dog.Age}} {{if eq .Dog.Age 1}}Jahr{{else}}Jahre{{end}}<br>
+                <strong>Größe:</strong> {{.Size}}</p>
+
+                {{- if gt (len .Dog.Photos) 1}}
+                <div style="display: flex; gap: 10px; flex-wrap: wrap; margin: 20px 0;">
+                    {{- range .Dog.Photos}}
+                    <a href="/uploads/{{.PhotoPath}}"><img src="/uploads/{{.PhotoThumbnail}}" alt="Foto von {{$.Dog.Name}}" style="width: 120px; height: 120px; object-fit: cover; border-radius: 6px;"></a>
+                    {{- end}}
+                </div>
+                {{- end}}
+
+                {{- if .Dog.ExternalLink}}
+                <p><a class="btn" href="{{.Dog.ExternalLink}}" target="_blank" rel="noopener">Mehr über {{.Dog.Name}} auf der Tierheim-Website</a></p>
+                {{- end}}
+
+                <p style="margin-top: 20px;">Du möchtest mit {{.Dog.Name}} spazieren gehen?
+                <a href="/register.html">Werde Gassigeher!</a></p>
+            </div>
+        </div>
+    </main>
+</body>
+</html>
+`))
+
+// publicDogNotFoundTemplate is shown for dogs that do not exist or are not public
+var publicDogNotFoundTemplate = template.Must(template.New("public_dog_not_found").Parse(`<!DOCTYPE html>
+<html lang="de">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <meta name="robots" content="noindex">
+    <title>Hund nicht gefunden - Gassigeher</title>
+    <link rel="stylesheet" href="/assets/css/main.css">
+</head>
+<body>
+    <main style="padding: 40px 0;">
+        <div class="container-narrow">
+            <div class="card">
+                <h1>Hund nicht gefunden</h1>
+                <p>Diesen Hund gibt es nicht oder sein Profil ist nicht mehr öffentlich.</p>
+                <p><a class="btn" href="/">Zur Startseite</a></p>
+            </div>
+        </div>
+    </main>
+</body>
+</html>
+`))
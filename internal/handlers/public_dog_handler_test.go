@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/tranmh/gassigeher/internal/config"
+	"github.com/tranmh/gassigeher/internal/models"
+	"github.com/tranmh/gassigeher/internal/testutil"
+)
+
+// TestPublicDogHandler tests the public dog API and profile pages
+func TestPublicDogHandler(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	handler := NewPublicDogHandler(db, &config.Config{BaseURL: "https://gassi.example.com/"})
+
+	dogID := testutil.SeedTestDog(t, db, "Mücke", "Mischling", "green")
+	db.Exec(`UPDATE dogs SET is_public = 1, photo = 'dogs/muecke.jpg', external_link = 'https://tierheim.example.com/muecke',
+		special_instructions = 'Schlüssel im Büro', pickup_location = 'Zwinger 7', shelter_id = 'T-77' WHERE id = ?`, dogID)
+	db.Exec("INSERT INTO dog_photos (dog_id, photo_path, photo_thumbnail, display_order) VALUES (?, 'dogs/muecke.jpg', 'dogs/muecke_thumb.jpg', 0), (?, 'dogs/muecke2.jpg', 'dogs/muecke2_thumb.jpg', 1)", dogID, dogID)
+
+	privateID := testutil.SeedTestDog(t, db, "Rex", "Beagle", "green")
+	adoptedID := testutil.SeedTestDog(t, db, "Luna", "Pudel", "green")
+	db.Exec("UPDATE dogs SET is_public = 1, status = 'adopted' WHERE id = ?", adoptedID)
+	scriptID := testutil.SeedTestDog(t, db, `<script>alert(1)</script>`, `"Pudel"`, "green")
+	db.Exec("UPDATE dogs SET is_public = 1 WHERE id = ?", scriptID)
+
+	get := func(serve http.HandlerFunc, path, slug string) *httptest.ResponseRecorder {
+		req := mux.SetURLVars(httptest.NewRequest("GET", path+slug, nil), map[string]string{"slug": slug})
+		rec := httptest.NewRecorder()
+		serve(rec, req)
+		return rec
+	}
+	slug := models.DogSlug(&models.Dog{ID: dogID, Name: "Mücke"})
+
+	t.Run("JSON has only public fields", func(t *testing.T) {
+		rec := get(handler.GetPublicDog, "/api/public/dogs/", slug)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+
+		var dog map[string]interface{}
+		json.Unmarshal(rec.Body.Bytes(), &dog)
+		if dog["slug"] != "muecke-"+strconv.Itoa(dogID) || dog["name"] != "Mücke" || dog["external_link"] != "https://tierheim.example.com/muecke" {
+			t.Errorf("Unexpected dog %v", dog)
+		}
+		if photos, _ := dog["photos"].([]interface{}); len(photos) != 2 {
+			t.Errorf("Expected 2 photos, got %v", dog["photos"])
+		}
+		for _, internal := range []string{"Schlüssel", "Zwinger", "T-77", "special_instructions", "pickup_location", "is_public"} {
+			if strings.Contains(rec.Body.String(), internal) {
+				t.Errorf("Expected %q not to leak, got %s", internal, rec.Body.String())
+			}
+		}
+	})
+
+	t.Run("page has share metadata", func(t *testing.T) {
+		rec := get(handler.GetPublicDogPage, "/hunde/", slug)
+		if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/html") {
+			t.Fatalf("Expected HTML page, got %d: %s", rec.Code, rec.Body.String())
+		}
+
+		page := rec.Body.String()
+		for _, want := range []string{
+			`<meta property="og:title" content="Mücke (Mischling) sucht ein Zuhause">`,
+			`<meta property="og:url" content="https://gassi.example.com/hunde/` + slug + `">`,
+			`<meta property="og:image" content="https://gassi.example.com/uploads/dogs/muecke.jpg">`,
+			`<meta name="twitter:card" content="summary_large_image">`,
+			`<meta name="twitter:description" content="Mischling, 5 Jahre, mittelgroß. Lerne Mücke im Tierheim kennen!">`,
+			`src="/uploads/dogs/muecke2_thumb.jpg"`,
+			`href="https://tierheim.example.com/muecke"`,
+		} {
+			if !strings.Contains(page, want) {
+				t.Errorf("Expected page to contain %s", want)
+			}
+		}
+		for _, internal := range []string{"Schlüssel", "Zwinger", "T-77"} {
+			if strings.Contains(page, internal) {
+				t.Errorf("Expected %q not to leak", internal)
+			}
+		}
+	})
+
+	t.Run("page escapes dog data", func(t *testing.T) {
+		rec := get(handler.GetPublicDogPage, "/hunde/", "script-alert-1-script-"+strconv.Itoa(scriptID))
+		if rec.Code != http.StatusOK || strings.Contains(rec.Body.String(), "<script>") {
+			t.Errorf("Expected escaped page, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if !strings.Contains(rec.Body.String(), `<meta name="twitter:card" content="summary">`) {
+			t.Error("Expected a summary card without photo")
+		}
+	})
+
+	t.Run("outdated slug redirects", func(t *testing.T) {
+		rec := get(handler.GetPublicDogPage, "/hunde/", "alter-name-"+strconv.Itoa(dogID))
+		if rec.Code != http.StatusMovedPermanently || rec.Header().Get("Location") != "/hunde/"+slug {
+			t.Errorf("Expected redirect to %s, got %d %s", slug, rec.Code, rec.Header().Get("Location"))
+		}
+	})
+
+	t.Run("hidden dogs are not found", func(t *testing.T) {
+		for _, s := range []string{"rex-" + strconv.Itoa(privateID), "luna-" + strconv.Itoa(adoptedID), "unbekannt-9999", "bello"} {
+			if rec := get(handler.GetPublicDog, "/api/public/dogs/", s); rec.Code != http.StatusNotFound {
+				t.Errorf("%s: expected status 404, got %d", s, rec.Code)
+			}
+			rec := get(handler.GetPublicDogPage, "/hunde/", s)
+			if rec.Code != http.StatusNotFound || !strings.Contains(rec.Body.String(), "Hund nicht gefunden") {
+				t.Errorf("%s: expected not found page, got %d", s, rec.Code)
+			}
+		}
+	})
+}
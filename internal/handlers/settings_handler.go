@@ -77,6 +77,9 @@ func (h *SettingsHandler) UpdateSetting(w http.ResponseWriter, r *http.Request)
 		"booking_advance_days":      true,
 		"cancellation_notice_hours": true,
 		"auto_deactivation_days":    true,
+		"waitlist_claim_hours":      true,
+		"no_show_grace_minutes":     true,
+		"strike_window_days":        true,
 	}
 
 	if numericSettings[key] {
@@ -86,6 +89,31 @@ func (h *SettingsHandler) UpdateSetting(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
+	// These settings must be valid non-negative integers (0 disables them)
+	nonNegativeSettings := map[string]bool{
+		"booking_buffer_minutes":        true,
+		"strike_warning_threshold":      true,
+		"strike_restriction_threshold":  true,
+		"max_future_bookings":           true,
+		"max_bookings_per_day":          true,
+		"max_bookings_per_dog_per_week": true,
+	}
+
+	if nonNegativeSettings[key] {
+		if val, err := strconv.Atoi(req.Value); err != nil || val < 0 {
+			respondError(w, http.StatusBadRequest, "Value must be a non-negative integer")
+			return
+		}
+	}
+
+	// Release hour is an hour of the day (0 = days open at midnight)
+	if key == "booking_release_hour" {
+		if val, err := strconv.Atoi(req.Value); err != nil || val < 0 || val > 23 {
+			respondError(w, http.StatusBadRequest, "Value must be an hour between 0 and 23")
+			return
+		}
+	}
+
 	// Validate registration password format (8 alphanumeric characters)
 	if key == "registration_password" {
 		if !regexp.MustCompile(`^[a-zA-Z0-9]{8}$`).MatchString(req.Value) {
@@ -102,6 +130,13 @@ func (h *SettingsHandler) UpdateSetting(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
+	if key == "booking_transfer_requires_approval" {
+		if req.Value != "true" && req.Value != "false" {
+			respondError(w, http.StatusBadRequest, "Value must be 'true' or 'false'")
+			return
+		}
+	}
+
 	// Validate WhatsApp group link (must be valid WhatsApp URL or empty)
 	if key == "whatsapp_group_link" {
 		if req.Value != "" && !strings.HasPrefix(req.Value, "https://chat.whatsapp.com/") {
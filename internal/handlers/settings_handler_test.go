@@ -243,6 +243,21 @@ func TestSettingsHandler_UpdateSetting(t *testing.T) {
 			t.Errorf("BUGFIX: Expected status 400 for zero value, got %d", rec.Code)
 		}
 	})
+
+	t.Run("zero disables strike restrictions", func(t *testing.T) {
+		body, _ := json.Marshal(map[string]interface{}{"value": "0"})
+		req := httptest.NewRequest("PUT", "/api/settings/strike_restriction_threshold", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req = mux.SetURLVars(req, map[string]string{"key": "strike_restriction_threshold"})
+		req = req.WithContext(contextWithUser(req.Context(), adminID, "admin@example.com", true))
+
+		rec := httptest.NewRecorder()
+		handler.UpdateSetting(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("Expected status 200 for zero threshold, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+	})
 }
 
 // createTestJPEG creates a test JPEG image in memory
@@ -27,6 +27,7 @@ type UserHandler struct {
 	userColorRepo *repository.UserColorRepository
 	authService   *services.AuthService
 	emailService  *services.EmailService
+	strikeService *services.StrikeService
 	config        *config.Config
 }
 
@@ -37,12 +38,16 @@ func NewUserHandler(db *sql.DB, cfg *config.Config) *UserHandler {
 		println("Warning: Failed to initialize email service:", err.Error())
 	}
 
+	userRepo := repository.NewUserRepository(db)
+
 	return &UserHandler{
-		userRepo:      repository.NewUserRepository(db),
+		userRepo:      userRepo,
 		userColorRepo: repository.NewUserColorRepository(db),
 		authService:   services.NewAuthService(cfg.JWTSecret, cfg.JWTExpirationHours),
 		emailService:  emailService,
-		config:        cfg,
+		strikeService: services.NewStrikeService(repository.NewStrikeRepository(db), userRepo,
+			repository.NewSettingsRepository(db), emailService),
+		config: cfg,
 	}
 }
 
@@ -432,9 +437,51 @@ func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Strikes within the strike window, including forgiven ones
+	if h.strikeService != nil {
+		summary, err := h.strikeService.Summary(userID, time.Now())
+		if err != nil {
+			log.Printf("Warning: Failed to get user strikes: %v", err)
+		} else {
+			user.Strikes = summary
+		}
+	}
+
 	respondJSON(w, http.StatusOK, user)
 }
 
+// ForgiveStrike forgives one of a user's strikes (admin only)
+func (h *UserHandler) ForgiveStrike(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+	strikeID, err := strconv.Atoi(vars["strikeId"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid strike ID")
+		return
+	}
+
+	adminID, _ := r.Context().Value(middleware.UserIDKey).(int)
+
+	// Reason is optional
+	var req models.ForgiveStrikeRequest
+	json.NewDecoder(r.Body).Decode(&req)
+
+	if err := h.strikeService.Forgive(userID, strikeID, adminID, req.Reason); err != nil {
+		if err.Error() == "strike not found or already forgiven" {
+			respondError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to forgive strike")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Strike forgiven successfully"})
+}
+
 // DeactivateUser deactivates a user account (admin only)
 func (h *UserHandler) DeactivateUser(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from URL
@@ -1315,3 +1315,69 @@ func TestUserHandler_AdminDeleteUser(t *testing.T) {
 		}
 	})
 }
+
+// TestUserHandler_Strikes tests the strike summary in the user detail and forgiving strikes
+func TestUserHandler_Strikes(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	cfg := &config.Config{JWTSecret: "test-secret"}
+	handler := NewUserHandler(db, cfg)
+
+	userID := testutil.SeedTestUser(t, db, "walker@example.com", "Walker", "green")
+	otherID := testutil.SeedTestUser(t, db, "other@example.com", "Other", "green")
+	adminID := testutil.SeedTestUser(t, db, "admin@example.com", "Admin", "orange")
+
+	strikeRepo := repository.NewStrikeRepository(db)
+	strike := &models.UserStrike{UserID: userID, Reason: models.StrikeReasonNoShow}
+	if err := strikeRepo.Create(strike); err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+
+	forgive := func(forUser, strikeID int) *httptest.ResponseRecorder {
+		body := bytes.NewBufferString(`{"reason": "Krankheit"}`)
+		req := httptest.NewRequest("PUT", fmt.Sprintf("/api/users/%d/strikes/%d/forgive", forUser, strikeID), body)
+		req = mux.SetURLVars(req, map[string]string{"id": fmt.Sprintf("%d", forUser), "strikeId": fmt.Sprintf("%d", strikeID)})
+		req = req.WithContext(contextWithUser(req.Context(), adminID, "admin@example.com", true))
+		rec := httptest.NewRecorder()
+		handler.ForgiveStrike(rec, req)
+		return rec
+	}
+
+	t.Run("user detail includes strikes", func(t *testing.T) {
+		req := httptest.NewRequest("GET", fmt.Sprintf("/api/users/%d", userID), nil)
+		req = mux.SetURLVars(req, map[string]string{"id": fmt.Sprintf("%d", userID)})
+		req = req.WithContext(contextWithUser(req.Context(), adminID, "admin@example.com", true))
+		rec := httptest.NewRecorder()
+		handler.GetUser(rec, req)
+
+		var user models.User
+		json.Unmarshal(rec.Body.Bytes(), &user)
+		if user.Strikes == nil || user.Strikes.ActiveCount != 1 || len(user.Strikes.Strikes) != 1 {
+			t.Fatalf("Expected 1 active strike, got %+v", user.Strikes)
+		}
+		if user.Strikes.RestrictionThreshold != 3 || user.Strikes.WindowDays != 90 {
+			t.Errorf("Expected default thresholds, got %+v", user.Strikes)
+		}
+	})
+
+	t.Run("strike of another user", func(t *testing.T) {
+		if rec := forgive(otherID, strike.ID); rec.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404, got %d", rec.Code)
+		}
+	})
+
+	t.Run("forgive strike", func(t *testing.T) {
+		rec := forgive(userID, strike.ID)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		forgiven, _ := strikeRepo.FindByID(strike.ID)
+		if forgiven.IsActive() || forgiven.ForgivenBy == nil || *forgiven.ForgivenBy != adminID {
+			t.Errorf("Expected strike forgiven by admin, got %+v", forgiven)
+		}
+
+		if rec := forgive(userID, strike.ID); rec.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404 for already forgiven strike, got %d", rec.Code)
+		}
+	})
+}
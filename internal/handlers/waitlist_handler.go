@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/tranmh/gassigeher/internal/config"
+	"github.com/tranmh/gassigeher/internal/middleware"
+	"github.com/tranmh/gassigeher/internal/models"
+	"github.com/tranmh/gassigeher/internal/repository"
+	"github.com/tranmh/gassigeher/internal/services"
+)
+
+// WaitlistHandler handles booking waitlist HTTP requests
+type WaitlistHandler struct {
+	waitlistRepo    *repository.WaitlistRepository
+	bookingRepo     *repository.BookingRepository
+	dogRepo         *repository.DogRepository
+	userRepo        *repository.UserRepository
+	userColorRepo   *repository.UserColorRepository
+	settingsRepo    *repository.SettingsRepository
+	ruleRepo        *repository.DogWalkerRuleRepository
+	waitlistService *services.WaitlistService
+}
+
+// NewWaitlistHandler creates a new waitlist handler
+func NewWaitlistHandler(db *sql.DB, cfg *config.Config) *WaitlistHandler {
+	emailService, err := services.NewEmailService(services.ConfigToEmailConfig(cfg))
+	if err != nil {
+		fmt.Printf("Warning: Failed to initialize email service in WaitlistHandler: %v\n", err)
+	}
+
+	waitlistRepo := repository.NewWaitlistRepository(db)
+	bookingRepo := repository.NewBookingRepository(db)
+	dogRepo := repository.NewDogRepository(db)
+	userRepo := repository.NewUserRepository(db)
+	settingsRepo := repository.NewSettingsRepository(db)
+
+	return &WaitlistHandler{
+		waitlistRepo:  waitlistRepo,
+		bookingRepo:   bookingRepo,
+		dogRepo:       dogRepo,
+		userRepo:      userRepo,
+		userColorRepo: repository.NewUserColorRepository(db),
+		settingsRepo:  settingsRepo,
+		ruleRepo:      repository.NewDogWalkerRuleRepository(db),
+		waitlistService: services.NewWaitlistService(waitlistRepo, bookingRepo, userRepo, dogRepo,
+			settingsRepo, emailService),
+	}
+}
+
+// JoinWaitlist handles POST /api/waitlist - queue for a dog slot that is currently taken
+func (h *WaitlistHandler) JoinWaitlist(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(middleware.UserIDKey).(int)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req models.JoinWaitlistRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	user, err := h.userRepo.FindByID(userID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get user")
+		return
+	}
+	if user == nil {
+		respondError(w, http.StatusNotFound, "User not found")
+		return
+	}
+	if !user.IsActive {
+		respondError(w, http.StatusForbidden, "Your account is deactivated")
+		return
+	}
+
+	dog, err := h.dogRepo.FindByID(req.DogID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get dog")
+		return
+	}
+	if dog == nil {
+		respondError(w, http.StatusNotFound, "Dog not found")
+		return
+	}
+	if !dog.IsBookableOn(req.Date) {
+		respondError(w, http.StatusBadRequest, "Dog is currently unavailable")
+		return
+	}
+
+	// Only users who could book the dog may queue for it (admins and super admins bypass this check)
+	if !user.IsAdmin && !user.IsSuperAdmin {
+		userColorIDs, err := h.userColorRepo.GetUserColorIDs(userID)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to check user permissions")
+			return
+		}
+
+		dogColorID := 0
+		if dog.ColorID != nil {
+			dogColorID = *dog.ColorID
+		}
+
+		if !repository.CanUserAccessDogByColor(userColorIDs, dogColorID) {
+			respondError(w, http.StatusForbidden, "Du hast nicht die erforderliche Farbkategorie für diesen Hund")
+			return
+		}
+	}
+
+	// Per-dog allowlists and denylists apply in addition to the colors
+	if !user.IsAdmin && !user.IsSuperAdmin && rejectIfWalkerRuleForbids(w, h.ruleRepo, dog.ID, userID, req.Date) {
+		return
+	}
+
+	// Date must lie between today and the booking horizon (UTC, like CreateBooking)
+	date, _ := time.Parse("2006-01-02", req.Date)
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	if date.Before(today) {
+		respondError(w, http.StatusBadRequest, "Cannot book dates in the past")
+		return
+	}
+	advanceDays := h.settingsRepo.GetInt("booking_advance_days", 14)
+	if date.After(today.AddDate(0, 0, advanceDays)) {
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("Cannot book more than %d days in advance", advanceDays))
+		return
+	}
+
+	// The waitlist is only for taken slots; free slots are booked directly
+	conflict, err := h.bookingRepo.FindConflictingBooking(dog.ID, req.Date, req.ScheduledTime, dog.GetWalkDuration(), h.settingsRepo.GetInt("booking_buffer_minutes", 0), 0)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to check availability")
+		return
+	}
+	if conflict == nil {
+		respondError(w, http.StatusBadRequest, "Dieser Termin ist frei und kann direkt gebucht werden")
+		return
+	}
+
+	entry := &models.WaitlistEntry{
+		UserID:        userID,
+		DogID:         dog.ID,
+		Date:          req.Date,
+		ScheduledTime: req.ScheduledTime,
+		DogName:       &dog.Name,
+	}
+
+	if err := h.waitlistRepo.Create(entry); err != nil {
+		if err.Error() == "already on waitlist" {
+			respondError(w, http.StatusConflict, "Du stehst bereits auf der Warteliste für diesen Termin")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to join waitlist")
+		return
+	}
+
+	h.userRepo.UpdateLastActivity(userID)
+
+	respondJSON(w, http.StatusCreated, entry)
+}
+
+// ListWaitlist handles GET /api/waitlist - list the current user's waitlist entries
+func (h *WaitlistHandler) ListWaitlist(w http.ResponseWriter, r *http.Request) {
+	userID, _ := r.Context().Value(middleware.UserIDKey).(int)
+
+	entries, err := h.waitlistRepo.FindByUser(userID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get waitlist")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, entries)
+}
+
+// LeaveWaitlist handles DELETE /api/waitlist/:id - leave a waitlist.
+// Leaving with an open offer passes the slot on to the next user in line.
+func (h *WaitlistHandler) LeaveWaitlist(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid waitlist entry ID")
+		return
+	}
+
+	userID, _ := r.Context().Value(middleware.UserIDKey).(int)
+
+	entry, err := h.waitlistRepo.FindByID(id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get waitlist entry")
+		return
+	}
+	if entry == nil || entry.UserID != userID {
+		respondError(w, http.StatusNotFound, "Waitlist entry not found")
+		return
+	}
+
+	if entry.Status != "waiting" && entry.Status != "offered" {
+		respondError(w, http.StatusBadRequest, "Waitlist entry is already "+entry.Status)
+		return
+	}
+
+	if err := h.waitlistRepo.UpdateStatus(id, "cancelled"); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to leave waitlist")
+		return
+	}
+
+	if entry.Status == "offered" {
+		if _, err := h.waitlistService.OfferSlot(entry.DogID, entry.Date, entry.ScheduledTime, time.Now()); err != nil {
+			log.Printf("Failed to offer waitlist slot to next user: %v", err)
+		}
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Left waitlist successfully"})
+}
@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/tranmh/gassigeher/internal/config"
+	"github.com/tranmh/gassigeher/internal/models"
+	"github.com/tranmh/gassigeher/internal/repository"
+	"github.com/tranmh/gassigeher/internal/testutil"
+)
+
+// TestWaitlistHandler tests joining a waitlist and claiming the slot after a cancellation
+func TestWaitlistHandler(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	db.Exec("UPDATE system_settings SET value = 'false' WHERE key = 'use_feiertage_api'")
+
+	cfg := &config.Config{
+		JWTSecret:          "test-secret",
+		JWTExpirationHours: 24,
+	}
+	handler := NewWaitlistHandler(db, cfg)
+	bookingHandler := NewBookingHandler(db, cfg)
+
+	ownerID := testutil.SeedTestUser(t, db, "owner@example.com", "Owner", "green")
+	waiterID := testutil.SeedTestUser(t, db, "waiter@example.com", "Waiter", "green")
+	otherID := testutil.SeedTestUser(t, db, "other@example.com", "Other", "green")
+	adminID := testutil.SeedTestUser(t, db, "admin@example.com", "Admin", "orange")
+	dogID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+
+	tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+
+	join := func(userID int) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(map[string]interface{}{
+			"dog_id":         dogID,
+			"date":           tomorrow,
+			"scheduled_time": "09:00",
+		})
+		req := httptest.NewRequest("POST", "/api/waitlist", bytes.NewReader(body))
+		req = req.WithContext(contextWithUser(req.Context(), userID, "", false))
+		rec := httptest.NewRecorder()
+		handler.JoinWaitlist(rec, req)
+		return rec
+	}
+
+	book := func(userID int) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(map[string]interface{}{
+			"dog_id":         dogID,
+			"date":           tomorrow,
+			"scheduled_time": "09:00",
+		})
+		req := httptest.NewRequest("POST", "/api/bookings", bytes.NewReader(body))
+		req = req.WithContext(contextWithUser(req.Context(), userID, "", false))
+		rec := httptest.NewRecorder()
+		bookingHandler.CreateBooking(rec, req)
+		return rec
+	}
+
+	t.Run("free slot cannot be joined", func(t *testing.T) {
+		rec := join(waiterID)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	bookingID := testutil.SeedTestBooking(t, db, ownerID, dogID, tomorrow, "09:00", "scheduled")
+
+	var entry models.WaitlistEntry
+	t.Run("join taken slot", func(t *testing.T) {
+		rec := join(waiterID)
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("Expected status 201, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+		json.Unmarshal(rec.Body.Bytes(), &entry)
+		if entry.ID == 0 || entry.Status != "waiting" {
+			t.Errorf("Unexpected waitlist entry: %+v", entry)
+		}
+
+		rec = join(waiterID)
+		if rec.Code != http.StatusConflict {
+			t.Errorf("Expected status 409 for duplicate entry, got %d", rec.Code)
+		}
+	})
+
+	t.Run("list own entries", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/waitlist", nil)
+		req = req.WithContext(contextWithUser(req.Context(), waiterID, "", false))
+		rec := httptest.NewRecorder()
+		handler.ListWaitlist(rec, req)
+
+		var entries []models.WaitlistEntry
+		json.Unmarshal(rec.Body.Bytes(), &entries)
+		if rec.Code != http.StatusOK || len(entries) != 1 {
+			t.Errorf("Expected 1 entry, got %d (status %d)", len(entries), rec.Code)
+		}
+	})
+
+	t.Run("cancellation reserves slot for the waitlisted user", func(t *testing.T) {
+		body, _ := json.Marshal(map[string]string{"reason": "Hund krank"})
+		req := httptest.NewRequest("PUT", fmt.Sprintf("/api/bookings/%d/cancel", bookingID), bytes.NewReader(body))
+		req = mux.SetURLVars(req, map[string]string{"id": fmt.Sprintf("%d", bookingID)})
+		req = req.WithContext(contextWithUser(req.Context(), adminID, "", true))
+		rec := httptest.NewRecorder()
+		bookingHandler.CancelBooking(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		if rec := book(otherID); rec.Code != http.StatusConflict {
+			t.Errorf("Expected status 409 for a reserved slot, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		if rec := book(waiterID); rec.Code != http.StatusCreated {
+			t.Fatalf("Expected waitlisted user to book the slot, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		var status string
+		db.QueryRow("SELECT status FROM booking_waitlist WHERE id = ?", entry.ID).Scan(&status)
+		if status != "claimed" {
+			t.Errorf("Expected entry to be claimed, got %s", status)
+		}
+	})
+
+	t.Run("series cancellation offers the freed slots", func(t *testing.T) {
+		nextWeek := time.Now().AddDate(0, 0, 7).Format("2006-01-02")
+		series := &models.BookingSeries{UserID: ownerID, DogID: dogID, ScheduledTime: "15:00", IntervalWeeks: 1,
+			StartDate: nextWeek, LastGeneratedDate: nextWeek}
+		if err := repository.NewBookingSeriesRepository(db).Create(series); err != nil {
+			t.Fatalf("Failed to create series: %v", err)
+		}
+		seriesBookingID := testutil.SeedTestBooking(t, db, ownerID, dogID, nextWeek, "15:00", "scheduled")
+		db.Exec("UPDATE bookings SET series_id = ? WHERE id = ?", series.ID, seriesBookingID)
+
+		seriesEntry := &models.WaitlistEntry{UserID: waiterID, DogID: dogID, Date: nextWeek, ScheduledTime: "15:00"}
+		if err := repository.NewWaitlistRepository(db).Create(seriesEntry); err != nil {
+			t.Fatalf("Failed to create waitlist entry: %v", err)
+		}
+
+		req := httptest.NewRequest("PUT", fmt.Sprintf("/api/bookings/series/%d/cancel", series.ID), nil)
+		req = mux.SetURLVars(req, map[string]string{"id": fmt.Sprintf("%d", series.ID)})
+		req = req.WithContext(contextWithUser(req.Context(), adminID, "", true))
+		rec := httptest.NewRecorder()
+		NewBookingSeriesHandler(db, cfg).CancelSeries(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+
+		var status string
+		db.QueryRow("SELECT status FROM booking_waitlist WHERE id = ?", seriesEntry.ID).Scan(&status)
+		if status != "offered" {
+			t.Errorf("Expected entry to be offered, got %s", status)
+		}
+	})
+
+	t.Run("leave waitlist", func(t *testing.T) {
+		rec := join(otherID)
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("Expected status 201, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+		var other models.WaitlistEntry
+		json.Unmarshal(rec.Body.Bytes(), &other)
+
+		req := httptest.NewRequest("DELETE", fmt.Sprintf("/api/waitlist/%d", other.ID), nil)
+		req = mux.SetURLVars(req, map[string]string{"id": fmt.Sprintf("%d", other.ID)})
+		req = req.WithContext(contextWithUser(req.Context(), waiterID, "", false))
+		rec = httptest.NewRecorder()
+		handler.LeaveWaitlist(rec, req)
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404 for another user's entry, got %d", rec.Code)
+		}
+
+		req = mux.SetURLVars(req, map[string]string{"id": fmt.Sprintf("%d", other.ID)})
+		req = req.WithContext(contextWithUser(req.Context(), otherID, "", false))
+		rec = httptest.NewRecorder()
+		handler.LeaveWaitlist(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Errorf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+		}
+	})
+}
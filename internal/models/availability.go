@@ -0,0 +1,13 @@
+package models
+
+// DogAvailability lists the free start times of one dog on a day
+type DogAvailability struct {
+	DogID          int      `json:"dog_id"`
+	DogName        string   `json:"dog_name"`
+	Breed          string   `json:"breed"`
+	Size           string   `json:"size"`
+	ColorID        *int     `json:"color_id,omitempty"`
+	PhotoThumbnail *string  `json:"photo_thumbnail,omitempty"`
+	WalkDuration   int      `json:"walk_duration"` // minutes
+	FreeSlots      []string `json:"free_slots"`    // HH:MM start times
+}
@@ -17,9 +17,11 @@ type BlockedDate struct {
 
 // CreateBlockedDateRequest represents a request to block a date
 type CreateBlockedDateRequest struct {
-	Date   string `json:"date"`
-	DogID  *int   `json:"dog_id,omitempty"` // Optional: NULL means all dogs
-	Reason string `json:"reason"`
+	Date         string `json:"date"`
+	DogID        *int   `json:"dog_id,omitempty"` // Optional: NULL means all dogs
+	Reason       string `json:"reason"`
+	KeepBookings bool   `json:"keep_bookings,omitempty"` // Block the date without cancelling existing bookings
+	DryRun       bool   `json:"dry_run,omitempty"`       // Only list the affected bookings, change nothing
 }
 
 // Validate validates the create blocked date request
@@ -1,6 +1,9 @@
 package models
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // Booking represents a dog walking booking
 type Booking struct {
@@ -14,6 +17,9 @@ type Booking struct {
 	ReminderSentAt          *time.Time `json:"reminder_sent_at,omitempty"`
 	UserNotes               *string    `json:"user_notes,omitempty"`
 	AdminCancellationReason *string    `json:"admin_cancellation_reason,omitempty"`
+	SeriesID                *int       `json:"series_id,omitempty"` // Set for occurrences of a recurring series
+	CheckedInAt             *time.Time `json:"checked_in_at,omitempty"`  // Actual walk start
+	CheckedOutAt            *time.Time `json:"checked_out_at,omitempty"` // Actual walk end
 	CreatedAt               time.Time  `json:"created_at"`
 	UpdatedAt               time.Time  `json:"updated_at"`
 
@@ -27,8 +33,15 @@ type Booking struct {
 	// Joined data for responses
 	User *User `json:"user,omitempty"`
 	Dog  *Dog  `json:"dog,omitempty"`
+
+	// Medication doses due during the walk (booking details only)
+	MedicationDue []MedicationDose `json:"medication_due,omitempty"`
 }
 
+// BookingApprovalExpiredReason is the rejection reason of bookings that were still waiting for
+// approval when their walk was due
+const BookingApprovalExpiredReason = "Die Buchung wurde bis zum Termin nicht bestätigt"
+
 // CreateBookingRequest represents a request to create a booking
 type CreateBookingRequest struct {
 	DogID         int    `json:"dog_id"`
@@ -38,7 +51,8 @@ type CreateBookingRequest struct {
 
 // CancelBookingRequest represents a request to cancel a booking
 type CancelBookingRequest struct {
-	Reason *string `json:"reason,omitempty"` // Optional for users, required for admins
+	Reason       *string `json:"reason,omitempty"`        // Optional for users, required for admins
+	AcceptStrike bool    `json:"accept_strike,omitempty"` // Cancel within the notice period and take a strike
 }
 
 // AddNotesRequest represents a request to add notes to a completed booking
@@ -89,6 +103,97 @@ type BookingFilterRequest struct {
 	Month    *int    `json:"month,omitempty"`
 }
 
+// BookingExportRow is one row of the admin booking export
+type BookingExportRow struct {
+	BookingID          int
+	Date               string
+	ScheduledTime      string
+	DogName            string
+	WalkerName         string
+	Status             string
+	ApprovalStatus     string
+	ApprovedBy         *string // Name of the admin who approved or rejected
+	ApprovedAt         *time.Time
+	RejectionReason    *string
+	CancellationReason *string
+	HasWalkReport      bool
+}
+
+// BookingConflict describes an existing booking that overlaps a requested time slot
+type BookingConflict struct {
+	BookingID     int    `json:"booking_id"`
+	DogID         int    `json:"dog_id"`
+	DogName       string `json:"dog_name"`
+	Date          string `json:"date"`
+	ScheduledTime string `json:"scheduled_time"` // HH:MM start of the clashing walk
+	EndTime       string `json:"end_time"`       // HH:MM end of the clashing walk (without buffer)
+	NextFreeTime  string `json:"next_free_time"` // HH:MM earliest start after the clashing walk and buffer
+}
+
+// Booking quota violation reasons
+const (
+	QuotaMaxFutureBookings     = "max_future_bookings"
+	QuotaMaxBookingsPerDay     = "max_bookings_per_day"
+	QuotaMaxBookingsPerDogWeek = "max_bookings_per_dog_per_week"
+	QuotaDateNotYetReleased    = "date_not_yet_released"
+)
+
+// BookingQuotaViolation describes the per-user booking limit a booking request exceeds
+type BookingQuotaViolation struct {
+	Reason    string     `json:"reason"`               // One of the Quota* constants
+	Limit     int        `json:"limit,omitempty"`      // Configured limit
+	Current   int        `json:"current,omitempty"`    // Bookings the user already has that count against the limit
+	ReleaseAt *time.Time `json:"release_at,omitempty"` // When the requested date becomes bookable
+}
+
+// Message explains the exceeded limit to the walker
+func (v *BookingQuotaViolation) Message() string {
+	switch v.Reason {
+	case QuotaMaxFutureBookings:
+		return fmt.Sprintf("Du kannst höchstens %d zukünftige Buchungen gleichzeitig haben", v.Limit)
+	case QuotaMaxBookingsPerDay:
+		return fmt.Sprintf("Du kannst höchstens %d Spaziergänge pro Tag buchen", v.Limit)
+	case QuotaMaxBookingsPerDogWeek:
+		return fmt.Sprintf("Du kannst diesen Hund höchstens %d Mal pro Woche buchen", v.Limit)
+	case QuotaDateNotYetReleased:
+		return fmt.Sprintf("Dieser Tag kann erst ab %s Uhr gebucht werden", v.ReleaseAt.Format("15:04"))
+	default:
+		return "Buchungslimit erreicht"
+	}
+}
+
+// BookingIntervalsOverlap reports whether two walks on the same day overlap.
+// Each walk occupies [start, start+duration+buffer) in minutes, so the buffer
+// keeps a gap between consecutive walks.
+func BookingIntervalsOverlap(startA string, durationA int, startB string, durationB int, bufferMinutes int) bool {
+	a, errA := minutesOfDay(startA)
+	b, errB := minutesOfDay(startB)
+	if errA != nil || errB != nil {
+		// Fall back to exact match if a time cannot be parsed
+		return startA == startB
+	}
+
+	return a < b+durationB+bufferMinutes && b < a+durationA+bufferMinutes
+}
+
+// AddMinutesToTime adds minutes to an HH:MM time and returns HH:MM
+func AddMinutesToTime(hhmm string, minutes int) string {
+	t, err := time.Parse("15:04", hhmm)
+	if err != nil {
+		return hhmm
+	}
+	return t.Add(time.Duration(minutes) * time.Minute).Format("15:04")
+}
+
+// minutesOfDay converts an HH:MM time to minutes since midnight
+func minutesOfDay(hhmm string) (int, error) {
+	t, err := time.Parse("15:04", hhmm)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
 // CalendarDay represents a day in the calendar with bookings
 type CalendarDay struct {
 	Date     string     `json:"date"`
@@ -103,6 +208,7 @@ type CalendarResponse struct {
 	Month        int            `json:"month"`
 	Days         []*CalendarDay `json:"days"`
 	BlockedDates []*BlockedDate `json:"blocked_dates,omitempty"` // All blocked dates including dog-specific
+	DogsOut      []*Booking     `json:"dogs_out,omitempty"`      // Admin only: walks checked in but not checked out
 }
 
 // Validate validates the create booking request
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// Booking event types
+const (
+	BookingEventCreated     = "created"
+	BookingEventApproved    = "approved"
+	BookingEventRejected    = "rejected"
+	BookingEventMoved       = "moved"
+	BookingEventCancelled   = "cancelled"
+	BookingEventNotes       = "notes"
+	BookingEventCompleted   = "completed"
+	BookingEventNoShow      = "no_show"
+	BookingEventTransferred = "transferred"
+)
+
+// BookingEvent is one entry of a booking's change history.
+// Values depend on the type: "YYYY-MM-DD HH:MM" for created and moved, the reason for
+// cancelled and rejected, the notes for notes and the walker's user ID for transferred.
+type BookingEvent struct {
+	ID        int       `json:"id"`
+	BookingID int       `json:"booking_id"`
+	EventType string    `json:"event_type"`
+	ActorID   *int      `json:"actor_id,omitempty"` // nil for changes made by the system
+	OldValue  *string   `json:"old_value,omitempty"`
+	NewValue  *string   `json:"new_value,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Joined data for responses
+	ActorName *string `json:"actor_name,omitempty"`
+}
+
+// BookingEventSlot formats a booking's date and time as stored in its history
+func BookingEventSlot(date, scheduledTime string) string {
+	return date + " " + scheduledTime
+}
@@ -0,0 +1,73 @@
+package models
+
+import "time"
+
+// BookingSeries represents a weekly or bi-weekly recurring booking of one dog by one user
+// Occurrences are created as regular bookings (with SeriesID set) up to the booking_advance_days horizon
+type BookingSeries struct {
+	ID                int       `json:"id"`
+	UserID            int       `json:"user_id"`
+	DogID             int       `json:"dog_id"`
+	ScheduledTime     string    `json:"scheduled_time"`      // HH:MM format
+	IntervalWeeks     int       `json:"interval_weeks"`      // 1 = weekly, 2 = bi-weekly
+	StartDate         string    `json:"start_date"`          // YYYY-MM-DD, first occurrence
+	LastGeneratedDate string    `json:"last_generated_date"` // YYYY-MM-DD, last occurrence already processed
+	IsActive          bool      `json:"is_active"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+
+	// Joined data for responses
+	Dog *Dog `json:"dog,omitempty"`
+}
+
+// CreateBookingSeriesRequest represents a request to create a recurring booking series
+type CreateBookingSeriesRequest struct {
+	DogID         int    `json:"dog_id"`
+	StartDate     string `json:"start_date"`     // YYYY-MM-DD
+	ScheduledTime string `json:"scheduled_time"` // HH:MM
+	IntervalWeeks int    `json:"interval_weeks"` // 1 = weekly, 2 = bi-weekly
+}
+
+// Validate validates the create booking series request
+func (r *CreateBookingSeriesRequest) Validate() error {
+	if r.DogID <= 0 {
+		return &ValidationError{Field: "dog_id", Message: "Dog ID is required"}
+	}
+
+	if r.StartDate == "" {
+		return &ValidationError{Field: "start_date", Message: "Start date is required"}
+	}
+
+	if _, err := time.Parse("2006-01-02", r.StartDate); err != nil {
+		return &ValidationError{Field: "start_date", Message: "Start date must be in YYYY-MM-DD format"}
+	}
+
+	if r.ScheduledTime == "" {
+		return &ValidationError{Field: "scheduled_time", Message: "Scheduled time is required"}
+	}
+
+	if _, err := time.Parse("15:04", r.ScheduledTime); err != nil {
+		return &ValidationError{Field: "scheduled_time", Message: "Scheduled time must be in HH:MM format"}
+	}
+
+	if r.IntervalWeeks != 1 && r.IntervalWeeks != 2 {
+		return &ValidationError{Field: "interval_weeks", Message: "Interval must be 1 (weekly) or 2 (bi-weekly)"}
+	}
+
+	return nil
+}
+
+// SkippedOccurrence describes a series date for which no booking was created
+type SkippedOccurrence struct {
+	Date   string `json:"date"`
+	Reason string `json:"reason"`
+}
+
+// BookingSeriesResult is returned after creating or extending a series
+type BookingSeriesResult struct {
+	Series   *BookingSeries      `json:"series"`
+	Bookings []*Booking          `json:"bookings"`
+	Skipped  []SkippedOccurrence `json:"skipped"`
+	// PausedReason says why nothing was booked because the walker may not book the dog right now
+	PausedReason string `json:"paused_reason,omitempty"`
+}
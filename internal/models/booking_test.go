@@ -209,3 +209,49 @@ func TestMoveBookingRequest_Validate(t *testing.T) {
 		})
 	}
 }
+
+// TestBookingIntervalsOverlap tests duration-aware overlap detection
+func TestBookingIntervalsOverlap(t *testing.T) {
+	tests := []struct {
+		name      string
+		startA    string
+		durationA int
+		startB    string
+		durationB int
+		buffer    int
+		want      bool
+	}{
+		{"Same start", "10:00", 60, "10:00", 60, 0, true},
+		{"Starts inside existing walk", "10:15", 60, "10:00", 60, 0, true},
+		{"Ends inside existing walk", "09:30", 60, "10:00", 60, 0, true},
+		{"Back to back without buffer", "11:00", 60, "10:00", 60, 0, false},
+		{"Back to back with buffer", "11:00", 60, "10:00", 60, 15, true},
+		{"After buffer", "11:15", 60, "10:00", 60, 15, false},
+		{"Short walk before", "09:00", 30, "10:00", 60, 0, false},
+		{"Different durations", "10:30", 30, "10:00", 45, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BookingIntervalsOverlap(tt.startA, tt.durationA, tt.startB, tt.durationB, tt.buffer)
+			if got != tt.want {
+				t.Errorf("BookingIntervalsOverlap(%s+%d, %s+%d, buffer %d) = %v, want %v",
+					tt.startA, tt.durationA, tt.startB, tt.durationB, tt.buffer, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestDog_GetWalkDuration tests the walk duration fallback
+func TestDog_GetWalkDuration(t *testing.T) {
+	dog := &Dog{}
+	if got := dog.GetWalkDuration(); got != DefaultWalkDuration {
+		t.Errorf("Expected default duration %d, got %d", DefaultWalkDuration, got)
+	}
+
+	duration := 45
+	dog.WalkDuration = &duration
+	if got := dog.GetWalkDuration(); got != 45 {
+		t.Errorf("Expected duration 45, got %d", got)
+	}
+}
@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// Booking transfer statuses
+const (
+	TransferStatusOffered         = "offered"
+	TransferStatusPendingApproval = "pending_approval"
+	TransferStatusCompleted       = "completed"
+	TransferStatusWithdrawn       = "withdrawn"
+	TransferStatusRejected        = "rejected"
+)
+
+// BookingTransfer represents handing a booking over to another walker
+// Status flow: offered -> completed, or offered -> pending_approval -> completed/rejected
+// when booking_transfer_requires_approval is set. The owner can withdraw an open offer.
+type BookingTransfer struct {
+	ID         int        `json:"id"`
+	BookingID  int        `json:"booking_id"`
+	FromUserID int        `json:"from_user_id"`
+	ToUserID   *int       `json:"to_user_id,omitempty"` // Set once someone accepts
+	Status     string     `json:"status"`
+	Note       *string    `json:"note,omitempty"`
+	AcceptedAt *time.Time `json:"accepted_at,omitempty"`
+	DecidedBy  *int       `json:"decided_by,omitempty"` // Admin who approved or rejected
+	DecidedAt  *time.Time `json:"decided_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+
+	// Joined data for responses
+	DogID         int     `json:"dog_id"`
+	DogName       string  `json:"dog_name"`
+	Date          string  `json:"date"`
+	ScheduledTime string  `json:"scheduled_time"`
+	FromUserName  string  `json:"from_user_name"`
+	ToUserName    *string `json:"to_user_name,omitempty"`
+}
+
+// IsOpen reports whether the transfer still waits for a walker or an admin decision
+func (t *BookingTransfer) IsOpen() bool {
+	return t.Status == TransferStatusOffered || t.Status == TransferStatusPendingApproval
+}
+
+// OfferTransferRequest represents a request to offer a booking to other walkers
+type OfferTransferRequest struct {
+	Note *string `json:"note,omitempty"`
+}
@@ -11,6 +11,9 @@ type DashboardStats struct {
 	UnavailableDogs       int `json:"unavailable_dogs"`
 	PendingExperienceReqs int `json:"pending_experience_requests"`
 	PendingReactivationReqs int `json:"pending_reactivation_requests"`
+
+	// NeedsAttention lists the dogs below their weekly walk target, longest without a walk first
+	NeedsAttention []*UnderWalkedDog `json:"needs_attention"`
 }
 
 // ActivityItem represents a recent activity item
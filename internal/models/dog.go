@@ -1,9 +1,13 @@
 package models
 
 import (
+	"strings"
 	"time"
 )
 
+// DefaultWalkDuration is the walk length in minutes assumed for dogs without a WalkDuration
+const DefaultWalkDuration = 60
+
 // Dog represents a dog in the system
 type Dog struct {
 	ID                   int            `json:"id"`
@@ -16,22 +20,58 @@ type Dog struct {
 	Color                *ColorCategory `json:"color,omitempty"`
 	Photo                *string        `json:"photo,omitempty"`
 	PhotoThumbnail       *string        `json:"photo_thumbnail,omitempty"`
+	Photos               []DogPhoto     `json:"photos,omitempty"` // Gallery, only loaded for single dogs
 	SpecialNeeds         *string        `json:"special_needs,omitempty"`
 	PickupLocation       *string        `json:"pickup_location,omitempty"`
 	WalkRoute            *string        `json:"walk_route,omitempty"`
 	WalkDuration         *int           `json:"walk_duration,omitempty"` // minutes
+	MaxWalksPerDay       *int           `json:"max_walks_per_day,omitempty"` // nil = no limit
+	MinRestMinutes       *int           `json:"min_rest_minutes,omitempty"`  // Rest between the end of one walk and the start of the next, nil = none
+	TargetWalksPerWeek   *int           `json:"target_walks_per_week,omitempty"` // Completed walks wanted per 7 days, nil = no target
 	SpecialInstructions  *string        `json:"special_instructions,omitempty"`
 	DefaultMorningTime   *string        `json:"default_morning_time,omitempty"` // HH:MM format
 	DefaultEveningTime   *string        `json:"default_evening_time,omitempty"` // HH:MM format
 	IsAvailable          bool           `json:"is_available"`
 	IsFeatured           bool           `json:"is_featured"`
+	IsPublic             bool           `json:"is_public"` // Has a public profile page at /hunde/{slug}
 	ExternalLink         *string        `json:"external_link,omitempty"`
+	ShelterID            *string        `json:"shelter_id,omitempty"` // ID in the shelter's own records, used by bulk import
 	UnavailableReason    *string        `json:"unavailable_reason,omitempty"`
 	UnavailableSince     *time.Time     `json:"unavailable_since,omitempty"`
+	UnavailableFrom      *string        `json:"unavailable_from,omitempty"`  // YYYY-MM-DD first day of a scheduled unavailability
+	UnavailableUntil     *string        `json:"unavailable_until,omitempty"` // YYYY-MM-DD last unavailable day, nil = until made available by hand
+	Status               string         `json:"status"`                // in_shelter, reserved, adopted, deceased, transferred
+	StatusDate           *string        `json:"status_date,omitempty"` // YYYY-MM-DD of the last status change
 	CreatedAt            time.Time      `json:"created_at"`
 	UpdatedAt            time.Time      `json:"updated_at"`
 }
 
+// GetWalkDuration returns the dog's walk duration in minutes, falling back to DefaultWalkDuration
+func (d *Dog) GetWalkDuration() int {
+	if d.WalkDuration != nil && *d.WalkDuration > 0 {
+		return *d.WalkDuration
+	}
+	return DefaultWalkDuration
+}
+
+// IsUnavailableOn reports whether date (YYYY-MM-DD) falls into the dog's scheduled unavailability
+func (d *Dog) IsUnavailableOn(date string) bool {
+	return d.UnavailableFrom != nil && date >= *d.UnavailableFrom &&
+		(d.UnavailableUntil == nil || date <= *d.UnavailableUntil)
+}
+
+// IsBookableOn reports whether the dog can be booked for date (YYYY-MM-DD). A dog that is
+// unavailable with an end date can already be booked for the days after its window.
+func (d *Dog) IsBookableOn(date string) bool {
+	if d.IsArchived() || d.IsUnavailableOn(date) {
+		return false
+	}
+	if !d.IsAvailable {
+		return d.UnavailableUntil != nil && date > *d.UnavailableUntil
+	}
+	return true
+}
+
 // CreateDogRequest represents the request to create a dog
 type CreateDogRequest struct {
 	Name                string  `json:"name"`
@@ -44,10 +84,14 @@ type CreateDogRequest struct {
 	PickupLocation      *string `json:"pickup_location,omitempty"`
 	WalkRoute           *string `json:"walk_route,omitempty"`
 	WalkDuration        *int    `json:"walk_duration,omitempty"`
+	MaxWalksPerDay      *int    `json:"max_walks_per_day,omitempty"` // 0 = no limit
+	MinRestMinutes      *int    `json:"min_rest_minutes,omitempty"`  // 0 = no rest required
+	TargetWalksPerWeek  *int    `json:"target_walks_per_week,omitempty"` // 0 = no target
 	SpecialInstructions *string `json:"special_instructions,omitempty"`
 	DefaultMorningTime  *string `json:"default_morning_time,omitempty"`
 	DefaultEveningTime  *string `json:"default_evening_time,omitempty"`
 	ExternalLink        *string `json:"external_link,omitempty"`
+	ShelterID           *string `json:"shelter_id,omitempty"`
 }
 
 // UpdateDogRequest represents the request to update a dog
@@ -62,16 +106,93 @@ type UpdateDogRequest struct {
 	PickupLocation      *string `json:"pickup_location,omitempty"`
 	WalkRoute           *string `json:"walk_route,omitempty"`
 	WalkDuration        *int    `json:"walk_duration,omitempty"`
+	MaxWalksPerDay      *int    `json:"max_walks_per_day,omitempty"` // 0 = no limit
+	MinRestMinutes      *int    `json:"min_rest_minutes,omitempty"`  // 0 = no rest required
+	TargetWalksPerWeek  *int    `json:"target_walks_per_week,omitempty"` // 0 = no target
 	SpecialInstructions *string `json:"special_instructions,omitempty"`
 	DefaultMorningTime  *string `json:"default_morning_time,omitempty"`
 	DefaultEveningTime  *string `json:"default_evening_time,omitempty"`
 	ExternalLink        *string `json:"external_link,omitempty"`
+	ShelterID           *string `json:"shelter_id,omitempty"`
 }
 
-// ToggleAvailabilityRequest represents the request to toggle dog availability
+// Validate validates the request of a new dog
+func (r *CreateDogRequest) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return &ValidationError{Field: "name", Message: "Name is required"}
+	}
+
+	if strings.TrimSpace(r.Breed) == "" {
+		return &ValidationError{Field: "breed", Message: "Breed is required"}
+	}
+
+	if r.Size != "small" && r.Size != "medium" && r.Size != "large" {
+		return &ValidationError{Field: "size", Message: "Size must be small, medium, or large"}
+	}
+
+	// Category is legacy - it is only checked when no color_id is given
+	if r.ColorID == nil {
+		if r.Category == "" {
+			return &ValidationError{Field: "color_id", Message: "Color is required"}
+		}
+		if r.Category != "green" && r.Category != "blue" && r.Category != "orange" {
+			return &ValidationError{Field: "category", Message: "Category must be green, blue, or orange"}
+		}
+	}
+
+	if err := ValidateDogWalkLimits(r.MaxWalksPerDay, r.MinRestMinutes); err != nil {
+		return err
+	}
+	if err := ValidateWalkTarget(r.TargetWalksPerWeek); err != nil {
+		return err
+	}
+	return ValidateShelterID(r.ShelterID)
+}
+
+// ToggleAvailabilityRequest represents the request to toggle dog availability.
+// Marking a dog unavailable may give a window; the dog is made available again automatically
+// after UnavailableUntil.
 type ToggleAvailabilityRequest struct {
 	IsAvailable       bool    `json:"is_available"`
 	UnavailableReason *string `json:"unavailable_reason,omitempty"`
+	UnavailableFrom   *string `json:"unavailable_from,omitempty"`  // YYYY-MM-DD, defaults to today
+	UnavailableUntil  *string `json:"unavailable_until,omitempty"` // YYYY-MM-DD last unavailable day, optional
+	CancelBookings    bool    `json:"cancel_bookings,omitempty"`   // Cancel the scheduled bookings inside the window
+}
+
+// Validate validates the unavailability window against today (YYYY-MM-DD)
+func (r *ToggleAvailabilityRequest) Validate(today string) error {
+	if r.IsAvailable {
+		return nil
+	}
+
+	if r.UnavailableFrom != nil {
+		if _, err := time.Parse("2006-01-02", *r.UnavailableFrom); err != nil {
+			return &ValidationError{Field: "unavailable_from", Message: "Start date must be in YYYY-MM-DD format"}
+		}
+	}
+
+	if r.UnavailableUntil != nil {
+		if _, err := time.Parse("2006-01-02", *r.UnavailableUntil); err != nil {
+			return &ValidationError{Field: "unavailable_until", Message: "End date must be in YYYY-MM-DD format"}
+		}
+		if *r.UnavailableUntil < today {
+			return &ValidationError{Field: "unavailable_until", Message: "End date cannot be in the past"}
+		}
+		if r.UnavailableFrom != nil && *r.UnavailableUntil < *r.UnavailableFrom {
+			return &ValidationError{Field: "unavailable_until", Message: "End date must not be before the start date"}
+		}
+	}
+
+	return nil
+}
+
+// DogAvailabilityResponse is the dog after an availability change with the scheduled bookings
+// inside its unavailability window
+type DogAvailabilityResponse struct {
+	*Dog
+	AffectedBookings []*Booking `json:"affected_bookings"`
+	CancelledCount   int        `json:"cancelled_count"`
 }
 
 // DogFilterRequest represents dog filtering parameters
@@ -82,5 +203,18 @@ type DogFilterRequest struct {
 	MaxAge      *int    `json:"max_age,omitempty"`
 	Category    *string `json:"category,omitempty"`
 	Available   *bool   `json:"available,omitempty"`
-	Search      *string `json:"search,omitempty"` // Search in name, breed
+	Search      *string `json:"search,omitempty"` // Full-text search in name, breed, special needs, instructions and walk route
+
+	Sort   string `json:"sort,omitempty"`   // One of the DogSort* constants, name by default
+	Order  string `json:"order,omitempty"`  // asc (default) or desc
+	Limit  int    `json:"limit,omitempty"`  // Page size; 0 lists all matching dogs
+	Cursor string `json:"cursor,omitempty"` // next_cursor of the previous page
+
+	// Archived lists only dogs that left the shelter instead of only dogs in the shelter
+	Archived bool `json:"-"`
+
+	// WalkerID hides dogs the walker's allowlist/denylist rules forbid (set for non-admins)
+	WalkerID *int `json:"-"`
+	// WalkerDate is the day (YYYY-MM-DD) the walker rules are checked for, today if empty
+	WalkerDate string `json:"-"`
 }
@@ -0,0 +1,173 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+// Dog care entry types
+const (
+	DogCareVaccination    = "vaccination"
+	DogCareMedication     = "medication"
+	DogCareVetAppointment = "vet_appointment"
+	DogCareInjury         = "injury"
+)
+
+// ValidDogCareTypes contains the allowed care entry types
+var ValidDogCareTypes = []string{DogCareVaccination, DogCareMedication, DogCareVetAppointment, DogCareInjury}
+
+// DogCareEntry is one entry of a dog's care log. StartDate is the day of the vaccination,
+// appointment or injury, or the first day of a medication. EndDate is optional: the last
+// day of a medication, the healing of an injury or the expiry of a vaccination.
+type DogCareEntry struct {
+	ID              int       `json:"id"`
+	DogID           int       `json:"dog_id"`
+	EntryType       string    `json:"entry_type"`
+	Title           string    `json:"title"`
+	Notes           *string   `json:"notes,omitempty"`
+	StartDate       string    `json:"start_date"`                 // YYYY-MM-DD
+	EndDate         *string   `json:"end_date,omitempty"`         // YYYY-MM-DD
+	AppointmentTime *string   `json:"appointment_time,omitempty"` // HH:MM, vet appointments
+	Dosage          *string   `json:"dosage,omitempty"`           // medication
+	ScheduleTimes   []string  `json:"schedule_times,omitempty"`   // HH:MM daily dose times, medication
+	BlockedDateID   *int      `json:"blocked_date_id,omitempty"`  // Dog block created for a vet appointment
+	CreatedBy       *int      `json:"created_by,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// ActiveOn reports whether the entry covers the given date (YYYY-MM-DD)
+func (e *DogCareEntry) ActiveOn(date string) bool {
+	if date < e.StartDate {
+		return false
+	}
+	return e.EndDate == nil || *e.EndDate == "" || date <= *e.EndDate
+}
+
+// DosesBetween returns the doses of a medication due on date at or after from and before
+// to (both HH:MM). A to of "24:00" or later includes the rest of the day.
+func (e *DogCareEntry) DosesBetween(date, from, to string) []MedicationDose {
+	if e.EntryType != DogCareMedication || !e.ActiveOn(date) {
+		return nil
+	}
+
+	doses := []MedicationDose{}
+	for _, t := range e.ScheduleTimes {
+		if t >= from && t < to {
+			doses = append(doses, MedicationDose{
+				EntryID: e.ID,
+				Title:   e.Title,
+				Dosage:  e.Dosage,
+				Notes:   e.Notes,
+				Time:    t,
+			})
+		}
+	}
+	return doses
+}
+
+// MedicationDose is a medication dose due during a walk
+type MedicationDose struct {
+	EntryID int     `json:"entry_id"`
+	Title   string  `json:"title"`
+	Dosage  *string `json:"dosage,omitempty"`
+	Notes   *string `json:"notes,omitempty"`
+	Time    string  `json:"time"` // HH:MM
+}
+
+// MedicationDuringWalk returns the medication doses due while a walk of durationMinutes
+// starting at startTime (HH:MM) on date takes place, ordered by time
+func MedicationDuringWalk(entries []*DogCareEntry, date, startTime string, durationMinutes int) []MedicationDose {
+	start, err := minutesOfDay(startTime)
+	if err != nil {
+		return nil
+	}
+	end := start + durationMinutes
+	endTime := "24:00" // Walks running past midnight include the rest of the day
+	if end < 24*60 {
+		endTime = AddMinutesToTime(startTime, durationMinutes)
+	}
+
+	doses := []MedicationDose{}
+	for _, entry := range entries {
+		doses = append(doses, entry.DosesBetween(date, startTime, endTime)...)
+	}
+	sort.SliceStable(doses, func(i, j int) bool { return doses[i].Time < doses[j].Time })
+	return doses
+}
+
+// DogCareEntryRequest creates or replaces a care log entry
+type DogCareEntryRequest struct {
+	EntryType       string   `json:"entry_type"`
+	Title           string   `json:"title"`
+	Notes           *string  `json:"notes,omitempty"`
+	StartDate       string   `json:"start_date"`
+	EndDate         *string  `json:"end_date,omitempty"`
+	AppointmentTime *string  `json:"appointment_time,omitempty"`
+	Dosage          *string  `json:"dosage,omitempty"`
+	ScheduleTimes   []string `json:"schedule_times,omitempty"`
+	BlockDate       bool     `json:"block_date,omitempty"` // Vet appointments: block the dog for StartDate
+}
+
+// Validate validates the care entry request and normalizes the dose times to sorted HH:MM
+func (r *DogCareEntryRequest) Validate() error {
+	validType := false
+	for _, t := range ValidDogCareTypes {
+		if r.EntryType == t {
+			validType = true
+			break
+		}
+	}
+	if !validType {
+		return &ValidationError{Field: "entry_type", Message: "Entry type must be 'vaccination', 'medication', 'vet_appointment' or 'injury'"}
+	}
+
+	if r.Title == "" {
+		return &ValidationError{Field: "title", Message: "Title is required"}
+	}
+	if len(r.Title) > 200 {
+		return &ValidationError{Field: "title", Message: "Title must be at most 200 characters"}
+	}
+	if r.Dosage != nil && len(*r.Dosage) > 200 {
+		return &ValidationError{Field: "dosage", Message: "Dosage must be at most 200 characters"}
+	}
+
+	if _, err := time.Parse("2006-01-02", r.StartDate); err != nil {
+		return &ValidationError{Field: "start_date", Message: "Start date must be in YYYY-MM-DD format"}
+	}
+	if r.EndDate != nil && *r.EndDate != "" {
+		if _, err := time.Parse("2006-01-02", *r.EndDate); err != nil {
+			return &ValidationError{Field: "end_date", Message: "End date must be in YYYY-MM-DD format"}
+		}
+		if *r.EndDate < r.StartDate {
+			return &ValidationError{Field: "end_date", Message: "End date must not be before start date"}
+		}
+	}
+
+	if r.AppointmentTime != nil && *r.AppointmentTime != "" && !isValidTimeFormat(*r.AppointmentTime) {
+		return &ValidationError{Field: "appointment_time", Message: "Appointment time must be in HH:MM format"}
+	}
+
+	if r.EntryType == DogCareMedication {
+		if len(r.ScheduleTimes) == 0 {
+			return &ValidationError{Field: "schedule_times", Message: "Medication needs at least one dose time"}
+		}
+		for i, value := range r.ScheduleTimes {
+			t, err := time.Parse("15:04", value)
+			if err != nil {
+				return &ValidationError{Field: "schedule_times", Message: "Dose times must be in HH:MM format"}
+			}
+			// Dose times are compared as strings, so "8:00" must become "08:00"
+			r.ScheduleTimes[i] = t.Format("15:04")
+		}
+		sort.Strings(r.ScheduleTimes)
+	} else if len(r.ScheduleTimes) > 0 {
+		return &ValidationError{Field: "schedule_times", Message: "Dose times are only allowed for medication"}
+	}
+
+	if r.BlockDate && r.EntryType != DogCareVetAppointment {
+		return &ValidationError{Field: "block_date", Message: "Only vet appointments can block the dog"}
+	}
+
+	return nil
+}
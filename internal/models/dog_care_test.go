@@ -0,0 +1,94 @@
+package models
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// TestDogCareEntryRequest_Validate tests validation of care log entries
+func TestDogCareEntryRequest_Validate(t *testing.T) {
+	endBeforeStart := "2030-01-01"
+
+	tests := []struct {
+		name    string
+		req     DogCareEntryRequest
+		wantErr string
+	}{
+		{"valid vaccination", DogCareEntryRequest{EntryType: DogCareVaccination, Title: "Tollwut", StartDate: "2030-01-10"}, ""},
+		{"valid medication", DogCareEntryRequest{EntryType: DogCareMedication, Title: "Rimadyl", StartDate: "2030-01-10", ScheduleTimes: []string{"18:00", "8:00"}}, ""},
+		{"valid blocked vet appointment", DogCareEntryRequest{EntryType: DogCareVetAppointment, Title: "Kontrolle", StartDate: "2030-01-10", BlockDate: true}, ""},
+		{"unknown type", DogCareEntryRequest{EntryType: "grooming", Title: "Bad", StartDate: "2030-01-10"}, "entry_type"},
+		{"missing title", DogCareEntryRequest{EntryType: DogCareInjury, StartDate: "2030-01-10"}, "title"},
+		{"invalid start date", DogCareEntryRequest{EntryType: DogCareInjury, Title: "Pfote", StartDate: "10.01.2030"}, "start_date"},
+		{"end before start", DogCareEntryRequest{EntryType: DogCareInjury, Title: "Pfote", StartDate: "2030-01-10", EndDate: &endBeforeStart}, "end_date"},
+		{"medication without doses", DogCareEntryRequest{EntryType: DogCareMedication, Title: "Rimadyl", StartDate: "2030-01-10"}, "schedule_times"},
+		{"invalid dose time", DogCareEntryRequest{EntryType: DogCareMedication, Title: "Rimadyl", StartDate: "2030-01-10", ScheduleTimes: []string{"25:00"}}, "schedule_times"},
+		{"doses for vaccination", DogCareEntryRequest{EntryType: DogCareVaccination, Title: "Tollwut", StartDate: "2030-01-10", ScheduleTimes: []string{"08:00"}}, "schedule_times"},
+		{"block for injury", DogCareEntryRequest{EntryType: DogCareInjury, Title: "Pfote", StartDate: "2030-01-10", BlockDate: true}, "block_date"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			validationErr, ok := err.(*ValidationError)
+			if !ok || validationErr.Field != tt.wantErr {
+				t.Errorf("Expected validation error on %s, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	t.Run("dose times are normalized", func(t *testing.T) {
+		req := DogCareEntryRequest{EntryType: DogCareMedication, Title: "Rimadyl", StartDate: "2030-01-10", ScheduleTimes: []string{"18:00", "8:00"}}
+		if err := req.Validate(); err != nil {
+			t.Fatalf("Validate() failed: %v", err)
+		}
+		if !reflect.DeepEqual(req.ScheduleTimes, []string{"08:00", "18:00"}) {
+			t.Errorf("Expected sorted HH:MM dose times, got %v", req.ScheduleTimes)
+		}
+	})
+}
+
+// TestMedicationDuringWalk tests which doses fall into a walk window
+func TestMedicationDuringWalk(t *testing.T) {
+	lastDay := "2030-01-20"
+	entries := []*DogCareEntry{
+		{ID: 1, EntryType: DogCareMedication, Title: "Rimadyl", StartDate: "2030-01-10", EndDate: &lastDay, ScheduleTimes: []string{"08:00", "09:30", "18:00"}},
+		{ID: 2, EntryType: DogCareMedication, Title: "Tropfen", StartDate: "2030-01-15", ScheduleTimes: []string{"09:00", "23:30"}},
+		{ID: 3, EntryType: DogCareVaccination, Title: "Tollwut", StartDate: "2030-01-10"},
+	}
+
+	tests := []struct {
+		name     string
+		date     string
+		start    string
+		duration int
+		want     []string // "<entry>@<time>"
+	}{
+		{"dose at walk start", "2030-01-12", "08:00", 60, []string{"1@08:00"}},
+		{"walk end is exclusive", "2030-01-12", "08:30", 60, []string{}},
+		{"doses ordered by time", "2030-01-16", "08:45", 60, []string{"2@09:00", "1@09:30"}},
+		{"before medication starts", "2030-01-09", "08:00", 120, []string{}},
+		{"after medication ends", "2030-01-21", "08:45", 60, []string{"2@09:00"}},
+		{"walk past midnight", "2030-01-16", "23:00", 90, []string{"2@23:30"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doses := MedicationDuringWalk(entries, tt.date, tt.start, tt.duration)
+			got := []string{}
+			for _, dose := range doses {
+				got = append(got, fmt.Sprintf("%d@%s", dose.EntryID, dose.Time))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package models
+
+import "strings"
+
+// MaxShelterIDLength is the longest accepted shelter_id
+const MaxShelterIDLength = 100
+
+// Dog import actions
+const (
+	DogImportActionCreate = "create"
+	DogImportActionUpdate = "update"
+	DogImportActionError  = "error"
+)
+
+// DogImportRecord is one dog of a bulk import or export: all CreateDogRequest fields plus the
+// color by name, so files can be moved between installations with different color IDs
+type DogImportRecord struct {
+	CreateDogRequest
+	Color string `json:"color,omitempty"` // Color category name, takes precedence over color_id
+}
+
+// DogImportRow is the outcome of one imported record
+type DogImportRow struct {
+	Row       int      `json:"row"` // 1-based position in the file, not counting the CSV header
+	ShelterID string   `json:"shelter_id"`
+	Name      string   `json:"name"`
+	Action    string   `json:"action"`           // One of the DogImportAction* constants
+	DogID     *int     `json:"dog_id,omitempty"` // Updated dog, or the new dog once created
+	Photo     string   `json:"photo,omitempty"`  // ZIP entry attached as cover photo
+	Errors    []string `json:"errors,omitempty"`
+}
+
+// DogImportResult is the response of a dog import. A dry run reports the same rows without
+// writing anything.
+type DogImportResult struct {
+	DryRun          bool            `json:"dry_run"`
+	Created         int             `json:"created"`
+	Updated         int             `json:"updated"`
+	Failed          int             `json:"failed"`
+	Rows            []*DogImportRow `json:"rows"`
+	UnmatchedPhotos []string        `json:"unmatched_photos,omitempty"` // ZIP entries without a matching shelter_id
+}
+
+// ValidateShelterID validates the shelter_id field of a create or update dog request
+func ValidateShelterID(shelterID *string) error {
+	if shelterID != nil && len(strings.TrimSpace(*shelterID)) > MaxShelterIDLength {
+		return &ValidationError{Field: "shelter_id", Message: "Shelter ID must be at most 100 characters"}
+	}
+	return nil
+}
+
+// ShelterIDOrNil trims a shelter ID and stores an empty one as "no shelter ID"
+func ShelterIDOrNil(shelterID *string) *string {
+	if shelterID == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*shelterID)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
@@ -0,0 +1,19 @@
+package models
+
+import "testing"
+
+// TestShelterIDOrNil tests normalizing shelter IDs
+func TestShelterIDOrNil(t *testing.T) {
+	padded := " T-12 "
+	blank := "  "
+
+	if got := ShelterIDOrNil(&padded); got == nil || *got != "T-12" {
+		t.Errorf("Expected trimmed shelter ID, got %v", got)
+	}
+	if got := ShelterIDOrNil(&blank); got != nil {
+		t.Errorf("Expected nil for a blank shelter ID, got %q", *got)
+	}
+	if got := ShelterIDOrNil(nil); got != nil {
+		t.Errorf("Expected nil, got %q", *got)
+	}
+}
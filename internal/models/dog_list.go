@@ -0,0 +1,116 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+// Dog list sort fields
+const (
+	DogSortName     = "name"
+	DogSortAge      = "age"
+	DogSortLastWalk = "last_walk" // Date of the latest completed walk; never walked dogs come first
+)
+
+// Dog list sort orders
+const (
+	DogOrderAsc  = "asc"
+	DogOrderDesc = "desc"
+)
+
+// MaxDogPageSize is the largest accepted page size of the dog list
+const MaxDogPageSize = 100
+
+// DogPage is one page of the dog list
+type DogPage struct {
+	Dogs       []*Dog `json:"dogs"`
+	NextCursor string `json:"next_cursor,omitempty"` // Empty on the last page
+}
+
+// DogCursor marks the last dog of a page: the value it was sorted by and its ID as tiebreaker.
+// Clients treat the encoded cursor as opaque.
+type DogCursor struct {
+	Sort  string `json:"s"`
+	Order string `json:"o"`
+	Value string `json:"v"`
+	ID    int    `json:"id"`
+}
+
+// Encode returns the cursor as a URL-safe string
+func (c *DogCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeDogCursor parses a cursor returned by Encode
+func DecodeDogCursor(cursor string) (*DogCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, &ValidationError{Field: "cursor", Message: "Invalid cursor"}
+	}
+	var c DogCursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID <= 0 {
+		return nil, &ValidationError{Field: "cursor", Message: "Invalid cursor"}
+	}
+	return &c, nil
+}
+
+// SortField returns the requested sort field, name by default
+func (f *DogFilterRequest) SortField() string {
+	if f.Sort == "" {
+		return DogSortName
+	}
+	return f.Sort
+}
+
+// SortOrder returns the requested sort order, ascending by default
+func (f *DogFilterRequest) SortOrder() string {
+	if f.Order == "" {
+		return DogOrderAsc
+	}
+	return f.Order
+}
+
+// Validate validates the sorting and pagination parameters
+func (f *DogFilterRequest) Validate() error {
+	switch f.SortField() {
+	case DogSortName, DogSortAge, DogSortLastWalk:
+	default:
+		return &ValidationError{Field: "sort", Message: "Sort must be 'name', 'age' or 'last_walk'"}
+	}
+
+	if order := f.SortOrder(); order != DogOrderAsc && order != DogOrderDesc {
+		return &ValidationError{Field: "order", Message: "Order must be 'asc' or 'desc'"}
+	}
+
+	if f.Limit < 0 || f.Limit > MaxDogPageSize {
+		return &ValidationError{Field: "limit", Message: "Limit must be between 1 and 100"}
+	}
+
+	if f.Cursor != "" {
+		if f.Limit == 0 {
+			return &ValidationError{Field: "cursor", Message: "Cursor requires a limit"}
+		}
+		if _, err := f.DecodeCursor(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DecodeCursor returns the position to continue after, or nil for the first page.
+// A cursor from a list with a different sort field or order is rejected.
+func (f *DogFilterRequest) DecodeCursor() (*DogCursor, error) {
+	if f.Cursor == "" {
+		return nil, nil
+	}
+	cursor, err := DecodeDogCursor(f.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	if cursor.Sort != f.SortField() || cursor.Order != f.SortOrder() {
+		return nil, &ValidationError{Field: "cursor", Message: "Cursor does not match the sort order"}
+	}
+	return cursor, nil
+}
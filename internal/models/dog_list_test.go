@@ -0,0 +1,55 @@
+package models
+
+import "testing"
+
+// TestDogFilterRequest_Validate tests sorting and pagination parameters
+func TestDogFilterRequest_Validate(t *testing.T) {
+	nameCursor := (&DogCursor{Sort: DogSortName, Order: DogOrderAsc, Value: "Bella", ID: 4}).Encode()
+
+	tests := []struct {
+		name    string
+		filter  DogFilterRequest
+		wantErr string // Field of the expected ValidationError, empty for none
+	}{
+		{"defaults", DogFilterRequest{}, ""},
+		{"age descending", DogFilterRequest{Sort: "age", Order: "desc", Limit: 100}, ""},
+		{"last walk", DogFilterRequest{Sort: "last_walk", Limit: 20}, ""},
+		{"cursor", DogFilterRequest{Limit: 20, Cursor: nameCursor}, ""},
+		{"unknown sort", DogFilterRequest{Sort: "breed"}, "sort"},
+		{"unknown order", DogFilterRequest{Order: "up"}, "order"},
+		{"negative limit", DogFilterRequest{Limit: -1}, "limit"},
+		{"limit too large", DogFilterRequest{Limit: 101}, "limit"},
+		{"cursor without limit", DogFilterRequest{Cursor: nameCursor}, "cursor"},
+		{"malformed cursor", DogFilterRequest{Limit: 20, Cursor: "not a cursor"}, "cursor"},
+		{"cursor of another sort", DogFilterRequest{Sort: "age", Limit: 20, Cursor: nameCursor}, "cursor"},
+		{"cursor of another order", DogFilterRequest{Order: "desc", Limit: 20, Cursor: nameCursor}, "cursor"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.filter.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if verr, ok := err.(*ValidationError); !ok || verr.Field != tt.wantErr {
+				t.Errorf("Expected validation error on %s, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+// TestDogCursor_Encode tests that cursors survive a round trip
+func TestDogCursor_Encode(t *testing.T) {
+	cursor := &DogCursor{Sort: DogSortLastWalk, Order: DogOrderDesc, Value: "2030-01-08", ID: 12}
+
+	decoded, err := DecodeDogCursor(cursor.Encode())
+	if err != nil {
+		t.Fatalf("DecodeDogCursor() failed: %v", err)
+	}
+	if *decoded != *cursor {
+		t.Errorf("Expected %+v, got %+v", cursor, decoded)
+	}
+}
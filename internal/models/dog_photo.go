@@ -0,0 +1,54 @@
+package models
+
+import "time"
+
+// MaxDogPhotos is the maximum number of photos in a dog's gallery
+const MaxDogPhotos = 12
+
+// DogPhoto is one photo of a dog's gallery. The cover photo is the one whose path is
+// stored in Dog.Photo.
+type DogPhoto struct {
+	ID             int       `json:"id"`
+	DogID          int       `json:"dog_id"`
+	PhotoPath      string    `json:"photo_path"`
+	PhotoThumbnail string    `json:"photo_thumbnail"`
+	DisplayOrder   int       `json:"display_order"`
+	IsCover        bool      `json:"is_cover"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// ReorderDogPhotosRequest lists all photo IDs of a gallery in their new order
+type ReorderDogPhotosRequest struct {
+	PhotoIDs []int `json:"photo_ids"`
+}
+
+// Validate validates the reorder request
+func (r *ReorderDogPhotosRequest) Validate() error {
+	if len(r.PhotoIDs) == 0 {
+		return &ValidationError{Field: "photo_ids", Message: "Photo IDs are required"}
+	}
+
+	seen := map[int]bool{}
+	for _, id := range r.PhotoIDs {
+		if id <= 0 || seen[id] {
+			return &ValidationError{Field: "photo_ids", Message: "Photo IDs must be unique positive integers"}
+		}
+		seen[id] = true
+	}
+
+	return nil
+}
+
+// PromoteWalkReportPhotoRequest copies a walk report photo into a dog's gallery
+type PromoteWalkReportPhotoRequest struct {
+	WalkReportPhotoID int `json:"walk_report_photo_id"`
+}
+
+// Validate validates the promote request
+func (r *PromoteWalkReportPhotoRequest) Validate() error {
+	if r.WalkReportPhotoID <= 0 {
+		return &ValidationError{Field: "walk_report_photo_id", Message: "Walk report photo ID is required"}
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Dog statuses
+const (
+	DogStatusInShelter   = "in_shelter"
+	DogStatusReserved    = "reserved" // Adoption agreed, the dog is still in the shelter and can be walked
+	DogStatusAdopted     = "adopted"
+	DogStatusDeceased    = "deceased"
+	DogStatusTransferred = "transferred" // Moved to another shelter or organisation
+)
+
+// IsArchivedDogStatus reports whether a dog with status has left the shelter.
+// Archived dogs cannot be booked but keep their bookings and walk reports.
+func IsArchivedDogStatus(status string) bool {
+	return status == DogStatusAdopted || status == DogStatusDeceased || status == DogStatusTransferred
+}
+
+// IsValidDogStatus reports whether status is a known dog status
+func IsValidDogStatus(status string) bool {
+	return status == DogStatusInShelter || status == DogStatusReserved || IsArchivedDogStatus(status)
+}
+
+// IsArchived reports whether the dog has left the shelter
+func (d *Dog) IsArchived() bool {
+	return IsArchivedDogStatus(d.Status)
+}
+
+// DogStatusEvent records one status transition of a dog
+type DogStatusEvent struct {
+	ID             int       `json:"id"`
+	DogID          int       `json:"dog_id"`
+	PreviousStatus string    `json:"previous_status"`
+	Status         string    `json:"status"`
+	EffectiveDate  string    `json:"effective_date"` // YYYY-MM-DD
+	Note           string    `json:"note"`
+	CreatedBy      *int      `json:"created_by,omitempty"`
+	CreatedByName  *string   `json:"created_by_name,omitempty"` // Populated via JOIN for display
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// ChangeDogStatusRequest moves a dog to a new status. Restoring an archived dog ignores Status.
+type ChangeDogStatusRequest struct {
+	Status string `json:"status"`
+	Date   string `json:"date"`
+	Note   string `json:"note"`
+}
+
+// Validate validates the change dog status request
+func (r *ChangeDogStatusRequest) Validate() error {
+	if !IsValidDogStatus(r.Status) {
+		return &ValidationError{Field: "status", Message: "Status must be one of in_shelter, reserved, adopted, deceased, transferred"}
+	}
+
+	if _, err := time.Parse("2006-01-02", r.Date); err != nil {
+		return &ValidationError{Field: "date", Message: "Date must be in YYYY-MM-DD format"}
+	}
+
+	r.Note = strings.TrimSpace(r.Note)
+	if r.Note == "" {
+		return &ValidationError{Field: "note", Message: "Note is required"}
+	}
+	if len(r.Note) > 1000 {
+		return &ValidationError{Field: "note", Message: "Note must be at most 1000 characters"}
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestIsArchivedDogStatus tests which statuses archive a dog
+func TestIsArchivedDogStatus(t *testing.T) {
+	for status, want := range map[string]bool{
+		DogStatusInShelter:   false,
+		DogStatusReserved:    false,
+		DogStatusAdopted:     true,
+		DogStatusDeceased:    true,
+		DogStatusTransferred: true,
+	} {
+		if got := IsArchivedDogStatus(status); got != want {
+			t.Errorf("IsArchivedDogStatus(%q) = %v, want %v", status, got, want)
+		}
+	}
+}
+
+// TestChangeDogStatusRequest_Validate tests validation of dog status changes
+func TestChangeDogStatusRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     ChangeDogStatusRequest
+		wantErr string
+	}{
+		{"valid adoption", ChangeDogStatusRequest{Status: DogStatusAdopted, Date: "2030-01-10", Note: "Familie Müller"}, ""},
+		{"valid reservation", ChangeDogStatusRequest{Status: DogStatusReserved, Date: "2030-01-10", Note: "Vorvertrag"}, ""},
+		{"unknown status", ChangeDogStatusRequest{Status: "sold", Date: "2030-01-10", Note: "Notiz"}, "status"},
+		{"missing date", ChangeDogStatusRequest{Status: DogStatusAdopted, Note: "Notiz"}, "date"},
+		{"invalid date", ChangeDogStatusRequest{Status: DogStatusAdopted, Date: "10.01.2030", Note: "Notiz"}, "date"},
+		{"blank note", ChangeDogStatusRequest{Status: DogStatusAdopted, Date: "2030-01-10", Note: "  "}, "note"},
+		{"note too long", ChangeDogStatusRequest{Status: DogStatusAdopted, Date: "2030-01-10", Note: strings.Repeat("a", 1001)}, "note"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			validationErr, ok := err.(*ValidationError)
+			if !ok || validationErr.Field != tt.wantErr {
+				t.Errorf("Expected validation error on %s, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestDog_IsBookableOn tests availability flags combined with scheduled unavailability
+func TestDog_IsBookableOn(t *testing.T) {
+	from := "2030-01-10"
+	until := "2030-01-14"
+
+	tests := []struct {
+		name string
+		dog  Dog
+		date string
+		want bool
+	}{
+		{"available dog", Dog{IsAvailable: true}, "2030-01-12", true},
+		{"unavailable without end", Dog{IsAvailable: false, UnavailableFrom: &from}, "2030-02-01", false},
+		{"inside window", Dog{IsAvailable: false, UnavailableFrom: &from, UnavailableUntil: &until}, "2030-01-14", false},
+		{"after window", Dog{IsAvailable: false, UnavailableFrom: &from, UnavailableUntil: &until}, "2030-01-15", true},
+		{"before upcoming window", Dog{IsAvailable: true, UnavailableFrom: &from, UnavailableUntil: &until}, "2030-01-09", true},
+		{"inside upcoming window", Dog{IsAvailable: true, UnavailableFrom: &from, UnavailableUntil: &until}, "2030-01-10", false},
+		{"archived dog", Dog{IsAvailable: false, Status: DogStatusAdopted, UnavailableUntil: &until}, "2030-02-01", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.dog.IsBookableOn(tt.date); got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestToggleAvailabilityRequest_Validate tests validation of unavailability windows
+func TestToggleAvailabilityRequest_Validate(t *testing.T) {
+	today := "2030-01-10"
+	from := "2030-01-12"
+	before := "2030-01-11"
+	past := "2030-01-09"
+	invalid := "12.01.2030"
+
+	tests := []struct {
+		name    string
+		req     ToggleAvailabilityRequest
+		wantErr string
+	}{
+		{"make available", ToggleAvailabilityRequest{IsAvailable: true, UnavailableUntil: &past}, ""},
+		{"open-ended", ToggleAvailabilityRequest{}, ""},
+		{"window", ToggleAvailabilityRequest{UnavailableFrom: &from, UnavailableUntil: &from}, ""},
+		{"invalid start", ToggleAvailabilityRequest{UnavailableFrom: &invalid}, "unavailable_from"},
+		{"invalid end", ToggleAvailabilityRequest{UnavailableUntil: &invalid}, "unavailable_until"},
+		{"end in the past", ToggleAvailabilityRequest{UnavailableUntil: &past}, "unavailable_until"},
+		{"end before start", ToggleAvailabilityRequest{UnavailableFrom: &from, UnavailableUntil: &before}, "unavailable_until"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate(today)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			validationErr, ok := err.(*ValidationError)
+			if !ok || validationErr.Field != tt.wantErr {
+				t.Errorf("Expected validation error on %s, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+// TestCreateDogRequest_Validate tests validation of new dogs (form and bulk import)
+func TestCreateDogRequest_Validate(t *testing.T) {
+	colorID := 1
+	negative := -1
+	longID := strings.Repeat("x", MaxShelterIDLength+1)
+	valid := func() CreateDogRequest {
+		return CreateDogRequest{Name: "Bella", Breed: "Labrador", Size: "medium", Age: 3, ColorID: &colorID}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(r *CreateDogRequest)
+		wantErr string
+	}{
+		{"valid", func(r *CreateDogRequest) {}, ""},
+		{"legacy category", func(r *CreateDogRequest) { r.ColorID = nil; r.Category = "blue" }, ""},
+		{"missing name", func(r *CreateDogRequest) { r.Name = "  " }, "name"},
+		{"missing breed", func(r *CreateDogRequest) { r.Breed = "" }, "breed"},
+		{"invalid size", func(r *CreateDogRequest) { r.Size = "huge" }, "size"},
+		{"missing color", func(r *CreateDogRequest) { r.ColorID = nil }, "color_id"},
+		{"invalid category", func(r *CreateDogRequest) { r.ColorID = nil; r.Category = "red" }, "category"},
+		{"negative walk limit", func(r *CreateDogRequest) { r.MaxWalksPerDay = &negative }, "max_walks_per_day"},
+		{"negative walk target", func(r *CreateDogRequest) { r.TargetWalksPerWeek = &negative }, "target_walks_per_week"},
+		{"shelter ID too long", func(r *CreateDogRequest) { r.ShelterID = &longID }, "shelter_id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid()
+			tt.modify(&req)
+			err := req.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			validationErr, ok := err.(*ValidationError)
+			if !ok || validationErr.Field != tt.wantErr {
+				t.Errorf("Expected validation error on %s, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
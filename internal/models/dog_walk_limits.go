@@ -0,0 +1,95 @@
+package models
+
+import "fmt"
+
+// Dog walk limit rules
+const (
+	WalkLimitMaxWalksPerDay = "max_walks_per_day"
+	WalkLimitMinRest        = "min_rest_minutes"
+)
+
+// MaxWalksPerDayLimit is the highest accepted max_walks_per_day value
+const MaxWalksPerDayLimit = 24
+
+// DogWalkLimitViolation describes which of a dog's walk limits a requested walk breaks
+type DogWalkLimitViolation struct {
+	Rule          string `json:"rule"` // One of the WalkLimit* constants
+	DogName       string `json:"dog_name"`
+	Limit         int    `json:"limit"`                    // Walks per day or rest minutes
+	Walks         int    `json:"walks,omitempty"`          // Walks the dog already has that day (max_walks_per_day)
+	ScheduledTime string `json:"scheduled_time,omitempty"` // HH:MM start of the walk too close to the requested one (min_rest_minutes)
+	EndTime       string `json:"end_time,omitempty"`       // HH:MM end of that walk
+}
+
+// Message explains the violated limit to the walker
+func (v *DogWalkLimitViolation) Message() string {
+	switch v.Rule {
+	case WalkLimitMaxWalksPerDay:
+		return fmt.Sprintf("%s darf höchstens %d Mal pro Tag ausgeführt werden und ist an diesem Tag bereits %d Mal gebucht",
+			v.DogName, v.Limit, v.Walks)
+	case WalkLimitMinRest:
+		return fmt.Sprintf("%s braucht mindestens %d Minuten Pause zwischen zwei Spaziergängen (gebuchter Spaziergang von %s bis %s Uhr)",
+			v.DogName, v.Limit, v.ScheduledTime, v.EndTime)
+	default:
+		return "Die Spaziergang-Grenzen dieses Hundes sind erreicht"
+	}
+}
+
+// HasWalkLimits reports whether the dog has a daily walk limit or a minimum rest
+func (d *Dog) HasWalkLimits() bool {
+	return (d.MaxWalksPerDay != nil && *d.MaxWalksPerDay > 0) || (d.MinRestMinutes != nil && *d.MinRestMinutes > 0)
+}
+
+// CheckDogWalkLimits checks a walk of dog starting at scheduledTime against its daily limits.
+// walkTimes are the HH:MM start times of the dog's other walks that day. All walks of a dog
+// take its walk duration; the rest is counted from the end of one walk to the start of the next.
+// Returns nil if the walk is allowed.
+func CheckDogWalkLimits(dog *Dog, walkTimes []string, scheduledTime string) *DogWalkLimitViolation {
+	if dog.MaxWalksPerDay != nil && *dog.MaxWalksPerDay > 0 && len(walkTimes) >= *dog.MaxWalksPerDay {
+		return &DogWalkLimitViolation{
+			Rule:    WalkLimitMaxWalksPerDay,
+			DogName: dog.Name,
+			Limit:   *dog.MaxWalksPerDay,
+			Walks:   len(walkTimes),
+		}
+	}
+
+	if dog.MinRestMinutes != nil && *dog.MinRestMinutes > 0 {
+		duration := dog.GetWalkDuration()
+		for _, walkTime := range walkTimes {
+			if BookingIntervalsOverlap(scheduledTime, duration, walkTime, duration, *dog.MinRestMinutes) {
+				return &DogWalkLimitViolation{
+					Rule:          WalkLimitMinRest,
+					DogName:       dog.Name,
+					Limit:         *dog.MinRestMinutes,
+					ScheduledTime: walkTime,
+					EndTime:       AddMinutesToTime(walkTime, duration),
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateDogWalkLimits validates the walk limit fields of a create or update dog request.
+// 0 removes a limit.
+func ValidateDogWalkLimits(maxWalksPerDay, minRestMinutes *int) error {
+	if maxWalksPerDay != nil && (*maxWalksPerDay < 0 || *maxWalksPerDay > MaxWalksPerDayLimit) {
+		return &ValidationError{Field: "max_walks_per_day", Message: fmt.Sprintf("Max walks per day must be between 0 and %d", MaxWalksPerDayLimit)}
+	}
+
+	if minRestMinutes != nil && (*minRestMinutes < 0 || *minRestMinutes > 24*60) {
+		return &ValidationError{Field: "min_rest_minutes", Message: "Min rest must be between 0 and 1440 minutes"}
+	}
+
+	return nil
+}
+
+// WalkLimitOrNil stores a walk limit of 0 as "no limit"
+func WalkLimitOrNil(limit *int) *int {
+	if limit == nil || *limit <= 0 {
+		return nil
+	}
+	return limit
+}
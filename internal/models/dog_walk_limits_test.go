@@ -0,0 +1,94 @@
+package models
+
+import "testing"
+
+// TestCheckDogWalkLimits tests the walks per day and rest between walks of a dog
+func TestCheckDogWalkLimits(t *testing.T) {
+	two := 2
+	rest := 180
+	duration := 30
+
+	limited := &Dog{Name: "Opa", MaxWalksPerDay: &two, MinRestMinutes: &rest}
+	shortWalks := &Dog{Name: "Opa", WalkDuration: &duration, MinRestMinutes: &rest}
+
+	tests := []struct {
+		name      string
+		dog       *Dog
+		walkTimes []string
+		time      string
+		want      string
+	}{
+		{"no limits", &Dog{Name: "Bello"}, []string{"09:00", "10:00", "11:00"}, "14:00", ""},
+		{"first walk of the day", limited, nil, "09:00", ""},
+		{"enough rest after a walk", limited, []string{"09:00"}, "13:00", ""},
+		{"too little rest after a walk", limited, []string{"09:00"}, "12:30", WalkLimitMinRest},
+		{"too little rest before a walk", limited, []string{"14:00"}, "10:30", WalkLimitMinRest},
+		{"enough rest before a walk", limited, []string{"14:00"}, "10:00", ""},
+		{"daily limit reached", limited, []string{"09:00", "14:00"}, "18:00", WalkLimitMaxWalksPerDay},
+		{"rest counts from the end of the dog's walk", shortWalks, []string{"09:00"}, "12:30", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violation := CheckDogWalkLimits(tt.dog, tt.walkTimes, tt.time)
+			got := ""
+			if violation != nil {
+				got = violation.Rule
+			}
+			if got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+
+	t.Run("rest violation names the clashing walk", func(t *testing.T) {
+		violation := CheckDogWalkLimits(limited, []string{"09:00"}, "11:00")
+		if violation == nil || violation.ScheduledTime != "09:00" || violation.EndTime != "10:00" {
+			t.Fatalf("Expected clash with the 09:00-10:00 walk, got %+v", violation)
+		}
+		want := "Opa braucht mindestens 180 Minuten Pause zwischen zwei Spaziergängen (gebuchter Spaziergang von 09:00 bis 10:00 Uhr)"
+		if violation.Message() != want {
+			t.Errorf("Expected %q, got %q", want, violation.Message())
+		}
+	})
+}
+
+// TestValidateDogWalkLimits tests validation of the walk limit fields
+func TestValidateDogWalkLimits(t *testing.T) {
+	zero := 0
+	two := 2
+	negative := -1
+	tooMany := MaxWalksPerDayLimit + 1
+	tooLong := 24*60 + 1
+
+	tests := []struct {
+		name     string
+		maxWalks *int
+		minRest  *int
+		wantErr  string
+	}{
+		{"unset", nil, nil, ""},
+		{"valid limits", &two, &two, ""},
+		{"zero clears the limits", &zero, &zero, ""},
+		{"negative walks", &negative, nil, "max_walks_per_day"},
+		{"too many walks", &tooMany, nil, "max_walks_per_day"},
+		{"negative rest", nil, &negative, "min_rest_minutes"},
+		{"rest longer than a day", nil, &tooLong, "min_rest_minutes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateDogWalkLimits(tt.maxWalks, tt.minRest)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			validationErr, ok := err.(*ValidationError)
+			if !ok || validationErr.Field != tt.wantErr {
+				t.Errorf("Expected validation error on %s, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
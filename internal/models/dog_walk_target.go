@@ -0,0 +1,23 @@
+package models
+
+// MaxTargetWalksPerWeek is the highest accepted target_walks_per_week value
+const MaxTargetWalksPerWeek = 50
+
+// UnderWalkedDog is a dog that got fewer completed walks in the last 7 days than its target
+type UnderWalkedDog struct {
+	DogID             int     `json:"dog_id"`
+	DogName           string  `json:"dog_name"`
+	TargetPerWeek     int     `json:"target_walks_per_week"`
+	WalksLast7Days    int     `json:"walks_last_7_days"`
+	LastWalkDate      *string `json:"last_walk_date,omitempty"`       // YYYY-MM-DD, nil = never walked
+	DaysSinceLastWalk *int    `json:"days_since_last_walk,omitempty"` // nil = never walked
+}
+
+// ValidateWalkTarget validates the target_walks_per_week field of a create or update dog request.
+// 0 removes the target.
+func ValidateWalkTarget(target *int) error {
+	if target != nil && (*target < 0 || *target > MaxTargetWalksPerWeek) {
+		return &ValidationError{Field: "target_walks_per_week", Message: "Target walks per week must be between 0 and 50"}
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package models
+
+import "testing"
+
+// TestValidateWalkTarget tests validation of the target_walks_per_week field
+func TestValidateWalkTarget(t *testing.T) {
+	zero := 0
+	seven := 7
+	negative := -1
+	tooMany := MaxTargetWalksPerWeek + 1
+
+	tests := []struct {
+		name    string
+		target  *int
+		wantErr bool
+	}{
+		{"unset", nil, false},
+		{"valid target", &seven, false},
+		{"zero clears the target", &zero, false},
+		{"negative target", &negative, true},
+		{"too many walks", &tooMany, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateWalkTarget(tt.target)
+			if !tt.wantErr {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			validationErr, ok := err.(*ValidationError)
+			if !ok || validationErr.Field != "target_walks_per_week" {
+				t.Errorf("Expected validation error on target_walks_per_week, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Dog walker rule types
+const (
+	DogWalkerRuleAllow = "allow" // Dog's allowlist: once a dog has one, only listed walkers may walk it
+	DogWalkerRuleDeny  = "deny"  // The walker may not walk the dog
+)
+
+// DogWalkerRule allows or forbids one walker to walk a dog, in addition to the color check.
+// ExpiresAt is the last day (YYYY-MM-DD) the rule applies to; without it the rule never expires.
+type DogWalkerRule struct {
+	ID        int       `json:"id"`
+	DogID     int       `json:"dog_id"`
+	UserID    int       `json:"user_id"`
+	UserName  *string   `json:"user_name,omitempty"` // Populated via JOIN for display
+	RuleType  string    `json:"rule_type"`
+	Reason    string    `json:"reason"`
+	ExpiresAt *string   `json:"expires_at,omitempty"`
+	CreatedBy *int      `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ActiveOn reports whether the rule applies to a walk on date (YYYY-MM-DD)
+func (r *DogWalkerRule) ActiveOn(date string) bool {
+	return r.ExpiresAt == nil || *r.ExpiresAt == "" || date <= *r.ExpiresAt
+}
+
+// DogWalkerRuleViolation returns why rules forbid userID to walk the dog on date, or ""
+// if they allow it. rules are all rules of one dog.
+func DogWalkerRuleViolation(rules []*DogWalkerRule, userID int, date string) string {
+	hasAllowlist := false
+	allowlisted := false
+	for _, rule := range rules {
+		if !rule.ActiveOn(date) {
+			continue
+		}
+		switch rule.RuleType {
+		case DogWalkerRuleDeny:
+			if rule.UserID == userID {
+				return DogWalkerRuleDeny
+			}
+		case DogWalkerRuleAllow:
+			hasAllowlist = true
+			if rule.UserID == userID {
+				allowlisted = true
+			}
+		}
+	}
+
+	if hasAllowlist && !allowlisted {
+		return DogWalkerRuleAllow
+	}
+	return ""
+}
+
+// DogWalkerRuleMessage returns the German message shown to a walker for a violation
+// returned by DogWalkerRuleViolation
+func DogWalkerRuleMessage(violation string) string {
+	if violation == DogWalkerRuleDeny {
+		return "Du darfst diesen Hund nicht ausführen"
+	}
+	return "Dieser Hund darf nur von ausgewählten Gassigehern ausgeführt werden"
+}
+
+// CreateDogWalkerRuleRequest adds a walker to a dog's allowlist or denylist
+type CreateDogWalkerRuleRequest struct {
+	UserID    int     `json:"user_id"`
+	RuleType  string  `json:"rule_type"`
+	Reason    string  `json:"reason"`
+	ExpiresAt *string `json:"expires_at,omitempty"`
+}
+
+// Validate validates the create dog walker rule request
+func (r *CreateDogWalkerRuleRequest) Validate() error {
+	if r.UserID <= 0 {
+		return &ValidationError{Field: "user_id", Message: "User ID must be a positive integer"}
+	}
+
+	if r.RuleType != DogWalkerRuleAllow && r.RuleType != DogWalkerRuleDeny {
+		return &ValidationError{Field: "rule_type", Message: "Rule type must be 'allow' or 'deny'"}
+	}
+
+	r.Reason = strings.TrimSpace(r.Reason)
+	if r.Reason == "" {
+		return &ValidationError{Field: "reason", Message: "Reason is required"}
+	}
+	if len(r.Reason) > 500 {
+		return &ValidationError{Field: "reason", Message: "Reason must be at most 500 characters"}
+	}
+
+	if r.ExpiresAt != nil && *r.ExpiresAt != "" {
+		if _, err := time.Parse("2006-01-02", *r.ExpiresAt); err != nil {
+			return &ValidationError{Field: "expires_at", Message: "Expiry date must be in YYYY-MM-DD format"}
+		}
+	}
+
+	return nil
+}
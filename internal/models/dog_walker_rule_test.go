@@ -0,0 +1,72 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestDogWalkerRuleViolation tests how allowlists, denylists and expiry combine
+func TestDogWalkerRuleViolation(t *testing.T) {
+	expired := "2030-01-09"
+	lastDay := "2030-01-10"
+
+	tests := []struct {
+		name  string
+		rules []*DogWalkerRule
+		user  int
+		want  string
+	}{
+		{"no rules", nil, 1, ""},
+		{"denied walker", []*DogWalkerRule{{UserID: 1, RuleType: DogWalkerRuleDeny}}, 1, DogWalkerRuleDeny},
+		{"other walker denied", []*DogWalkerRule{{UserID: 2, RuleType: DogWalkerRuleDeny}}, 1, ""},
+		{"allowlisted walker", []*DogWalkerRule{{UserID: 1, RuleType: DogWalkerRuleAllow}}, 1, ""},
+		{"walker not on allowlist", []*DogWalkerRule{{UserID: 2, RuleType: DogWalkerRuleAllow}}, 1, DogWalkerRuleAllow},
+		{"expired deny", []*DogWalkerRule{{UserID: 1, RuleType: DogWalkerRuleDeny, ExpiresAt: &expired}}, 1, ""},
+		{"expired allowlist", []*DogWalkerRule{{UserID: 2, RuleType: DogWalkerRuleAllow, ExpiresAt: &expired}}, 1, ""},
+		{"deny on its last day", []*DogWalkerRule{{UserID: 1, RuleType: DogWalkerRuleDeny, ExpiresAt: &lastDay}}, 1, DogWalkerRuleDeny},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DogWalkerRuleViolation(tt.rules, tt.user, "2030-01-10"); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestCreateDogWalkerRuleRequest_Validate tests validation of walker rules
+func TestCreateDogWalkerRuleRequest_Validate(t *testing.T) {
+	validDate := "2030-01-10"
+	invalidDate := "10.01.2030"
+
+	tests := []struct {
+		name    string
+		req     CreateDogWalkerRuleRequest
+		wantErr string
+	}{
+		{"valid deny", CreateDogWalkerRuleRequest{UserID: 1, RuleType: DogWalkerRuleDeny, Reason: "Zieht zu stark"}, ""},
+		{"valid allow with expiry", CreateDogWalkerRuleRequest{UserID: 1, RuleType: DogWalkerRuleAllow, Reason: "Eingewöhnung", ExpiresAt: &validDate}, ""},
+		{"missing user", CreateDogWalkerRuleRequest{RuleType: DogWalkerRuleDeny, Reason: "Grund"}, "user_id"},
+		{"unknown type", CreateDogWalkerRuleRequest{UserID: 1, RuleType: "maybe", Reason: "Grund"}, "rule_type"},
+		{"blank reason", CreateDogWalkerRuleRequest{UserID: 1, RuleType: DogWalkerRuleDeny, Reason: "   "}, "reason"},
+		{"reason too long", CreateDogWalkerRuleRequest{UserID: 1, RuleType: DogWalkerRuleDeny, Reason: strings.Repeat("a", 501)}, "reason"},
+		{"invalid expiry", CreateDogWalkerRuleRequest{UserID: 1, RuleType: DogWalkerRuleDeny, Reason: "Grund", ExpiresAt: &invalidDate}, "expires_at"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			validationErr, ok := err.(*ValidationError)
+			if !ok || validationErr.Field != tt.wantErr {
+				t.Errorf("Expected validation error on %s, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// PairWalk represents two dogs an admin has allowed one walker to take out together
+// DogID is always the lower of the two dog IDs so each pair is stored only once
+type PairWalk struct {
+	ID             int       `json:"id"`
+	DogID          int       `json:"dog_id"`
+	PartnerDogID   int       `json:"partner_dog_id"`
+	PartnerDogName *string   `json:"partner_dog_name,omitempty"` // Populated via JOIN for display
+	CreatedBy      int       `json:"created_by"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// CreatePairWalkRequest represents a request to allow a pair walk for a dog
+type CreatePairWalkRequest struct {
+	PartnerDogID int `json:"partner_dog_id"`
+}
+
+// Validate validates the create pair walk request
+func (r *CreatePairWalkRequest) Validate() error {
+	if r.PartnerDogID <= 0 {
+		return &ValidationError{Field: "partner_dog_id", Message: "Partner dog ID must be a positive integer"}
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+)
+
+// PublicDog is the public profile of an adoptable dog. It is built field by field from the
+// dog so internal fields (special needs and instructions, pickup location, walk route,
+// shelter ID, ...) can never reach the public page or API.
+type PublicDog struct {
+	Slug           string           `json:"slug"`
+	Name           string           `json:"name"`
+	Breed          string           `json:"breed"`
+	Size           string           `json:"size"` // small, medium, large
+	Age            int              `json:"age"`
+	Photo          *string          `json:"photo,omitempty"`
+	PhotoThumbnail *string          `json:"photo_thumbnail,omitempty"`
+	Photos         []PublicDogPhoto `json:"photos"`
+	ExternalLink   *string          `json:"external_link,omitempty"`
+}
+
+// PublicDogPhoto is one photo of a public dog profile
+type PublicDogPhoto struct {
+	PhotoPath      string `json:"photo_path"`
+	PhotoThumbnail string `json:"photo_thumbnail"`
+}
+
+// NewPublicDog returns the public profile of a dog with its gallery photos. Dogs without a
+// gallery show their cover photo only.
+func NewPublicDog(dog *Dog, photos []DogPhoto) *PublicDog {
+	public := &PublicDog{
+		Slug:           DogSlug(dog),
+		Name:           dog.Name,
+		Breed:          dog.Breed,
+		Size:           dog.Size,
+		Age:            dog.Age,
+		Photo:          dog.Photo,
+		PhotoThumbnail: dog.PhotoThumbnail,
+		Photos:         []PublicDogPhoto{},
+		ExternalLink:   dog.ExternalLink,
+	}
+	for _, photo := range photos {
+		public.Photos = append(public.Photos, PublicDogPhoto{PhotoPath: photo.PhotoPath, PhotoThumbnail: photo.PhotoThumbnail})
+	}
+	if len(public.Photos) == 0 && dog.Photo != nil {
+		thumbnail := *dog.Photo
+		if dog.PhotoThumbnail != nil {
+			thumbnail = *dog.PhotoThumbnail
+		}
+		public.Photos = append(public.Photos, PublicDogPhoto{PhotoPath: *dog.Photo, PhotoThumbnail: thumbnail})
+	}
+	return public
+}
+
+// IsPubliclyVisible reports whether the dog's public profile may be shown
+func (d *Dog) IsPubliclyVisible() bool {
+	return d.IsPublic && !d.IsArchived()
+}
+
+// slugReplacer spells out German umlauts so names stay readable in URLs
+var slugReplacer = strings.NewReplacer("ä", "ae", "ö", "oe", "ü", "ue", "ß", "ss")
+
+// DogSlug returns the URL slug of a dog's public page: its name followed by its ID,
+// e.g. "muecke-12". The ID keeps slugs unique; the name part is only for readers.
+func DogSlug(dog *Dog) string {
+	name := slugReplacer.Replace(strings.ToLower(dog.Name))
+
+	var b strings.Builder
+	dash := false
+	for _, r := range name {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	if b.Len() == 0 {
+		b.WriteString("hund")
+	}
+
+	return b.String() + "-" + strconv.Itoa(dog.ID)
+}
+
+// ParseDogSlug returns the dog ID at the end of a slug
+func ParseDogSlug(slug string) (int, bool) {
+	digits := slug[strings.LastIndex(slug, "-")+1:]
+	if digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return 0, false
+	}
+	id, err := strconv.Atoi(digits)
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+	return id, true
+}
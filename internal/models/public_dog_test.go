@@ -0,0 +1,119 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestDogSlug tests URL slugs of public dog pages
+func TestDogSlug(t *testing.T) {
+	tests := []struct {
+		name string
+		id   int
+		want string
+	}{
+		{"Bello", 3, "bello-3"},
+		{"Mücke", 12, "muecke-12"},
+		{"Herr Größe", 5, "herr-groesse-5"},
+		{"  Kira & Nala! ", 7, "kira-nala-7"},
+		{"Rex 2", 8, "rex-2-8"},
+		{"🐕", 9, "hund-9"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DogSlug(&Dog{ID: tt.id, Name: tt.name}); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestParseDogSlug tests reading the dog ID from a slug
+func TestParseDogSlug(t *testing.T) {
+	tests := []struct {
+		slug   string
+		wantID int
+		wantOK bool
+	}{
+		{"bello-3", 3, true},
+		{"rex-2-8", 8, true},
+		{"42", 42, true},
+		{"bello", 0, false},
+		{"bello-", 0, false},
+		{"bello-0", 0, false},
+		{"bello-+3", 0, false},
+		{"bello-3a", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.slug, func(t *testing.T) {
+			id, ok := ParseDogSlug(tt.slug)
+			if id != tt.wantID || ok != tt.wantOK {
+				t.Errorf("Expected (%d, %v), got (%d, %v)", tt.wantID, tt.wantOK, id, ok)
+			}
+		})
+	}
+}
+
+// TestNewPublicDog tests that the public profile only carries public fields
+func TestNewPublicDog(t *testing.T) {
+	photo := "dogs/bello.jpg"
+	thumbnail := "dogs/bello_thumb.jpg"
+	link := "https://tierheim.example.com/bello"
+	secret := "geheim"
+	shelterID := "T-1"
+	dog := &Dog{
+		ID: 3, Name: "Bello", Breed: "Mischling", Size: "large", Age: 7,
+		Photo: &photo, PhotoThumbnail: &thumbnail, ExternalLink: &link,
+		SpecialNeeds: &secret, SpecialInstructions: &secret, PickupLocation: &secret,
+		WalkRoute: &secret, ShelterID: &shelterID, IsPublic: true,
+	}
+
+	t.Run("cover photo without gallery", func(t *testing.T) {
+		public := NewPublicDog(dog, nil)
+		if public.Slug != "bello-3" || len(public.Photos) != 1 || public.Photos[0].PhotoThumbnail != thumbnail {
+			t.Errorf("Expected slug and cover photo, got %+v", public)
+		}
+	})
+
+	t.Run("gallery", func(t *testing.T) {
+		public := NewPublicDog(dog, []DogPhoto{
+			{PhotoPath: "dogs/1.jpg", PhotoThumbnail: "dogs/1_thumb.jpg"},
+			{PhotoPath: "dogs/2.jpg", PhotoThumbnail: "dogs/2_thumb.jpg"},
+		})
+		if len(public.Photos) != 2 || public.Photos[1].PhotoPath != "dogs/2.jpg" {
+			t.Errorf("Expected the gallery photos, got %+v", public.Photos)
+		}
+	})
+
+	t.Run("no internal fields", func(t *testing.T) {
+		data, _ := json.Marshal(NewPublicDog(dog, nil))
+		if strings.Contains(string(data), secret) || strings.Contains(string(data), shelterID) {
+			t.Errorf("Expected no internal fields, got %s", data)
+		}
+	})
+}
+
+// TestDog_IsPubliclyVisible tests which dogs have a public page
+func TestDog_IsPubliclyVisible(t *testing.T) {
+	tests := []struct {
+		name string
+		dog  Dog
+		want bool
+	}{
+		{"public", Dog{IsPublic: true, Status: DogStatusInShelter}, true},
+		{"reserved", Dog{IsPublic: true, Status: DogStatusReserved}, true},
+		{"not public", Dog{Status: DogStatusInShelter}, false},
+		{"adopted", Dog{IsPublic: true, Status: DogStatusAdopted}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.dog.IsPubliclyVisible(); got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
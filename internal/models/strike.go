@@ -0,0 +1,52 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Strike reasons
+const (
+	StrikeReasonNoShow           = "no_show"
+	StrikeReasonLateCancellation = "late_cancellation"
+)
+
+// UserStrike represents a no-show or a cancellation inside the cancellation notice period
+type UserStrike struct {
+	ID            int        `json:"id"`
+	UserID        int        `json:"user_id"`
+	BookingID     *int       `json:"booking_id,omitempty"`
+	Reason        string     `json:"reason"` // 'no_show', 'late_cancellation'
+	CreatedAt     time.Time  `json:"created_at"`
+	ForgivenAt    *time.Time `json:"forgiven_at,omitempty"`
+	ForgivenBy    *int       `json:"forgiven_by,omitempty"`
+	ForgiveReason *string    `json:"forgive_reason,omitempty"`
+}
+
+// IsActive reports whether the strike still counts (not forgiven)
+func (s *UserStrike) IsActive() bool {
+	return s.ForgivenAt == nil
+}
+
+// StrikeSummary describes a user's strikes within the strike window
+type StrikeSummary struct {
+	ActiveCount          int           `json:"active_count"`
+	WindowDays           int           `json:"window_days"`
+	WarningThreshold     int           `json:"warning_threshold"`
+	RestrictionThreshold int           `json:"restriction_threshold"`
+	IsRestricted         bool          `json:"is_restricted"`
+	RestrictedUntil      *time.Time    `json:"restricted_until,omitempty"`
+	Strikes              []*UserStrike `json:"strikes"` // Strikes within the window, including forgiven ones
+}
+
+// RestrictionMessage explains a strike restriction to the walker
+func (s *StrikeSummary) RestrictionMessage() string {
+	return fmt.Sprintf(
+		"Du hast %d Verwarnungen wegen nicht wahrgenommener oder kurzfristig stornierter Spaziergänge und kannst bis zum %s keine neuen Buchungen anlegen",
+		s.ActiveCount, s.RestrictedUntil.Format("02.01.2006"))
+}
+
+// ForgiveStrikeRequest represents an admin request to forgive a strike
+type ForgiveStrikeRequest struct {
+	Reason *string `json:"reason,omitempty"`
+}
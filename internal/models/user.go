@@ -9,34 +9,35 @@ import (
 
 // User represents a user in the system
 type User struct {
-	ID                       int              `json:"id"`
-	FirstName                string           `json:"first_name"`
-	LastName                 string           `json:"last_name"`
-	Email                    *string          `json:"email,omitempty"`
-	Phone                    *string          `json:"phone,omitempty"`
-	PasswordHash             *string          `json:"-"`
-	Colors                   []ColorCategory  `json:"colors,omitempty"`
+	ID           int             `json:"id"`
+	FirstName    string          `json:"first_name"`
+	LastName     string          `json:"last_name"`
+	Email        *string         `json:"email,omitempty"`
+	Phone        *string         `json:"phone,omitempty"`
+	PasswordHash *string         `json:"-"`
+	Colors       []ColorCategory `json:"colors,omitempty"`
 	// DONE: Admin flags
-	IsAdmin                  bool             `json:"is_admin"`
-	IsSuperAdmin             bool             `json:"is_super_admin"`
-	IsVerified               bool       `json:"is_verified"`
-	IsActive                 bool       `json:"is_active"`
-	IsDeleted                bool       `json:"is_deleted"`
-	MustChangePassword       bool       `json:"must_change_password"`
-	VerificationToken        *string    `json:"-"`
-	VerificationTokenExpires *time.Time `json:"-"`
-	PasswordResetToken       *string    `json:"-"`
-	PasswordResetExpires     *time.Time `json:"-"`
-	ProfilePhoto             *string    `json:"profile_photo,omitempty"`
-	AnonymousID              *string    `json:"anonymous_id,omitempty"`
-	TermsAcceptedAt          time.Time  `json:"terms_accepted_at"`
-	LastActivityAt           time.Time  `json:"last_activity_at"`
-	DeactivatedAt            *time.Time `json:"deactivated_at,omitempty"`
-	DeactivationReason       *string    `json:"deactivation_reason,omitempty"`
-	ReactivatedAt            *time.Time `json:"reactivated_at,omitempty"`
-	DeletedAt                *time.Time `json:"deleted_at,omitempty"`
-	CreatedAt                time.Time  `json:"created_at"`
-	UpdatedAt                time.Time  `json:"updated_at"`
+	IsAdmin                  bool           `json:"is_admin"`
+	IsSuperAdmin             bool           `json:"is_super_admin"`
+	IsVerified               bool           `json:"is_verified"`
+	IsActive                 bool           `json:"is_active"`
+	IsDeleted                bool           `json:"is_deleted"`
+	MustChangePassword       bool           `json:"must_change_password"`
+	VerificationToken        *string        `json:"-"`
+	VerificationTokenExpires *time.Time     `json:"-"`
+	PasswordResetToken       *string        `json:"-"`
+	PasswordResetExpires     *time.Time     `json:"-"`
+	ProfilePhoto             *string        `json:"profile_photo,omitempty"`
+	AnonymousID              *string        `json:"anonymous_id,omitempty"`
+	TermsAcceptedAt          time.Time      `json:"terms_accepted_at"`
+	LastActivityAt           time.Time      `json:"last_activity_at"`
+	DeactivatedAt            *time.Time     `json:"deactivated_at,omitempty"`
+	DeactivationReason       *string        `json:"deactivation_reason,omitempty"`
+	ReactivatedAt            *time.Time     `json:"reactivated_at,omitempty"`
+	DeletedAt                *time.Time     `json:"deleted_at,omitempty"`
+	Strikes                  *StrikeSummary `json:"strikes,omitempty"` // Only filled in the admin user detail
+	CreatedAt                time.Time      `json:"created_at"`
+	UpdatedAt                time.Time      `json:"updated_at"`
 }
 
 // FullName returns the user's full name (FirstName LastName)
@@ -0,0 +1,54 @@
+package models
+
+import "time"
+
+// WaitlistEntry represents a user queueing for a taken dog slot
+// Status flow: waiting -> offered -> claimed, or offered -> expired (offer moves to the next user).
+// Users can leave the waitlist at any time (cancelled).
+type WaitlistEntry struct {
+	ID             int        `json:"id"`
+	UserID         int        `json:"user_id"`
+	DogID          int        `json:"dog_id"`
+	Date           string     `json:"date"`           // YYYY-MM-DD format
+	ScheduledTime  string     `json:"scheduled_time"` // HH:MM format
+	Status         string     `json:"status"`         // 'waiting', 'offered', 'claimed', 'expired', 'cancelled'
+	OfferedAt      *time.Time `json:"offered_at,omitempty"`
+	OfferExpiresAt *time.Time `json:"offer_expires_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+
+	// Joined data for responses
+	DogName *string `json:"dog_name,omitempty"`
+}
+
+// JoinWaitlistRequest represents a request to queue for a taken dog slot
+type JoinWaitlistRequest struct {
+	DogID         int    `json:"dog_id"`
+	Date          string `json:"date"`           // YYYY-MM-DD
+	ScheduledTime string `json:"scheduled_time"` // HH:MM
+}
+
+// Validate validates the join waitlist request
+func (r *JoinWaitlistRequest) Validate() error {
+	if r.DogID <= 0 {
+		return &ValidationError{Field: "dog_id", Message: "Dog ID is required"}
+	}
+
+	if r.Date == "" {
+		return &ValidationError{Field: "date", Message: "Date is required"}
+	}
+
+	if _, err := time.Parse("2006-01-02", r.Date); err != nil {
+		return &ValidationError{Field: "date", Message: "Date must be in YYYY-MM-DD format"}
+	}
+
+	if r.ScheduledTime == "" {
+		return &ValidationError{Field: "scheduled_time", Message: "Scheduled time is required"}
+	}
+
+	if _, err := time.Parse("15:04", r.ScheduledTime); err != nil {
+		return &ValidationError{Field: "scheduled_time", Message: "Scheduled time must be in HH:MM format"}
+	}
+
+	return nil
+}
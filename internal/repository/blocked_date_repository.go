@@ -19,15 +19,70 @@ func NewBlockedDateRepository(db *sql.DB) *BlockedDateRepository {
 	return &BlockedDateRepository{db: db}
 }
 
+// sqlExecer is implemented by both *sql.DB and *sql.Tx
+type sqlExecer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
 // Create creates a new blocked date (global or dog-specific)
 func (r *BlockedDateRepository) Create(blockedDate *models.BlockedDate) error {
+	return r.insert(r.db, blockedDate)
+}
+
+// CreateWithCancellations blocks a date and cancels the given bookings in one transaction,
+// recording reason as admin cancellation reason. Bookings that are no longer scheduled are skipped.
+// Returns the IDs of the bookings actually cancelled.
+func (r *BlockedDateRepository) CreateWithCancellations(blockedDate *models.BlockedDate, bookingIDs []int, reason string) ([]int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := r.insert(tx, blockedDate); err != nil {
+		return nil, err
+	}
+
+	cancelled := []int{}
+	now := time.Now()
+	for _, id := range bookingIDs {
+		result, err := tx.Exec(`
+			UPDATE bookings
+			SET status = 'cancelled', admin_cancellation_reason = ?, updated_at = ?
+			WHERE id = ? AND status = 'scheduled'
+		`, reason, now, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to cancel booking %d: %w", id, err)
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		if rows == 0 {
+			continue
+		}
+		if err := insertBookingEvent(tx, id, models.BookingEventCancelled, &blockedDate.CreatedBy, "", reason); err != nil {
+			return nil, err
+		}
+		cancelled = append(cancelled, id)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return cancelled, nil
+}
+
+// insert stores a blocked date using db or a transaction
+func (r *BlockedDateRepository) insert(exec sqlExecer, blockedDate *models.BlockedDate) error {
 	query := `
 		INSERT INTO blocked_dates (date, dog_id, reason, created_by, created_at)
 		VALUES (?, ?, ?, ?, ?)
 	`
 
 	now := time.Now()
-	result, err := r.db.Exec(query,
+	result, err := exec.Exec(query,
 		blockedDate.Date,
 		blockedDate.DogID, // Can be nil for global block
 		blockedDate.Reason,
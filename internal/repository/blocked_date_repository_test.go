@@ -546,3 +546,59 @@ func TestBlockedDateRepository_FindAllWithDogInfo(t *testing.T) {
 		}
 	})
 }
+
+// TestBlockedDateRepository_CreateWithCancellations tests blocking a date and cancelling bookings in one transaction
+func TestBlockedDateRepository_CreateWithCancellations(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := NewBlockedDateRepository(db)
+
+	adminID := testutil.SeedTestUser(t, db, "admin@test.com", "Admin", "orange")
+	userID := testutil.SeedTestUser(t, db, "walker@test.com", "Walker", "green")
+	dogID := testutil.SeedTestDog(t, db, "Buddy", "Labrador", "green")
+
+	scheduledID := testutil.SeedTestBooking(t, db, userID, dogID, "2030-12-25", "09:00", "scheduled")
+	completedID := testutil.SeedTestBooking(t, db, userID, dogID, "2030-12-25", "15:00", "completed")
+
+	status := func(id int) (string, string) {
+		var status string
+		var reason *string
+		db.QueryRow("SELECT status, admin_cancellation_reason FROM bookings WHERE id = ?", id).Scan(&status, &reason)
+		if reason == nil {
+			return status, ""
+		}
+		return status, *reason
+	}
+
+	t.Run("block and cancel", func(t *testing.T) {
+		blockedDate := &models.BlockedDate{Date: "2030-12-25", Reason: "Weihnachten", CreatedBy: adminID}
+		cancelled, err := repo.CreateWithCancellations(blockedDate, []int{scheduledID, completedID}, "Gesperrt: Weihnachten")
+		if err != nil {
+			t.Fatalf("CreateWithCancellations() failed: %v", err)
+		}
+		if len(cancelled) != 1 || cancelled[0] != scheduledID {
+			t.Errorf("Expected only booking %d to be cancelled, got %v", scheduledID, cancelled)
+		}
+		if blockedDate.ID == 0 {
+			t.Error("BlockedDate ID should be set after creation")
+		}
+
+		if s, reason := status(scheduledID); s != "cancelled" || reason != "Gesperrt: Weihnachten" {
+			t.Errorf("Expected cancelled with reason, got %s (%q)", s, reason)
+		}
+		if s, _ := status(completedID); s != "completed" {
+			t.Errorf("Completed booking should stay completed, got %s", s)
+		}
+	})
+
+	t.Run("duplicate block rolls back cancellations", func(t *testing.T) {
+		otherID := testutil.SeedTestBooking(t, db, userID, dogID, "2030-12-25", "12:00", "scheduled")
+
+		blockedDate := &models.BlockedDate{Date: "2030-12-25", Reason: "Nochmal", CreatedBy: adminID}
+		if _, err := repo.CreateWithCancellations(blockedDate, []int{otherID}, "Gesperrt"); err == nil {
+			t.Fatal("Expected error for duplicate block")
+		}
+		if s, _ := status(otherID); s != "scheduled" {
+			t.Errorf("Booking should stay scheduled after rollback, got %s", s)
+		}
+	})
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tranmh/gassigeher/internal/models"
+)
+
+// BookingEventRepository handles the change history of bookings
+type BookingEventRepository struct {
+	db *sql.DB
+}
+
+// NewBookingEventRepository creates a new booking event repository
+func NewBookingEventRepository(db *sql.DB) *BookingEventRepository {
+	return &BookingEventRepository{db: db}
+}
+
+// Record appends an event to a booking's history. actorID is nil for changes made
+// by the system; empty values are stored as NULL.
+func (r *BookingEventRepository) Record(bookingID int, eventType string, actorID *int, oldValue, newValue string) error {
+	return insertBookingEvent(r.db, bookingID, eventType, actorID, oldValue, newValue)
+}
+
+// FindByBooking returns the history of a booking, oldest first
+func (r *BookingEventRepository) FindByBooking(bookingID int) ([]*models.BookingEvent, error) {
+	rows, err := r.db.Query(`
+		SELECT e.id, e.booking_id, e.event_type, e.actor_id, e.old_value, e.new_value, e.created_at,
+		       u.first_name, u.last_name
+		FROM booking_events e
+		LEFT JOIN users u ON e.actor_id = u.id
+		WHERE e.booking_id = ?
+		ORDER BY e.created_at ASC, e.id ASC
+	`, bookingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query booking events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []*models.BookingEvent{}
+	for rows.Next() {
+		event := &models.BookingEvent{}
+		var firstName, lastName sql.NullString
+		if err := rows.Scan(
+			&event.ID,
+			&event.BookingID,
+			&event.EventType,
+			&event.ActorID,
+			&event.OldValue,
+			&event.NewValue,
+			&event.CreatedAt,
+			&firstName,
+			&lastName,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan booking event: %w", err)
+		}
+		if firstName.Valid || lastName.Valid {
+			name := strings.TrimSpace(firstName.String + " " + lastName.String)
+			event.ActorName = &name
+		}
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+// insertBookingEvent stores a booking event using db or a transaction, so changes made
+// inside a transaction are recorded atomically with it
+func insertBookingEvent(exec sqlExecer, bookingID int, eventType string, actorID *int, oldValue, newValue string) error {
+	_, err := exec.Exec(`
+		INSERT INTO booking_events (booking_id, event_type, actor_id, old_value, new_value, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, bookingID, eventType, actorID, nullIfEmpty(oldValue), nullIfEmpty(newValue), time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to record booking event: %w", err)
+	}
+
+	return nil
+}
+
+// nullIfEmpty maps "" to NULL
+func nullIfEmpty(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
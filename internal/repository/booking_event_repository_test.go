@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tranmh/gassigeher/internal/models"
+	"github.com/tranmh/gassigeher/internal/testutil"
+)
+
+// TestBookingEventRepository tests that booking changes end up in the booking's history
+func TestBookingEventRepository(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := NewBookingEventRepository(db)
+	bookingRepo := NewBookingRepository(db)
+
+	walkerID := testutil.SeedTestUser(t, db, "walker@example.com", "Wanda Walker", "green")
+	takerID := testutil.SeedTestUser(t, db, "taker@example.com", "Tom Taker", "green")
+	adminID := testutil.SeedTestUser(t, db, "admin@example.com", "Admin", "orange")
+	dogID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+
+	eventTypes := func(t *testing.T, bookingID int) []string {
+		t.Helper()
+		events, err := repo.FindByBooking(bookingID)
+		if err != nil {
+			t.Fatalf("FindByBooking() failed: %v", err)
+		}
+		types := []string{}
+		for _, event := range events {
+			types = append(types, event.EventType)
+		}
+		return types
+	}
+
+	t.Run("creation and manual events", func(t *testing.T) {
+		booking := &models.Booking{UserID: walkerID, DogID: dogID, Date: "2030-01-09", ScheduledTime: "09:00"}
+		if err := bookingRepo.Create(booking); err != nil {
+			t.Fatalf("Create() failed: %v", err)
+		}
+		if err := repo.Record(booking.ID, models.BookingEventMoved, &adminID, "2030-01-09 09:00", "2030-01-10 10:00"); err != nil {
+			t.Fatalf("Record() failed: %v", err)
+		}
+		if err := repo.Record(booking.ID, models.BookingEventCancelled, &walkerID, "", ""); err != nil {
+			t.Fatalf("Record() failed: %v", err)
+		}
+
+		events, err := repo.FindByBooking(booking.ID)
+		if err != nil {
+			t.Fatalf("FindByBooking() failed: %v", err)
+		}
+		if len(events) != 3 {
+			t.Fatalf("Expected 3 events, got %d", len(events))
+		}
+
+		created := events[0]
+		if created.EventType != models.BookingEventCreated || created.ActorID == nil || *created.ActorID != walkerID {
+			t.Errorf("Expected creation by walker, got %+v", created)
+		}
+		if created.NewValue == nil || *created.NewValue != "2030-01-09 09:00" {
+			t.Errorf("Expected creation slot as new value, got %v", created.NewValue)
+		}
+		if created.ActorName == nil || *created.ActorName != "Wanda Walker" {
+			t.Errorf("Expected actor name 'Wanda Walker', got %v", created.ActorName)
+		}
+
+		moved := events[1]
+		if moved.OldValue == nil || *moved.OldValue != "2030-01-09 09:00" || moved.NewValue == nil || *moved.NewValue != "2030-01-10 10:00" {
+			t.Errorf("Unexpected move values: %+v", moved)
+		}
+		if events[2].OldValue != nil || events[2].NewValue != nil {
+			t.Errorf("Expected empty values to be stored as NULL, got %+v", events[2])
+		}
+	})
+
+	t.Run("approval and rejection", func(t *testing.T) {
+		approved := &models.Booking{UserID: walkerID, DogID: dogID, Date: "2030-02-01", ScheduledTime: "09:00", RequiresApproval: true}
+		rejected := &models.Booking{UserID: walkerID, DogID: dogID, Date: "2030-02-02", ScheduledTime: "09:00", RequiresApproval: true}
+		bookingRepo.Create(approved)
+		bookingRepo.Create(rejected)
+
+		if err := bookingRepo.ApproveBooking(approved.ID, adminID); err != nil {
+			t.Fatalf("ApproveBooking() failed: %v", err)
+		}
+		if err := bookingRepo.RejectBooking(rejected.ID, adminID, "Zu kalt"); err != nil {
+			t.Fatalf("RejectBooking() failed: %v", err)
+		}
+
+		if types := eventTypes(t, approved.ID); len(types) != 2 || types[1] != models.BookingEventApproved {
+			t.Errorf("Expected created, approved; got %v", types)
+		}
+		events, _ := repo.FindByBooking(rejected.ID)
+		last := events[len(events)-1]
+		if last.EventType != models.BookingEventRejected || last.NewValue == nil || *last.NewValue != "Zu kalt" {
+			t.Errorf("Expected rejection with reason, got %+v", last)
+		}
+	})
+
+	t.Run("transfer", func(t *testing.T) {
+		bookingID := testutil.SeedTestBooking(t, db, walkerID, dogID, "2030-03-01", "09:00", "scheduled")
+		transferRepo := NewBookingTransferRepository(db)
+		offer := &models.BookingTransfer{BookingID: bookingID, FromUserID: walkerID}
+		transferRepo.Create(offer)
+
+		if err := transferRepo.Complete(offer, takerID, &adminID, time.Now()); err != nil {
+			t.Fatalf("Complete() failed: %v", err)
+		}
+
+		events, _ := repo.FindByBooking(bookingID)
+		if len(events) != 1 || events[0].EventType != models.BookingEventTransferred {
+			t.Fatalf("Expected one transfer event, got %v", events)
+		}
+		if *events[0].ActorID != adminID || *events[0].OldValue == "" || *events[0].NewValue == "" {
+			t.Errorf("Unexpected transfer event %+v", events[0])
+		}
+	})
+
+	t.Run("cancellation by blocked date", func(t *testing.T) {
+		bookingID := testutil.SeedTestBooking(t, db, walkerID, dogID, "2030-04-01", "09:00", "scheduled")
+		cancelledID := testutil.SeedTestBooking(t, db, walkerID, dogID, "2030-04-01", "14:00", "cancelled")
+
+		blockedRepo := NewBlockedDateRepository(db)
+		blocked := &models.BlockedDate{Date: "2030-04-01", Reason: "Tierarzt", CreatedBy: adminID}
+		if _, err := blockedRepo.CreateWithCancellations(blocked, []int{bookingID, cancelledID}, "Tierarzt"); err != nil {
+			t.Fatalf("CreateWithCancellations() failed: %v", err)
+		}
+
+		if types := eventTypes(t, bookingID); len(types) != 1 || types[0] != models.BookingEventCancelled {
+			t.Errorf("Expected a cancellation event, got %v", types)
+		}
+		if types := eventTypes(t, cancelledID); len(types) != 0 {
+			t.Errorf("Expected no event for an already cancelled booking, got %v", types)
+		}
+	})
+
+	t.Run("system completion and no-show", func(t *testing.T) {
+		yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+		walkedID := testutil.SeedTestBooking(t, db, walkerID, dogID, yesterday, "09:00", "scheduled")
+		missedID := testutil.SeedTestBooking(t, db, walkerID, dogID, yesterday, "14:00", "scheduled")
+		db.Exec("UPDATE bookings SET checked_in_at = ? WHERE id = ?", time.Now().AddDate(0, 0, -1), walkedID)
+
+		if _, err := bookingRepo.AutoComplete(); err != nil {
+			t.Fatalf("AutoComplete() failed: %v", err)
+		}
+		if _, err := bookingRepo.MarkNoShows(60); err != nil {
+			t.Fatalf("MarkNoShows() failed: %v", err)
+		}
+
+		events, _ := repo.FindByBooking(walkedID)
+		if len(events) != 1 || events[0].EventType != models.BookingEventCompleted || events[0].ActorID != nil {
+			t.Errorf("Expected one system completion event, got %+v", events)
+		}
+		events, _ = repo.FindByBooking(missedID)
+		if len(events) != 1 || events[0].EventType != models.BookingEventNoShow || events[0].ActorID != nil {
+			t.Errorf("Expected one system no-show event, got %+v", events)
+		}
+	})
+}
@@ -27,11 +27,87 @@ func NewBookingRepository(db *sql.DB) *BookingRepository {
 	return &BookingRepository{db: db}
 }
 
+// errSlotTaken is returned when the database rejects a second scheduled booking for the same slot
+const errSlotTaken = "booking slot already taken"
+
+// sqlRunner is implemented by both *sql.DB and *sql.Tx
+type sqlRunner interface {
+	sqlExecer
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
 // Create creates a new booking
 func (r *BookingRepository) Create(booking *models.Booking) error {
+	return r.insert(r.db, booking)
+}
+
+// CreateIfFree creates a booking in one transaction after checking again that neither the dog
+// (its walk duration + bufferMinutes) nor the walker has an overlapping scheduled walk and that
+// the walk stays within the dog's walks per day and rest between walks.
+// The dog and user rows are locked first, so concurrent requests for the same dog or walker
+// run one after another and only the first one gets the slot.
+// Returns the conflicting booking or the violated walk limit instead of creating one; a
+// conflict with another dog (DogID != booking.DogID) means the walker is busy.
+func (r *BookingRepository) CreateIfFree(booking *models.Booking, dog *models.Dog, bufferMinutes int) (*models.BookingConflict, *models.DogWalkLimitViolation, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// A no-op update takes a row lock on MySQL/PostgreSQL and the write lock on SQLite
+	if _, err := tx.Exec("UPDATE dogs SET updated_at = updated_at WHERE id = ?", booking.DogID); err != nil {
+		return nil, nil, fmt.Errorf("failed to lock dog: %w", err)
+	}
+	if _, err := tx.Exec("UPDATE users SET updated_at = updated_at WHERE id = ?", booking.UserID); err != nil {
+		return nil, nil, fmt.Errorf("failed to lock user: %w", err)
+	}
+
+	durationMinutes := dog.GetWalkDuration()
+	conflict, err := r.findConflict(tx, dogConflictQuery, []interface{}{booking.DogID, booking.Date, 0},
+		booking.ScheduledTime, durationMinutes, bufferMinutes)
+	if err != nil || conflict != nil {
+		return conflict, nil, err
+	}
+
+	if dog.HasWalkLimits() {
+		walkTimes, err := r.findDogWalkTimes(tx, booking.DogID, booking.Date, 0)
+		if err != nil {
+			return nil, nil, err
+		}
+		if violation := models.CheckDogWalkLimits(dog, walkTimes, booking.ScheduledTime); violation != nil {
+			return nil, violation, nil
+		}
+	}
+
+	conflict, err = r.findConflict(tx, userConflictQuery,
+		[]interface{}{booking.UserID, booking.DogID, booking.Date, 0, booking.ScheduledTime, booking.DogID, booking.DogID},
+		booking.ScheduledTime, durationMinutes, 0)
+	if err != nil || conflict != nil {
+		return conflict, nil, err
+	}
+
+	if err := r.insert(tx, booking); err != nil {
+		return nil, nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil, nil, nil
+}
+
+// IsSlotTakenError reports whether err is the unique slot violation returned by Create and CreateIfFree
+func IsSlotTakenError(err error) bool {
+	return err != nil && err.Error() == errSlotTaken
+}
+
+// insert stores a booking and its creation event using db or a transaction
+func (r *BookingRepository) insert(exec sqlExecer, booking *models.Booking) error {
 	query := `
-		INSERT INTO bookings (user_id, dog_id, date, scheduled_time, status, requires_approval, approval_status, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO bookings (user_id, dog_id, date, scheduled_time, status, requires_approval, approval_status, series_id, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	now := time.Now()
@@ -48,7 +124,7 @@ func (r *BookingRepository) Create(booking *models.Booking) error {
 		}
 	}
 
-	result, err := r.db.Exec(query,
+	result, err := exec.Exec(query,
 		booking.UserID,
 		booking.DogID,
 		booking.Date,
@@ -56,11 +132,15 @@ func (r *BookingRepository) Create(booking *models.Booking) error {
 		booking.Status,
 		booking.RequiresApproval,
 		booking.ApprovalStatus,
+		booking.SeriesID,
 		now,
 		now,
 	)
 
 	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf(errSlotTaken)
+		}
 		return fmt.Errorf("failed to create booking: %w", err)
 	}
 
@@ -73,19 +153,30 @@ func (r *BookingRepository) Create(booking *models.Booking) error {
 	booking.CreatedAt = now
 	booking.UpdatedAt = now
 
-	return nil
+	// Walkers always book for themselves (series occurrences on behalf of their owner)
+	return insertBookingEvent(exec, booking.ID, models.BookingEventCreated, &booking.UserID, "",
+		models.BookingEventSlot(booking.Date, booking.ScheduledTime))
+}
+
+// isUniqueViolation detects unique index violations of SQLite, PostgreSQL and MySQL
+func isUniqueViolation(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "UNIQUE constraint") || // SQLite
+		strings.Contains(msg, "unique constraint") || // PostgreSQL
+		strings.Contains(msg, "Duplicate entry") // MySQL (Error 1062)
 }
 
 // FindByID finds a booking by ID
 func (r *BookingRepository) FindByID(id int) (*models.Booking, error) {
 	query := `
-		SELECT id, user_id, dog_id, date, scheduled_time, status,
-		       completed_at, user_notes, admin_cancellation_reason, created_at, updated_at
+		SELECT id, user_id, dog_id, date, scheduled_time, status, approval_status,
+		       completed_at, user_notes, admin_cancellation_reason, series_id, checked_in_at, checked_out_at, created_at, updated_at
 		FROM bookings
 		WHERE id = ?
 	`
 
 	booking := &models.Booking{}
+	var approvalStatus sql.NullString
 	err := r.db.QueryRow(query, id).Scan(
 		&booking.ID,
 		&booking.UserID,
@@ -93,9 +184,13 @@ func (r *BookingRepository) FindByID(id int) (*models.Booking, error) {
 		&booking.Date,
 		&booking.ScheduledTime,
 		&booking.Status,
+		&approvalStatus,
 		&booking.CompletedAt,
 		&booking.UserNotes,
 		&booking.AdminCancellationReason,
+		&booking.SeriesID,
+		&booking.CheckedInAt,
+		&booking.CheckedOutAt,
 		&booking.CreatedAt,
 		&booking.UpdatedAt,
 	)
@@ -108,57 +203,20 @@ func (r *BookingRepository) FindByID(id int) (*models.Booking, error) {
 		return nil, fmt.Errorf("failed to find booking: %w", err)
 	}
 
+	booking.ApprovalStatus = approvalStatus.String
 	booking.Date = normalizeDate(booking.Date)
 	return booking, nil
 }
 
 // FindAll finds all bookings with optional filters
 func (r *BookingRepository) FindAll(filter *models.BookingFilterRequest) ([]*models.Booking, error) {
+	conditions, args := bookingFilterConditions(filter)
 	query := `
-		SELECT id, user_id, dog_id, date, scheduled_time, status,
-		       completed_at, user_notes, admin_cancellation_reason, created_at, updated_at
-		FROM bookings
+		SELECT id, user_id, dog_id, date, scheduled_time, status, approval_status,
+		       completed_at, user_notes, admin_cancellation_reason, series_id, checked_in_at, checked_out_at, created_at, updated_at
+		FROM bookings b
 		WHERE 1=1
-	`
-	args := []interface{}{}
-
-	if filter != nil {
-		if filter.UserID != nil {
-			query += " AND user_id = ?"
-			args = append(args, *filter.UserID)
-		}
-
-		if filter.DogID != nil {
-			query += " AND dog_id = ?"
-			args = append(args, *filter.DogID)
-		}
-
-		if filter.DateFrom != nil {
-			query += " AND date >= ?"
-			args = append(args, *filter.DateFrom)
-		}
-
-		if filter.DateTo != nil {
-			query += " AND date <= ?"
-			args = append(args, *filter.DateTo)
-		}
-
-		if filter.Status != nil {
-			query += " AND status = ?"
-			args = append(args, *filter.Status)
-		}
-
-		if filter.Year != nil && filter.Month != nil {
-			// Filter by year and month
-			startDate := fmt.Sprintf("%d-%02d-01", *filter.Year, *filter.Month)
-			// Calculate last day of month
-			nextMonth := time.Date(*filter.Year, time.Month(*filter.Month+1), 1, 0, 0, 0, 0, time.UTC)
-			endDate := nextMonth.Add(-24 * time.Hour).Format("2006-01-02")
-
-			query += " AND date >= ? AND date <= ?"
-			args = append(args, startDate, endDate)
-		}
-	}
+	` + conditions
 
 	query += " ORDER BY date ASC, scheduled_time ASC"
 
@@ -171,6 +229,7 @@ func (r *BookingRepository) FindAll(filter *models.BookingFilterRequest) ([]*mod
 	bookings := []*models.Booking{}
 	for rows.Next() {
 		booking := &models.Booking{}
+		var approvalStatus sql.NullString
 		err := rows.Scan(
 			&booking.ID,
 			&booking.UserID,
@@ -178,9 +237,13 @@ func (r *BookingRepository) FindAll(filter *models.BookingFilterRequest) ([]*mod
 			&booking.Date,
 			&booking.ScheduledTime,
 			&booking.Status,
+			&approvalStatus,
 			&booking.CompletedAt,
 			&booking.UserNotes,
 			&booking.AdminCancellationReason,
+			&booking.SeriesID,
+			&booking.CheckedInAt,
+			&booking.CheckedOutAt,
 			&booking.CreatedAt,
 			&booking.UpdatedAt,
 		)
@@ -188,12 +251,125 @@ func (r *BookingRepository) FindAll(filter *models.BookingFilterRequest) ([]*mod
 			return nil, fmt.Errorf("failed to scan booking: %w", err)
 		}
 		booking.Date = normalizeDate(booking.Date)
+		booking.ApprovalStatus = approvalStatus.String
 		bookings = append(bookings, booking)
 	}
 
 	return bookings, nil
 }
 
+// bookingFilterConditions builds the WHERE conditions of a booking filter on the bookings table aliased as b
+func bookingFilterConditions(filter *models.BookingFilterRequest) (string, []interface{}) {
+	query := ""
+	args := []interface{}{}
+	if filter == nil {
+		return query, args
+	}
+
+	if filter.UserID != nil {
+		query += " AND b.user_id = ?"
+		args = append(args, *filter.UserID)
+	}
+
+	if filter.DogID != nil {
+		query += " AND b.dog_id = ?"
+		args = append(args, *filter.DogID)
+	}
+
+	if filter.DateFrom != nil {
+		query += " AND b.date >= ?"
+		args = append(args, *filter.DateFrom)
+	}
+
+	if filter.DateTo != nil {
+		query += " AND b.date <= ?"
+		args = append(args, *filter.DateTo)
+	}
+
+	if filter.Status != nil {
+		query += " AND b.status = ?"
+		args = append(args, *filter.Status)
+	}
+
+	if filter.Year != nil && filter.Month != nil {
+		// Filter by year and month
+		startDate := fmt.Sprintf("%d-%02d-01", *filter.Year, *filter.Month)
+		// Calculate last day of month
+		nextMonth := time.Date(*filter.Year, time.Month(*filter.Month+1), 1, 0, 0, 0, 0, time.UTC)
+		endDate := nextMonth.Add(-24 * time.Hour).Format("2006-01-02")
+
+		query += " AND b.date >= ? AND b.date <= ?"
+		args = append(args, startDate, endDate)
+	}
+
+	return query, args
+}
+
+// ExportRows streams the bookings matching filter with walker, dog, approval and walk
+// report details to fn, ordered by date and time. Rows are read one at a time so large
+// exports are never held in memory; an error returned by fn stops the export.
+func (r *BookingRepository) ExportRows(filter *models.BookingFilterRequest, fn func(*models.BookingExportRow) error) error {
+	conditions, args := bookingFilterConditions(filter)
+	query := `
+		SELECT b.id, b.date, b.scheduled_time, b.status, b.approval_status, b.approved_at,
+		       b.rejection_reason, b.admin_cancellation_reason,
+		       d.name, u.first_name, u.last_name, a.first_name, a.last_name,
+		       CASE WHEN wr.id IS NULL THEN 0 ELSE 1 END
+		FROM bookings b
+		LEFT JOIN dogs d ON b.dog_id = d.id
+		LEFT JOIN users u ON b.user_id = u.id
+		LEFT JOIN users a ON b.approved_by = a.id
+		LEFT JOIN walk_reports wr ON wr.booking_id = b.id
+		WHERE 1=1
+	` + conditions + " ORDER BY b.date ASC, b.scheduled_time ASC, b.id ASC"
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query bookings: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		row := &models.BookingExportRow{}
+		var approvalStatus, dogName, walkerFirst, walkerLast, approverFirst, approverLast sql.NullString
+		var hasWalkReport int
+		if err := rows.Scan(
+			&row.BookingID,
+			&row.Date,
+			&row.ScheduledTime,
+			&row.Status,
+			&approvalStatus,
+			&row.ApprovedAt,
+			&row.RejectionReason,
+			&row.CancellationReason,
+			&dogName,
+			&walkerFirst,
+			&walkerLast,
+			&approverFirst,
+			&approverLast,
+			&hasWalkReport,
+		); err != nil {
+			return fmt.Errorf("failed to scan booking: %w", err)
+		}
+
+		row.Date = normalizeDate(row.Date)
+		row.ApprovalStatus = approvalStatus.String
+		row.DogName = dogName.String
+		row.WalkerName = strings.TrimSpace(walkerFirst.String + " " + walkerLast.String)
+		if approverFirst.Valid || approverLast.Valid {
+			name := strings.TrimSpace(approverFirst.String + " " + approverLast.String)
+			row.ApprovedBy = &name
+		}
+		row.HasWalkReport = hasWalkReport == 1
+
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
 // Cancel cancels a booking
 func (r *BookingRepository) Cancel(id int, reason *string) error {
 	query := `
@@ -210,6 +386,54 @@ func (r *BookingRepository) Cancel(id int, reason *string) error {
 	return nil
 }
 
+// FindUpcomingBySeries finds the scheduled occurrences of a series that have not started yet
+func (r *BookingRepository) FindUpcomingBySeries(seriesID int) ([]*models.Booking, error) {
+	query := `
+		SELECT id, user_id, dog_id, date, scheduled_time, status,
+		       completed_at, user_notes, admin_cancellation_reason, series_id, checked_in_at, checked_out_at, created_at, updated_at
+		FROM bookings
+		WHERE series_id = ? AND status = 'scheduled'
+		AND (date > ? OR (date = ? AND scheduled_time > ?))
+		ORDER BY date ASC, scheduled_time ASC
+	`
+
+	now := time.Now()
+	currentDate := now.Format("2006-01-02")
+	rows, err := r.db.Query(query, seriesID, currentDate, currentDate, now.Format("15:04"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query series bookings: %w", err)
+	}
+	defer rows.Close()
+
+	bookings := []*models.Booking{}
+	for rows.Next() {
+		booking := &models.Booking{}
+		err := rows.Scan(
+			&booking.ID,
+			&booking.UserID,
+			&booking.DogID,
+			&booking.Date,
+			&booking.ScheduledTime,
+			&booking.Status,
+			&booking.CompletedAt,
+			&booking.UserNotes,
+			&booking.AdminCancellationReason,
+			&booking.SeriesID,
+			&booking.CheckedInAt,
+			&booking.CheckedOutAt,
+			&booking.CreatedAt,
+			&booking.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan booking: %w", err)
+		}
+		booking.Date = normalizeDate(booking.Date)
+		bookings = append(bookings, booking)
+	}
+
+	return bookings, nil
+}
+
 // AddNotes adds notes to a completed booking
 func (r *BookingRepository) AddNotes(id int, notes string) error {
 	query := `
@@ -252,41 +476,388 @@ func (r *BookingRepository) CheckDoubleBooking(dogID int, date, scheduledTime st
 	return count > 0, nil
 }
 
-// AutoComplete marks all past scheduled bookings as completed
-func (r *BookingRepository) AutoComplete() (int, error) {
-	// Get current date and time
-	now := time.Now()
-	currentDate := now.Format("2006-01-02")
-	currentTime := now.Format("15:04")
+// CountFutureByUser counts the user's scheduled bookings on or after fromDate
+func (r *BookingRepository) CountFutureByUser(userID int, fromDate string) (int, error) {
+	var count int
+	err := r.db.QueryRow(`
+		SELECT COUNT(*)
+		FROM bookings
+		WHERE user_id = ? AND status = 'scheduled' AND date >= ?
+	`, userID, normalizeDate(fromDate)).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count future bookings: %w", err)
+	}
 
+	return count, nil
+}
+
+// CountByUserInRange counts the user's bookings between fromDate and toDate (inclusive) that
+// were not cancelled. dogID restricts the count to one dog; pass 0 to count all dogs.
+func (r *BookingRepository) CountByUserInRange(userID, dogID int, fromDate, toDate string) (int, error) {
 	query := `
-		UPDATE bookings
-		SET status = 'completed', completed_at = ?, updated_at = ?
-		WHERE status = 'scheduled'
-		AND (
-			date < ?
-			OR (date = ? AND scheduled_time < ?)
-		)
+		SELECT COUNT(*)
+		FROM bookings
+		WHERE user_id = ? AND status != 'cancelled' AND date >= ? AND date <= ?
 	`
+	args := []interface{}{userID, normalizeDate(fromDate), normalizeDate(toDate)}
+	if dogID != 0 {
+		query += " AND dog_id = ?"
+		args = append(args, dogID)
+	}
 
-	result, err := r.db.Exec(query, now, now, currentDate, currentDate, currentTime)
+	var count int
+	if err := r.db.QueryRow(query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count bookings: %w", err)
+	}
+
+	return count, nil
+}
+
+// FindConflictingBooking finds a scheduled booking of the dog whose walk overlaps the requested slot.
+// Each booking occupies its scheduled time plus the dog's walk duration plus bufferMinutes.
+// excludeBookingID skips one booking (e.g. the booking being moved); pass 0 to check all.
+// Returns nil if the slot is free.
+func (r *BookingRepository) FindConflictingBooking(dogID int, date, scheduledTime string, durationMinutes, bufferMinutes, excludeBookingID int) (*models.BookingConflict, error) {
+	return r.findConflict(r.db, dogConflictQuery, []interface{}{dogID, date, excludeBookingID}, scheduledTime, durationMinutes, bufferMinutes)
+}
+
+// FindUserConflictingBooking finds a scheduled booking of the user for another dog whose walk
+// overlaps the requested slot, so one walker cannot take out two dogs at the same time.
+// Bookings of dogs paired with dogID for pair walks are allowed if they start at the same time.
+// excludeBookingID skips one booking (e.g. the booking being moved); pass 0 to check all.
+// Returns nil if the user is free.
+func (r *BookingRepository) FindUserConflictingBooking(userID, dogID int, date, scheduledTime string, durationMinutes, excludeBookingID int) (*models.BookingConflict, error) {
+	args := []interface{}{userID, dogID, date, excludeBookingID, scheduledTime, dogID, dogID}
+	return r.findConflict(r.db, userConflictQuery, args, scheduledTime, durationMinutes, 0)
+}
+
+// FindDogWalkTimes returns the HH:MM start times of the dog's scheduled and completed walks
+// on date, earliest first, for checking its daily walk limits.
+// excludeBookingID skips one booking (e.g. the booking being moved); pass 0 to include all.
+func (r *BookingRepository) FindDogWalkTimes(dogID int, date string, excludeBookingID int) ([]string, error) {
+	return r.findDogWalkTimes(r.db, dogID, date, excludeBookingID)
+}
+
+// findDogWalkTimes runs FindDogWalkTimes using db or a transaction
+func (r *BookingRepository) findDogWalkTimes(q sqlRunner, dogID int, date string, excludeBookingID int) ([]string, error) {
+	query := `
+		SELECT scheduled_time
+		FROM bookings
+		WHERE dog_id = ? AND date = ? AND status IN ('scheduled', 'completed') AND id != ?
+		ORDER BY scheduled_time ASC
+	`
+
+	rows, err := q.Query(query, dogID, date, excludeBookingID)
 	if err != nil {
-		return 0, fmt.Errorf("failed to auto-complete bookings: %w", err)
+		return nil, fmt.Errorf("failed to query dog walk times: %w", err)
+	}
+	defer rows.Close()
+
+	times := []string{}
+	for rows.Next() {
+		var scheduledTime string
+		if err := rows.Scan(&scheduledTime); err != nil {
+			return nil, fmt.Errorf("failed to scan dog walk time: %w", err)
+		}
+		times = append(times, scheduledTime)
+	}
+
+	return times, rows.Err()
+}
+
+// Conflict queries return (id, dog_id, dog name, date, scheduled_time, walk_duration) rows.
+// dogConflictQuery takes dog_id, date and a booking ID to skip.
+const dogConflictQuery = `
+		SELECT b.id, b.dog_id, d.name, b.date, b.scheduled_time, d.walk_duration
+		FROM bookings b
+		JOIN dogs d ON b.dog_id = d.id
+		WHERE b.dog_id = ? AND b.date = ? AND b.status = 'scheduled' AND b.id != ?
+		ORDER BY b.scheduled_time ASC
+	`
+
+// userConflictQuery takes user_id, dog_id, date, a booking ID to skip, scheduled_time and dog_id twice
+const userConflictQuery = `
+		SELECT b.id, b.dog_id, d.name, b.date, b.scheduled_time, d.walk_duration
+		FROM bookings b
+		JOIN dogs d ON b.dog_id = d.id
+		WHERE b.user_id = ? AND b.dog_id != ? AND b.date = ? AND b.status = 'scheduled' AND b.id != ?
+		  AND NOT (b.scheduled_time = ? AND EXISTS (
+		      SELECT 1 FROM dog_pair_walks p
+		      WHERE (p.dog_id = b.dog_id AND p.partner_dog_id = ?)
+		         OR (p.dog_id = ? AND p.partner_dog_id = b.dog_id)
+		  ))
+		ORDER BY b.scheduled_time ASC
+	`
+
+// findConflict runs a conflict query using db or a transaction and returns the first row
+// overlapping the requested slot
+func (r *BookingRepository) findConflict(q sqlRunner, query string, args []interface{}, scheduledTime string, durationMinutes, bufferMinutes int) (*models.BookingConflict, error) {
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check booking conflicts: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		conflict := &models.BookingConflict{}
+		var walkDuration sql.NullInt64
+		if err := rows.Scan(&conflict.BookingID, &conflict.DogID, &conflict.DogName, &conflict.Date, &conflict.ScheduledTime, &walkDuration); err != nil {
+			return nil, fmt.Errorf("failed to scan booking conflict: %w", err)
+		}
+
+		existingDuration := models.DefaultWalkDuration
+		if walkDuration.Valid && walkDuration.Int64 > 0 {
+			existingDuration = int(walkDuration.Int64)
+		}
+
+		if models.BookingIntervalsOverlap(scheduledTime, durationMinutes, conflict.ScheduledTime, existingDuration, bufferMinutes) {
+			conflict.Date = normalizeDate(conflict.Date)
+			conflict.EndTime = models.AddMinutesToTime(conflict.ScheduledTime, existingDuration)
+			conflict.NextFreeTime = models.AddMinutesToTime(conflict.ScheduledTime, existingDuration+bufferMinutes)
+			return conflict, nil
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to check booking conflicts: %w", err)
+	}
+
+	return nil, nil
+}
+
+// CheckIn records the actual start of a scheduled walk
+func (r *BookingRepository) CheckIn(id int, at time.Time) error {
+	result, err := r.db.Exec(`
+		UPDATE bookings
+		SET checked_in_at = ?, updated_at = ?
+		WHERE id = ? AND status = 'scheduled' AND checked_in_at IS NULL
+	`, at, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to check in booking: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("booking not found or already checked in")
+	}
+
+	return nil
+}
+
+// CheckOut records the actual end of a checked-in walk and completes the booking
+func (r *BookingRepository) CheckOut(id int, at time.Time) error {
+	result, err := r.db.Exec(`
+		UPDATE bookings
+		SET checked_out_at = ?, status = 'completed', completed_at = ?, updated_at = ?
+		WHERE id = ? AND status = 'scheduled' AND checked_in_at IS NOT NULL AND checked_out_at IS NULL
+	`, at, at, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to check out booking: %w", err)
 	}
 
 	rows, err := result.RowsAffected()
 	if err != nil {
-		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("booking not found or not checked in")
 	}
 
-	return int(rows), nil
+	return nil
+}
+
+// AutoComplete completes checked-in walks that were not checked out once their
+// scheduled end (scheduled time + the dog's walk duration) has passed
+func (r *BookingRepository) AutoComplete() (int, error) {
+	now := time.Now()
+
+	bookings, err := r.findPastScheduled(`b.checked_in_at IS NOT NULL`, now, func(walkDuration int) time.Duration {
+		return time.Duration(walkDuration) * time.Minute
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to auto-complete bookings: %w", err)
+	}
+
+	for _, booking := range bookings {
+		result, err := r.db.Exec(`
+			UPDATE bookings SET status = 'completed', completed_at = ?, updated_at = ?
+			WHERE id = ? AND status = 'scheduled'
+		`, now, now, booking.ID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to auto-complete booking %d: %w", booking.ID, err)
+		}
+		if rows, err := result.RowsAffected(); err == nil && rows > 0 {
+			if err := insertBookingEvent(r.db, booking.ID, models.BookingEventCompleted, nil, "", ""); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return len(bookings), nil
+}
+
+// MarkNoShows marks approved bookings that were not checked in within graceMinutes
+// after their scheduled time as no_show and returns them
+func (r *BookingRepository) MarkNoShows(graceMinutes int) ([]*models.Booking, error) {
+	now := time.Now()
+
+	bookings, err := r.findPastScheduled(`b.checked_in_at IS NULL AND b.approval_status = 'approved'`, now, func(walkDuration int) time.Duration {
+		return time.Duration(graceMinutes) * time.Minute
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark no-shows: %w", err)
+	}
+
+	for _, booking := range bookings {
+		result, err := r.db.Exec(`
+			UPDATE bookings SET status = 'no_show', updated_at = ?
+			WHERE id = ? AND status = 'scheduled' AND checked_in_at IS NULL
+		`, now, booking.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to mark booking %d as no-show: %w", booking.ID, err)
+		}
+		booking.Status = "no_show"
+		if rows, err := result.RowsAffected(); err == nil && rows > 0 {
+			if err := insertBookingEvent(r.db, booking.ID, models.BookingEventNoShow, nil, "", ""); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return bookings, nil
+}
+
+// ExpirePendingApprovals rejects bookings still waiting for approval once their scheduled
+// time has passed and returns them. The rejection is recorded without an actor.
+func (r *BookingRepository) ExpirePendingApprovals() ([]*models.Booking, error) {
+	now := time.Now()
+
+	bookings, err := r.findPastScheduled(`b.approval_status = 'pending'`, now, func(walkDuration int) time.Duration {
+		return 0
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to expire pending approvals: %w", err)
+	}
+
+	reason := models.BookingApprovalExpiredReason
+	expired := []*models.Booking{}
+	for _, booking := range bookings {
+		result, err := r.db.Exec(`
+			UPDATE bookings SET approval_status = 'rejected', rejection_reason = ?, status = 'cancelled', updated_at = ?
+			WHERE id = ? AND status = 'scheduled' AND approval_status = 'pending'
+		`, reason, now, booking.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to expire approval of booking %d: %w", booking.ID, err)
+		}
+		if rows, err := result.RowsAffected(); err != nil || rows == 0 {
+			continue
+		}
+		if err := insertBookingEvent(r.db, booking.ID, models.BookingEventRejected, nil, "", reason); err != nil {
+			return nil, err
+		}
+		booking.Status = "cancelled"
+		booking.ApprovalStatus = "rejected"
+		booking.RejectionReason = &reason
+		expired = append(expired, booking)
+	}
+
+	return expired, nil
+}
+
+// findPastScheduled returns the scheduled bookings matching condition whose
+// scheduled time plus the returned offset lies before now (times are local)
+func (r *BookingRepository) findPastScheduled(condition string, now time.Time, offset func(walkDuration int) time.Duration) ([]*models.Booking, error) {
+	query := `
+		SELECT b.id, b.user_id, b.dog_id, b.date, b.scheduled_time, d.walk_duration
+		FROM bookings b
+		JOIN dogs d ON b.dog_id = d.id
+		WHERE b.status = 'scheduled' AND b.date <= ? AND ` + condition
+
+	rows, err := r.db.Query(query, now.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	bookings := []*models.Booking{}
+	for rows.Next() {
+		booking := &models.Booking{Status: "scheduled"}
+		var walkDuration sql.NullInt64
+		if err := rows.Scan(&booking.ID, &booking.UserID, &booking.DogID, &booking.Date, &booking.ScheduledTime, &walkDuration); err != nil {
+			return nil, err
+		}
+
+		duration := models.DefaultWalkDuration
+		if walkDuration.Valid && walkDuration.Int64 > 0 {
+			duration = int(walkDuration.Int64)
+		}
+
+		booking.Date = normalizeDate(booking.Date)
+		start, err := time.ParseInLocation("2006-01-02 15:04", booking.Date+" "+booking.ScheduledTime, time.Local)
+		if err != nil {
+			continue
+		}
+		if start.Add(offset(duration)).Before(now) {
+			bookings = append(bookings, booking)
+		}
+	}
+
+	return bookings, rows.Err()
+}
+
+// FindCurrentlyOut finds the checked-in walks that have not been checked out yet
+func (r *BookingRepository) FindCurrentlyOut() ([]*models.Booking, error) {
+	query := `
+		SELECT b.id, b.user_id, b.dog_id, b.date, b.scheduled_time, b.checked_in_at,
+		       u.first_name, u.last_name, u.phone, d.name
+		FROM bookings b
+		JOIN users u ON b.user_id = u.id
+		JOIN dogs d ON b.dog_id = d.id
+		WHERE b.status = 'scheduled' AND b.checked_in_at IS NOT NULL AND b.checked_out_at IS NULL
+		ORDER BY b.checked_in_at ASC
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query walks in progress: %w", err)
+	}
+	defer rows.Close()
+
+	bookings := []*models.Booking{}
+	for rows.Next() {
+		booking := &models.Booking{Status: "scheduled", User: &models.User{}, Dog: &models.Dog{}}
+		err := rows.Scan(
+			&booking.ID,
+			&booking.UserID,
+			&booking.DogID,
+			&booking.Date,
+			&booking.ScheduledTime,
+			&booking.CheckedInAt,
+			&booking.User.FirstName,
+			&booking.User.LastName,
+			&booking.User.Phone,
+			&booking.Dog.Name,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan booking: %w", err)
+		}
+		booking.Date = normalizeDate(booking.Date)
+		booking.User.ID = booking.UserID
+		booking.Dog.ID = booking.DogID
+		bookings = append(bookings, booking)
+	}
+
+	return bookings, rows.Err()
 }
 
 // GetUpcoming gets upcoming bookings for a user
 func (r *BookingRepository) GetUpcoming(userID int, limit int) ([]*models.Booking, error) {
 	query := `
 		SELECT id, user_id, dog_id, date, scheduled_time, status,
-		       completed_at, user_notes, admin_cancellation_reason, created_at, updated_at
+		       completed_at, user_notes, admin_cancellation_reason, series_id, checked_in_at, checked_out_at, created_at, updated_at
 		FROM bookings
 		WHERE user_id = ? AND status = 'scheduled' AND date >= ?
 		ORDER BY date ASC, scheduled_time ASC
@@ -313,6 +884,9 @@ func (r *BookingRepository) GetUpcoming(userID int, limit int) ([]*models.Bookin
 			&booking.CompletedAt,
 			&booking.UserNotes,
 			&booking.AdminCancellationReason,
+			&booking.SeriesID,
+			&booking.CheckedInAt,
+			&booking.CheckedOutAt,
 			&booking.CreatedAt,
 			&booking.UpdatedAt,
 		)
@@ -341,7 +915,7 @@ func (r *BookingRepository) GetForReminders() ([]*models.Booking, error) {
 	// Query with user and dog details, excluding already-sent reminders
 	query := `
 		SELECT b.id, b.user_id, b.dog_id, b.date, b.scheduled_time, b.status,
-		       b.completed_at, b.user_notes, b.admin_cancellation_reason, b.created_at, b.updated_at,
+		       b.completed_at, b.user_notes, b.admin_cancellation_reason, b.series_id, b.checked_in_at, b.checked_out_at, b.created_at, b.updated_at,
 		       u.first_name as user_first_name, u.last_name as user_last_name, u.email as user_email,
 		       d.name as dog_name
 		FROM bookings b
@@ -378,6 +952,9 @@ func (r *BookingRepository) GetForReminders() ([]*models.Booking, error) {
 			&booking.CompletedAt,
 			&booking.UserNotes,
 			&booking.AdminCancellationReason,
+			&booking.SeriesID,
+			&booking.CheckedInAt,
+			&booking.CheckedOutAt,
 			&booking.CreatedAt,
 			&booking.UpdatedAt,
 			&userFirstName,
@@ -450,7 +1027,7 @@ func (r *BookingRepository) FindByIDWithDetails(id int) (*models.Booking, error)
 	query := `
 		SELECT
 			b.id, b.user_id, b.dog_id, b.date, b.scheduled_time, b.status,
-			b.completed_at, b.user_notes, b.admin_cancellation_reason, b.created_at, b.updated_at,
+			b.completed_at, b.user_notes, b.admin_cancellation_reason, b.series_id, b.checked_in_at, b.checked_out_at, b.created_at, b.updated_at,
 			u.first_name as user_first_name, u.last_name as user_last_name, u.email as user_email, u.phone as user_phone,
 			d.name as dog_name, d.breed, d.size, d.age
 		FROM bookings b
@@ -478,6 +1055,9 @@ func (r *BookingRepository) FindByIDWithDetails(id int) (*models.Booking, error)
 		&booking.CompletedAt,
 		&booking.UserNotes,
 		&booking.AdminCancellationReason,
+		&booking.SeriesID,
+		&booking.CheckedInAt,
+		&booking.CheckedOutAt,
 		&booking.CreatedAt,
 		&booking.UpdatedAt,
 		&userFirstName,
@@ -662,7 +1242,7 @@ func (r *BookingRepository) ApproveBooking(bookingID int, adminID int) error {
 		return fmt.Errorf("booking not found or not pending")
 	}
 
-	return nil
+	return insertBookingEvent(r.db, bookingID, models.BookingEventApproved, &adminID, "", "")
 }
 
 // RejectBooking rejects a pending booking
@@ -688,5 +1268,5 @@ func (r *BookingRepository) RejectBooking(bookingID int, adminID int, reason str
 		return fmt.Errorf("booking not found or not pending")
 	}
 
-	return nil
+	return insertBookingEvent(r.db, bookingID, models.BookingEventRejected, &adminID, "", reason)
 }
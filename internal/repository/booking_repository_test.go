@@ -3,11 +3,15 @@ package repository
 import (
 	"database/sql"
 	"fmt"
+	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	_ "modernc.org/sqlite"
+	"github.com/tranmh/gassigeher/internal/database"
 	"github.com/tranmh/gassigeher/internal/models"
 	"github.com/tranmh/gassigeher/internal/testutil"
 )
@@ -151,13 +155,187 @@ func TestBookingRepository_CheckDoubleBooking(t *testing.T) {
 	}
 }
 
+// TestBookingRepository_FindConflictingBooking tests duration-aware overlap detection
+func TestBookingRepository_FindConflictingBooking(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewBookingRepository(db)
+
+	// Dog 1 walks take 60 minutes
+	db.Exec("UPDATE dogs SET walk_duration = 60 WHERE id = 1")
+
+	booking := &models.Booking{
+		UserID:        1,
+		DogID:         1,
+		Date:          "2025-12-01",
+		ScheduledTime: "10:00",
+	}
+	if err := repo.Create(booking); err != nil {
+		t.Fatalf("Failed to create booking: %v", err)
+	}
+
+	t.Run("overlapping start is a conflict", func(t *testing.T) {
+		conflict, err := repo.FindConflictingBooking(1, "2025-12-01", "10:15", 60, 0, 0)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if conflict == nil {
+			t.Fatal("Expected 10:15 to conflict with 10:00 walk")
+		}
+		if conflict.BookingID != booking.ID {
+			t.Errorf("Expected conflicting booking %d, got %d", booking.ID, conflict.BookingID)
+		}
+		if conflict.ScheduledTime != "10:00" || conflict.EndTime != "11:00" {
+			t.Errorf("Expected conflict 10:00-11:00, got %s-%s", conflict.ScheduledTime, conflict.EndTime)
+		}
+		if conflict.NextFreeTime != "11:00" {
+			t.Errorf("Expected next free time 11:00, got %s", conflict.NextFreeTime)
+		}
+	})
+
+	t.Run("slot after walk is free", func(t *testing.T) {
+		conflict, err := repo.FindConflictingBooking(1, "2025-12-01", "11:00", 60, 0, 0)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if conflict != nil {
+			t.Errorf("Expected 11:00 to be free, got conflict with booking %d", conflict.BookingID)
+		}
+	})
+
+	t.Run("buffer extends the walk", func(t *testing.T) {
+		conflict, err := repo.FindConflictingBooking(1, "2025-12-01", "11:00", 60, 15, 0)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if conflict == nil {
+			t.Fatal("Expected 11:00 to conflict when a 15 minute buffer is configured")
+		}
+		if conflict.NextFreeTime != "11:15" {
+			t.Errorf("Expected next free time 11:15, got %s", conflict.NextFreeTime)
+		}
+	})
+
+	t.Run("excluded booking is ignored", func(t *testing.T) {
+		conflict, err := repo.FindConflictingBooking(1, "2025-12-01", "10:30", 60, 0, booking.ID)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if conflict != nil {
+			t.Error("Expected excluded booking not to be reported as conflict")
+		}
+	})
+
+	t.Run("other dogs and cancelled bookings are ignored", func(t *testing.T) {
+		conflict, err := repo.FindConflictingBooking(2, "2025-12-01", "10:00", 60, 0, 0)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if conflict != nil {
+			t.Error("Expected no conflict for a different dog")
+		}
+
+		repo.Cancel(booking.ID, nil)
+		conflict, err = repo.FindConflictingBooking(1, "2025-12-01", "10:15", 60, 0, 0)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if conflict != nil {
+			t.Error("Expected cancelled booking not to be reported as conflict")
+		}
+	})
+}
+
+// TestBookingRepository_FindUserConflictingBooking tests per-walker overlap detection
+func TestBookingRepository_FindUserConflictingBooking(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewBookingRepository(db)
+
+	db.Exec("UPDATE dogs SET walk_duration = 60 WHERE id IN (1, 2)")
+
+	booking := &models.Booking{
+		UserID:        1,
+		DogID:         1,
+		Date:          "2025-12-01",
+		ScheduledTime: "10:00",
+	}
+	if err := repo.Create(booking); err != nil {
+		t.Fatalf("Failed to create booking: %v", err)
+	}
+
+	t.Run("overlapping walk with another dog is a conflict", func(t *testing.T) {
+		conflict, err := repo.FindUserConflictingBooking(1, 2, "2025-12-01", "10:30", 60, 0)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if conflict == nil {
+			t.Fatal("Expected 10:30 to conflict with the walker's 10:00 walk")
+		}
+		if conflict.DogName != "Buddy" {
+			t.Errorf("Expected conflicting dog Buddy, got %s", conflict.DogName)
+		}
+	})
+
+	t.Run("other walkers and later slots are free", func(t *testing.T) {
+		conflict, err := repo.FindUserConflictingBooking(2, 2, "2025-12-01", "10:30", 60, 0)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if conflict != nil {
+			t.Error("Expected no conflict for a different walker")
+		}
+
+		conflict, err = repo.FindUserConflictingBooking(1, 2, "2025-12-01", "11:00", 60, 0)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if conflict != nil {
+			t.Error("Expected 11:00 to be free for the walker")
+		}
+	})
+
+	t.Run("pair walk allowed only at the same start time", func(t *testing.T) {
+		pairWalkRepo := NewPairWalkRepository(db)
+		if err := pairWalkRepo.Create(&models.PairWalk{DogID: 1, PartnerDogID: 2, CreatedBy: 1}); err != nil {
+			t.Fatalf("Failed to create pair walk: %v", err)
+		}
+
+		conflict, err := repo.FindUserConflictingBooking(1, 2, "2025-12-01", "10:00", 60, 0)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if conflict != nil {
+			t.Error("Expected pair walk at the same time to be allowed")
+		}
+
+		conflict, err = repo.FindUserConflictingBooking(1, 2, "2025-12-01", "10:30", 60, 0)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if conflict == nil {
+			t.Error("Expected pair walk at a different start time to conflict")
+		}
+
+		conflict, err = repo.FindUserConflictingBooking(1, 3, "2025-12-01", "10:00", 60, 0)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if conflict == nil {
+			t.Error("Expected dog without pair walk to conflict")
+		}
+	})
+}
+
 func TestBookingRepository_AutoComplete(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Close()
 
 	repo := NewBookingRepository(db)
 
-	// Create past booking
+	// Create past bookings, only the first one was checked in
 	yesterday := time.Now().Add(-24 * time.Hour).Format("2006-01-02")
 	booking := &models.Booking{
 		UserID:        1,
@@ -166,6 +344,17 @@ func TestBookingRepository_AutoComplete(t *testing.T) {
 		ScheduledTime: "09:00",
 	}
 	repo.Create(booking)
+	if err := repo.CheckIn(booking.ID, time.Now().Add(-24*time.Hour)); err != nil {
+		t.Fatalf("CheckIn() failed: %v", err)
+	}
+
+	missed := &models.Booking{
+		UserID:        1,
+		DogID:         2,
+		Date:          yesterday,
+		ScheduledTime: "09:00",
+	}
+	repo.Create(missed)
 
 	// Run auto-complete
 	count, err := repo.AutoComplete()
@@ -182,6 +371,118 @@ func TestBookingRepository_AutoComplete(t *testing.T) {
 	if completed.Status != "completed" {
 		t.Errorf("Expected status 'completed', got %s", completed.Status)
 	}
+
+	t.Run("booking never checked in becomes no-show", func(t *testing.T) {
+		noShows, err := repo.MarkNoShows(60)
+		if err != nil {
+			t.Fatalf("MarkNoShows() failed: %v", err)
+		}
+		if len(noShows) != 1 || noShows[0].ID != missed.ID || noShows[0].UserID != 1 {
+			t.Errorf("Expected the missed booking to be returned, got %+v", noShows)
+		}
+
+		noShow, _ := repo.FindByID(missed.ID)
+		if noShow.Status != "no_show" {
+			t.Errorf("Expected status 'no_show', got %s", noShow.Status)
+		}
+	})
+}
+
+// TestBookingRepository_ExpirePendingApprovals tests rejecting unapproved bookings once they are due
+func TestBookingRepository_ExpirePendingApprovals(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := NewBookingRepository(db)
+
+	userID := testutil.SeedTestUser(t, db, "walker@example.com", "Walker", "green")
+	dogID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+
+	yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+	tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+	due := &models.Booking{UserID: userID, DogID: dogID, Date: yesterday, ScheduledTime: "07:00", RequiresApproval: true}
+	upcoming := &models.Booking{UserID: userID, DogID: dogID, Date: tomorrow, ScheduledTime: "07:00", RequiresApproval: true}
+	approved := &models.Booking{UserID: userID, DogID: dogID, Date: yesterday, ScheduledTime: "10:00"}
+	for _, booking := range []*models.Booking{due, upcoming, approved} {
+		if err := repo.Create(booking); err != nil {
+			t.Fatalf("Create() failed: %v", err)
+		}
+	}
+
+	expired, err := repo.ExpirePendingApprovals()
+	if err != nil {
+		t.Fatalf("ExpirePendingApprovals() failed: %v", err)
+	}
+	if len(expired) != 1 || expired[0].ID != due.ID {
+		t.Fatalf("Expected only the due booking to expire, got %+v", expired)
+	}
+
+	var status, approvalStatus, reason string
+	db.QueryRow("SELECT status, approval_status, rejection_reason FROM bookings WHERE id = ?", due.ID).Scan(&status, &approvalStatus, &reason)
+	if status != "cancelled" || approvalStatus != "rejected" || reason != models.BookingApprovalExpiredReason {
+		t.Errorf("Expected the due booking to be rejected, got %s / %s / %q", status, approvalStatus, reason)
+	}
+	for _, booking := range []*models.Booking{upcoming, approved} {
+		if stored, _ := repo.FindByID(booking.ID); stored.Status != "scheduled" {
+			t.Errorf("Booking %d: expected status scheduled, got %s", booking.ID, stored.Status)
+		}
+	}
+
+	events, _ := NewBookingEventRepository(db).FindByBooking(due.ID)
+	if last := events[len(events)-1]; last.EventType != models.BookingEventRejected || last.ActorID != nil {
+		t.Errorf("Expected a rejection event by the system, got %+v", last)
+	}
+
+	if again, _ := repo.ExpirePendingApprovals(); len(again) != 0 {
+		t.Errorf("Expected nothing left to expire, got %d", len(again))
+	}
+}
+
+// TestBookingRepository_CheckInOut tests recording actual walk times
+func TestBookingRepository_CheckInOut(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewBookingRepository(db)
+
+	booking := &models.Booking{
+		UserID:        1,
+		DogID:         1,
+		Date:          time.Now().Format("2006-01-02"),
+		ScheduledTime: "09:00",
+	}
+	repo.Create(booking)
+
+	if err := repo.CheckOut(booking.ID, time.Now()); err == nil {
+		t.Error("Expected error when checking out before check-in")
+	}
+
+	if err := repo.CheckIn(booking.ID, time.Now()); err != nil {
+		t.Fatalf("CheckIn() failed: %v", err)
+	}
+	if err := repo.CheckIn(booking.ID, time.Now()); err == nil {
+		t.Error("Expected error when checking in twice")
+	}
+
+	out, err := repo.FindCurrentlyOut()
+	if err != nil {
+		t.Fatalf("FindCurrentlyOut() failed: %v", err)
+	}
+	if len(out) != 1 || out[0].ID != booking.ID || out[0].Dog.Name != "Buddy" {
+		t.Fatalf("Expected the booking to be out on a walk, got %+v", out)
+	}
+
+	if err := repo.CheckOut(booking.ID, time.Now()); err != nil {
+		t.Fatalf("CheckOut() failed: %v", err)
+	}
+
+	found, _ := repo.FindByID(booking.ID)
+	if found.Status != "completed" || found.CheckedInAt == nil || found.CheckedOutAt == nil {
+		t.Errorf("Expected completed booking with check-in/out times, got %+v", found)
+	}
+
+	out, _ = repo.FindCurrentlyOut()
+	if len(out) != 0 {
+		t.Errorf("Expected no walks in progress after check-out, got %d", len(out))
+	}
 }
 
 // DONE: TestBookingRepository_Cancel tests booking cancellation
@@ -1439,3 +1740,198 @@ func TestRejectBooking_ReasonRequired(t *testing.T) {
 		}
 	})
 }
+
+// TestBookingRepository_CreateIfFree_Concurrent hammers the same dog and the same walker from many
+// goroutines on a file database with a real connection pool: exactly one booking may win
+func TestBookingRepository_CreateIfFree_Concurrent(t *testing.T) {
+	db, dialect, err := database.InitializeWithConfig(&database.DBConfig{Type: "sqlite", Path: filepath.Join(t.TempDir(), "race.db")})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+	if err := database.RunMigrationsWithDialect(db, dialect); err != nil {
+		t.Fatalf("Migration failed: %v", err)
+	}
+
+	repo := NewBookingRepository(db)
+	const workers = 20
+	const rounds = 10
+	// All start times overlap with each other for a 60 minute walk
+	times := []string{"10:00", "10:15", "10:30", "10:45"}
+
+	dogRepo := NewDogRepository(db)
+	userIDs := make([]int, workers)
+	dogIDs := make([]int, workers)
+	dogs := make(map[int]*models.Dog)
+	for i := 0; i < workers; i++ {
+		userIDs[i] = testutil.SeedTestUser(t, db, fmt.Sprintf("walker%d@example.com", i), fmt.Sprintf("Walker %d", i), "green")
+		dogIDs[i] = testutil.SeedTestDog(t, db, fmt.Sprintf("Dog %d", i), "Mischling", "green")
+		dogs[dogIDs[i]], _ = dogRepo.FindByID(dogIDs[i])
+	}
+
+	// hammer books one slot per worker concurrently on a fresh date per round and checks
+	// that the expected number of bookings was created each time
+	day := 0
+	hammer := func(t *testing.T, expected int, booking func(i int, date string) *models.Booking) {
+		for round := 0; round < rounds; round++ {
+			day++
+			date := time.Date(2030, 3, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, day).Format("2006-01-02")
+
+			var wg sync.WaitGroup
+			var created int32
+			start := make(chan struct{})
+			for i := 0; i < workers; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					<-start
+					b := booking(i, date)
+					conflict, walkLimit, err := repo.CreateIfFree(b, dogs[b.DogID], 0)
+					switch {
+					case err != nil && !IsSlotTakenError(err):
+						t.Errorf("Worker %d: unexpected error: %v", i, err)
+					case err == nil && conflict == nil && walkLimit == nil:
+						atomic.AddInt32(&created, 1)
+					}
+				}(i)
+			}
+			close(start)
+			wg.Wait()
+
+			var scheduled int
+			db.QueryRow("SELECT COUNT(*) FROM bookings WHERE date = ? AND status = 'scheduled'", date).Scan(&scheduled)
+			if int(created) != expected || scheduled != expected {
+				t.Fatalf("Round %d: expected %d bookings, %d reported created and %d in the database", round, expected, created, scheduled)
+			}
+		}
+	}
+
+	t.Run("many walkers, one dog", func(t *testing.T) {
+		hammer(t, 1, func(i int, date string) *models.Booking {
+			return &models.Booking{UserID: userIDs[i], DogID: dogIDs[0], Date: date, ScheduledTime: times[i%len(times)]}
+		})
+	})
+
+	t.Run("one walker, many dogs", func(t *testing.T) {
+		hammer(t, 1, func(i int, date string) *models.Booking {
+			return &models.Booking{UserID: userIDs[0], DogID: dogIDs[i], Date: date, ScheduledTime: times[i%len(times)]}
+		})
+	})
+
+	t.Run("exact same slot", func(t *testing.T) {
+		hammer(t, 1, func(i int, date string) *models.Booking {
+			return &models.Booking{UserID: userIDs[i], DogID: dogIDs[1], Date: date, ScheduledTime: "09:00"}
+		})
+	})
+
+	t.Run("non-overlapping walks all succeed", func(t *testing.T) {
+		hammer(t, workers, func(i int, date string) *models.Booking {
+			return &models.Booking{UserID: userIDs[i], DogID: dogIDs[i], Date: date, ScheduledTime: "09:00"}
+		})
+	})
+
+	t.Run("walks per day of one dog", func(t *testing.T) {
+		db.Exec("UPDATE dogs SET max_walks_per_day = 2 WHERE id = ?", dogIDs[2])
+		dogs[dogIDs[2]], _ = dogRepo.FindByID(dogIDs[2])
+
+		// Every walker gets a different hour, so only the walk limit stops them
+		hammer(t, 2, func(i int, date string) *models.Booking {
+			return &models.Booking{UserID: userIDs[i], DogID: dogIDs[2], Date: date, ScheduledTime: fmt.Sprintf("%02d:00", i)}
+		})
+	})
+}
+
+// TestBookingRepository_ExportRows tests the joined export rows and the filters
+func TestBookingRepository_ExportRows(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := NewBookingRepository(db)
+
+	walkerID := testutil.SeedTestUser(t, db, "walker@example.com", "Wanda Walker", "green")
+	adminID := testutil.SeedTestUser(t, db, "admin@example.com", "Anna Admin", "orange")
+	dogID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+
+	walkedID := testutil.SeedTestBooking(t, db, walkerID, dogID, "2030-01-09", "09:00", "completed")
+	testutil.SeedTestWalkReport(t, db, walkedID, 5, "high", "Super")
+	pending := &models.Booking{UserID: walkerID, DogID: dogID, Date: "2030-01-20", ScheduledTime: "08:00", RequiresApproval: true}
+	repo.Create(pending)
+	repo.RejectBooking(pending.ID, adminID, "Zu früh")
+	testutil.SeedTestBooking(t, db, walkerID, dogID, "2030-02-01", "09:00", "scheduled")
+
+	collect := func(filter *models.BookingFilterRequest) []*models.BookingExportRow {
+		rows := []*models.BookingExportRow{}
+		if err := repo.ExportRows(filter, func(row *models.BookingExportRow) error {
+			rows = append(rows, row)
+			return nil
+		}); err != nil {
+			t.Fatalf("ExportRows() failed: %v", err)
+		}
+		return rows
+	}
+
+	year, month := 2030, 1
+	rows := collect(&models.BookingFilterRequest{Year: &year, Month: &month})
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 bookings in January, got %d", len(rows))
+	}
+
+	walked := rows[0]
+	if walked.BookingID != walkedID || walked.DogName != "Bella" || walked.WalkerName != "Wanda Walker" || !walked.HasWalkReport {
+		t.Errorf("Unexpected walked row %+v", walked)
+	}
+
+	rejected := rows[1]
+	if rejected.ApprovalStatus != "rejected" || rejected.ApprovedBy == nil || *rejected.ApprovedBy != "Anna Admin" || rejected.ApprovedAt == nil {
+		t.Errorf("Expected rejection by Anna Admin, got %+v", rejected)
+	}
+	if rejected.RejectionReason == nil || *rejected.RejectionReason != "Zu früh" || rejected.HasWalkReport {
+		t.Errorf("Unexpected rejected row %+v", rejected)
+	}
+
+	status := "scheduled"
+	if rows := collect(&models.BookingFilterRequest{Status: &status}); len(rows) != 1 || rows[0].Date != "2030-02-01" {
+		t.Errorf("Expected only the scheduled booking, got %d rows", len(rows))
+	}
+	if rows := collect(nil); len(rows) != 3 {
+		t.Errorf("Expected all 3 bookings without filter, got %d", len(rows))
+	}
+
+	stop := fmt.Errorf("stop")
+	count := 0
+	err := repo.ExportRows(nil, func(row *models.BookingExportRow) error {
+		count++
+		return stop
+	})
+	if err != stop || count != 1 {
+		t.Errorf("Expected the callback error to stop the export after one row, got %v after %d rows", err, count)
+	}
+}
+
+// TestBookingRepository_FindDogWalkTimes tests listing the walks counting against a dog's limits
+func TestBookingRepository_FindDogWalkTimes(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := NewBookingRepository(db)
+
+	userID := testutil.SeedTestUser(t, db, "walker@example.com", "Walker", "green")
+	dogID := testutil.SeedTestDog(t, db, "Opa", "Dackel", "green")
+	otherDogID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+
+	date := "2030-01-09"
+	testutil.SeedTestBooking(t, db, userID, dogID, date, "15:00", "scheduled")
+	completedID := testutil.SeedTestBooking(t, db, userID, dogID, date, "09:00", "completed")
+	testutil.SeedTestBooking(t, db, userID, dogID, date, "11:00", "cancelled")
+	testutil.SeedTestBooking(t, db, userID, dogID, "2030-01-10", "09:00", "scheduled")
+	testutil.SeedTestBooking(t, db, userID, otherDogID, date, "12:00", "scheduled")
+
+	times, err := repo.FindDogWalkTimes(dogID, date, 0)
+	if err != nil {
+		t.Fatalf("FindDogWalkTimes() failed: %v", err)
+	}
+	if len(times) != 2 || times[0] != "09:00" || times[1] != "15:00" {
+		t.Errorf("Expected [09:00 15:00], got %v", times)
+	}
+
+	times, _ = repo.FindDogWalkTimes(dogID, date, completedID)
+	if len(times) != 1 || times[0] != "15:00" {
+		t.Errorf("Expected [15:00] without the excluded booking, got %v", times)
+	}
+}
@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/tranmh/gassigeher/internal/models"
+)
+
+// BookingSeriesRepository handles recurring booking series database operations
+type BookingSeriesRepository struct {
+	db *sql.DB
+}
+
+// NewBookingSeriesRepository creates a new booking series repository
+func NewBookingSeriesRepository(db *sql.DB) *BookingSeriesRepository {
+	return &BookingSeriesRepository{db: db}
+}
+
+// Create creates a new active booking series
+func (r *BookingSeriesRepository) Create(series *models.BookingSeries) error {
+	query := `
+		INSERT INTO booking_series (user_id, dog_id, scheduled_time, interval_weeks, start_date, last_generated_date, is_active, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	now := time.Now()
+	series.IsActive = true
+
+	result, err := r.db.Exec(query,
+		series.UserID,
+		series.DogID,
+		series.ScheduledTime,
+		series.IntervalWeeks,
+		series.StartDate,
+		series.LastGeneratedDate,
+		series.IsActive,
+		now,
+		now,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create booking series: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get booking series ID: %w", err)
+	}
+
+	series.ID = int(id)
+	series.CreatedAt = now
+	series.UpdatedAt = now
+
+	return nil
+}
+
+// FindByID finds a booking series by ID
+func (r *BookingSeriesRepository) FindByID(id int) (*models.BookingSeries, error) {
+	query := `
+		SELECT id, user_id, dog_id, scheduled_time, interval_weeks, start_date, last_generated_date, is_active, created_at, updated_at
+		FROM booking_series
+		WHERE id = ?
+	`
+
+	series, err := scanBookingSeries(r.db.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find booking series: %w", err)
+	}
+
+	return series, nil
+}
+
+// FindByUser finds all booking series of a user, newest first
+func (r *BookingSeriesRepository) FindByUser(userID int) ([]*models.BookingSeries, error) {
+	query := `
+		SELECT id, user_id, dog_id, scheduled_time, interval_weeks, start_date, last_generated_date, is_active, created_at, updated_at
+		FROM booking_series
+		WHERE user_id = ?
+		ORDER BY created_at DESC
+	`
+
+	return r.query(query, userID)
+}
+
+// FindActive finds all active booking series (used by the cron job to extend them)
+func (r *BookingSeriesRepository) FindActive() ([]*models.BookingSeries, error) {
+	query := `
+		SELECT id, user_id, dog_id, scheduled_time, interval_weeks, start_date, last_generated_date, is_active, created_at, updated_at
+		FROM booking_series
+		WHERE is_active = ?
+		ORDER BY id ASC
+	`
+
+	return r.query(query, true)
+}
+
+// UpdateLastGeneratedDate records the last occurrence date that has been processed
+func (r *BookingSeriesRepository) UpdateLastGeneratedDate(id int, date string) error {
+	query := `UPDATE booking_series SET last_generated_date = ?, updated_at = ? WHERE id = ?`
+
+	if _, err := r.db.Exec(query, date, time.Now(), id); err != nil {
+		return fmt.Errorf("failed to update booking series: %w", err)
+	}
+
+	return nil
+}
+
+// Deactivate stops a series from being extended
+func (r *BookingSeriesRepository) Deactivate(id int) error {
+	query := `UPDATE booking_series SET is_active = ?, updated_at = ? WHERE id = ?`
+
+	if _, err := r.db.Exec(query, false, time.Now(), id); err != nil {
+		return fmt.Errorf("failed to deactivate booking series: %w", err)
+	}
+
+	return nil
+}
+
+// query runs a booking series query and scans all rows
+func (r *BookingSeriesRepository) query(query string, args ...interface{}) ([]*models.BookingSeries, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query booking series: %w", err)
+	}
+	defer rows.Close()
+
+	seriesList := []*models.BookingSeries{}
+	for rows.Next() {
+		series, err := scanBookingSeries(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan booking series: %w", err)
+		}
+		seriesList = append(seriesList, series)
+	}
+
+	return seriesList, nil
+}
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanBookingSeries scans one booking series row
+func scanBookingSeries(row rowScanner) (*models.BookingSeries, error) {
+	series := &models.BookingSeries{}
+	err := row.Scan(
+		&series.ID,
+		&series.UserID,
+		&series.DogID,
+		&series.ScheduledTime,
+		&series.IntervalWeeks,
+		&series.StartDate,
+		&series.LastGeneratedDate,
+		&series.IsActive,
+		&series.CreatedAt,
+		&series.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	series.StartDate = normalizeDate(series.StartDate)
+	series.LastGeneratedDate = normalizeDate(series.LastGeneratedDate)
+	return series, nil
+}
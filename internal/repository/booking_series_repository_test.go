@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/tranmh/gassigeher/internal/models"
+	"github.com/tranmh/gassigeher/internal/testutil"
+)
+
+// TestBookingSeriesRepository tests creating, finding and deactivating series
+func TestBookingSeriesRepository(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := NewBookingSeriesRepository(db)
+
+	userID := testutil.SeedTestUser(t, db, "series@example.com", "Series User", "green")
+	dogID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+
+	series := &models.BookingSeries{
+		UserID:            userID,
+		DogID:             dogID,
+		ScheduledTime:     "14:00",
+		IntervalWeeks:     1,
+		StartDate:         "2030-01-08",
+		LastGeneratedDate: "2030-01-07",
+	}
+
+	if err := repo.Create(series); err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	if series.ID == 0 || !series.IsActive {
+		t.Fatal("Expected series to be created active with an ID")
+	}
+
+	t.Run("find by id", func(t *testing.T) {
+		found, err := repo.FindByID(series.ID)
+		if err != nil {
+			t.Fatalf("FindByID() failed: %v", err)
+		}
+		if found == nil || found.StartDate != "2030-01-08" || found.ScheduledTime != "14:00" {
+			t.Errorf("Unexpected series: %+v", found)
+		}
+
+		missing, err := repo.FindByID(9999)
+		if err != nil || missing != nil {
+			t.Errorf("Expected nil for missing series, got %+v (err %v)", missing, err)
+		}
+	})
+
+	t.Run("update last generated date", func(t *testing.T) {
+		if err := repo.UpdateLastGeneratedDate(series.ID, "2030-01-15"); err != nil {
+			t.Fatalf("UpdateLastGeneratedDate() failed: %v", err)
+		}
+		found, _ := repo.FindByID(series.ID)
+		if found.LastGeneratedDate != "2030-01-15" {
+			t.Errorf("Expected 2030-01-15, got %s", found.LastGeneratedDate)
+		}
+	})
+
+	t.Run("deactivate removes series from active list", func(t *testing.T) {
+		active, err := repo.FindActive()
+		if err != nil || len(active) != 1 {
+			t.Fatalf("Expected 1 active series, got %d (err %v)", len(active), err)
+		}
+
+		if err := repo.Deactivate(series.ID); err != nil {
+			t.Fatalf("Deactivate() failed: %v", err)
+		}
+
+		active, _ = repo.FindActive()
+		if len(active) != 0 {
+			t.Errorf("Expected no active series, got %d", len(active))
+		}
+
+		byUser, _ := repo.FindByUser(userID)
+		if len(byUser) != 1 || byUser[0].IsActive {
+			t.Error("Expected deactivated series to still be listed for the user")
+		}
+	})
+}
@@ -0,0 +1,294 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tranmh/gassigeher/internal/models"
+)
+
+// BookingTransferRepository handles booking hand-over database operations
+type BookingTransferRepository struct {
+	db *sql.DB
+}
+
+// NewBookingTransferRepository creates a new booking transfer repository
+func NewBookingTransferRepository(db *sql.DB) *BookingTransferRepository {
+	return &BookingTransferRepository{db: db}
+}
+
+const transferColumns = `t.id, t.booking_id, t.from_user_id, t.to_user_id, t.status, t.note,
+		       t.accepted_at, t.decided_by, t.decided_at, t.created_at, t.updated_at,
+		       b.dog_id, d.name, b.date, b.scheduled_time,
+		       fu.first_name, fu.last_name, tu.first_name, tu.last_name`
+
+const transferJoins = `
+		FROM booking_transfers t
+		JOIN bookings b ON t.booking_id = b.id
+		JOIN dogs d ON b.dog_id = d.id
+		JOIN users fu ON t.from_user_id = fu.id
+		LEFT JOIN users tu ON t.to_user_id = tu.id`
+
+// Create offers a booking for transfer
+// Returns an error if the booking already has an open transfer
+func (r *BookingTransferRepository) Create(transfer *models.BookingTransfer) error {
+	var count int
+	err := r.db.QueryRow(`
+		SELECT COUNT(*) FROM booking_transfers
+		WHERE booking_id = ? AND status IN ('offered', 'pending_approval')
+	`, transfer.BookingID).Scan(&count)
+	if err != nil {
+		return fmt.Errorf("failed to check transfers: %w", err)
+	}
+	if count > 0 {
+		return fmt.Errorf("transfer already open")
+	}
+
+	now := time.Now()
+	transfer.Status = models.TransferStatusOffered
+
+	result, err := r.db.Exec(`
+		INSERT INTO booking_transfers (booking_id, from_user_id, status, note, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, transfer.BookingID, transfer.FromUserID, transfer.Status, transfer.Note, now, now)
+	if err != nil {
+		return fmt.Errorf("failed to create transfer: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get transfer ID: %w", err)
+	}
+
+	transfer.ID = int(id)
+	transfer.CreatedAt = now
+	transfer.UpdatedAt = now
+
+	return nil
+}
+
+// FindByID finds a transfer by ID
+func (r *BookingTransferRepository) FindByID(id int) (*models.BookingTransfer, error) {
+	transfers, err := r.query(`SELECT `+transferColumns+transferJoins+`
+		WHERE t.id = ?
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(transfers) == 0 {
+		return nil, nil
+	}
+
+	return transfers[0], nil
+}
+
+// FindOpenByBooking finds the open transfer of a booking, if any
+func (r *BookingTransferRepository) FindOpenByBooking(bookingID int) (*models.BookingTransfer, error) {
+	transfers, err := r.query(`SELECT `+transferColumns+transferJoins+`
+		WHERE t.booking_id = ? AND t.status IN ('offered', 'pending_approval')
+	`, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if len(transfers) == 0 {
+		return nil, nil
+	}
+
+	return transfers[0], nil
+}
+
+// FindByBooking finds all transfers of a booking, oldest first
+func (r *BookingTransferRepository) FindByBooking(bookingID int) ([]*models.BookingTransfer, error) {
+	return r.query(`SELECT `+transferColumns+transferJoins+`
+		WHERE t.booking_id = ?
+		ORDER BY t.created_at ASC, t.id ASC
+	`, bookingID)
+}
+
+// FindOffered finds open offers for upcoming scheduled bookings
+func (r *BookingTransferRepository) FindOffered(fromDate string) ([]*models.BookingTransfer, error) {
+	return r.query(`SELECT `+transferColumns+transferJoins+`
+		WHERE t.status = 'offered' AND b.status = 'scheduled' AND b.date >= ?
+		ORDER BY b.date ASC, b.scheduled_time ASC
+	`, fromDate)
+}
+
+// FindPendingApproval finds accepted transfers waiting for an admin decision
+func (r *BookingTransferRepository) FindPendingApproval() ([]*models.BookingTransfer, error) {
+	return r.query(`SELECT ` + transferColumns + transferJoins + `
+		WHERE t.status = 'pending_approval'
+		ORDER BY t.accepted_at ASC
+	`)
+}
+
+// MarkAccepted records the walker taking over and waits for admin approval
+func (r *BookingTransferRepository) MarkAccepted(id, toUserID int, at time.Time) error {
+	return r.updateOpen(`
+		UPDATE booking_transfers
+		SET status = 'pending_approval', to_user_id = ?, accepted_at = ?, updated_at = ?
+		WHERE id = ? AND status = 'offered'
+	`, toUserID, at, at, id)
+}
+
+// Withdraw closes an offer that nobody has accepted yet
+func (r *BookingTransferRepository) Withdraw(id int) error {
+	return r.updateOpen(`
+		UPDATE booking_transfers
+		SET status = 'withdrawn', updated_at = ?
+		WHERE id = ? AND status = 'offered'
+	`, time.Now(), id)
+}
+
+// Reject closes a transfer waiting for approval; the booking stays with its owner
+func (r *BookingTransferRepository) Reject(id, adminID int) error {
+	now := time.Now()
+	return r.updateOpen(`
+		UPDATE booking_transfers
+		SET status = 'rejected', decided_by = ?, decided_at = ?, updated_at = ?
+		WHERE id = ? AND status = 'pending_approval'
+	`, adminID, now, now, id)
+}
+
+// WithdrawOpenForBooking closes any open transfer of a booking (e.g. when it is cancelled)
+func (r *BookingTransferRepository) WithdrawOpenForBooking(bookingID int) error {
+	_, err := r.db.Exec(`
+		UPDATE booking_transfers
+		SET status = 'withdrawn', updated_at = ?
+		WHERE booking_id = ? AND status IN ('offered', 'pending_approval')
+	`, time.Now(), bookingID)
+	if err != nil {
+		return fmt.Errorf("failed to withdraw transfers: %w", err)
+	}
+
+	return nil
+}
+
+// Complete hands the booking over to toUserID and closes the transfer in one transaction.
+// decidedBy is the approving admin, or nil when no approval was needed.
+// Fails if the booking was cancelled or changed owner in the meantime.
+func (r *BookingTransferRepository) Complete(transfer *models.BookingTransfer, toUserID int, decidedBy *int, at time.Time) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// The walk leaves the giver's series, so cancelling that series does not cancel it
+	result, err := tx.Exec(`
+		UPDATE bookings
+		SET user_id = ?, series_id = NULL, updated_at = ?
+		WHERE id = ? AND user_id = ? AND status = 'scheduled'
+	`, toUserID, at, transfer.BookingID, transfer.FromUserID)
+	if err != nil {
+		return fmt.Errorf("failed to transfer booking: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	} else if rows == 0 {
+		return fmt.Errorf("booking is no longer transferable")
+	}
+
+	// The accepting walker hands the booking over, or the admin approving the transfer
+	actorID := &toUserID
+	if decidedBy != nil {
+		actorID = decidedBy
+	}
+	if err := insertBookingEvent(tx, transfer.BookingID, models.BookingEventTransferred, actorID,
+		strconv.Itoa(transfer.FromUserID), strconv.Itoa(toUserID)); err != nil {
+		return err
+	}
+
+	var decidedAt *time.Time
+	if decidedBy != nil {
+		decidedAt = &at
+	}
+	result, err = tx.Exec(`
+		UPDATE booking_transfers
+		SET status = 'completed', to_user_id = ?, accepted_at = COALESCE(accepted_at, ?),
+		    decided_by = ?, decided_at = ?, updated_at = ?
+		WHERE id = ? AND status IN ('offered', 'pending_approval')
+	`, toUserID, at, decidedBy, decidedAt, at, transfer.ID)
+	if err != nil {
+		return fmt.Errorf("failed to complete transfer: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	} else if rows == 0 {
+		return fmt.Errorf("transfer not found or not open")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// updateOpen runs a status change and reports a missing or already closed transfer
+func (r *BookingTransferRepository) updateOpen(query string, args ...interface{}) error {
+	result, err := r.db.Exec(query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update transfer: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("transfer not found or not open")
+	}
+
+	return nil
+}
+
+// query runs a transfer query and scans all rows
+func (r *BookingTransferRepository) query(query string, args ...interface{}) ([]*models.BookingTransfer, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query transfers: %w", err)
+	}
+	defer rows.Close()
+
+	transfers := []*models.BookingTransfer{}
+	for rows.Next() {
+		transfer := &models.BookingTransfer{}
+		var fromFirst, fromLast, toFirst, toLast sql.NullString
+		err := rows.Scan(
+			&transfer.ID,
+			&transfer.BookingID,
+			&transfer.FromUserID,
+			&transfer.ToUserID,
+			&transfer.Status,
+			&transfer.Note,
+			&transfer.AcceptedAt,
+			&transfer.DecidedBy,
+			&transfer.DecidedAt,
+			&transfer.CreatedAt,
+			&transfer.UpdatedAt,
+			&transfer.DogID,
+			&transfer.DogName,
+			&transfer.Date,
+			&transfer.ScheduledTime,
+			&fromFirst,
+			&fromLast,
+			&toFirst,
+			&toLast,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan transfer: %w", err)
+		}
+		transfer.Date = normalizeDate(transfer.Date)
+		transfer.FromUserName = strings.TrimSpace(fromFirst.String + " " + fromLast.String)
+		if toFirst.Valid {
+			toName := strings.TrimSpace(toFirst.String + " " + toLast.String)
+			transfer.ToUserName = &toName
+		}
+		transfers = append(transfers, transfer)
+	}
+
+	return transfers, rows.Err()
+}
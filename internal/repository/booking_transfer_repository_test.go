@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tranmh/gassigeher/internal/models"
+	"github.com/tranmh/gassigeher/internal/testutil"
+)
+
+// TestBookingTransferRepository tests offering a booking and handing it over
+func TestBookingTransferRepository(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := NewBookingTransferRepository(db)
+	bookingRepo := NewBookingRepository(db)
+
+	ownerID := testutil.SeedTestUser(t, db, "owner@example.com", "Olga Owner", "green")
+	takerID := testutil.SeedTestUser(t, db, "taker@example.com", "Tom Taker", "green")
+	dogID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+	bookingID := testutil.SeedTestBooking(t, db, ownerID, dogID, "2030-01-08", "09:00", "scheduled")
+
+	transfer := &models.BookingTransfer{BookingID: bookingID, FromUserID: ownerID}
+	if err := repo.Create(transfer); err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+
+	t.Run("reject second open transfer", func(t *testing.T) {
+		duplicate := &models.BookingTransfer{BookingID: bookingID, FromUserID: ownerID}
+		if err := repo.Create(duplicate); err == nil || err.Error() != "transfer already open" {
+			t.Errorf("Expected 'transfer already open' error, got %v", err)
+		}
+	})
+
+	t.Run("offer is listed with booking details", func(t *testing.T) {
+		offers, err := repo.FindOffered("2030-01-01")
+		if err != nil {
+			t.Fatalf("FindOffered() failed: %v", err)
+		}
+		if len(offers) != 1 || offers[0].DogName != "Bella" || offers[0].Date != "2030-01-08" || offers[0].FromUserName != "Olga Owner" {
+			t.Fatalf("Expected Bella's offer from Olga Owner, got %+v", offers)
+		}
+	})
+
+	t.Run("complete hands the booking over", func(t *testing.T) {
+		if err := repo.Complete(transfer, takerID, nil, time.Now()); err != nil {
+			t.Fatalf("Complete() failed: %v", err)
+		}
+
+		booking, _ := bookingRepo.FindByID(bookingID)
+		if booking.UserID != takerID {
+			t.Errorf("Expected booking owner %d, got %d", takerID, booking.UserID)
+		}
+
+		history, err := repo.FindByBooking(bookingID)
+		if err != nil {
+			t.Fatalf("FindByBooking() failed: %v", err)
+		}
+		if len(history) != 1 || history[0].Status != models.TransferStatusCompleted || history[0].ToUserName == nil || *history[0].ToUserName != "Tom Taker" {
+			t.Errorf("Expected completed transfer to Tom Taker, got %+v", history)
+		}
+	})
+
+	t.Run("transferred booking leaves the giver's series", func(t *testing.T) {
+		series := &models.BookingSeries{
+			UserID:            ownerID,
+			DogID:             dogID,
+			ScheduledTime:     "11:00",
+			IntervalWeeks:     1,
+			StartDate:         "2030-01-15",
+			LastGeneratedDate: "2030-01-22",
+		}
+		if err := NewBookingSeriesRepository(db).Create(series); err != nil {
+			t.Fatalf("Create() series failed: %v", err)
+		}
+		seriesBookingID := testutil.SeedTestBooking(t, db, ownerID, dogID, "2030-01-15", "11:00", "scheduled")
+		keptBookingID := testutil.SeedTestBooking(t, db, ownerID, dogID, "2030-01-22", "11:00", "scheduled")
+		db.Exec("UPDATE bookings SET series_id = ? WHERE id IN (?, ?)", series.ID, seriesBookingID, keptBookingID)
+
+		offer := &models.BookingTransfer{BookingID: seriesBookingID, FromUserID: ownerID}
+		if err := repo.Create(offer); err != nil {
+			t.Fatalf("Create() failed: %v", err)
+		}
+		if err := repo.Complete(offer, takerID, nil, time.Now()); err != nil {
+			t.Fatalf("Complete() failed: %v", err)
+		}
+
+		// Cancelling the series the way CancelSeries does must not touch the handed-over walk
+		upcoming, err := bookingRepo.FindUpcomingBySeries(series.ID)
+		if err != nil {
+			t.Fatalf("FindUpcomingBySeries() failed: %v", err)
+		}
+		for _, booking := range upcoming {
+			if err := bookingRepo.Cancel(booking.ID, nil); err != nil {
+				t.Fatalf("Cancel() failed: %v", err)
+			}
+		}
+
+		transferred, _ := bookingRepo.FindByID(seriesBookingID)
+		if transferred.Status != "scheduled" || transferred.SeriesID != nil {
+			t.Errorf("Expected transferred booking scheduled outside the series, got status %s", transferred.Status)
+		}
+		kept, _ := bookingRepo.FindByID(keptBookingID)
+		if kept.Status != "cancelled" {
+			t.Errorf("Expected the giver's own series booking to be cancelled, got %s", kept.Status)
+		}
+	})
+
+	t.Run("complete fails when the booking is gone", func(t *testing.T) {
+		cancelledID := testutil.SeedTestBooking(t, db, ownerID, dogID, "2030-01-09", "09:00", "scheduled")
+		offer := &models.BookingTransfer{BookingID: cancelledID, FromUserID: ownerID}
+		if err := repo.Create(offer); err != nil {
+			t.Fatalf("Create() failed: %v", err)
+		}
+		bookingRepo.Cancel(cancelledID, nil)
+
+		if err := repo.Complete(offer, takerID, nil, time.Now()); err == nil {
+			t.Fatal("Expected error for cancelled booking")
+		}
+		stored, _ := repo.FindByID(offer.ID)
+		if stored.Status != models.TransferStatusOffered {
+			t.Errorf("Transfer should stay offered after rollback, got %s", stored.Status)
+		}
+
+		if err := repo.WithdrawOpenForBooking(cancelledID); err != nil {
+			t.Fatalf("WithdrawOpenForBooking() failed: %v", err)
+		}
+		stored, _ = repo.FindByID(offer.ID)
+		if stored.Status != models.TransferStatusWithdrawn {
+			t.Errorf("Expected withdrawn transfer, got %s", stored.Status)
+		}
+	})
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// CalendarTokenRepository handles the secret tokens of personal calendar feeds
+type CalendarTokenRepository struct {
+	db *sql.DB
+}
+
+// NewCalendarTokenRepository creates a new calendar token repository
+func NewCalendarTokenRepository(db *sql.DB) *CalendarTokenRepository {
+	return &CalendarTokenRepository{db: db}
+}
+
+// FindByUser returns the user's feed token, or "" if the user has none yet
+func (r *CalendarTokenRepository) FindByUser(userID int) (string, error) {
+	var token string
+	err := r.db.QueryRow("SELECT token FROM calendar_tokens WHERE user_id = ?", userID).Scan(&token)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get calendar token: %w", err)
+	}
+
+	return token, nil
+}
+
+// FindUserID returns the ID of the user owning token, or 0 if the token is unknown
+func (r *CalendarTokenRepository) FindUserID(token string) (int, error) {
+	var userID int
+	err := r.db.QueryRow("SELECT user_id FROM calendar_tokens WHERE token = ?", token).Scan(&userID)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to find calendar token: %w", err)
+	}
+
+	return userID, nil
+}
+
+// Set stores token as the user's feed token, replacing (and so revoking) the previous one
+func (r *CalendarTokenRepository) Set(userID int, token string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM calendar_tokens WHERE user_id = ?", userID); err != nil {
+		return fmt.Errorf("failed to remove calendar token: %w", err)
+	}
+	if _, err := tx.Exec(`
+		INSERT INTO calendar_tokens (user_id, token, created_at)
+		VALUES (?, ?, ?)
+	`, userID, token, time.Now()); err != nil {
+		return fmt.Errorf("failed to store calendar token: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/tranmh/gassigeher/internal/testutil"
+)
+
+// TestCalendarTokenRepository tests storing, replacing and looking up feed tokens
+func TestCalendarTokenRepository(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := NewCalendarTokenRepository(db)
+
+	userID := testutil.SeedTestUser(t, db, "walker@example.com", "Walker", "green")
+
+	if token, err := repo.FindByUser(userID); err != nil || token != "" {
+		t.Fatalf("Expected no token yet, got %q (err %v)", token, err)
+	}
+
+	if err := repo.Set(userID, "first"); err != nil {
+		t.Fatalf("Set() failed: %v", err)
+	}
+	if err := repo.Set(userID, "second"); err != nil {
+		t.Fatalf("Set() replacing the token failed: %v", err)
+	}
+
+	if token, _ := repo.FindByUser(userID); token != "second" {
+		t.Errorf("Expected token 'second', got %q", token)
+	}
+	if id, _ := repo.FindUserID("second"); id != userID {
+		t.Errorf("Expected user %d, got %d", userID, id)
+	}
+	if id, _ := repo.FindUserID("first"); id != 0 {
+		t.Errorf("Expected replaced token to be unknown, got user %d", id)
+	}
+}
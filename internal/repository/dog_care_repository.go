@@ -0,0 +1,250 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tranmh/gassigeher/internal/models"
+)
+
+// DogCareRepository handles the care log of dogs
+type DogCareRepository struct {
+	db *sql.DB
+}
+
+// NewDogCareRepository creates a new dog care repository
+func NewDogCareRepository(db *sql.DB) *DogCareRepository {
+	return &DogCareRepository{db: db}
+}
+
+const dogCareColumns = `id, dog_id, entry_type, title, notes, start_date, end_date, appointment_time,
+	dosage, schedule_times, blocked_date_id, created_by, created_at, updated_at`
+
+// Create creates a new care entry
+func (r *DogCareRepository) Create(entry *models.DogCareEntry) error {
+	now := time.Now()
+	result, err := r.db.Exec(`
+		INSERT INTO dog_care_entries (dog_id, entry_type, title, notes, start_date, end_date, appointment_time,
+			dosage, schedule_times, blocked_date_id, created_by, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		entry.DogID,
+		entry.EntryType,
+		entry.Title,
+		entry.Notes,
+		entry.StartDate,
+		emptyToNil(entry.EndDate),
+		emptyToNil(entry.AppointmentTime),
+		entry.Dosage,
+		joinScheduleTimes(entry.ScheduleTimes),
+		entry.BlockedDateID,
+		entry.CreatedBy,
+		now,
+		now,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create care entry: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get care entry ID: %w", err)
+	}
+
+	entry.ID = int(id)
+	entry.CreatedAt = now
+	entry.UpdatedAt = now
+
+	return nil
+}
+
+// Update updates a care entry
+func (r *DogCareRepository) Update(entry *models.DogCareEntry) error {
+	now := time.Now()
+	result, err := r.db.Exec(`
+		UPDATE dog_care_entries SET
+			entry_type = ?, title = ?, notes = ?, start_date = ?, end_date = ?, appointment_time = ?,
+			dosage = ?, schedule_times = ?, blocked_date_id = ?, updated_at = ?
+		WHERE id = ?
+	`,
+		entry.EntryType,
+		entry.Title,
+		entry.Notes,
+		entry.StartDate,
+		emptyToNil(entry.EndDate),
+		emptyToNil(entry.AppointmentTime),
+		entry.Dosage,
+		joinScheduleTimes(entry.ScheduleTimes),
+		entry.BlockedDateID,
+		now,
+		entry.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update care entry: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("care entry not found")
+	}
+
+	entry.UpdatedAt = now
+	return nil
+}
+
+// FindByID finds a care entry by ID, returning nil if it does not exist
+func (r *DogCareRepository) FindByID(id int) (*models.DogCareEntry, error) {
+	row := r.db.QueryRow(`SELECT `+dogCareColumns+` FROM dog_care_entries WHERE id = ?`, id)
+	entry, err := scanDogCareEntry(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find care entry: %w", err)
+	}
+	return entry, nil
+}
+
+// FindByDog returns a dog's care log, newest entries first
+func (r *DogCareRepository) FindByDog(dogID int) ([]*models.DogCareEntry, error) {
+	rows, err := r.db.Query(`
+		SELECT `+dogCareColumns+`
+		FROM dog_care_entries
+		WHERE dog_id = ?
+		ORDER BY start_date DESC, id DESC
+	`, dogID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query care entries: %w", err)
+	}
+	defer rows.Close()
+
+	return scanDogCareEntries(rows)
+}
+
+// FindActiveMedications returns the medications of a dog that cover the given date
+func (r *DogCareRepository) FindActiveMedications(dogID int, date string) ([]*models.DogCareEntry, error) {
+	rows, err := r.db.Query(`
+		SELECT `+dogCareColumns+`
+		FROM dog_care_entries
+		WHERE dog_id = ? AND entry_type = ? AND start_date <= ?
+		  AND (end_date IS NULL OR end_date >= ?)
+		ORDER BY id ASC
+	`, dogID, models.DogCareMedication, date, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query medications: %w", err)
+	}
+	defer rows.Close()
+
+	return scanDogCareEntries(rows)
+}
+
+// MedicationDuringWalk returns the medication doses due during a walk of the dog starting
+// at scheduledTime on date, using the dog's walk duration
+func (r *DogCareRepository) MedicationDuringWalk(dogID int, date, scheduledTime string) ([]models.MedicationDose, error) {
+	medications, err := r.FindActiveMedications(dogID, date)
+	if err != nil {
+		return nil, err
+	}
+	if len(medications) == 0 {
+		return []models.MedicationDose{}, nil
+	}
+
+	var walkDuration sql.NullInt64
+	if err := r.db.QueryRow(`SELECT walk_duration FROM dogs WHERE id = ?`, dogID).Scan(&walkDuration); err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to get walk duration: %w", err)
+	}
+	duration := models.DefaultWalkDuration
+	if walkDuration.Valid && walkDuration.Int64 > 0 {
+		duration = int(walkDuration.Int64)
+	}
+
+	return models.MedicationDuringWalk(medications, date, scheduledTime, duration), nil
+}
+
+// Delete deletes a care entry
+func (r *DogCareRepository) Delete(id int) error {
+	result, err := r.db.Exec(`DELETE FROM dog_care_entries WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete care entry: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("care entry not found")
+	}
+
+	return nil
+}
+
+// scanDogCareEntry scans one row selected with dogCareColumns
+func scanDogCareEntry(scanner rowScanner) (*models.DogCareEntry, error) {
+	entry := &models.DogCareEntry{}
+	var endDate, scheduleTimes sql.NullString
+	err := scanner.Scan(
+		&entry.ID,
+		&entry.DogID,
+		&entry.EntryType,
+		&entry.Title,
+		&entry.Notes,
+		&entry.StartDate,
+		&endDate,
+		&entry.AppointmentTime,
+		&entry.Dosage,
+		&scheduleTimes,
+		&entry.BlockedDateID,
+		&entry.CreatedBy,
+		&entry.CreatedAt,
+		&entry.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.StartDate = normalizeDate(entry.StartDate)
+	if endDate.Valid && endDate.String != "" {
+		end := normalizeDate(endDate.String)
+		entry.EndDate = &end
+	}
+	if scheduleTimes.Valid && scheduleTimes.String != "" {
+		entry.ScheduleTimes = strings.Split(scheduleTimes.String, ",")
+	}
+
+	return entry, nil
+}
+
+// scanDogCareEntries scans all rows selected with dogCareColumns
+func scanDogCareEntries(rows *sql.Rows) ([]*models.DogCareEntry, error) {
+	entries := []*models.DogCareEntry{}
+	for rows.Next() {
+		entry, err := scanDogCareEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan care entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+// joinScheduleTimes stores dose times as "08:00,18:00" (NULL without doses)
+func joinScheduleTimes(times []string) interface{} {
+	if len(times) == 0 {
+		return nil
+	}
+	return strings.Join(times, ",")
+}
+
+// emptyToNil stores empty optional strings as NULL
+func emptyToNil(value *string) *string {
+	if value == nil || *value == "" {
+		return nil
+	}
+	return value
+}
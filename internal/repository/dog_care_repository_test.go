@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/tranmh/gassigeher/internal/models"
+	"github.com/tranmh/gassigeher/internal/testutil"
+)
+
+// TestDogCareRepository_CRUD tests storing, listing, updating and deleting care entries
+func TestDogCareRepository_CRUD(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := NewDogCareRepository(db)
+
+	dogID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+	dosage := "1 Tablette"
+	emptyEnd := ""
+
+	vaccination := &models.DogCareEntry{DogID: dogID, EntryType: models.DogCareVaccination, Title: "Tollwut", StartDate: "2030-01-05", EndDate: &emptyEnd}
+	medication := &models.DogCareEntry{DogID: dogID, EntryType: models.DogCareMedication, Title: "Rimadyl", StartDate: "2030-01-10",
+		Dosage: &dosage, ScheduleTimes: []string{"08:00", "18:00"}}
+	for _, entry := range []*models.DogCareEntry{vaccination, medication} {
+		if err := repo.Create(entry); err != nil {
+			t.Fatalf("Create() failed: %v", err)
+		}
+	}
+
+	found, err := repo.FindByID(medication.ID)
+	if err != nil || found == nil {
+		t.Fatalf("FindByID() failed: %v", err)
+	}
+	if found.StartDate != "2030-01-10" || found.EndDate != nil || len(found.ScheduleTimes) != 2 || found.ScheduleTimes[1] != "18:00" ||
+		found.Dosage == nil || *found.Dosage != dosage {
+		t.Errorf("Unexpected entry %+v", found)
+	}
+
+	stored, _ := repo.FindByID(vaccination.ID)
+	if stored.EndDate != nil || stored.ScheduleTimes != nil {
+		t.Errorf("Expected empty end date and doses to be stored as NULL, got %+v", stored)
+	}
+
+	entries, err := repo.FindByDog(dogID)
+	if err != nil {
+		t.Fatalf("FindByDog() failed: %v", err)
+	}
+	if len(entries) != 2 || entries[0].ID != medication.ID {
+		t.Errorf("Expected newest entry first, got %+v", entries)
+	}
+
+	lastDay := "2030-01-12"
+	found.EndDate = &lastDay
+	if err := repo.Update(found); err != nil {
+		t.Fatalf("Update() failed: %v", err)
+	}
+
+	for date, want := range map[string]int{"2030-01-09": 0, "2030-01-10": 1, "2030-01-12": 1, "2030-01-13": 0} {
+		active, err := repo.FindActiveMedications(dogID, date)
+		if err != nil {
+			t.Fatalf("FindActiveMedications() failed: %v", err)
+		}
+		if len(active) != want {
+			t.Errorf("Expected %d active medications on %s, got %d", want, date, len(active))
+		}
+	}
+
+	if err := repo.Delete(vaccination.ID); err != nil {
+		t.Fatalf("Delete() failed: %v", err)
+	}
+	if err := repo.Delete(vaccination.ID); err == nil || err.Error() != "care entry not found" {
+		t.Errorf("Expected 'care entry not found', got %v", err)
+	}
+}
+
+// TestDogCareRepository_MedicationDuringWalk tests that the dog's walk duration sets the window
+func TestDogCareRepository_MedicationDuringWalk(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := NewDogCareRepository(db)
+
+	dogID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+	repo.Create(&models.DogCareEntry{DogID: dogID, EntryType: models.DogCareMedication, Title: "Rimadyl", StartDate: "2030-01-10",
+		ScheduleTimes: []string{"08:30", "09:15"}})
+
+	// Default walk duration (60 minutes)
+	doses, err := repo.MedicationDuringWalk(dogID, "2030-01-10", "08:00")
+	if err != nil {
+		t.Fatalf("MedicationDuringWalk() failed: %v", err)
+	}
+	if len(doses) != 1 || doses[0].Time != "08:30" {
+		t.Errorf("Expected the 08:30 dose, got %+v", doses)
+	}
+
+	if _, err := db.Exec(`UPDATE dogs SET walk_duration = 90 WHERE id = ?`, dogID); err != nil {
+		t.Fatalf("Failed to set walk duration: %v", err)
+	}
+	doses, _ = repo.MedicationDuringWalk(dogID, "2030-01-10", "08:00")
+	if len(doses) != 2 {
+		t.Errorf("Expected both doses during a 90 minute walk, got %+v", doses)
+	}
+
+	doses, _ = repo.MedicationDuringWalk(dogID, "2030-01-09", "08:00")
+	if len(doses) != 0 {
+		t.Errorf("Expected no doses before the medication starts, got %+v", doses)
+	}
+}
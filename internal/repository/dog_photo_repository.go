@@ -0,0 +1,274 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tranmh/gassigeher/internal/models"
+)
+
+// ErrIncompletePhotoOrder is returned by Reorder when the order does not list exactly
+// the photos of the dog
+var ErrIncompletePhotoOrder = errors.New("photo order must list every photo of the dog")
+
+// DogPhotoRepository handles the photo galleries of dogs. The cover photo is mirrored
+// into dogs.photo / dogs.photo_thumbnail so lists and cards keep working unchanged.
+type DogPhotoRepository struct {
+	db *sql.DB
+}
+
+// NewDogPhotoRepository creates a new dog photo repository
+func NewDogPhotoRepository(db *sql.DB) *DogPhotoRepository {
+	return &DogPhotoRepository{db: db}
+}
+
+// Add appends a photo to the end of a dog's gallery. The first photo of a dog without
+// a cover becomes the cover.
+func (r *DogPhotoRepository) Add(dogID int, photoPath, thumbnailPath string) (*models.DogPhoto, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var displayOrder int
+	if err := tx.QueryRow(`SELECT COALESCE(MAX(display_order) + 1, 0) FROM dog_photos WHERE dog_id = ?`, dogID).Scan(&displayOrder); err != nil {
+		return nil, fmt.Errorf("failed to get display order: %w", err)
+	}
+
+	now := time.Now()
+	result, err := tx.Exec(`
+		INSERT INTO dog_photos (dog_id, photo_path, photo_thumbnail, display_order, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`, dogID, photoPath, thumbnailPath, displayOrder, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add dog photo: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get photo ID: %w", err)
+	}
+
+	result, err = tx.Exec(`
+		UPDATE dogs SET photo = ?, photo_thumbnail = ?, updated_at = ?
+		WHERE id = ? AND (photo IS NULL OR photo = '')
+	`, photoPath, thumbnailPath, now, dogID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set cover photo: %w", err)
+	}
+	covered, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to check rows affected: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &models.DogPhoto{
+		ID:             int(id),
+		DogID:          dogID,
+		PhotoPath:      photoPath,
+		PhotoThumbnail: thumbnailPath,
+		DisplayOrder:   displayOrder,
+		IsCover:        covered > 0,
+		CreatedAt:      now,
+	}, nil
+}
+
+// FindByDog returns a dog's gallery in display order
+func (r *DogPhotoRepository) FindByDog(dogID int) ([]models.DogPhoto, error) {
+	rows, err := r.db.Query(`
+		SELECT p.id, p.dog_id, p.photo_path, p.photo_thumbnail, p.display_order, p.created_at,
+		       CASE WHEN d.photo = p.photo_path THEN 1 ELSE 0 END
+		FROM dog_photos p
+		JOIN dogs d ON p.dog_id = d.id
+		WHERE p.dog_id = ?
+		ORDER BY p.display_order ASC, p.id ASC
+	`, dogID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dog photos: %w", err)
+	}
+	defer rows.Close()
+
+	photos := []models.DogPhoto{}
+	for rows.Next() {
+		photo := models.DogPhoto{}
+		var isCover int
+		if err := rows.Scan(
+			&photo.ID,
+			&photo.DogID,
+			&photo.PhotoPath,
+			&photo.PhotoThumbnail,
+			&photo.DisplayOrder,
+			&photo.CreatedAt,
+			&isCover,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan dog photo: %w", err)
+		}
+		photo.IsCover = isCover == 1
+		photos = append(photos, photo)
+	}
+
+	return photos, rows.Err()
+}
+
+// FindByID finds a photo by ID, returning nil if it does not exist
+func (r *DogPhotoRepository) FindByID(id int) (*models.DogPhoto, error) {
+	photo := &models.DogPhoto{}
+	err := r.db.QueryRow(`
+		SELECT id, dog_id, photo_path, photo_thumbnail, display_order, created_at
+		FROM dog_photos
+		WHERE id = ?
+	`, id).Scan(
+		&photo.ID,
+		&photo.DogID,
+		&photo.PhotoPath,
+		&photo.PhotoThumbnail,
+		&photo.DisplayOrder,
+		&photo.CreatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find dog photo: %w", err)
+	}
+
+	return photo, nil
+}
+
+// Count counts the photos of a dog
+func (r *DogPhotoRepository) Count(dogID int) (int, error) {
+	var count int
+	if err := r.db.QueryRow(`SELECT COUNT(*) FROM dog_photos WHERE dog_id = ?`, dogID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count dog photos: %w", err)
+	}
+	return count, nil
+}
+
+// SetCover makes a gallery photo the dog's cover photo
+func (r *DogPhotoRepository) SetCover(dogID, photoID int) error {
+	result, err := r.db.Exec(`
+		UPDATE dogs SET
+			photo = (SELECT photo_path FROM dog_photos WHERE id = ? AND dog_id = ?),
+			photo_thumbnail = (SELECT photo_thumbnail FROM dog_photos WHERE id = ? AND dog_id = ?),
+			updated_at = ?
+		WHERE id = ? AND EXISTS (SELECT 1 FROM dog_photos WHERE id = ? AND dog_id = ?)
+	`, photoID, dogID, photoID, dogID, time.Now(), dogID, photoID, dogID)
+	if err != nil {
+		return fmt.Errorf("failed to set cover photo: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("photo not found")
+	}
+
+	return nil
+}
+
+// Reorder sets the display order of a dog's gallery. photoIDs must list every photo
+// of the dog exactly once.
+func (r *DogPhotoRepository) Reorder(dogID int, photoIDs []int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var count int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM dog_photos WHERE dog_id = ?`, dogID).Scan(&count); err != nil {
+		return fmt.Errorf("failed to count dog photos: %w", err)
+	}
+	if count != len(photoIDs) {
+		return ErrIncompletePhotoOrder
+	}
+
+	// Check membership up front: MySQL only counts rows whose value actually changed
+	// as affected, so the updates below cannot tell a foreign ID from an unchanged one
+	args := []interface{}{dogID}
+	for _, photoID := range photoIDs {
+		args = append(args, photoID)
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(photoIDs)), ", ")
+	var owned int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM dog_photos WHERE dog_id = ? AND id IN (`+placeholders+`)`, args...).Scan(&owned); err != nil {
+		return fmt.Errorf("failed to count dog photos: %w", err)
+	}
+	if owned != len(photoIDs) {
+		return ErrIncompletePhotoOrder
+	}
+
+	for i, photoID := range photoIDs {
+		if _, err := tx.Exec(`UPDATE dog_photos SET display_order = ? WHERE id = ? AND dog_id = ?`, i, photoID, dogID); err != nil {
+			return fmt.Errorf("failed to update display order: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a photo from a dog's gallery. If it was the cover, the first remaining
+// photo becomes the cover (or the dog has no photo anymore).
+func (r *DogPhotoRepository) Delete(dogID, photoID int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var photoPath string
+	err = tx.QueryRow(`SELECT photo_path FROM dog_photos WHERE id = ? AND dog_id = ?`, photoID, dogID).Scan(&photoPath)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("photo not found")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find dog photo: %w", err)
+	}
+
+	if _, err := tx.Exec(`DELETE FROM dog_photos WHERE id = ?`, photoID); err != nil {
+		return fmt.Errorf("failed to delete dog photo: %w", err)
+	}
+
+	var cover sql.NullString
+	if err := tx.QueryRow(`SELECT photo FROM dogs WHERE id = ?`, dogID).Scan(&cover); err != nil {
+		return fmt.Errorf("failed to get cover photo: %w", err)
+	}
+
+	if cover.String == photoPath {
+		var nextPath, nextThumb *string
+		err := tx.QueryRow(`
+			SELECT photo_path, photo_thumbnail FROM dog_photos
+			WHERE dog_id = ?
+			ORDER BY display_order ASC, id ASC
+			LIMIT 1
+		`, dogID).Scan(&nextPath, &nextThumb)
+		if err != nil && err != sql.ErrNoRows {
+			return fmt.Errorf("failed to find next cover photo: %w", err)
+		}
+
+		if _, err := tx.Exec(`UPDATE dogs SET photo = ?, photo_thumbnail = ?, updated_at = ? WHERE id = ?`,
+			nextPath, nextThumb, time.Now(), dogID); err != nil {
+			return fmt.Errorf("failed to update cover photo: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
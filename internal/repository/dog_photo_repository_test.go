@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/tranmh/gassigeher/internal/testutil"
+)
+
+// TestDogPhotoRepository_Gallery tests adding, ordering, cover selection and deletion
+func TestDogPhotoRepository_Gallery(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := NewDogPhotoRepository(db)
+	dogRepo := NewDogRepository(db)
+
+	dogID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+	otherDogID := testutil.SeedTestDog(t, db, "Max", "Beagle", "green")
+
+	first, err := repo.Add(dogID, "dogs/a_full.jpg", "dogs/a_thumb.jpg")
+	if err != nil {
+		t.Fatalf("Add() failed: %v", err)
+	}
+	second, _ := repo.Add(dogID, "dogs/b_full.jpg", "dogs/b_thumb.jpg")
+	third, _ := repo.Add(dogID, "dogs/c_full.jpg", "dogs/c_thumb.jpg")
+
+	if !first.IsCover || second.IsCover || first.DisplayOrder != 0 || third.DisplayOrder != 2 {
+		t.Errorf("Expected the first photo as cover and ascending order, got %+v %+v %+v", first, second, third)
+	}
+	dog, _ := dogRepo.FindByID(dogID)
+	if dog.Photo == nil || *dog.Photo != "dogs/a_full.jpg" || *dog.PhotoThumbnail != "dogs/a_thumb.jpg" {
+		t.Errorf("Expected dog photo to be the first gallery photo, got %v", dog.Photo)
+	}
+
+	t.Run("reorder", func(t *testing.T) {
+		if err := repo.Reorder(dogID, []int{third.ID, first.ID, second.ID}); err != nil {
+			t.Fatalf("Reorder() failed: %v", err)
+		}
+		photos, _ := repo.FindByDog(dogID)
+		if len(photos) != 3 || photos[0].ID != third.ID || photos[1].ID != first.ID || photos[2].ID != second.ID {
+			t.Errorf("Unexpected order %+v", photos)
+		}
+		if !photos[1].IsCover {
+			t.Error("Expected the cover to stay on the first uploaded photo")
+		}
+
+		if err := repo.Reorder(dogID, []int{third.ID, first.ID, second.ID}); err != nil {
+			t.Errorf("Expected reordering to the unchanged order to succeed, got %v", err)
+		}
+		if err := repo.Reorder(dogID, []int{third.ID, first.ID}); err != ErrIncompletePhotoOrder {
+			t.Errorf("Expected ErrIncompletePhotoOrder when a photo is missing from the order, got %v", err)
+		}
+		if err := repo.Reorder(otherDogID, []int{first.ID}); err != ErrIncompletePhotoOrder {
+			t.Errorf("Expected ErrIncompletePhotoOrder when reordering photos of another dog, got %v", err)
+		}
+	})
+
+	t.Run("set cover", func(t *testing.T) {
+		if err := repo.SetCover(dogID, second.ID); err != nil {
+			t.Fatalf("SetCover() failed: %v", err)
+		}
+		dog, _ := dogRepo.FindByID(dogID)
+		if *dog.Photo != "dogs/b_full.jpg" || *dog.PhotoThumbnail != "dogs/b_thumb.jpg" {
+			t.Errorf("Expected cover b, got %s", *dog.Photo)
+		}
+
+		if err := repo.SetCover(otherDogID, second.ID); err == nil || err.Error() != "photo not found" {
+			t.Errorf("Expected 'photo not found' for a photo of another dog, got %v", err)
+		}
+	})
+
+	t.Run("delete cover falls back to first photo", func(t *testing.T) {
+		if err := repo.Delete(dogID, second.ID); err != nil {
+			t.Fatalf("Delete() failed: %v", err)
+		}
+		dog, _ := dogRepo.FindByID(dogID)
+		if *dog.Photo != "dogs/c_full.jpg" {
+			t.Errorf("Expected the first remaining photo (c) as cover, got %s", *dog.Photo)
+		}
+		if photo, _ := repo.FindByID(second.ID); photo != nil {
+			t.Error("Expected deleted photo to be gone")
+		}
+	})
+
+	t.Run("delete last photos clears dog photo", func(t *testing.T) {
+		repo.Delete(dogID, third.ID)
+		repo.Delete(dogID, first.ID)
+
+		dog, _ := dogRepo.FindByID(dogID)
+		if dog.Photo != nil || dog.PhotoThumbnail != nil {
+			t.Errorf("Expected no dog photo, got %v", *dog.Photo)
+		}
+		if count, _ := repo.Count(dogID); count != 0 {
+			t.Errorf("Expected empty gallery, got %d photos", count)
+		}
+		if err := repo.Delete(dogID, first.ID); err == nil || err.Error() != "photo not found" {
+			t.Errorf("Expected 'photo not found', got %v", err)
+		}
+	})
+}
@@ -4,6 +4,8 @@ import (
 	"database/sql"
 	"fmt"
 	"math/rand"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -12,22 +14,42 @@ import (
 
 // DogRepository handles dog database operations
 type DogRepository struct {
-	db *sql.DB
+	db     *sql.DB
+	search SearchDialect
 }
 
+// SearchDialect builds full-text search conditions for the database in use. It is
+// implemented by database.Dialect (declared here because the database tests import this package).
+type SearchDialect interface {
+	FullTextSearch(tableName string, columns []string, query string) (string, []interface{})
+}
+
+// dogSearchColumns are the columns covered by the dogs full-text index (migration 022_dog_search)
+var dogSearchColumns = []string{"name", "breed", "special_needs", "special_instructions", "walk_route"}
+
+// neverWalked sorts dogs without a completed walk before all others by last walk
+const neverWalked = "0001-01-01"
+
 // NewDogRepository creates a new dog repository
 func NewDogRepository(db *sql.DB) *DogRepository {
 	return &DogRepository{db: db}
 }
 
+// NewDogRepositoryWithSearch creates a dog repository whose search uses the database's
+// full-text index. Without a search dialect the search falls back to LIKE.
+func NewDogRepositoryWithSearch(db *sql.DB, search SearchDialect) *DogRepository {
+	return &DogRepository{db: db, search: search}
+}
+
 // Create creates a new dog
 func (r *DogRepository) Create(dog *models.Dog) error {
 	query := `
 		INSERT INTO dogs (
 			name, breed, size, age, color_id, photo, photo_thumbnail, special_needs,
-			pickup_location, walk_route, walk_duration, special_instructions,
-			default_morning_time, default_evening_time, is_available, external_link
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			pickup_location, walk_route, walk_duration, max_walks_per_day, min_rest_minutes, target_walks_per_week,
+			special_instructions,
+			default_morning_time, default_evening_time, is_available, external_link, shelter_id
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	result, err := r.db.Exec(
@@ -43,11 +65,15 @@ func (r *DogRepository) Create(dog *models.Dog) error {
 		dog.PickupLocation,
 		dog.WalkRoute,
 		dog.WalkDuration,
+		dog.MaxWalksPerDay,
+		dog.MinRestMinutes,
+		dog.TargetWalksPerWeek,
 		dog.SpecialInstructions,
 		dog.DefaultMorningTime,
 		dog.DefaultEveningTime,
 		dog.IsAvailable,
 		dog.ExternalLink,
+		dog.ShelterID,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create dog: %w", err)
@@ -68,14 +94,17 @@ func (r *DogRepository) Create(dog *models.Dog) error {
 func (r *DogRepository) FindByID(id int) (*models.Dog, error) {
 	query := `
 		SELECT id, name, breed, size, age, color_id, photo, photo_thumbnail, special_needs,
-		       pickup_location, walk_route, walk_duration, special_instructions,
-		       default_morning_time, default_evening_time, is_available, is_featured,
-		       external_link, unavailable_reason, unavailable_since, created_at, updated_at
+		       pickup_location, walk_route, walk_duration, max_walks_per_day, min_rest_minutes, target_walks_per_week,
+		       special_instructions,
+		       default_morning_time, default_evening_time, is_available, is_featured, is_public,
+		       external_link, shelter_id, unavailable_reason, unavailable_since, unavailable_from, unavailable_until,
+		       status, status_date, created_at, updated_at
 		FROM dogs
 		WHERE id = ?
 	`
 
 	dog := &models.Dog{}
+	var statusDate, unavailableFrom, unavailableUntil sql.NullString
 	err := r.db.QueryRow(query, id).Scan(
 		&dog.ID,
 		&dog.Name,
@@ -89,14 +118,23 @@ func (r *DogRepository) FindByID(id int) (*models.Dog, error) {
 		&dog.PickupLocation,
 		&dog.WalkRoute,
 		&dog.WalkDuration,
+		&dog.MaxWalksPerDay,
+		&dog.MinRestMinutes,
+		&dog.TargetWalksPerWeek,
 		&dog.SpecialInstructions,
 		&dog.DefaultMorningTime,
 		&dog.DefaultEveningTime,
 		&dog.IsAvailable,
 		&dog.IsFeatured,
+		&dog.IsPublic,
 		&dog.ExternalLink,
+		&dog.ShelterID,
 		&dog.UnavailableReason,
 		&dog.UnavailableSince,
+		&unavailableFrom,
+		&unavailableUntil,
+		&dog.Status,
+		&statusDate,
 		&dog.CreatedAt,
 		&dog.UpdatedAt,
 	)
@@ -107,17 +145,76 @@ func (r *DogRepository) FindByID(id int) (*models.Dog, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to find dog: %w", err)
 	}
+	setDogDates(dog, statusDate, unavailableFrom, unavailableUntil)
 
 	return dog, nil
 }
 
-// FindAll finds all dogs with optional filtering
+// FindByShelterID finds a dog by the ID in the shelter's own records
+func (r *DogRepository) FindByShelterID(shelterID string) (*models.Dog, error) {
+	var id int
+	err := r.db.QueryRow("SELECT id FROM dogs WHERE shelter_id = ?", shelterID).Scan(&id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find dog by shelter ID: %w", err)
+	}
+	return r.FindByID(id)
+}
+
+// FindAll finds all dogs with optional filtering. Without a filter archived dogs are included.
+// With a limit only the first page is returned (see FindPage).
 func (r *DogRepository) FindAll(filter *models.DogFilterRequest) ([]*models.Dog, error) {
+	dogs, _, err := r.findDogs(filter)
+	if err != nil {
+		return nil, err
+	}
+	if filter != nil && filter.Limit > 0 && len(dogs) > filter.Limit {
+		dogs = dogs[:filter.Limit]
+	}
+	return dogs, nil
+}
+
+// FindPage finds one page of dogs, continuing after the filter's cursor. Dogs are sorted by
+// the filter's sort field with the dog ID as tiebreaker, so pages neither overlap nor skip
+// dogs when the list changes in between.
+func (r *DogRepository) FindPage(filter *models.DogFilterRequest) (*models.DogPage, error) {
+	dogs, sortValues, err := r.findDogs(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &models.DogPage{Dogs: dogs}
+	if filter.Limit > 0 && len(dogs) > filter.Limit {
+		last := filter.Limit - 1
+		page.Dogs = dogs[:filter.Limit]
+		page.NextCursor = (&models.DogCursor{
+			Sort:  filter.SortField(),
+			Order: filter.SortOrder(),
+			Value: sortValues[last],
+			ID:    dogs[last].ID,
+		}).Encode()
+	}
+	return page, nil
+}
+
+// findDogs runs the dog list query and returns the dogs with the values they were sorted by.
+// With a limit one extra dog is fetched to tell whether another page follows.
+func (r *DogRepository) findDogs(filter *models.DogFilterRequest) ([]*models.Dog, []string, error) {
+	sortField, order := models.DogSortName, models.DogOrderAsc
+	if filter != nil {
+		sortField, order = filter.SortField(), filter.SortOrder()
+	}
+	sortExpr := dogSortExpression(sortField)
+
 	query := `
 		SELECT id, name, breed, size, age, color_id, photo, photo_thumbnail, special_needs,
-		       pickup_location, walk_route, walk_duration, special_instructions,
-		       default_morning_time, default_evening_time, is_available, is_featured,
-		       external_link, unavailable_reason, unavailable_since, created_at, updated_at
+		       pickup_location, walk_route, walk_duration, max_walks_per_day, min_rest_minutes, target_walks_per_week,
+		       special_instructions,
+		       default_morning_time, default_evening_time, is_available, is_featured, is_public,
+		       external_link, shelter_id, unavailable_reason, unavailable_since, unavailable_from, unavailable_until,
+		       status, status_date, created_at, updated_at, ` + sortExpr + `
 		FROM dogs
 		WHERE 1=1
 	`
@@ -126,6 +223,13 @@ func (r *DogRepository) FindAll(filter *models.DogFilterRequest) ([]*models.Dog,
 
 	// Apply filters
 	if filter != nil {
+		// Dogs that left the shelter are only listed in the archive
+		if filter.Archived {
+			query += " AND status IN ('adopted', 'deceased', 'transferred')"
+		} else {
+			query += " AND status NOT IN ('adopted', 'deceased', 'transferred')"
+		}
+
 		if filter.Breed != nil && *filter.Breed != "" {
 			query += " AND LOWER(breed) = LOWER(?)"
 			args = append(args, *filter.Breed)
@@ -161,24 +265,86 @@ func (r *DogRepository) FindAll(filter *models.DogFilterRequest) ([]*models.Dog,
 			args = append(args, *filter.Available)
 		}
 
-		if filter.Search != nil && *filter.Search != "" {
-			query += " AND (LOWER(name) LIKE LOWER(?) OR LOWER(breed) LIKE LOWER(?))"
-			searchTerm := "%" + *filter.Search + "%"
-			args = append(args, searchTerm, searchTerm)
+		if filter.Search != nil && strings.TrimSpace(*filter.Search) != "" {
+			condition, searchArgs := r.searchCondition(*filter.Search)
+			query += " AND " + condition
+			args = append(args, searchArgs...)
+		}
+
+		// Walker rules: hide dogs the walker is denied, and dogs with an allowlist
+		// the walker is not on (see models.DogWalkerRuleViolation)
+		if filter.WalkerID != nil {
+			day := filter.WalkerDate
+			if day == "" {
+				day = time.Now().Format("2006-01-02")
+			}
+			query += `
+				AND NOT EXISTS (
+					SELECT 1 FROM dog_walker_rules w
+					WHERE w.dog_id = dogs.id AND w.user_id = ? AND w.rule_type = 'deny'
+					  AND (w.expires_at IS NULL OR w.expires_at >= ?)
+				)
+				AND (
+					NOT EXISTS (
+						SELECT 1 FROM dog_walker_rules w
+						WHERE w.dog_id = dogs.id AND w.rule_type = 'allow'
+						  AND (w.expires_at IS NULL OR w.expires_at >= ?)
+					)
+					OR EXISTS (
+						SELECT 1 FROM dog_walker_rules w
+						WHERE w.dog_id = dogs.id AND w.user_id = ? AND w.rule_type = 'allow'
+						  AND (w.expires_at IS NULL OR w.expires_at >= ?)
+					)
+				)`
+			args = append(args, *filter.WalkerID, day, day, *filter.WalkerID, day)
+		}
+
+		// Keyset pagination: continue after the last dog of the previous page
+		cursor, err := filter.DecodeCursor()
+		if err != nil {
+			return nil, nil, err
+		}
+		if cursor != nil {
+			var value interface{} = cursor.Value
+			if sortField == models.DogSortAge {
+				age, err := strconv.Atoi(cursor.Value)
+				if err != nil {
+					return nil, nil, &models.ValidationError{Field: "cursor", Message: "Invalid cursor"}
+				}
+				value = age
+			}
+			op := ">"
+			if order == models.DogOrderDesc {
+				op = "<"
+			}
+			query += fmt.Sprintf(" AND (%s %s ? OR (%s = ? AND id %s ?))", sortExpr, op, sortExpr, op)
+			args = append(args, value, value, cursor.ID)
 		}
 	}
 
-	query += " ORDER BY name ASC"
+	direction := "ASC"
+	if order == models.DogOrderDesc {
+		direction = "DESC"
+	}
+	query += fmt.Sprintf(" ORDER BY %s %s, id %s", sortExpr, direction, direction)
+
+	if filter != nil && filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit+1)
+	}
 
 	rows, err := r.db.Query(query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query dogs: %w", err)
+		return nil, nil, fmt.Errorf("failed to query dogs: %w", err)
 	}
 	defer rows.Close()
 
 	dogs := []*models.Dog{}
+	sortValues := []string{}
 	for rows.Next() {
 		dog := &models.Dog{}
+		var statusDate, unavailableFrom, unavailableUntil sql.NullString
+		var sortValue string
 		err := rows.Scan(
 			&dog.ID,
 			&dog.Name,
@@ -192,24 +358,77 @@ func (r *DogRepository) FindAll(filter *models.DogFilterRequest) ([]*models.Dog,
 			&dog.PickupLocation,
 			&dog.WalkRoute,
 			&dog.WalkDuration,
+			&dog.MaxWalksPerDay,
+			&dog.MinRestMinutes,
+			&dog.TargetWalksPerWeek,
 			&dog.SpecialInstructions,
 			&dog.DefaultMorningTime,
 			&dog.DefaultEveningTime,
 			&dog.IsAvailable,
 			&dog.IsFeatured,
+			&dog.IsPublic,
 			&dog.ExternalLink,
+			&dog.ShelterID,
 			&dog.UnavailableReason,
 			&dog.UnavailableSince,
+			&unavailableFrom,
+			&unavailableUntil,
+			&dog.Status,
+			&statusDate,
 			&dog.CreatedAt,
 			&dog.UpdatedAt,
+			&sortValue,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan dog: %w", err)
+			return nil, nil, fmt.Errorf("failed to scan dog: %w", err)
+		}
+		setDogDates(dog, statusDate, unavailableFrom, unavailableUntil)
+		if sortField == models.DogSortLastWalk {
+			sortValue = normalizeDate(sortValue)
 		}
 		dogs = append(dogs, dog)
+		sortValues = append(sortValues, sortValue)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
 	}
 
-	return dogs, nil
+	return dogs, sortValues, nil
+}
+
+// dogSortExpression returns the SQL expression the dog list is sorted by
+func dogSortExpression(sortField string) string {
+	switch sortField {
+	case models.DogSortAge:
+		return "age"
+	case models.DogSortLastWalk:
+		return `COALESCE((SELECT MAX(b.date) FROM bookings b
+		        WHERE b.dog_id = dogs.id AND b.status = 'completed'), '` + neverWalked + `')`
+	default:
+		return "name"
+	}
+}
+
+// searchCondition returns the condition for a dog search: the full-text index if the database
+// can answer the query, otherwise every word must appear in one of the search columns
+func (r *DogRepository) searchCondition(search string) (string, []interface{}) {
+	if r.search != nil {
+		if condition, args := r.search.FullTextSearch("dogs", dogSearchColumns, search); condition != "" {
+			return condition, args
+		}
+	}
+
+	conditions := []string{}
+	args := []interface{}{}
+	for _, word := range strings.Fields(search) {
+		columns := make([]string, len(dogSearchColumns))
+		for i, column := range dogSearchColumns {
+			columns[i] = fmt.Sprintf("LOWER(%s) LIKE LOWER(?)", column)
+			args = append(args, "%"+word+"%")
+		}
+		conditions = append(conditions, "("+strings.Join(columns, " OR ")+")")
+	}
+	return "(" + strings.Join(conditions, " AND ") + ")", args
 }
 
 // GetFeatured returns up to 3 randomly selected featured dogs that are available
@@ -217,9 +436,11 @@ func (r *DogRepository) FindAll(filter *models.DogFilterRequest) ([]*models.Dog,
 func (r *DogRepository) GetFeatured() ([]*models.Dog, error) {
 	query := `
 		SELECT id, name, breed, size, age, color_id, photo, photo_thumbnail, special_needs,
-		       pickup_location, walk_route, walk_duration, special_instructions,
-		       default_morning_time, default_evening_time, is_available, is_featured,
-		       external_link, unavailable_reason, unavailable_since, created_at, updated_at
+		       pickup_location, walk_route, walk_duration, max_walks_per_day, min_rest_minutes, target_walks_per_week,
+		       special_instructions,
+		       default_morning_time, default_evening_time, is_available, is_featured, is_public,
+		       external_link, shelter_id, unavailable_reason, unavailable_since, unavailable_from, unavailable_until,
+		       status, status_date, created_at, updated_at
 		FROM dogs
 		WHERE is_featured = 1 AND is_available = 1
 		ORDER BY name ASC
@@ -234,6 +455,7 @@ func (r *DogRepository) GetFeatured() ([]*models.Dog, error) {
 	allFeatured := []*models.Dog{}
 	for rows.Next() {
 		dog := &models.Dog{}
+		var statusDate, unavailableFrom, unavailableUntil sql.NullString
 		err := rows.Scan(
 			&dog.ID,
 			&dog.Name,
@@ -247,20 +469,30 @@ func (r *DogRepository) GetFeatured() ([]*models.Dog, error) {
 			&dog.PickupLocation,
 			&dog.WalkRoute,
 			&dog.WalkDuration,
+			&dog.MaxWalksPerDay,
+			&dog.MinRestMinutes,
+			&dog.TargetWalksPerWeek,
 			&dog.SpecialInstructions,
 			&dog.DefaultMorningTime,
 			&dog.DefaultEveningTime,
 			&dog.IsAvailable,
 			&dog.IsFeatured,
+			&dog.IsPublic,
 			&dog.ExternalLink,
+			&dog.ShelterID,
 			&dog.UnavailableReason,
 			&dog.UnavailableSince,
+			&unavailableFrom,
+			&unavailableUntil,
+			&dog.Status,
+			&statusDate,
 			&dog.CreatedAt,
 			&dog.UpdatedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan featured dog: %w", err)
 		}
+		setDogDates(dog, statusDate, unavailableFrom, unavailableUntil)
 		allFeatured = append(allFeatured, dog)
 	}
 
@@ -289,6 +521,18 @@ func (r *DogRepository) SetFeatured(id int, isFeatured bool) error {
 	return nil
 }
 
+// SetPublic sets whether a dog has a public profile page
+func (r *DogRepository) SetPublic(id int, isPublic bool) error {
+	query := `UPDATE dogs SET is_public = ?, updated_at = ? WHERE id = ?`
+
+	_, err := r.db.Exec(query, isPublic, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to set public status: %w", err)
+	}
+
+	return nil
+}
+
 // CountFeatured returns the number of featured dogs
 func (r *DogRepository) CountFeatured() (int, error) {
 	query := `SELECT COUNT(*) FROM dogs WHERE is_featured = 1`
@@ -317,11 +561,15 @@ func (r *DogRepository) Update(dog *models.Dog) error {
 			pickup_location = ?,
 			walk_route = ?,
 			walk_duration = ?,
+			max_walks_per_day = ?,
+			min_rest_minutes = ?,
+			target_walks_per_week = ?,
 			special_instructions = ?,
 			default_morning_time = ?,
 			default_evening_time = ?,
 			is_available = ?,
 			external_link = ?,
+			shelter_id = ?,
 			unavailable_reason = ?,
 			unavailable_since = ?,
 			updated_at = ?
@@ -341,11 +589,15 @@ func (r *DogRepository) Update(dog *models.Dog) error {
 		dog.PickupLocation,
 		dog.WalkRoute,
 		dog.WalkDuration,
+		dog.MaxWalksPerDay,
+		dog.MinRestMinutes,
+		dog.TargetWalksPerWeek,
 		dog.SpecialInstructions,
 		dog.DefaultMorningTime,
 		dog.DefaultEveningTime,
 		dog.IsAvailable,
 		dog.ExternalLink,
+		dog.ShelterID,
 		dog.UnavailableReason,
 		dog.UnavailableSince,
 		time.Now(),
@@ -448,6 +700,8 @@ func (r *DogRepository) GetFutureBookings(dogID int) ([]*models.Booking, error)
 			return nil, fmt.Errorf("failed to scan booking: %w", err)
 		}
 
+		booking.Date = normalizeDate(booking.Date)
+
 		// Populate user details
 		if userFirstName.Valid {
 			booking.User.FirstName = userFirstName.String
@@ -482,17 +736,21 @@ func (r *DogRepository) ToggleAvailability(id int, isAvailable bool, reason *str
 				is_available = 1,
 				unavailable_reason = NULL,
 				unavailable_since = NULL,
+				unavailable_from = NULL,
+				unavailable_until = NULL,
 				updated_at = ?
 			WHERE id = ?
 		`
 		args = []interface{}{time.Now(), id}
 	} else {
-		// Mark as unavailable
+		// Mark as unavailable until made available again by hand
 		query = `
 			UPDATE dogs SET
 				is_available = 0,
 				unavailable_reason = ?,
 				unavailable_since = ?,
+				unavailable_from = NULL,
+				unavailable_until = NULL,
 				updated_at = ?
 			WHERE id = ?
 		`
@@ -508,6 +766,257 @@ func (r *DogRepository) ToggleAvailability(id int, isAvailable bool, reason *str
 	return nil
 }
 
+// SetUnavailability schedules a window from the first to the last (optional) unavailable day.
+// The dog becomes unavailable right away if the window has started by today (YYYY-MM-DD),
+// otherwise it stays available until ApplyUnavailabilityWindows starts the window.
+func (r *DogRepository) SetUnavailability(id int, from string, until *string, reason *string, today string) error {
+	now := time.Now()
+	var err error
+	if from <= today {
+		_, err = r.db.Exec(`
+			UPDATE dogs SET is_available = 0, unavailable_reason = ?, unavailable_since = ?,
+				unavailable_from = ?, unavailable_until = ?, updated_at = ?
+			WHERE id = ?
+		`, reason, now, from, emptyToNil(until), now, id)
+	} else {
+		_, err = r.db.Exec(`
+			UPDATE dogs SET is_available = 1, unavailable_reason = ?, unavailable_since = NULL,
+				unavailable_from = ?, unavailable_until = ?, updated_at = ?
+			WHERE id = ?
+		`, reason, from, emptyToNil(until), now, id)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to set unavailability: %w", err)
+	}
+
+	return nil
+}
+
+// ApplyUnavailabilityWindows makes dogs whose window starts by today (YYYY-MM-DD) unavailable
+// and dogs whose window ended before today available again. Archived dogs are left alone.
+// Returns how many dogs were disabled and re-enabled.
+func (r *DogRepository) ApplyUnavailabilityWindows(today string) (int, int, error) {
+	now := time.Now()
+	archived := []interface{}{models.DogStatusAdopted, models.DogStatusDeceased, models.DogStatusTransferred}
+
+	result, err := r.db.Exec(`
+		UPDATE dogs SET is_available = 0, unavailable_since = ?, updated_at = ?
+		WHERE is_available = 1 AND unavailable_from <= ?
+		  AND (unavailable_until IS NULL OR unavailable_until >= ?)
+		  AND status NOT IN (?, ?, ?)
+	`, append([]interface{}{now, now, today, today}, archived...)...)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to start unavailability windows: %w", err)
+	}
+	started, err := result.RowsAffected()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to check started unavailability windows: %w", err)
+	}
+
+	result, err = r.db.Exec(`
+		UPDATE dogs SET is_available = 1, unavailable_reason = NULL, unavailable_since = NULL,
+			unavailable_from = NULL, unavailable_until = NULL, updated_at = ?
+		WHERE unavailable_until < ? AND status NOT IN (?, ?, ?)
+	`, append([]interface{}{now, today}, archived...)...)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to end unavailability windows: %w", err)
+	}
+	ended, err := result.RowsAffected()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to check ended unavailability windows: %w", err)
+	}
+
+	return int(started), int(ended), nil
+}
+
+// ChangeStatus moves a dog to event.Status and records the transition. Archiving a dog
+// also makes it unavailable (reason), restoring it makes it available again.
+func (r *DogRepository) ChangeStatus(event *models.DogStatusEvent, reason *string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	switch {
+	case models.IsArchivedDogStatus(event.Status):
+		_, err = tx.Exec(`
+			UPDATE dogs SET status = ?, status_date = ?, is_available = 0, is_featured = 0,
+				unavailable_reason = ?, unavailable_since = ?, unavailable_from = NULL, unavailable_until = NULL, updated_at = ?
+			WHERE id = ?
+		`, event.Status, event.EffectiveDate, reason, now, now, event.DogID)
+	case models.IsArchivedDogStatus(event.PreviousStatus):
+		_, err = tx.Exec(`
+			UPDATE dogs SET status = ?, status_date = ?, is_available = 1,
+				unavailable_reason = NULL, unavailable_since = NULL, unavailable_from = NULL, unavailable_until = NULL, updated_at = ?
+			WHERE id = ?
+		`, event.Status, event.EffectiveDate, now, event.DogID)
+	default:
+		_, err = tx.Exec(`UPDATE dogs SET status = ?, status_date = ?, updated_at = ? WHERE id = ?`,
+			event.Status, event.EffectiveDate, now, event.DogID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update dog status: %w", err)
+	}
+
+	result, err := tx.Exec(`
+		INSERT INTO dog_status_events (dog_id, previous_status, status, effective_date, note, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, event.DogID, event.PreviousStatus, event.Status, event.EffectiveDate, event.Note, event.CreatedBy, now)
+	if err != nil {
+		return fmt.Errorf("failed to record dog status change: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get dog status event ID: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit dog status change: %w", err)
+	}
+
+	event.ID = int(id)
+	event.CreatedAt = now
+	return nil
+}
+
+// GetStatusHistory returns the status transitions of a dog, newest first
+func (r *DogRepository) GetStatusHistory(dogID int) ([]*models.DogStatusEvent, error) {
+	query := `
+		SELECT e.id, e.dog_id, e.previous_status, e.status, e.effective_date, e.note,
+		       e.created_by, u.first_name, u.last_name, e.created_at
+		FROM dog_status_events e
+		LEFT JOIN users u ON e.created_by = u.id
+		WHERE e.dog_id = ?
+		ORDER BY e.created_at DESC, e.id DESC
+	`
+
+	rows, err := r.db.Query(query, dogID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dog status history: %w", err)
+	}
+	defer rows.Close()
+
+	events := []*models.DogStatusEvent{}
+	for rows.Next() {
+		event := &models.DogStatusEvent{}
+		var firstName, lastName sql.NullString
+		err := rows.Scan(
+			&event.ID,
+			&event.DogID,
+			&event.PreviousStatus,
+			&event.Status,
+			&event.EffectiveDate,
+			&event.Note,
+			&event.CreatedBy,
+			&firstName,
+			&lastName,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan dog status event: %w", err)
+		}
+		event.EffectiveDate = normalizeDate(event.EffectiveDate)
+		if firstName.Valid {
+			name := strings.TrimSpace(firstName.String + " " + lastName.String)
+			event.CreatedByName = &name
+		}
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+// HasWalkHistory reports whether a dog has completed walks that deleting it would lose
+func (r *DogRepository) HasWalkHistory(dogID int) (bool, error) {
+	var count int
+	err := r.db.QueryRow(`SELECT COUNT(*) FROM bookings WHERE dog_id = ? AND status = 'completed'`, dogID).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to check walk history: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// FindUnderWalked returns the available dogs in the shelter that got fewer completed walks in
+// the 7 days up to today than their weekly target, longest without a walk first (never walked
+// dogs lead the list)
+func (r *DogRepository) FindUnderWalked(today time.Time) ([]*models.UnderWalkedDog, error) {
+	todayDate := today.Format("2006-01-02")
+	weekStart := today.AddDate(0, 0, -6).Format("2006-01-02")
+
+	query := `
+		SELECT d.id, d.name, d.target_walks_per_week,
+		       (SELECT COUNT(*) FROM bookings b
+		        WHERE b.dog_id = d.id AND b.status = 'completed' AND b.date >= ? AND b.date <= ?),
+		       (SELECT MAX(b.date) FROM bookings b
+		        WHERE b.dog_id = d.id AND b.status = 'completed' AND b.date <= ?)
+		FROM dogs d
+		WHERE d.target_walks_per_week > 0 AND d.is_available = 1 AND d.status NOT IN (?, ?, ?)
+		ORDER BY d.name ASC
+	`
+
+	rows, err := r.db.Query(query, weekStart, todayDate, todayDate,
+		models.DogStatusAdopted, models.DogStatusDeceased, models.DogStatusTransferred)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query walk targets: %w", err)
+	}
+	defer rows.Close()
+
+	midnight := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	dogs := []*models.UnderWalkedDog{}
+	for rows.Next() {
+		dog := &models.UnderWalkedDog{}
+		var lastWalk sql.NullString
+		if err := rows.Scan(&dog.DogID, &dog.DogName, &dog.TargetPerWeek, &dog.WalksLast7Days, &lastWalk); err != nil {
+			return nil, fmt.Errorf("failed to scan walk target: %w", err)
+		}
+		if dog.WalksLast7Days >= dog.TargetPerWeek {
+			continue
+		}
+
+		dog.LastWalkDate = nullableDate(lastWalk)
+		if dog.LastWalkDate != nil {
+			if walked, err := time.Parse("2006-01-02", *dog.LastWalkDate); err == nil {
+				days := int(midnight.Sub(walked).Hours() / 24)
+				dog.DaysSinceLastWalk = &days
+			}
+		}
+		dogs = append(dogs, dog)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(dogs, func(i, j int) bool {
+		a, b := dogs[i].DaysSinceLastWalk, dogs[j].DaysSinceLastWalk
+		if a == nil || b == nil {
+			return a == nil && b != nil
+		}
+		return *a > *b
+	})
+
+	return dogs, nil
+}
+
+// setDogDates copies the scanned status_date and unavailability window into the dog
+func setDogDates(dog *models.Dog, statusDate, unavailableFrom, unavailableUntil sql.NullString) {
+	dog.StatusDate = nullableDate(statusDate)
+	dog.UnavailableFrom = nullableDate(unavailableFrom)
+	dog.UnavailableUntil = nullableDate(unavailableUntil)
+}
+
+// nullableDate converts a scanned DATE column to a YYYY-MM-DD pointer (nil if NULL)
+func nullableDate(value sql.NullString) *string {
+	if !value.Valid || value.String == "" {
+		return nil
+	}
+	date := normalizeDate(value.String)
+	return &date
+}
+
 // GetBreeds returns a list of unique breeds
 func (r *DogRepository) GetBreeds() ([]string, error) {
 	query := `SELECT DISTINCT breed FROM dogs ORDER BY breed ASC`
@@ -1,9 +1,11 @@
 package repository
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/tranmh/gassigeher/internal/database"
 	"github.com/tranmh/gassigeher/internal/models"
 	"github.com/tranmh/gassigeher/internal/testutil"
 )
@@ -630,6 +632,75 @@ func TestDogRepository_ToggleAvailability(t *testing.T) {
 	})
 }
 
+// TestDogRepository_UnavailabilityWindows tests scheduling and applying unavailability windows
+func TestDogRepository_UnavailabilityWindows(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := NewDogRepository(db)
+
+	reason := "Tierarzt"
+	today := "2030-01-10"
+	until := "2030-01-12"
+	later := "2030-01-15"
+
+	currentID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+	upcomingID := testutil.SeedTestDog(t, db, "Max", "Beagle", "green")
+	openEndedID := testutil.SeedTestDog(t, db, "Rex", "Schäferhund", "green")
+
+	if err := repo.SetUnavailability(currentID, today, &until, &reason, today); err != nil {
+		t.Fatalf("SetUnavailability() failed: %v", err)
+	}
+	repo.SetUnavailability(upcomingID, until, &later, &reason, today)
+	repo.SetUnavailability(openEndedID, "2030-01-01", nil, &reason, today)
+
+	t.Run("started window disables the dog", func(t *testing.T) {
+		dog, _ := repo.FindByID(currentID)
+		if dog.IsAvailable || dog.UnavailableSince == nil {
+			t.Error("Expected dog to be unavailable since now")
+		}
+		if dog.UnavailableFrom == nil || *dog.UnavailableFrom != today || dog.UnavailableUntil == nil || *dog.UnavailableUntil != until {
+			t.Errorf("Expected window %s-%s, got %v-%v", today, until, dog.UnavailableFrom, dog.UnavailableUntil)
+		}
+	})
+
+	t.Run("upcoming window keeps the dog available", func(t *testing.T) {
+		dog, _ := repo.FindByID(upcomingID)
+		if !dog.IsAvailable || dog.UnavailableFrom == nil {
+			t.Error("Expected available dog with an upcoming window")
+		}
+	})
+
+	t.Run("windows start and end by date", func(t *testing.T) {
+		started, ended, err := repo.ApplyUnavailabilityWindows("2030-01-13")
+		if err != nil {
+			t.Fatalf("ApplyUnavailabilityWindows() failed: %v", err)
+		}
+		if started != 1 || ended != 1 {
+			t.Errorf("Expected 1 started and 1 ended window, got %d and %d", started, ended)
+		}
+
+		current, _ := repo.FindByID(currentID)
+		if !current.IsAvailable || current.UnavailableReason != nil || current.UnavailableFrom != nil {
+			t.Errorf("Expected Bella to be available again without window, got %+v", current)
+		}
+		upcoming, _ := repo.FindByID(upcomingID)
+		if upcoming.IsAvailable {
+			t.Error("Expected Max to be unavailable inside his window")
+		}
+		openEnded, _ := repo.FindByID(openEndedID)
+		if openEnded.IsAvailable {
+			t.Error("Expected open-ended window to stay")
+		}
+	})
+
+	t.Run("making the dog available clears the window", func(t *testing.T) {
+		repo.ToggleAvailability(openEndedID, true, nil)
+		dog, _ := repo.FindByID(openEndedID)
+		if !dog.IsAvailable || dog.UnavailableFrom != nil {
+			t.Errorf("Expected available dog without window, got %+v", dog)
+		}
+	})
+}
+
 // DONE: TestDogRepository_GetBreeds tests getting unique breed list
 func TestDogRepository_GetBreeds(t *testing.T) {
 	db := testutil.SetupTestDB(t)
@@ -900,3 +971,309 @@ func TestCanUserAccessDogByColor(t *testing.T) {
 		})
 	}
 }
+
+// TestDogRepository_ChangeStatus tests archiving and restoring dogs and their status history
+func TestDogRepository_ChangeStatus(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := NewDogRepository(db)
+
+	adminID := testutil.SeedTestUser(t, db, "admin@example.com", "Anna Admin", "orange")
+	dogID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+	testutil.SeedTestDog(t, db, "Max", "Beagle", "green")
+	repo.SetFeatured(dogID, true)
+
+	dog, _ := repo.FindByID(dogID)
+	if dog.Status != models.DogStatusInShelter || dog.StatusDate != nil {
+		t.Fatalf("Expected new dogs to be in the shelter, got %q %v", dog.Status, dog.StatusDate)
+	}
+
+	reason := "Hund wurde adoptiert"
+	adoption := &models.DogStatusEvent{DogID: dogID, PreviousStatus: models.DogStatusInShelter, Status: models.DogStatusAdopted,
+		EffectiveDate: "2030-01-10", Note: "Familie Müller", CreatedBy: &adminID}
+	if err := repo.ChangeStatus(adoption, &reason); err != nil {
+		t.Fatalf("ChangeStatus() failed: %v", err)
+	}
+
+	dog, _ = repo.FindByID(dogID)
+	if dog.Status != models.DogStatusAdopted || dog.StatusDate == nil || *dog.StatusDate != "2030-01-10" {
+		t.Errorf("Expected adopted on 2030-01-10, got %q %v", dog.Status, dog.StatusDate)
+	}
+	if dog.IsAvailable || dog.IsFeatured || dog.UnavailableReason == nil || *dog.UnavailableReason != reason {
+		t.Errorf("Expected the archived dog to be unavailable and not featured, got %+v", dog)
+	}
+
+	active, _ := repo.FindAll(&models.DogFilterRequest{})
+	archived, _ := repo.FindAll(&models.DogFilterRequest{Archived: true})
+	all, _ := repo.FindAll(nil)
+	if len(active) != 1 || active[0].Name != "Max" || len(archived) != 1 || archived[0].ID != dogID || len(all) != 2 {
+		t.Errorf("Expected Max active, Bella archived and both without filter, got %d/%d/%d", len(active), len(archived), len(all))
+	}
+
+	restore := &models.DogStatusEvent{DogID: dogID, PreviousStatus: models.DogStatusAdopted, Status: models.DogStatusInShelter,
+		EffectiveDate: "2030-02-01", Note: "Rückgabe", CreatedBy: &adminID}
+	if err := repo.ChangeStatus(restore, nil); err != nil {
+		t.Fatalf("ChangeStatus() failed: %v", err)
+	}
+
+	dog, _ = repo.FindByID(dogID)
+	if dog.Status != models.DogStatusInShelter || !dog.IsAvailable || dog.UnavailableReason != nil {
+		t.Errorf("Expected the restored dog to be available again, got %+v", dog)
+	}
+
+	history, err := repo.GetStatusHistory(dogID)
+	if err != nil {
+		t.Fatalf("GetStatusHistory() failed: %v", err)
+	}
+	if len(history) != 2 || history[0].ID != restore.ID || history[1].EffectiveDate != "2030-01-10" {
+		t.Fatalf("Expected both transitions newest first, got %+v", history)
+	}
+	if history[1].CreatedByName == nil || *history[1].CreatedByName != "Anna Admin" {
+		t.Errorf("Expected admin name 'Anna Admin', got %v", history[1].CreatedByName)
+	}
+}
+
+// TestDogRepository_HasWalkHistory tests that only completed walks count as history
+func TestDogRepository_HasWalkHistory(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := NewDogRepository(db)
+
+	userID := testutil.SeedTestUser(t, db, "user@example.com", "Test User", "green")
+	dogID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+	testutil.SeedTestBooking(t, db, userID, dogID, "2030-01-10", "09:00", "cancelled")
+
+	if has, err := repo.HasWalkHistory(dogID); err != nil || has {
+		t.Errorf("Expected no walk history, got %v (%v)", has, err)
+	}
+
+	testutil.SeedTestBooking(t, db, userID, dogID, "2030-01-11", "09:00", "completed")
+	if has, _ := repo.HasWalkHistory(dogID); !has {
+		t.Error("Expected walk history after a completed walk")
+	}
+}
+
+// TestDogRepository_FindUnderWalked tests comparing walk targets with completed walks
+func TestDogRepository_FindUnderWalked(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := NewDogRepository(db)
+	today := time.Date(2030, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	userID := testutil.SeedTestUser(t, db, "user@example.com", "Test User", "green")
+	happyID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+	shyID := testutil.SeedTestDog(t, db, "Max", "Beagle", "green")
+	neverID := testutil.SeedTestDog(t, db, "Rex", "Schäferhund", "green")
+	recentID := testutil.SeedTestDog(t, db, "Luna", "Pudel", "green")
+	sickID := testutil.SeedTestDog(t, db, "Kira", "Mischling", "green")
+	testutil.SeedTestDog(t, db, "Nala", "Mischling", "green") // no target
+
+	db.Exec("UPDATE dogs SET target_walks_per_week = 2 WHERE id IN (?, ?, ?, ?, ?)", happyID, shyID, neverID, recentID, sickID)
+	db.Exec("UPDATE dogs SET is_available = 0 WHERE id = ?", sickID)
+
+	testutil.SeedTestBooking(t, db, userID, happyID, "2030-01-04", "09:00", "completed")
+	testutil.SeedTestBooking(t, db, userID, happyID, "2030-01-09", "09:00", "completed")
+	testutil.SeedTestBooking(t, db, userID, shyID, "2030-01-03", "09:00", "completed") // outside the week
+	testutil.SeedTestBooking(t, db, userID, shyID, "2030-01-11", "09:00", "scheduled")
+	testutil.SeedTestBooking(t, db, userID, recentID, "2030-01-08", "09:00", "completed")
+	testutil.SeedTestBooking(t, db, userID, recentID, "2030-01-09", "09:00", "cancelled")
+
+	dogs, err := repo.FindUnderWalked(today)
+	if err != nil {
+		t.Fatalf("FindUnderWalked() failed: %v", err)
+	}
+
+	if len(dogs) != 3 {
+		t.Fatalf("Expected 3 under-walked dogs, got %d: %+v", len(dogs), dogs)
+	}
+	if dogs[0].DogID != neverID || dogs[0].LastWalkDate != nil || dogs[0].DaysSinceLastWalk != nil {
+		t.Errorf("Expected never walked dog first, got %+v", dogs[0])
+	}
+	if dogs[1].DogID != shyID || dogs[1].WalksLast7Days != 0 || *dogs[1].LastWalkDate != "2030-01-03" || *dogs[1].DaysSinceLastWalk != 7 {
+		t.Errorf("Expected dog without walk for 7 days second, got %+v", dogs[1])
+	}
+	if dogs[2].DogID != recentID || dogs[2].WalksLast7Days != 1 || dogs[2].TargetPerWeek != 2 || *dogs[2].DaysSinceLastWalk != 2 {
+		t.Errorf("Expected dog with 1 of 2 walks last, got %+v", dogs[2])
+	}
+}
+
+// TestDogRepository_FindByShelterID tests looking up dogs by their shelter ID
+func TestDogRepository_FindByShelterID(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := NewDogRepository(db)
+
+	shelterID := "T-100"
+	dog := &models.Dog{Name: "Bella", Breed: "Labrador", Size: "medium", Age: 3, IsAvailable: true, ShelterID: &shelterID}
+	if err := repo.Create(dog); err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+
+	found, err := repo.FindByShelterID("T-100")
+	if err != nil {
+		t.Fatalf("FindByShelterID() failed: %v", err)
+	}
+	if found == nil || found.ID != dog.ID || found.ShelterID == nil || *found.ShelterID != "T-100" {
+		t.Errorf("Expected dog %d with shelter ID T-100, got %+v", dog.ID, found)
+	}
+
+	if missing, err := repo.FindByShelterID("T-999"); err != nil || missing != nil {
+		t.Errorf("Expected no dog for unknown shelter ID, got %+v (%v)", missing, err)
+	}
+
+	duplicate := &models.Dog{Name: "Max", Breed: "Beagle", Size: "small", Age: 2, IsAvailable: true, ShelterID: &shelterID}
+	if err := repo.Create(duplicate); err == nil {
+		t.Error("Expected the shelter ID to be unique")
+	}
+}
+
+// TestDogRepository_Search tests the full-text search and the LIKE fallback
+func TestDogRepository_Search(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+
+	bellaID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+	rexID := testutil.SeedTestDog(t, db, "Rex", "Schäferhund", "green")
+	lunaID := testutil.SeedTestDog(t, db, "Luna", "Pudel", "green")
+	db.Exec("UPDATE dogs SET special_needs = 'Braucht Medikamente gegen Allergie' WHERE id = ?", bellaID)
+	db.Exec("UPDATE dogs SET walk_route = 'Runde um den Stadtpark', special_instructions = 'Nicht ohne Maulkorb' WHERE id = ?", rexID)
+	db.Exec("UPDATE dogs SET walk_route = 'Feldweg am Waldrand' WHERE id = ?", lunaID)
+
+	search := func(t *testing.T, repo *DogRepository, query string) []int {
+		dogs, err := repo.FindAll(&models.DogFilterRequest{Search: &query})
+		if err != nil {
+			t.Fatalf("FindAll(%q) failed: %v", query, err)
+		}
+		ids := []int{}
+		for _, dog := range dogs {
+			ids = append(ids, dog.ID)
+		}
+		return ids
+	}
+
+	for name, repo := range map[string]*DogRepository{
+		"full-text": NewDogRepositoryWithSearch(db, database.NewSQLiteDialect()),
+		"LIKE":      NewDogRepository(db),
+	} {
+		t.Run(name, func(t *testing.T) {
+			testCases := []struct {
+				query string
+				want  []int
+			}{
+				{"allergie", []int{bellaID}},
+				{"Stadtpark", []int{rexID}},
+				{"maulkorb", []int{rexID}},
+				{"Schäfer", []int{rexID}},
+				{"Wald Feld", []int{lunaID}},
+				{"Runde Wald", []int{}},
+				{"Pudel", []int{lunaID}},
+			}
+			for _, tc := range testCases {
+				if got := search(t, repo, tc.query); fmt.Sprint(got) != fmt.Sprint(tc.want) {
+					t.Errorf("Search %q: expected dogs %v, got %v", tc.query, tc.want, got)
+				}
+			}
+		})
+	}
+
+	t.Run("full-text index follows changes", func(t *testing.T) {
+		repo := NewDogRepositoryWithSearch(db, database.NewSQLiteDialect())
+
+		if got := search(t, repo, "Schaferhund"); fmt.Sprint(got) != fmt.Sprint([]int{rexID}) {
+			t.Errorf("Expected diacritics to be ignored, got %v", got)
+		}
+
+		db.Exec("UPDATE dogs SET special_needs = NULL WHERE id = ?", bellaID)
+		if got := search(t, repo, "Allergie"); len(got) != 0 {
+			t.Errorf("Expected the updated dog to leave the index, got %v", got)
+		}
+
+		repo.ForceDelete(lunaID)
+		if got := search(t, repo, "Waldrand"); len(got) != 0 {
+			t.Errorf("Expected the deleted dog to leave the index, got %v", got)
+		}
+
+		// Full-text syntax in the query is treated as plain words
+		if got := search(t, repo, `"Rex*" -(Maulkorb`); fmt.Sprint(got) != fmt.Sprint([]int{rexID}) {
+			t.Errorf("Expected operators to be ignored, got %v", got)
+		}
+	})
+}
+
+// TestDogRepository_FindPage tests sorting and cursor pagination of the dog list
+func TestDogRepository_FindPage(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := NewDogRepository(db)
+
+	userID := testutil.SeedTestUser(t, db, "user@example.com", "Test User", "green")
+	ids := map[string]int{}
+	for i, name := range []string{"Emma", "Bella", "Anton", "Dino", "Carla"} {
+		ids[name] = testutil.SeedTestDog(t, db, name, "Mischling", "green")
+		db.Exec("UPDATE dogs SET age = ? WHERE id = ?", []int{3, 7, 3, 1, 9}[i], ids[name])
+	}
+	testutil.SeedTestBooking(t, db, userID, ids["Emma"], "2030-01-05", "09:00", "completed")
+	testutil.SeedTestBooking(t, db, userID, ids["Bella"], "2030-01-02", "09:00", "completed")
+	testutil.SeedTestBooking(t, db, userID, ids["Bella"], "2030-01-08", "09:00", "completed")
+	testutil.SeedTestBooking(t, db, userID, ids["Carla"], "2030-01-09", "09:00", "scheduled")
+	testutil.SeedTestBooking(t, db, userID, ids["Dino"], "2030-01-01", "09:00", "completed")
+
+	// allPages follows the cursors and returns the dog names in order
+	allPages := func(t *testing.T, filter models.DogFilterRequest) []string {
+		names := []string{}
+		for pages := 0; pages < 10; pages++ {
+			page, err := repo.FindPage(&filter)
+			if err != nil {
+				t.Fatalf("FindPage() failed: %v", err)
+			}
+			if len(page.Dogs) > filter.Limit {
+				t.Fatalf("Expected at most %d dogs, got %d", filter.Limit, len(page.Dogs))
+			}
+			for _, dog := range page.Dogs {
+				names = append(names, dog.Name)
+			}
+			if page.NextCursor == "" {
+				return names
+			}
+			filter.Cursor = page.NextCursor
+		}
+		t.Fatal("Pagination did not end")
+		return nil
+	}
+
+	testCases := []struct {
+		name   string
+		filter models.DogFilterRequest
+		want   string
+	}{
+		{"name", models.DogFilterRequest{Limit: 2}, "[Anton Bella Carla Dino Emma]"},
+		{"name descending", models.DogFilterRequest{Order: "desc", Limit: 2}, "[Emma Dino Carla Bella Anton]"},
+		{"age with ID tiebreaker", models.DogFilterRequest{Sort: "age", Limit: 1}, "[Dino Emma Anton Bella Carla]"},
+		{"age descending", models.DogFilterRequest{Sort: "age", Order: "desc", Limit: 3}, "[Carla Bella Anton Emma Dino]"},
+		{"last walk", models.DogFilterRequest{Sort: "last_walk", Limit: 2}, "[Anton Carla Dino Emma Bella]"},
+		{"last walk descending", models.DogFilterRequest{Sort: "last_walk", Order: "desc", Limit: 4}, "[Bella Emma Dino Carla Anton]"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := fmt.Sprint(allPages(t, tc.filter)); got != tc.want {
+				t.Errorf("Expected %s, got %s", tc.want, got)
+			}
+		})
+	}
+
+	t.Run("last page without cursor", func(t *testing.T) {
+		page, err := repo.FindPage(&models.DogFilterRequest{Limit: 5})
+		if err != nil || len(page.Dogs) != 5 || page.NextCursor != "" {
+			t.Errorf("Expected all dogs on one page without cursor, got %+v (%v)", page, err)
+		}
+	})
+
+	t.Run("cursor of another sort order", func(t *testing.T) {
+		page, _ := repo.FindPage(&models.DogFilterRequest{Limit: 2})
+		_, err := repo.FindPage(&models.DogFilterRequest{Sort: "age", Limit: 2, Cursor: page.NextCursor})
+		if verr, ok := err.(*models.ValidationError); !ok || verr.Field != "cursor" {
+			t.Errorf("Expected cursor validation error, got %v", err)
+		}
+	})
+
+	t.Run("FindAll returns the first page", func(t *testing.T) {
+		dogs, err := repo.FindAll(&models.DogFilterRequest{Sort: "age", Limit: 2})
+		if err != nil || len(dogs) != 2 || dogs[0].Name != "Dino" {
+			t.Errorf("Expected the 2 youngest dogs, got %v (%v)", dogs, err)
+		}
+	})
+}
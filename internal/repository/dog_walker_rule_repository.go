@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tranmh/gassigeher/internal/models"
+)
+
+// DogWalkerRuleRepository handles the per-dog walker allowlists and denylists
+type DogWalkerRuleRepository struct {
+	db *sql.DB
+}
+
+// NewDogWalkerRuleRepository creates a new dog walker rule repository
+func NewDogWalkerRuleRepository(db *sql.DB) *DogWalkerRuleRepository {
+	return &DogWalkerRuleRepository{db: db}
+}
+
+// Create adds a walker to a dog's allowlist or denylist. A walker can only be on one
+// list of a dog at a time.
+func (r *DogWalkerRuleRepository) Create(rule *models.DogWalkerRule) error {
+	query := `
+		INSERT INTO dog_walker_rules (dog_id, user_id, rule_type, reason, expires_at, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	now := time.Now()
+	result, err := r.db.Exec(query, rule.DogID, rule.UserID, rule.RuleType, rule.Reason,
+		emptyToNil(rule.ExpiresAt), rule.CreatedBy, now)
+	if err != nil {
+		// Check for unique constraint violation (different messages by DB)
+		errStr := strings.ToLower(err.Error())
+		if strings.Contains(errStr, "unique") || strings.Contains(errStr, "duplicate") {
+			return fmt.Errorf("walker rule already exists")
+		}
+		return fmt.Errorf("failed to create walker rule: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get walker rule ID: %w", err)
+	}
+
+	rule.ID = int(id)
+	rule.CreatedAt = now
+
+	return nil
+}
+
+// FindByDog finds all rules of a dog, including expired ones, ordered by walker name
+func (r *DogWalkerRuleRepository) FindByDog(dogID int) ([]*models.DogWalkerRule, error) {
+	query := `
+		SELECT w.id, w.dog_id, w.user_id, u.first_name, u.last_name, w.rule_type, w.reason,
+		       w.expires_at, w.created_by, w.created_at
+		FROM dog_walker_rules w
+		LEFT JOIN users u ON w.user_id = u.id
+		WHERE w.dog_id = ?
+		ORDER BY w.rule_type ASC, u.first_name ASC, u.last_name ASC
+	`
+
+	rows, err := r.db.Query(query, dogID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query walker rules: %w", err)
+	}
+	defer rows.Close()
+
+	rules := []*models.DogWalkerRule{}
+	for rows.Next() {
+		rule := &models.DogWalkerRule{}
+		var firstName, lastName, expiresAt sql.NullString
+		err := rows.Scan(
+			&rule.ID,
+			&rule.DogID,
+			&rule.UserID,
+			&firstName,
+			&lastName,
+			&rule.RuleType,
+			&rule.Reason,
+			&expiresAt,
+			&rule.CreatedBy,
+			&rule.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan walker rule: %w", err)
+		}
+		if firstName.Valid {
+			name := strings.TrimSpace(firstName.String + " " + lastName.String)
+			rule.UserName = &name
+		}
+		if expiresAt.Valid && expiresAt.String != "" {
+			date := normalizeDate(expiresAt.String)
+			rule.ExpiresAt = &date
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, rows.Err()
+}
+
+// Violation returns why the dog's rules forbid the user to walk it on date
+// (models.DogWalkerRuleDeny or models.DogWalkerRuleAllow), or "" if they allow it
+func (r *DogWalkerRuleRepository) Violation(dogID, userID int, date string) (string, error) {
+	rules, err := r.FindByDog(dogID)
+	if err != nil {
+		return "", err
+	}
+	return models.DogWalkerRuleViolation(rules, userID, date), nil
+}
+
+// Delete removes a rule of a dog
+func (r *DogWalkerRuleRepository) Delete(dogID, ruleID int) error {
+	result, err := r.db.Exec(`DELETE FROM dog_walker_rules WHERE id = ? AND dog_id = ?`, ruleID, dogID)
+	if err != nil {
+		return fmt.Errorf("failed to delete walker rule: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check delete result: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("walker rule not found")
+	}
+
+	return nil
+}
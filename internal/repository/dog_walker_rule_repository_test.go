@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tranmh/gassigeher/internal/models"
+	"github.com/tranmh/gassigeher/internal/testutil"
+)
+
+// TestDogWalkerRuleRepository_CRUD tests storing, listing and deleting walker rules
+func TestDogWalkerRuleRepository_CRUD(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := NewDogWalkerRuleRepository(db)
+
+	dogID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+	annaID := testutil.SeedTestUser(t, db, "anna@example.com", "Anna Alt", "green")
+	bertID := testutil.SeedTestUser(t, db, "bert@example.com", "Bert Bauer", "green")
+	lastDay := "2030-01-10"
+
+	deny := &models.DogWalkerRule{DogID: dogID, UserID: bertID, RuleType: models.DogWalkerRuleDeny, Reason: "Zieht zu stark", ExpiresAt: &lastDay}
+	allow := &models.DogWalkerRule{DogID: dogID, UserID: annaID, RuleType: models.DogWalkerRuleAllow, Reason: "Vertraut"}
+	for _, rule := range []*models.DogWalkerRule{deny, allow} {
+		if err := repo.Create(rule); err != nil {
+			t.Fatalf("Create() failed: %v", err)
+		}
+	}
+
+	duplicate := &models.DogWalkerRule{DogID: dogID, UserID: bertID, RuleType: models.DogWalkerRuleAllow, Reason: "Doch"}
+	if err := repo.Create(duplicate); err == nil || err.Error() != "walker rule already exists" {
+		t.Errorf("Expected 'walker rule already exists', got %v", err)
+	}
+
+	rules, err := repo.FindByDog(dogID)
+	if err != nil {
+		t.Fatalf("FindByDog() failed: %v", err)
+	}
+	if len(rules) != 2 || rules[0].ID != allow.ID || rules[1].ExpiresAt == nil || *rules[1].ExpiresAt != lastDay {
+		t.Fatalf("Expected allowlist first and the expiry date, got %+v", rules)
+	}
+	if rules[0].UserName == nil || *rules[0].UserName != "Anna Alt" {
+		t.Errorf("Expected walker name 'Anna Alt', got %v", rules[0].UserName)
+	}
+
+	tests := []struct {
+		user int
+		date string
+		want string
+	}{
+		{annaID, "2030-01-10", ""},
+		{bertID, "2030-01-10", models.DogWalkerRuleDeny},
+		{bertID, "2030-01-11", models.DogWalkerRuleAllow},
+	}
+	for _, tt := range tests {
+		got, err := repo.Violation(dogID, tt.user, tt.date)
+		if err != nil {
+			t.Fatalf("Violation() failed: %v", err)
+		}
+		if got != tt.want {
+			t.Errorf("Expected violation %q for user %d on %s, got %q", tt.want, tt.user, tt.date, got)
+		}
+	}
+
+	otherDogID := testutil.SeedTestDog(t, db, "Max", "Beagle", "green")
+	if err := repo.Delete(otherDogID, deny.ID); err == nil || err.Error() != "walker rule not found" {
+		t.Errorf("Expected 'walker rule not found' for another dog, got %v", err)
+	}
+	if err := repo.Delete(dogID, deny.ID); err != nil {
+		t.Fatalf("Delete() failed: %v", err)
+	}
+	if rules, _ := repo.FindByDog(dogID); len(rules) != 1 {
+		t.Errorf("Expected 1 rule after delete, got %d", len(rules))
+	}
+}
+
+// TestDogRepository_FindAll_WalkerRules tests that walkers don't see dogs the rules forbid them
+func TestDogRepository_FindAll_WalkerRules(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	dogRepo := NewDogRepository(db)
+	ruleRepo := NewDogWalkerRuleRepository(db)
+
+	walkerID := testutil.SeedTestUser(t, db, "walker@example.com", "Wanda Walker", "green")
+	otherID := testutil.SeedTestUser(t, db, "other@example.com", "Otto Other", "green")
+	deniedDogID := testutil.SeedTestDog(t, db, "Arko", "Schäferhund", "green")
+	allowlistDogID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+	expiredDogID := testutil.SeedTestDog(t, db, "Cleo", "Pudel", "green")
+	testutil.SeedTestDog(t, db, "Dino", "Dackel", "green")
+
+	yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+	ruleRepo.Create(&models.DogWalkerRule{DogID: deniedDogID, UserID: walkerID, RuleType: models.DogWalkerRuleDeny, Reason: "Konflikt"})
+	ruleRepo.Create(&models.DogWalkerRule{DogID: allowlistDogID, UserID: otherID, RuleType: models.DogWalkerRuleAllow, Reason: "Bezugsperson"})
+	ruleRepo.Create(&models.DogWalkerRule{DogID: expiredDogID, UserID: walkerID, RuleType: models.DogWalkerRuleDeny, Reason: "Abgelaufen", ExpiresAt: &yesterday})
+
+	names := func(userID int) []string {
+		dogs, err := dogRepo.FindAll(&models.DogFilterRequest{WalkerID: &userID})
+		if err != nil {
+			t.Fatalf("FindAll() failed: %v", err)
+		}
+		result := []string{}
+		for _, dog := range dogs {
+			result = append(result, dog.Name)
+		}
+		return result
+	}
+
+	if got := names(walkerID); len(got) != 2 || got[0] != "Cleo" || got[1] != "Dino" {
+		t.Errorf("Expected [Cleo Dino] for the walker, got %v", got)
+	}
+	if got := names(otherID); len(got) != 4 {
+		t.Errorf("Expected all 4 dogs for the allowlisted walker, got %v", got)
+	}
+
+	all, _ := dogRepo.FindAll(&models.DogFilterRequest{})
+	if len(all) != 4 {
+		t.Errorf("Expected all 4 dogs without a walker filter, got %d", len(all))
+	}
+}
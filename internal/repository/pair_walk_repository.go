@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tranmh/gassigeher/internal/models"
+)
+
+// PairWalkRepository handles dog pair walk database operations
+type PairWalkRepository struct {
+	db *sql.DB
+}
+
+// NewPairWalkRepository creates a new pair walk repository
+func NewPairWalkRepository(db *sql.DB) *PairWalkRepository {
+	return &PairWalkRepository{db: db}
+}
+
+// orderedPair returns the two dog IDs with the lower ID first
+func orderedPair(dogID, partnerDogID int) (int, int) {
+	if dogID > partnerDogID {
+		return partnerDogID, dogID
+	}
+	return dogID, partnerDogID
+}
+
+// Create allows the two dogs of the pair walk to be booked together
+func (r *PairWalkRepository) Create(pairWalk *models.PairWalk) error {
+	if pairWalk.DogID == pairWalk.PartnerDogID {
+		return fmt.Errorf("a dog cannot be paired with itself")
+	}
+	pairWalk.DogID, pairWalk.PartnerDogID = orderedPair(pairWalk.DogID, pairWalk.PartnerDogID)
+
+	query := `
+		INSERT INTO dog_pair_walks (dog_id, partner_dog_id, created_by, created_at)
+		VALUES (?, ?, ?, ?)
+	`
+
+	now := time.Now()
+	result, err := r.db.Exec(query, pairWalk.DogID, pairWalk.PartnerDogID, pairWalk.CreatedBy, now)
+	if err != nil {
+		// Check for unique constraint violation (different messages by DB)
+		errStr := strings.ToLower(err.Error())
+		if strings.Contains(errStr, "unique") || strings.Contains(errStr, "duplicate") {
+			return fmt.Errorf("pair walk already exists")
+		}
+		return fmt.Errorf("failed to create pair walk: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get pair walk ID: %w", err)
+	}
+
+	pairWalk.ID = int(id)
+	pairWalk.CreatedAt = now
+
+	return nil
+}
+
+// FindByDog finds all pair walks of a dog, seen from that dog
+// (DogID is the given dog and PartnerDogID the other dog of each pair)
+func (r *PairWalkRepository) FindByDog(dogID int) ([]*models.PairWalk, error) {
+	query := `
+		SELECT p.id,
+		       CASE WHEN p.dog_id = ? THEN p.partner_dog_id ELSE p.dog_id END AS partner_id,
+		       d.name, p.created_by, p.created_at
+		FROM dog_pair_walks p
+		JOIN dogs d ON d.id = CASE WHEN p.dog_id = ? THEN p.partner_dog_id ELSE p.dog_id END
+		WHERE p.dog_id = ? OR p.partner_dog_id = ?
+		ORDER BY d.name ASC
+	`
+
+	rows, err := r.db.Query(query, dogID, dogID, dogID, dogID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pair walks: %w", err)
+	}
+	defer rows.Close()
+
+	pairWalks := []*models.PairWalk{}
+	for rows.Next() {
+		pairWalk := &models.PairWalk{DogID: dogID}
+		var partnerName sql.NullString
+		if err := rows.Scan(&pairWalk.ID, &pairWalk.PartnerDogID, &partnerName, &pairWalk.CreatedBy, &pairWalk.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan pair walk: %w", err)
+		}
+		if partnerName.Valid {
+			pairWalk.PartnerDogName = &partnerName.String
+		}
+		pairWalks = append(pairWalks, pairWalk)
+	}
+
+	return pairWalks, nil
+}
+
+// Delete removes the pair walk between two dogs
+func (r *PairWalkRepository) Delete(dogID, partnerDogID int) error {
+	dogID, partnerDogID = orderedPair(dogID, partnerDogID)
+
+	result, err := r.db.Exec(`DELETE FROM dog_pair_walks WHERE dog_id = ? AND partner_dog_id = ?`, dogID, partnerDogID)
+	if err != nil {
+		return fmt.Errorf("failed to delete pair walk: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check delete result: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("pair walk not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/tranmh/gassigeher/internal/models"
+	"github.com/tranmh/gassigeher/internal/testutil"
+)
+
+// TestPairWalkRepository_CreateAndFind tests allowing and listing pair walks
+func TestPairWalkRepository_CreateAndFind(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := NewPairWalkRepository(db)
+
+	adminID := testutil.SeedTestUser(t, db, "admin@test.com", "Admin", "orange")
+	bellaID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+	lunaID := testutil.SeedTestDog(t, db, "Luna", "Beagle", "green")
+
+	t.Run("successful creation stores ordered pair", func(t *testing.T) {
+		pairWalk := &models.PairWalk{DogID: lunaID, PartnerDogID: bellaID, CreatedBy: adminID}
+		if err := repo.Create(pairWalk); err != nil {
+			t.Fatalf("Create() failed: %v", err)
+		}
+		if pairWalk.ID == 0 {
+			t.Error("PairWalk ID should be set after creation")
+		}
+		if pairWalk.DogID != bellaID || pairWalk.PartnerDogID != lunaID {
+			t.Errorf("Expected pair (%d, %d), got (%d, %d)", bellaID, lunaID, pairWalk.DogID, pairWalk.PartnerDogID)
+		}
+	})
+
+	t.Run("duplicate pair in either order", func(t *testing.T) {
+		err := repo.Create(&models.PairWalk{DogID: bellaID, PartnerDogID: lunaID, CreatedBy: adminID})
+		if err == nil || err.Error() != "pair walk already exists" {
+			t.Errorf("Expected 'pair walk already exists', got %v", err)
+		}
+	})
+
+	t.Run("dog cannot be paired with itself", func(t *testing.T) {
+		if err := repo.Create(&models.PairWalk{DogID: bellaID, PartnerDogID: bellaID, CreatedBy: adminID}); err == nil {
+			t.Error("Expected error when pairing a dog with itself")
+		}
+	})
+
+	t.Run("find from both dogs", func(t *testing.T) {
+		for _, tc := range []struct{ dogID, partnerID int }{{bellaID, lunaID}, {lunaID, bellaID}} {
+			pairWalks, err := repo.FindByDog(tc.dogID)
+			if err != nil {
+				t.Fatalf("FindByDog() failed: %v", err)
+			}
+			if len(pairWalks) != 1 {
+				t.Fatalf("Expected 1 pair walk for dog %d, got %d", tc.dogID, len(pairWalks))
+			}
+			if pairWalks[0].PartnerDogID != tc.partnerID {
+				t.Errorf("Expected partner %d, got %d", tc.partnerID, pairWalks[0].PartnerDogID)
+			}
+			if pairWalks[0].PartnerDogName == nil {
+				t.Error("Expected partner dog name to be populated")
+			}
+		}
+	})
+}
+
+// TestPairWalkRepository_Delete tests removing pair walks in either order
+func TestPairWalkRepository_Delete(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := NewPairWalkRepository(db)
+
+	adminID := testutil.SeedTestUser(t, db, "admin@test.com", "Admin", "orange")
+	bellaID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+	lunaID := testutil.SeedTestDog(t, db, "Luna", "Beagle", "green")
+
+	repo.Create(&models.PairWalk{DogID: bellaID, PartnerDogID: lunaID, CreatedBy: adminID})
+
+	if err := repo.Delete(lunaID, bellaID); err != nil {
+		t.Fatalf("Delete() failed: %v", err)
+	}
+	if pairWalks, _ := repo.FindByDog(bellaID); len(pairWalks) != 0 {
+		t.Errorf("Expected no pair walks after Delete(), got %d", len(pairWalks))
+	}
+	if err := repo.Delete(lunaID, bellaID); err == nil || err.Error() != "pair walk not found" {
+		t.Errorf("Expected 'pair walk not found', got %v", err)
+	}
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"database/sql"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/tranmh/gassigeher/internal/models"
@@ -44,6 +45,19 @@ func (r *SettingsRepository) Get(key string) (*models.SystemSetting, error) {
 	return setting, nil
 }
 
+// GetInt reads a numeric setting, falling back to defaultValue if it is unset or invalid
+func (r *SettingsRepository) GetInt(key string, defaultValue int) int {
+	setting, err := r.Get(key)
+	if err != nil || setting == nil {
+		return defaultValue
+	}
+	value, err := strconv.Atoi(setting.Value)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
 // GetAll retrieves all settings
 func (r *SettingsRepository) GetAll() ([]*models.SystemSetting, error) {
 	query := `
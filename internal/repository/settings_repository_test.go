@@ -50,6 +50,29 @@ func TestSettingsRepository_Get(t *testing.T) {
 	})
 }
 
+// TestSettingsRepository_GetInt tests reading numeric settings with a fallback
+func TestSettingsRepository_GetInt(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := NewSettingsRepository(db)
+
+	db.Exec(`INSERT INTO system_settings (key, value) VALUES (?, ?)`, "test_number", "42")
+	db.Exec(`INSERT INTO system_settings (key, value) VALUES (?, ?)`, "test_text", "viele")
+
+	tests := []struct {
+		key  string
+		want int
+	}{
+		{"test_number", 42},
+		{"test_text", 7},
+		{"non_existent_setting", 7},
+	}
+	for _, tt := range tests {
+		if got := repo.GetInt(tt.key, 7); got != tt.want {
+			t.Errorf("GetInt(%q) = %d, want %d", tt.key, got, tt.want)
+		}
+	}
+}
+
 // DONE: TestSettingsRepository_GetAll tests getting all settings
 func TestSettingsRepository_GetAll(t *testing.T) {
 	db := testutil.SetupTestDB(t)
@@ -66,8 +89,8 @@ func TestSettingsRepository_GetAll(t *testing.T) {
 			t.Fatalf("GetAll() failed: %v", err)
 		}
 
-		if len(settings) != 13 {
-			t.Errorf("Expected 13 settings, got %d", len(settings))
+		if len(settings) != 24 {
+			t.Errorf("Expected 24 settings, got %d", len(settings))
 		}
 
 		// Verify all expected settings are present
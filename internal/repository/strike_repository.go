@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/tranmh/gassigeher/internal/models"
+)
+
+// StrikeRepository handles user strike database operations
+type StrikeRepository struct {
+	db *sql.DB
+}
+
+// NewStrikeRepository creates a new strike repository
+func NewStrikeRepository(db *sql.DB) *StrikeRepository {
+	return &StrikeRepository{db: db}
+}
+
+// Create records a new strike
+func (r *StrikeRepository) Create(strike *models.UserStrike) error {
+	if strike.CreatedAt.IsZero() {
+		strike.CreatedAt = time.Now()
+	}
+
+	result, err := r.db.Exec(`
+		INSERT INTO user_strikes (user_id, booking_id, reason, created_at)
+		VALUES (?, ?, ?, ?)
+	`, strike.UserID, strike.BookingID, strike.Reason, strike.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create strike: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get strike ID: %w", err)
+	}
+
+	strike.ID = int(id)
+	return nil
+}
+
+// FindByID finds a strike by ID
+func (r *StrikeRepository) FindByID(id int) (*models.UserStrike, error) {
+	strikes, err := r.query(`
+		SELECT id, user_id, booking_id, reason, created_at, forgiven_at, forgiven_by, forgive_reason
+		FROM user_strikes
+		WHERE id = ?
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(strikes) == 0 {
+		return nil, nil
+	}
+
+	return strikes[0], nil
+}
+
+// FindByUserSince finds the strikes of a user created at or after since, oldest first
+func (r *StrikeRepository) FindByUserSince(userID int, since time.Time) ([]*models.UserStrike, error) {
+	return r.query(`
+		SELECT id, user_id, booking_id, reason, created_at, forgiven_at, forgiven_by, forgive_reason
+		FROM user_strikes
+		WHERE user_id = ? AND created_at >= ?
+		ORDER BY created_at ASC, id ASC
+	`, userID, since)
+}
+
+// Forgive marks a strike as forgiven so it no longer counts
+func (r *StrikeRepository) Forgive(id, adminID int, reason *string) error {
+	result, err := r.db.Exec(`
+		UPDATE user_strikes
+		SET forgiven_at = ?, forgiven_by = ?, forgive_reason = ?
+		WHERE id = ? AND forgiven_at IS NULL
+	`, time.Now(), adminID, reason, id)
+	if err != nil {
+		return fmt.Errorf("failed to forgive strike: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("strike not found or already forgiven")
+	}
+
+	return nil
+}
+
+// query runs a strike query and scans all rows
+func (r *StrikeRepository) query(query string, args ...interface{}) ([]*models.UserStrike, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query strikes: %w", err)
+	}
+	defer rows.Close()
+
+	strikes := []*models.UserStrike{}
+	for rows.Next() {
+		strike := &models.UserStrike{}
+		err := rows.Scan(
+			&strike.ID,
+			&strike.UserID,
+			&strike.BookingID,
+			&strike.Reason,
+			&strike.CreatedAt,
+			&strike.ForgivenAt,
+			&strike.ForgivenBy,
+			&strike.ForgiveReason,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan strike: %w", err)
+		}
+		strikes = append(strikes, strike)
+	}
+
+	return strikes, rows.Err()
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tranmh/gassigeher/internal/models"
+	"github.com/tranmh/gassigeher/internal/testutil"
+)
+
+// TestStrikeRepository tests recording, listing and forgiving strikes
+func TestStrikeRepository(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := NewStrikeRepository(db)
+
+	userID := testutil.SeedTestUser(t, db, "walker@example.com", "Walker", "green")
+	adminID := testutil.SeedTestUser(t, db, "admin@example.com", "Admin", "green")
+	dogID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+	bookingID := testutil.SeedTestBooking(t, db, userID, dogID, "2030-01-08", "09:00", "no_show")
+
+	now := time.Date(2030, 3, 1, 12, 0, 0, 0, time.UTC)
+	old := &models.UserStrike{UserID: userID, Reason: models.StrikeReasonLateCancellation, CreatedAt: now.AddDate(0, 0, -120)}
+	recent := &models.UserStrike{UserID: userID, BookingID: &bookingID, Reason: models.StrikeReasonNoShow, CreatedAt: now.AddDate(0, 0, -10)}
+	for _, strike := range []*models.UserStrike{old, recent} {
+		if err := repo.Create(strike); err != nil {
+			t.Fatalf("Create() failed: %v", err)
+		}
+	}
+
+	t.Run("find strikes inside the window", func(t *testing.T) {
+		strikes, err := repo.FindByUserSince(userID, now.AddDate(0, 0, -90))
+		if err != nil {
+			t.Fatalf("FindByUserSince() failed: %v", err)
+		}
+		if len(strikes) != 1 || strikes[0].ID != recent.ID {
+			t.Fatalf("Expected only the recent strike, got %d", len(strikes))
+		}
+		if strikes[0].BookingID == nil || *strikes[0].BookingID != bookingID {
+			t.Errorf("Expected booking ID %d, got %v", bookingID, strikes[0].BookingID)
+		}
+		if !strikes[0].IsActive() {
+			t.Error("Expected strike to be active")
+		}
+	})
+
+	t.Run("forgive strike", func(t *testing.T) {
+		reason := "Hund war krank"
+		if err := repo.Forgive(recent.ID, adminID, &reason); err != nil {
+			t.Fatalf("Forgive() failed: %v", err)
+		}
+
+		strike, err := repo.FindByID(recent.ID)
+		if err != nil || strike == nil {
+			t.Fatalf("FindByID() failed: %v", err)
+		}
+		if strike.IsActive() {
+			t.Error("Expected strike to be forgiven")
+		}
+		if strike.ForgivenBy == nil || *strike.ForgivenBy != adminID {
+			t.Errorf("Expected forgiven by %d, got %v", adminID, strike.ForgivenBy)
+		}
+		if strike.ForgiveReason == nil || *strike.ForgiveReason != reason {
+			t.Errorf("Expected forgive reason %q, got %v", reason, strike.ForgiveReason)
+		}
+	})
+
+	t.Run("forgive twice", func(t *testing.T) {
+		if err := repo.Forgive(recent.ID, adminID, nil); err == nil || err.Error() != "strike not found or already forgiven" {
+			t.Errorf("Expected 'strike not found or already forgiven' error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,276 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/tranmh/gassigeher/internal/models"
+)
+
+// WaitlistRepository handles booking waitlist database operations
+type WaitlistRepository struct {
+	db *sql.DB
+}
+
+// NewWaitlistRepository creates a new waitlist repository
+func NewWaitlistRepository(db *sql.DB) *WaitlistRepository {
+	return &WaitlistRepository{db: db}
+}
+
+const waitlistColumns = `w.id, w.user_id, w.dog_id, w.date, w.scheduled_time, w.status,
+		       w.offered_at, w.offer_expires_at, w.created_at, w.updated_at, d.name`
+
+// Create adds a user to the waitlist of a slot
+// Returns an error if the user is already waiting for (or has been offered) that slot
+func (r *WaitlistRepository) Create(entry *models.WaitlistEntry) error {
+	var count int
+	err := r.db.QueryRow(`
+		SELECT COUNT(*) FROM booking_waitlist
+		WHERE user_id = ? AND dog_id = ? AND date = ? AND scheduled_time = ? AND status IN ('waiting', 'offered')
+	`, entry.UserID, entry.DogID, entry.Date, entry.ScheduledTime).Scan(&count)
+	if err != nil {
+		return fmt.Errorf("failed to check waitlist: %w", err)
+	}
+	if count > 0 {
+		return fmt.Errorf("already on waitlist")
+	}
+
+	now := time.Now()
+	entry.Status = "waiting"
+
+	result, err := r.db.Exec(`
+		INSERT INTO booking_waitlist (user_id, dog_id, date, scheduled_time, status, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, entry.UserID, entry.DogID, entry.Date, entry.ScheduledTime, entry.Status, now, now)
+	if err != nil {
+		return fmt.Errorf("failed to create waitlist entry: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get waitlist entry ID: %w", err)
+	}
+
+	entry.ID = int(id)
+	entry.CreatedAt = now
+	entry.UpdatedAt = now
+
+	return nil
+}
+
+// FindByID finds a waitlist entry by ID
+func (r *WaitlistRepository) FindByID(id int) (*models.WaitlistEntry, error) {
+	entries, err := r.query(`
+		SELECT `+waitlistColumns+`
+		FROM booking_waitlist w
+		JOIN dogs d ON w.dog_id = d.id
+		WHERE w.id = ?
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	return entries[0], nil
+}
+
+// FindByUser finds the waitlist entries of a user for today and later
+func (r *WaitlistRepository) FindByUser(userID int) ([]*models.WaitlistEntry, error) {
+	return r.query(`
+		SELECT `+waitlistColumns+`
+		FROM booking_waitlist w
+		JOIN dogs d ON w.dog_id = d.id
+		WHERE w.user_id = ? AND w.date >= ?
+		ORDER BY w.date ASC, w.scheduled_time ASC
+	`, userID, time.Now().Format("2006-01-02"))
+}
+
+// FindActiveOffer finds an unexpired offer for a slot overlapping the requested one, if any.
+// Slots overlap like bookings do: each occupies its start plus the walk duration plus bufferMinutes.
+func (r *WaitlistRepository) FindActiveOffer(dogID int, date, scheduledTime string, durationMinutes, bufferMinutes int, now time.Time) (*models.WaitlistEntry, error) {
+	entries, err := r.query(`
+		SELECT `+waitlistColumns+`
+		FROM booking_waitlist w
+		JOIN dogs d ON w.dog_id = d.id
+		WHERE w.dog_id = ? AND w.date = ? AND w.status = 'offered'
+		ORDER BY w.offered_at DESC
+	`, dogID, date)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if entry.OfferExpiresAt == nil || !entry.OfferExpiresAt.After(now) {
+			continue
+		}
+		if models.BookingIntervalsOverlap(scheduledTime, durationMinutes, entry.ScheduledTime, durationMinutes, bufferMinutes) {
+			return entry, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// FindWaiting finds the waiting entries for slots overlapping the given one, first come first
+// served. These are the users whose slot a booking at that time blocked when they joined.
+func (r *WaitlistRepository) FindWaiting(dogID int, date, scheduledTime string, durationMinutes, bufferMinutes int) ([]*models.WaitlistEntry, error) {
+	entries, err := r.query(`
+		SELECT `+waitlistColumns+`
+		FROM booking_waitlist w
+		JOIN dogs d ON w.dog_id = d.id
+		WHERE w.dog_id = ? AND w.date = ? AND w.status = 'waiting'
+		ORDER BY w.created_at ASC, w.id ASC
+	`, dogID, date)
+	if err != nil {
+		return nil, err
+	}
+
+	waiting := []*models.WaitlistEntry{}
+	for _, entry := range entries {
+		if models.BookingIntervalsOverlap(scheduledTime, durationMinutes, entry.ScheduledTime, durationMinutes, bufferMinutes) {
+			waiting = append(waiting, entry)
+		}
+	}
+
+	return waiting, nil
+}
+
+// FindExpiredOffers finds offers whose claim window has passed
+func (r *WaitlistRepository) FindExpiredOffers(now time.Time) ([]*models.WaitlistEntry, error) {
+	entries, err := r.query(`
+		SELECT ` + waitlistColumns + `
+		FROM booking_waitlist w
+		JOIN dogs d ON w.dog_id = d.id
+		WHERE w.status = 'offered'
+		ORDER BY w.offered_at ASC
+	`)
+	if err != nil {
+		return nil, err
+	}
+
+	expired := []*models.WaitlistEntry{}
+	for _, entry := range entries {
+		if entry.OfferExpiresAt == nil || !entry.OfferExpiresAt.After(now) {
+			expired = append(expired, entry)
+		}
+	}
+
+	return expired, nil
+}
+
+// MarkOffered reserves the slot for the entry's user until expiresAt
+func (r *WaitlistRepository) MarkOffered(id int, offeredAt, expiresAt time.Time) error {
+	_, err := r.db.Exec(`
+		UPDATE booking_waitlist
+		SET status = 'offered', offered_at = ?, offer_expires_at = ?, updated_at = ?
+		WHERE id = ?
+	`, offeredAt, expiresAt, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to mark waitlist entry as offered: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateStatus sets the status of a waitlist entry
+func (r *WaitlistRepository) UpdateStatus(id int, status string) error {
+	_, err := r.db.Exec(`UPDATE booking_waitlist SET status = ?, updated_at = ? WHERE id = ?`, status, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to update waitlist entry: %w", err)
+	}
+
+	return nil
+}
+
+// MarkClaimed marks the user's active entries for a slot as claimed after they booked it
+func (r *WaitlistRepository) MarkClaimed(userID, dogID int, date, scheduledTime string) error {
+	_, err := r.db.Exec(`
+		UPDATE booking_waitlist
+		SET status = 'claimed', updated_at = ?
+		WHERE user_id = ? AND dog_id = ? AND date = ? AND scheduled_time = ? AND status IN ('waiting', 'offered')
+	`, time.Now(), userID, dogID, date, scheduledTime)
+	if err != nil {
+		return fmt.Errorf("failed to mark waitlist entry as claimed: %w", err)
+	}
+
+	return nil
+}
+
+// CancelForDog cancels all open entries of a dog, e.g. when it leaves the shelter
+func (r *WaitlistRepository) CancelForDog(dogID int) (int, error) {
+	result, err := r.db.Exec(`
+		UPDATE booking_waitlist
+		SET status = 'cancelled', updated_at = ?
+		WHERE dog_id = ? AND status IN ('waiting', 'offered')
+	`, time.Now(), dogID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to cancel waitlist entries: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return int(rows), nil
+}
+
+// ExpirePast expires all open entries for dates before today
+func (r *WaitlistRepository) ExpirePast(today string) (int, error) {
+	result, err := r.db.Exec(`
+		UPDATE booking_waitlist
+		SET status = 'expired', updated_at = ?
+		WHERE status IN ('waiting', 'offered') AND date < ?
+	`, time.Now(), today)
+	if err != nil {
+		return 0, fmt.Errorf("failed to expire past waitlist entries: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return int(rows), nil
+}
+
+// query runs a waitlist query and scans all rows
+func (r *WaitlistRepository) query(query string, args ...interface{}) ([]*models.WaitlistEntry, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query waitlist: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*models.WaitlistEntry{}
+	for rows.Next() {
+		entry := &models.WaitlistEntry{}
+		var dogName sql.NullString
+		err := rows.Scan(
+			&entry.ID,
+			&entry.UserID,
+			&entry.DogID,
+			&entry.Date,
+			&entry.ScheduledTime,
+			&entry.Status,
+			&entry.OfferedAt,
+			&entry.OfferExpiresAt,
+			&entry.CreatedAt,
+			&entry.UpdatedAt,
+			&dogName,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan waitlist entry: %w", err)
+		}
+		entry.Date = normalizeDate(entry.Date)
+		if dogName.Valid {
+			entry.DogName = &dogName.String
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tranmh/gassigeher/internal/models"
+	"github.com/tranmh/gassigeher/internal/testutil"
+)
+
+// TestWaitlistRepository tests joining a waitlist and the offer lifecycle
+func TestWaitlistRepository(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := NewWaitlistRepository(db)
+
+	firstID := testutil.SeedTestUser(t, db, "first@example.com", "First", "green")
+	secondID := testutil.SeedTestUser(t, db, "second@example.com", "Second", "green")
+	dogID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+
+	first := &models.WaitlistEntry{UserID: firstID, DogID: dogID, Date: "2030-01-08", ScheduledTime: "09:00"}
+	if err := repo.Create(first); err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	second := &models.WaitlistEntry{UserID: secondID, DogID: dogID, Date: "2030-01-08", ScheduledTime: "09:00"}
+	if err := repo.Create(second); err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+
+	t.Run("reject duplicate entry", func(t *testing.T) {
+		duplicate := &models.WaitlistEntry{UserID: firstID, DogID: dogID, Date: "2030-01-08", ScheduledTime: "09:00"}
+		if err := repo.Create(duplicate); err == nil || err.Error() != "already on waitlist" {
+			t.Errorf("Expected 'already on waitlist' error, got %v", err)
+		}
+	})
+
+	t.Run("waiting is first come first served", func(t *testing.T) {
+		waiting, err := repo.FindWaiting(dogID, "2030-01-08", "09:00", 60, 0)
+		if err != nil {
+			t.Fatalf("FindWaiting() failed: %v", err)
+		}
+		if len(waiting) != 2 || waiting[0].ID != first.ID || waiting[1].ID != second.ID {
+			t.Fatalf("Expected first and second entry in order, got %+v", waiting)
+		}
+		if waiting[0].DogName == nil || *waiting[0].DogName != "Bella" {
+			t.Error("Expected dog name to be joined")
+		}
+	})
+
+	t.Run("waiting matches overlapping slots", func(t *testing.T) {
+		// A walk at 08:30 blocks 09:00 for a 60 minute walk, one at 07:45 only with a 30 minute buffer
+		if waiting, _ := repo.FindWaiting(dogID, "2030-01-08", "08:30", 60, 0); len(waiting) != 2 {
+			t.Errorf("Expected both entries to overlap 08:30, got %d", len(waiting))
+		}
+		if waiting, _ := repo.FindWaiting(dogID, "2030-01-08", "07:45", 60, 0); len(waiting) != 0 {
+			t.Errorf("Expected no entries to overlap 07:45, got %d", len(waiting))
+		}
+		if waiting, _ := repo.FindWaiting(dogID, "2030-01-08", "07:45", 60, 30); len(waiting) != 2 {
+			t.Errorf("Expected both entries to overlap 07:45 with buffer, got %d", len(waiting))
+		}
+	})
+
+	now := time.Date(2030, 1, 7, 10, 0, 0, 0, time.UTC)
+
+	t.Run("offer and expire", func(t *testing.T) {
+		if err := repo.MarkOffered(first.ID, now, now.Add(2*time.Hour)); err != nil {
+			t.Fatalf("MarkOffered() failed: %v", err)
+		}
+
+		offer, err := repo.FindActiveOffer(dogID, "2030-01-08", "09:30", 60, 0, now.Add(time.Hour))
+		if err != nil {
+			t.Fatalf("FindActiveOffer() failed: %v", err)
+		}
+		if offer == nil || offer.UserID != firstID {
+			t.Fatalf("Expected active offer for first user, got %+v", offer)
+		}
+
+		expired, err := repo.FindExpiredOffers(now.Add(time.Hour))
+		if err != nil || len(expired) != 0 {
+			t.Errorf("Expected no expired offers yet, got %d (err %v)", len(expired), err)
+		}
+
+		expired, err = repo.FindExpiredOffers(now.Add(3 * time.Hour))
+		if err != nil || len(expired) != 1 || expired[0].ID != first.ID {
+			t.Errorf("Expected the first offer to be expired, got %+v (err %v)", expired, err)
+		}
+
+		offer, _ = repo.FindActiveOffer(dogID, "2030-01-08", "09:00", 60, 0, now.Add(3*time.Hour))
+		if offer != nil {
+			t.Error("Expected no active offer after expiry")
+		}
+	})
+
+	t.Run("mark claimed", func(t *testing.T) {
+		if err := repo.MarkClaimed(secondID, dogID, "2030-01-08", "09:00"); err != nil {
+			t.Fatalf("MarkClaimed() failed: %v", err)
+		}
+		found, _ := repo.FindByID(second.ID)
+		if found == nil || found.Status != "claimed" {
+			t.Errorf("Expected claimed entry, got %+v", found)
+		}
+	})
+
+	t.Run("expire past entries", func(t *testing.T) {
+		past := &models.WaitlistEntry{UserID: secondID, DogID: dogID, Date: "2030-01-01", ScheduledTime: "09:00"}
+		if err := repo.Create(past); err != nil {
+			t.Fatalf("Create() failed: %v", err)
+		}
+
+		count, err := repo.ExpirePast("2030-01-07")
+		if err != nil {
+			t.Fatalf("ExpirePast() failed: %v", err)
+		}
+		// The past entry and the still open offer for a future date: only the past one expires
+		if count != 1 {
+			t.Errorf("Expected 1 expired entry, got %d", count)
+		}
+	})
+}
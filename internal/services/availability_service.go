@@ -0,0 +1,179 @@
+package services
+
+import (
+	"time"
+
+	"github.com/tranmh/gassigeher/internal/models"
+	"github.com/tranmh/gassigeher/internal/repository"
+)
+
+// AvailabilityService finds the dogs a user can walk on a day and their free start times.
+// It combines the booking time rules, blocked dates, existing bookings (walk duration +
+// booking_buffer_minutes), the dogs' walk limits, dog availability (including scheduled unavailability) and the user's color categories.
+// Like a booking, a slot is not free while the user is out with another dog or while it is
+// reserved for someone else's waitlist offer.
+type AvailabilityService struct {
+	bookingTimeService *BookingTimeService
+	bookingRepo        *repository.BookingRepository
+	dogRepo            *repository.DogRepository
+	blockedDateRepo    *repository.BlockedDateRepository
+	userColorRepo      *repository.UserColorRepository
+	settingsRepo       *repository.SettingsRepository
+	waitlistService    *WaitlistService
+}
+
+// NewAvailabilityService creates a new availability service
+func NewAvailabilityService(
+	bookingTimeService *BookingTimeService,
+	bookingRepo *repository.BookingRepository,
+	dogRepo *repository.DogRepository,
+	blockedDateRepo *repository.BlockedDateRepository,
+	userColorRepo *repository.UserColorRepository,
+	settingsRepo *repository.SettingsRepository,
+	waitlistService *WaitlistService,
+) *AvailabilityService {
+	return &AvailabilityService{
+		bookingTimeService: bookingTimeService,
+		bookingRepo:        bookingRepo,
+		dogRepo:            dogRepo,
+		blockedDateRepo:    blockedDateRepo,
+		userColorRepo:      userColorRepo,
+		settingsRepo:       settingsRepo,
+		waitlistService:    waitlistService,
+	}
+}
+
+// FindFreeSlots returns every dog the user may walk with its free start times on date (YYYY-MM-DD).
+// from and to (HH:MM, inclusive) limit the start times; pass "" for no limit.
+// allColors skips the color and walker rule checks (admins). Start times before now are left out.
+func (s *AvailabilityService) FindFreeSlots(userID int, allColors bool, date, from, to string, now time.Time) ([]*models.DogAvailability, error) {
+	result := []*models.DogAvailability{}
+
+	globalBlock, blockedDogIDs, err := s.blockedDateRepo.GetBlockedDogsForDate(date)
+	if err != nil {
+		return nil, err
+	}
+	if globalBlock {
+		return result, nil
+	}
+	blockedDogs := make(map[int]bool)
+	for _, id := range blockedDogIDs {
+		blockedDogs[id] = true
+	}
+
+	slots, err := s.bookingTimeService.GetAvailableTimeSlots(date)
+	if err != nil {
+		return nil, err
+	}
+	candidates := []string{}
+	for _, slot := range slots {
+		if (from != "" && slot < from) || (to != "" && slot > to) {
+			continue
+		}
+		if start, err := time.ParseInLocation("2006-01-02 15:04", date+" "+slot, now.Location()); err == nil && start.Before(now) {
+			continue
+		}
+		candidates = append(candidates, slot)
+	}
+
+	// Dogs in the shelter; availability is checked per date below. Walkers do not see dogs
+	// their allowlist/denylist rules forbid on that date.
+	filter := &models.DogFilterRequest{}
+	if !allColors {
+		filter.WalkerID = &userID
+		filter.WalkerDate = date
+	}
+	dogs, err := s.dogRepo.FindAll(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var userColorIDs []int
+	if !allColors {
+		userColorIDs, err = s.userColorRepo.GetUserColorIDs(userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// All bookings of the day, grouped by dog. Scheduled walks block their slot; completed
+	// walks only count against the dog's walk limits.
+	bookings, err := s.bookingRepo.FindAll(&models.BookingFilterRequest{DateFrom: &date, DateTo: &date})
+	if err != nil {
+		return nil, err
+	}
+	bookingsByDog := make(map[int][]*models.Booking)
+	walkTimesByDog := make(map[int][]string)
+	hasOwnWalks := false
+	for _, booking := range bookings {
+		if booking.Status == "scheduled" {
+			bookingsByDog[booking.DogID] = append(bookingsByDog[booking.DogID], booking)
+			hasOwnWalks = hasOwnWalks || booking.UserID == userID
+		}
+		if booking.Status == "scheduled" || booking.Status == "completed" {
+			walkTimesByDog[booking.DogID] = append(walkTimesByDog[booking.DogID], booking.ScheduledTime)
+		}
+	}
+
+	buffer := s.settingsRepo.GetInt("booking_buffer_minutes", 0)
+
+	for _, dog := range dogs {
+		if blockedDogs[dog.ID] || !dog.IsBookableOn(date) {
+			continue
+		}
+		if !allColors {
+			dogColorID := 0
+			if dog.ColorID != nil {
+				dogColorID = *dog.ColorID
+			}
+			if !repository.CanUserAccessDogByColor(userColorIDs, dogColorID) {
+				continue
+			}
+		}
+
+		duration := dog.GetWalkDuration()
+		free := []string{}
+		for _, slot := range candidates {
+			taken := false
+			for _, booking := range bookingsByDog[dog.ID] {
+				if models.BookingIntervalsOverlap(slot, duration, booking.ScheduledTime, duration, buffer) {
+					taken = true
+					break
+				}
+			}
+			if !taken && dog.HasWalkLimits() && models.CheckDogWalkLimits(dog, walkTimesByDog[dog.ID], slot) != nil {
+				taken = true
+			}
+			if !taken && hasOwnWalks {
+				conflict, err := s.bookingRepo.FindUserConflictingBooking(userID, dog.ID, date, slot, duration, 0)
+				if err != nil {
+					return nil, err
+				}
+				taken = conflict != nil
+			}
+			if !taken {
+				reserved, err := s.waitlistService.CheckReservation(userID, dog.ID, date, slot, duration, now)
+				if err != nil {
+					return nil, err
+				}
+				taken = reserved
+			}
+			if !taken {
+				free = append(free, slot)
+			}
+		}
+
+		result = append(result, &models.DogAvailability{
+			DogID:          dog.ID,
+			DogName:        dog.Name,
+			Breed:          dog.Breed,
+			Size:           dog.Size,
+			ColorID:        dog.ColorID,
+			PhotoThumbnail: dog.PhotoThumbnail,
+			WalkDuration:   duration,
+			FreeSlots:      free,
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tranmh/gassigeher/internal/models"
+	"github.com/tranmh/gassigeher/internal/repository"
+	"github.com/tranmh/gassigeher/internal/testutil"
+)
+
+// TestAvailabilityService_FindFreeSlots tests combining time rules, bookings, blocks and colors
+func TestAvailabilityService_FindFreeSlots(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	db.Exec("UPDATE system_settings SET value = 'false' WHERE key = 'use_feiertage_api'")
+
+	settingsRepo := repository.NewSettingsRepository(db)
+	holidayService := NewHolidayService(repository.NewHolidayRepository(db), settingsRepo)
+	service := NewAvailabilityService(
+		NewBookingTimeService(repository.NewBookingTimeRepository(db), holidayService, settingsRepo),
+		repository.NewBookingRepository(db),
+		repository.NewDogRepository(db),
+		repository.NewBlockedDateRepository(db),
+		repository.NewUserColorRepository(db),
+		settingsRepo,
+		NewWaitlistService(repository.NewWaitlistRepository(db), repository.NewBookingRepository(db),
+			repository.NewUserRepository(db), repository.NewDogRepository(db), settingsRepo, nil),
+	)
+
+	adminID := testutil.SeedTestUser(t, db, "admin@example.com", "Admin", "blue")
+	userID := testutil.SeedTestUser(t, db, "walker@example.com", "Walker", "green")
+	bellaID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+	maxID := testutil.SeedTestDog(t, db, "Max", "Beagle", "green")
+	rexID := testutil.SeedTestDog(t, db, "Rex", "Schäferhund", "blue")
+	sleepyID := testutil.SeedTestDog(t, db, "Sleepy", "Mops", "green")
+	db.Exec("UPDATE dogs SET is_available = 0 WHERE id = ?", sleepyID)
+
+	// Wednesday; Bella is walked 10:00-11:00, a cancelled booking must not block Max
+	date := "2030-01-09"
+	testutil.SeedTestBooking(t, db, adminID, bellaID, date, "10:00", "scheduled")
+	testutil.SeedTestBooking(t, db, adminID, maxID, date, "10:00", "cancelled")
+
+	morning := []string{"09:00", "09:15", "09:30", "09:45", "10:00", "10:15", "10:30", "10:45", "11:00", "11:15", "11:30", "11:45"}
+	dayBefore := time.Date(2030, 1, 8, 12, 0, 0, 0, time.UTC)
+
+	freeByDog := func(result []*models.DogAvailability) map[int][]string {
+		slots := make(map[int][]string)
+		for _, dog := range result {
+			slots[dog.DogID] = dog.FreeSlots
+		}
+		return slots
+	}
+
+	t.Run("user only sees available dogs of their colors", func(t *testing.T) {
+		result, err := service.FindFreeSlots(userID, false, date, "09:00", "11:45", dayBefore)
+		if err != nil {
+			t.Fatalf("FindFreeSlots failed: %v", err)
+		}
+		slots := freeByDog(result)
+		if len(slots) != 2 {
+			t.Fatalf("Expected Bella and Max, got %d dogs", len(slots))
+		}
+		if want := []string{"09:00", "11:00", "11:15", "11:30", "11:45"}; !reflect.DeepEqual(slots[bellaID], want) {
+			t.Errorf("Bella: expected %v, got %v", want, slots[bellaID])
+		}
+		if !reflect.DeepEqual(slots[maxID], morning) {
+			t.Errorf("Max: expected %v, got %v", morning, slots[maxID])
+		}
+	})
+
+	t.Run("admins see all colors", func(t *testing.T) {
+		result, err := service.FindFreeSlots(adminID, true, date, "09:00", "11:45", dayBefore)
+		if err != nil {
+			t.Fatalf("FindFreeSlots failed: %v", err)
+		}
+		if _, ok := freeByDog(result)[rexID]; !ok || len(result) != 3 {
+			t.Errorf("Expected Bella, Max and Rex, got %d dogs", len(result))
+		}
+	})
+
+	t.Run("buffer widens booked intervals", func(t *testing.T) {
+		db.Exec("UPDATE system_settings SET value = '15' WHERE key = 'booking_buffer_minutes'")
+		defer db.Exec("UPDATE system_settings SET value = '0' WHERE key = 'booking_buffer_minutes'")
+
+		result, _ := service.FindFreeSlots(userID, false, date, "09:00", "11:45", dayBefore)
+		if want := []string{"11:15", "11:30", "11:45"}; !reflect.DeepEqual(freeByDog(result)[bellaID], want) {
+			t.Errorf("Expected %v, got %v", want, freeByDog(result)[bellaID])
+		}
+	})
+
+	t.Run("past start times are dropped on the same day", func(t *testing.T) {
+		now := time.Date(2030, 1, 9, 11, 10, 0, 0, time.UTC)
+		result, _ := service.FindFreeSlots(userID, false, date, "09:00", "11:45", now)
+		if want := []string{"11:15", "11:30", "11:45"}; !reflect.DeepEqual(freeByDog(result)[maxID], want) {
+			t.Errorf("Expected %v, got %v", want, freeByDog(result)[maxID])
+		}
+	})
+
+	t.Run("dog-specific block hides the dog", func(t *testing.T) {
+		testutil.SeedTestBlockedDateForDog(t, db, "2030-01-10", "Tierarzt", adminID, maxID)
+		result, _ := service.FindFreeSlots(userID, false, "2030-01-10", "", "", dayBefore)
+		slots := freeByDog(result)
+		if _, ok := slots[maxID]; ok {
+			t.Error("Expected Max to be hidden on his blocked date")
+		}
+		if len(slots[bellaID]) != 28 {
+			t.Errorf("Expected all 28 weekday slots for Bella, got %d", len(slots[bellaID]))
+		}
+	})
+
+	t.Run("walk limits hide slots of the dog", func(t *testing.T) {
+		opaID := testutil.SeedTestDog(t, db, "Opa", "Dackel", "green")
+		db.Exec("UPDATE dogs SET max_walks_per_day = 2, min_rest_minutes = 90 WHERE id = ?", opaID)
+		testutil.SeedTestBooking(t, db, adminID, opaID, "2030-01-10", "09:00", "completed")
+
+		// Walked 09:00-10:00, so the next walk may start at 11:30
+		result, _ := service.FindFreeSlots(userID, false, "2030-01-10", "09:00", "11:45", dayBefore)
+		if want := []string{"11:30", "11:45"}; !reflect.DeepEqual(freeByDog(result)[opaID], want) {
+			t.Errorf("Expected %v, got %v", want, freeByDog(result)[opaID])
+		}
+
+		// A second walk uses up the day
+		testutil.SeedTestBooking(t, db, adminID, opaID, "2030-01-10", "15:00", "scheduled")
+		result, _ = service.FindFreeSlots(userID, false, "2030-01-10", "", "", dayBefore)
+		if slots := freeByDog(result)[opaID]; len(slots) != 0 {
+			t.Errorf("Expected no free slots after two walks, got %v", slots)
+		}
+	})
+
+	t.Run("scheduled unavailability hides the dog inside its window", func(t *testing.T) {
+		fritzID := testutil.SeedTestDog(t, db, "Fritz", "Pudel", "green")
+		db.Exec(`UPDATE dogs SET is_available = 0, unavailable_from = '2030-01-01', unavailable_until = '2030-01-09'
+			WHERE id = ?`, fritzID)
+
+		inside, _ := service.FindFreeSlots(userID, false, "2030-01-09", "", "", dayBefore)
+		if _, ok := freeByDog(inside)[fritzID]; ok {
+			t.Error("Expected Fritz to be hidden inside his window")
+		}
+		after, _ := service.FindFreeSlots(userID, false, "2030-01-10", "", "", dayBefore)
+		if _, ok := freeByDog(after)[fritzID]; !ok {
+			t.Error("Expected Fritz to be bookable after his window")
+		}
+	})
+
+	t.Run("walker rules hide the dog on the days they apply", func(t *testing.T) {
+		lumpiID := testutil.SeedTestDog(t, db, "Lumpi", "Terrier", "green")
+		lastDay := "2030-01-10"
+		deny := &models.DogWalkerRule{DogID: lumpiID, UserID: userID, RuleType: models.DogWalkerRuleDeny, Reason: "Zieht zu stark", ExpiresAt: &lastDay}
+		if err := repository.NewDogWalkerRuleRepository(db).Create(deny); err != nil {
+			t.Fatalf("Create() failed: %v", err)
+		}
+
+		during, _ := service.FindFreeSlots(userID, false, "2030-01-10", "", "", dayBefore)
+		if _, ok := freeByDog(during)[lumpiID]; ok {
+			t.Error("Expected Lumpi to be hidden while the walker is denied")
+		}
+		after, _ := service.FindFreeSlots(userID, false, "2030-01-14", "", "", dayBefore)
+		if _, ok := freeByDog(after)[lumpiID]; !ok {
+			t.Error("Expected Lumpi to be bookable after the rule expired")
+		}
+		admin, _ := service.FindFreeSlots(adminID, true, "2030-01-10", "", "", dayBefore)
+		if _, ok := freeByDog(admin)[lumpiID]; !ok {
+			t.Error("Expected admins to ignore walker rules")
+		}
+	})
+
+	t.Run("own walks and waitlist offers of others hide slots", func(t *testing.T) {
+		day := "2030-01-16"
+		otherID := testutil.SeedTestUser(t, db, "other@example.com", "Other", "green")
+		fipsID := testutil.SeedTestDog(t, db, "Fips", "Spitz", "green")
+		testutil.SeedTestBooking(t, db, userID, maxID, day, "09:00", "scheduled")
+
+		// The walker is out with Max 09:00-10:00
+		result, _ := service.FindFreeSlots(userID, false, day, "09:00", "10:15", dayBefore)
+		if want := []string{"10:00", "10:15"}; !reflect.DeepEqual(freeByDog(result)[bellaID], want) {
+			t.Errorf("Expected %v, got %v", want, freeByDog(result)[bellaID])
+		}
+
+		// Bella may join Max's walk at the same start time
+		pair := &models.PairWalk{DogID: maxID, PartnerDogID: bellaID, CreatedBy: adminID}
+		if err := repository.NewPairWalkRepository(db).Create(pair); err != nil {
+			t.Fatalf("Create() pair walk failed: %v", err)
+		}
+		result, _ = service.FindFreeSlots(userID, false, day, "09:00", "10:15", dayBefore)
+		if want := []string{"09:00", "10:00", "10:15"}; !reflect.DeepEqual(freeByDog(result)[bellaID], want) {
+			t.Errorf("Expected %v, got %v", want, freeByDog(result)[bellaID])
+		}
+
+		// Fips at 11:00 is offered to someone on the waitlist
+		waitlistRepo := repository.NewWaitlistRepository(db)
+		entry := &models.WaitlistEntry{UserID: otherID, DogID: fipsID, Date: day, ScheduledTime: "11:00"}
+		if err := waitlistRepo.Create(entry); err != nil {
+			t.Fatalf("Create() waitlist entry failed: %v", err)
+		}
+		waitlistRepo.MarkOffered(entry.ID, dayBefore, dayBefore.Add(2*time.Hour))
+
+		result, _ = service.FindFreeSlots(userID, false, day, "10:00", "11:45", dayBefore)
+		if want := []string{"10:00"}; !reflect.DeepEqual(freeByDog(result)[fipsID], want) {
+			t.Errorf("Expected %v, got %v", want, freeByDog(result)[fipsID])
+		}
+		result, _ = service.FindFreeSlots(otherID, false, day, "11:00", "11:00", dayBefore)
+		if want := []string{"11:00"}; !reflect.DeepEqual(freeByDog(result)[fipsID], want) {
+			t.Errorf("Expected the offered slot to stay free for its user, got %v", freeByDog(result)[fipsID])
+		}
+	})
+
+	t.Run("global block returns no dogs", func(t *testing.T) {
+		testutil.SeedTestBlockedDate(t, db, "2030-01-11", "Betriebsausflug", adminID)
+		result, err := service.FindFreeSlots(userID, false, "2030-01-11", "", "", dayBefore)
+		if err != nil || len(result) != 0 {
+			t.Errorf("Expected no dogs, got %d (err %v)", len(result), err)
+		}
+	})
+}
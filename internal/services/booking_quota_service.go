@@ -0,0 +1,78 @@
+package services
+
+import (
+	"time"
+
+	"github.com/tranmh/gassigeher/internal/models"
+	"github.com/tranmh/gassigeher/internal/repository"
+)
+
+// BookingQuotaService enforces the per-user booking limits from system_settings so
+// popular dogs are shared fairly. Each limit is disabled when set to 0.
+// booking_release_hour delays the newest bookable day (today + booking_advance_days)
+// until that hour of the day.
+type BookingQuotaService struct {
+	bookingRepo  *repository.BookingRepository
+	settingsRepo *repository.SettingsRepository
+}
+
+// NewBookingQuotaService creates a new booking quota service
+func NewBookingQuotaService(bookingRepo *repository.BookingRepository, settingsRepo *repository.SettingsRepository) *BookingQuotaService {
+	return &BookingQuotaService{
+		bookingRepo:  bookingRepo,
+		settingsRepo: settingsRepo,
+	}
+}
+
+// Check returns the first limit a new booking of dogID on date (YYYY-MM-DD) would exceed, or nil
+func (s *BookingQuotaService) Check(userID, dogID int, date string, now time.Time) (*models.BookingQuotaViolation, error) {
+	bookingDate, err := time.ParseInLocation("2006-01-02", date, now.Location())
+	if err != nil {
+		return nil, err
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	// The newest day of the booking window opens at the release hour
+	if releaseHour := s.settingsRepo.GetInt("booking_release_hour", 0); releaseHour > 0 {
+		lastDay := today.AddDate(0, 0, s.settingsRepo.GetInt("booking_advance_days", 14))
+		releaseAt := today.Add(time.Duration(releaseHour) * time.Hour)
+		if !bookingDate.Before(lastDay) && now.Before(releaseAt) {
+			return &models.BookingQuotaViolation{Reason: models.QuotaDateNotYetReleased, ReleaseAt: &releaseAt}, nil
+		}
+	}
+
+	if limit := s.settingsRepo.GetInt("max_future_bookings", 0); limit > 0 {
+		count, err := s.bookingRepo.CountFutureByUser(userID, today.Format("2006-01-02"))
+		if err != nil {
+			return nil, err
+		}
+		if count >= limit {
+			return &models.BookingQuotaViolation{Reason: models.QuotaMaxFutureBookings, Limit: limit, Current: count}, nil
+		}
+	}
+
+	if limit := s.settingsRepo.GetInt("max_bookings_per_day", 0); limit > 0 {
+		count, err := s.bookingRepo.CountByUserInRange(userID, 0, date, date)
+		if err != nil {
+			return nil, err
+		}
+		if count >= limit {
+			return &models.BookingQuotaViolation{Reason: models.QuotaMaxBookingsPerDay, Limit: limit, Current: count}, nil
+		}
+	}
+
+	if limit := s.settingsRepo.GetInt("max_bookings_per_dog_per_week", 0); limit > 0 {
+		// Weeks run Monday to Sunday
+		monday := bookingDate.AddDate(0, 0, -((int(bookingDate.Weekday()) + 6) % 7))
+		sunday := monday.AddDate(0, 0, 6)
+		count, err := s.bookingRepo.CountByUserInRange(userID, dogID, monday.Format("2006-01-02"), sunday.Format("2006-01-02"))
+		if err != nil {
+			return nil, err
+		}
+		if count >= limit {
+			return &models.BookingQuotaViolation{Reason: models.QuotaMaxBookingsPerDogWeek, Limit: limit, Current: count}, nil
+		}
+	}
+
+	return nil, nil
+}
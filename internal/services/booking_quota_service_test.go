@@ -0,0 +1,102 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tranmh/gassigeher/internal/models"
+	"github.com/tranmh/gassigeher/internal/repository"
+	"github.com/tranmh/gassigeher/internal/testutil"
+)
+
+// TestBookingQuotaService_Check tests each per-user booking limit
+func TestBookingQuotaService_Check(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	service := NewBookingQuotaService(repository.NewBookingRepository(db), repository.NewSettingsRepository(db))
+
+	userID := testutil.SeedTestUser(t, db, "walker@example.com", "Walker", "green")
+	bellaID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+	maxID := testutil.SeedTestDog(t, db, "Max", "Beagle", "green")
+
+	// Wednesday 2030-01-09, 10:00
+	now := time.Date(2030, 1, 9, 10, 0, 0, 0, time.UTC)
+	testutil.SeedTestBooking(t, db, userID, bellaID, "2030-01-07", "09:00", "completed")
+	testutil.SeedTestBooking(t, db, userID, bellaID, "2030-01-10", "09:00", "scheduled")
+	testutil.SeedTestBooking(t, db, userID, maxID, "2030-01-11", "09:00", "scheduled")
+	testutil.SeedTestBooking(t, db, userID, maxID, "2030-01-12", "09:00", "cancelled")
+
+	setSetting := func(key, value string) {
+		db.Exec("UPDATE system_settings SET value = ? WHERE key = ?", value, key)
+	}
+
+	t.Run("no limits by default", func(t *testing.T) {
+		violation, err := service.Check(userID, bellaID, "2030-01-11", now)
+		if err != nil || violation != nil {
+			t.Errorf("Expected no violation, got %+v (err %v)", violation, err)
+		}
+	})
+
+	tests := []struct {
+		name    string
+		key     string
+		value   string
+		dogID   int
+		date    string
+		reason  string
+		current int
+	}{
+		{"future bookings", "max_future_bookings", "2", maxID, "2030-01-20", models.QuotaMaxFutureBookings, 2},
+		{"per day", "max_bookings_per_day", "1", maxID, "2030-01-10", models.QuotaMaxBookingsPerDay, 1},
+		{"per dog per week counts completed walks", "max_bookings_per_dog_per_week", "2", bellaID, "2030-01-13", models.QuotaMaxBookingsPerDogWeek, 2},
+		{"cancelled bookings do not count", "max_bookings_per_dog_per_week", "2", maxID, "2030-01-13", "", 0},
+		{"next week is a new week", "max_bookings_per_dog_per_week", "2", bellaID, "2030-01-14", "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setSetting(tt.key, tt.value)
+			defer setSetting(tt.key, "0")
+
+			violation, err := service.Check(userID, tt.dogID, tt.date, now)
+			if err != nil {
+				t.Fatalf("Check() failed: %v", err)
+			}
+			if tt.reason == "" {
+				if violation != nil {
+					t.Errorf("Expected no violation, got %+v", violation)
+				}
+				return
+			}
+			if violation == nil || violation.Reason != tt.reason {
+				t.Fatalf("Expected reason %s, got %+v", tt.reason, violation)
+			}
+			if violation.Current != tt.current {
+				t.Errorf("Expected current %d, got %d", tt.current, violation.Current)
+			}
+		})
+	}
+
+	t.Run("newest day opens at release hour", func(t *testing.T) {
+		setSetting("booking_release_hour", "18")
+		defer setSetting("booking_release_hour", "0")
+
+		// Default booking_advance_days is 14
+		violation, err := service.Check(userID, bellaID, "2030-01-23", now)
+		if err != nil {
+			t.Fatalf("Check() failed: %v", err)
+		}
+		if violation == nil || violation.Reason != models.QuotaDateNotYetReleased {
+			t.Fatalf("Expected date_not_yet_released, got %+v", violation)
+		}
+		if want := time.Date(2030, 1, 9, 18, 0, 0, 0, time.UTC); !violation.ReleaseAt.Equal(want) {
+			t.Errorf("Expected release at %v, got %v", want, violation.ReleaseAt)
+		}
+
+		if violation, _ := service.Check(userID, bellaID, "2030-01-22", now); violation != nil {
+			t.Errorf("Expected earlier days to be bookable, got %+v", violation)
+		}
+		if violation, _ := service.Check(userID, bellaID, "2030-01-23", now.Add(8*time.Hour)); violation != nil {
+			t.Errorf("Expected newest day to be bookable after release hour, got %+v", violation)
+		}
+	})
+}
@@ -0,0 +1,302 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tranmh/gassigeher/internal/models"
+	"github.com/tranmh/gassigeher/internal/repository"
+)
+
+// BookingSeriesService creates the bookings of recurring series up to the booking horizon
+type BookingSeriesService struct {
+	seriesRepo         *repository.BookingSeriesRepository
+	bookingRepo        *repository.BookingRepository
+	dogRepo            *repository.DogRepository
+	userRepo           *repository.UserRepository
+	userColorRepo      *repository.UserColorRepository
+	ruleRepo           *repository.DogWalkerRuleRepository
+	blockedDateRepo    *repository.BlockedDateRepository
+	settingsRepo       *repository.SettingsRepository
+	bookingTimeService *BookingTimeService
+	quotaService       *BookingQuotaService
+	strikeService      *StrikeService
+	waitlistService    *WaitlistService
+}
+
+// NewBookingSeriesService creates a new booking series service
+func NewBookingSeriesService(
+	seriesRepo *repository.BookingSeriesRepository,
+	bookingRepo *repository.BookingRepository,
+	dogRepo *repository.DogRepository,
+	userRepo *repository.UserRepository,
+	userColorRepo *repository.UserColorRepository,
+	ruleRepo *repository.DogWalkerRuleRepository,
+	blockedDateRepo *repository.BlockedDateRepository,
+	settingsRepo *repository.SettingsRepository,
+	bookingTimeService *BookingTimeService,
+	quotaService *BookingQuotaService,
+	strikeService *StrikeService,
+	waitlistService *WaitlistService,
+) *BookingSeriesService {
+	return &BookingSeriesService{
+		seriesRepo:         seriesRepo,
+		bookingRepo:        bookingRepo,
+		dogRepo:            dogRepo,
+		userRepo:           userRepo,
+		userColorRepo:      userColorRepo,
+		ruleRepo:           ruleRepo,
+		blockedDateRepo:    blockedDateRepo,
+		settingsRepo:       settingsRepo,
+		bookingTimeService: bookingTimeService,
+		quotaService:       quotaService,
+		strikeService:      strikeService,
+		waitlistService:    waitlistService,
+	}
+}
+
+// Horizon returns the last bookable date (today + booking_advance_days)
+func (s *BookingSeriesService) Horizon(today time.Time) time.Time {
+	return today.AddDate(0, 0, s.settingsRepo.GetInt("booking_advance_days", 14))
+}
+
+// Create stores a new series and books all its occurrences up to the horizon
+func (s *BookingSeriesService) Create(series *models.BookingSeries, today time.Time) (*models.BookingSeriesResult, error) {
+	start, err := time.Parse("2006-01-02", series.StartDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start date: %w", err)
+	}
+
+	// Nothing has been generated yet, so the first processed occurrence is the start date
+	series.LastGeneratedDate = start.AddDate(0, 0, -1).Format("2006-01-02")
+	if err := s.seriesRepo.Create(series); err != nil {
+		return nil, err
+	}
+
+	return s.Extend(series, today)
+}
+
+// Extend books all occurrences of the series after its last generated date up to the horizon.
+// Occurrences that fail validation are skipped and reported with the reason. While the walker
+// may not book the dog at all (see pauseReason) the series is paused: nothing is booked and
+// the result only carries the reason.
+func (s *BookingSeriesService) Extend(series *models.BookingSeries, today time.Time) (*models.BookingSeriesResult, error) {
+	result := &models.BookingSeriesResult{
+		Series:   series,
+		Bookings: []*models.Booking{},
+		Skipped:  []models.SkippedOccurrence{},
+	}
+
+	dog, err := s.dogRepo.FindByID(series.DogID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dog: %w", err)
+	}
+	if dog == nil {
+		return nil, fmt.Errorf("dog not found")
+	}
+
+	user, err := s.userRepo.FindByID(series.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		return nil, fmt.Errorf("user not found")
+	}
+
+	pauseReason, err := s.pauseReason(user, dog, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if pauseReason != "" {
+		result.PausedReason = pauseReason
+		return result, nil
+	}
+
+	start, err := time.Parse("2006-01-02", series.StartDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start date: %w", err)
+	}
+	lastGenerated, err := time.Parse("2006-01-02", series.LastGeneratedDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid last generated date: %w", err)
+	}
+	horizon := s.Horizon(today)
+	bufferMinutes := s.settingsRepo.GetInt("booking_buffer_minutes", 0)
+	if bufferMinutes < 0 {
+		bufferMinutes = 0
+	}
+
+	processed := ""
+	for date := start; !date.After(horizon); date = date.AddDate(0, 0, 7*series.IntervalWeeks) {
+		if !date.After(lastGenerated) {
+			continue
+		}
+		dateStr := date.Format("2006-01-02")
+
+		if date.Before(today) {
+			processed = dateStr
+			result.Skipped = append(result.Skipped, models.SkippedOccurrence{Date: dateStr, Reason: "Datum liegt in der Vergangenheit"})
+			continue
+		}
+
+		reason, booking, err := s.bookOccurrence(series, user, dog, dateStr, bufferMinutes)
+		if err != nil {
+			return nil, err
+		}
+		if reason == "" && booking == nil {
+			// The date is not released yet (booking_release_hour); a later run books it
+			break
+		}
+		processed = dateStr
+		if reason != "" {
+			result.Skipped = append(result.Skipped, models.SkippedOccurrence{Date: dateStr, Reason: reason})
+			continue
+		}
+		result.Bookings = append(result.Bookings, booking)
+	}
+
+	if processed != "" {
+		if err := s.seriesRepo.UpdateLastGeneratedDate(series.ID, processed); err != nil {
+			return nil, err
+		}
+		series.LastGeneratedDate = processed
+	}
+
+	return result, nil
+}
+
+// pauseReason returns why the walker may not book the dog right now, the same checks as for
+// creating a series: the dog's color category and a strike restriction. Admins and super admins
+// are exempt. Returns "" if the walker may book.
+func (s *BookingSeriesService) pauseReason(user *models.User, dog *models.Dog, now time.Time) (string, error) {
+	if user.IsAdmin || user.IsSuperAdmin {
+		return "", nil
+	}
+
+	userColorIDs, err := s.userColorRepo.GetUserColorIDs(user.ID)
+	if err != nil {
+		return "", err
+	}
+	dogColorID := 0
+	if dog.ColorID != nil {
+		dogColorID = *dog.ColorID
+	}
+	if !repository.CanUserAccessDogByColor(userColorIDs, dogColorID) {
+		return "Du hast nicht die erforderliche Farbkategorie für diesen Hund", nil
+	}
+
+	summary, err := s.strikeService.Summary(user.ID, now)
+	if err != nil {
+		return "", err
+	}
+	if summary.IsRestricted {
+		return summary.RestrictionMessage(), nil
+	}
+
+	return "", nil
+}
+
+// bookOccurrence validates and creates one occurrence.
+// Returns a skip reason instead of a booking if the occurrence cannot be booked, and neither
+// if the date is not released for booking yet and has to be tried again later.
+func (s *BookingSeriesService) bookOccurrence(series *models.BookingSeries, user *models.User, dog *models.Dog, date string, bufferMinutes int) (string, *models.Booking, error) {
+	if !dog.IsBookableOn(date) {
+		return "Hund ist an diesem Tag nicht verfügbar", nil, nil
+	}
+
+	// Allowlists and denylists are checked per date, rules may start or expire during the series
+	if !user.IsAdmin && !user.IsSuperAdmin {
+		violation, err := s.ruleRepo.Violation(dog.ID, user.ID, date)
+		if err != nil {
+			return "", nil, err
+		}
+		if violation != "" {
+			return models.DogWalkerRuleMessage(violation), nil, nil
+		}
+	}
+
+	// Every occurrence counts against the walker's booking quotas like a single booking
+	if !user.IsAdmin && !user.IsSuperAdmin {
+		violation, err := s.quotaService.Check(user.ID, dog.ID, date, time.Now())
+		if err != nil {
+			return "", nil, err
+		}
+		if violation != nil && violation.Reason == models.QuotaDateNotYetReleased {
+			return "", nil, nil
+		}
+		if violation != nil {
+			return violation.Message(), nil, nil
+		}
+	}
+
+	if err := s.bookingTimeService.ValidateBookingTime(date, series.ScheduledTime); err != nil {
+		return err.Error(), nil, nil
+	}
+
+	isBlocked, err := s.blockedDateRepo.IsBlockedForDog(date, dog.ID)
+	if err != nil {
+		return "", nil, err
+	}
+	if isBlocked {
+		return "Datum ist für diesen Hund gesperrt", nil, nil
+	}
+
+	conflict, err := s.bookingRepo.FindConflictingBooking(dog.ID, date, series.ScheduledTime, dog.GetWalkDuration(), bufferMinutes, 0)
+	if err != nil {
+		return "", nil, err
+	}
+	if conflict != nil {
+		return fmt.Sprintf("Hund ist bereits von %s bis %s Uhr gebucht", conflict.ScheduledTime, conflict.EndTime), nil, nil
+	}
+
+	userConflict, err := s.bookingRepo.FindUserConflictingBooking(series.UserID, dog.ID, date, series.ScheduledTime, dog.GetWalkDuration(), 0)
+	if err != nil {
+		return "", nil, err
+	}
+	if userConflict != nil {
+		return fmt.Sprintf("Du hast bereits von %s bis %s Uhr einen Spaziergang mit %s gebucht",
+			userConflict.ScheduledTime, userConflict.EndTime, userConflict.DogName), nil, nil
+	}
+
+	// A freed slot offered to someone on the waitlist is reserved for them until the offer expires
+	reserved, err := s.waitlistService.CheckReservation(series.UserID, dog.ID, date, series.ScheduledTime, dog.GetWalkDuration(), time.Now())
+	if err != nil {
+		return "", nil, err
+	}
+	if reserved {
+		return "Termin ist für die Warteliste reserviert", nil, nil
+	}
+
+	requiresApproval, err := s.bookingTimeService.RequiresApproval(series.ScheduledTime)
+	if err != nil {
+		return "", nil, err
+	}
+
+	seriesID := series.ID
+	booking := &models.Booking{
+		UserID:           series.UserID,
+		DogID:            dog.ID,
+		Date:             date,
+		ScheduledTime:    series.ScheduledTime,
+		RequiresApproval: requiresApproval,
+		SeriesID:         &seriesID,
+	}
+
+	// The dog's walk limits are checked inside the insert transaction; the conflicts are checked
+	// again there in case another request took the slot meanwhile
+	conflict, walkLimit, err := s.bookingRepo.CreateIfFree(booking, dog, bufferMinutes)
+	if err != nil {
+		if repository.IsSlotTakenError(err) {
+			return "Termin ist bereits belegt", nil, nil
+		}
+		return "", nil, err
+	}
+	if walkLimit != nil {
+		return walkLimit.Message(), nil, nil
+	}
+	if conflict != nil {
+		return "Termin ist bereits belegt", nil, nil
+	}
+
+	return "", booking, nil
+}
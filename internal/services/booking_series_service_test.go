@@ -0,0 +1,292 @@
+package services
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/tranmh/gassigeher/internal/models"
+	"github.com/tranmh/gassigeher/internal/repository"
+	"github.com/tranmh/gassigeher/internal/testutil"
+)
+
+// newTestBookingSeriesService creates a series service on the given test database
+func newTestBookingSeriesService(t *testing.T, db *sql.DB) (*BookingSeriesService, *repository.BookingSeriesRepository, int, int, int) {
+	settingsRepo := repository.NewSettingsRepository(db)
+	_ = settingsRepo.Update("use_feiertage_api", "false")
+
+	holidayService := NewHolidayService(repository.NewHolidayRepository(db), settingsRepo)
+	bookingTimeService := NewBookingTimeService(repository.NewBookingTimeRepository(db), holidayService, settingsRepo)
+	seriesRepo := repository.NewBookingSeriesRepository(db)
+	bookingRepo := repository.NewBookingRepository(db)
+	userRepo := repository.NewUserRepository(db)
+	service := NewBookingSeriesService(seriesRepo, bookingRepo, repository.NewDogRepository(db), userRepo,
+		repository.NewUserColorRepository(db), repository.NewDogWalkerRuleRepository(db), repository.NewBlockedDateRepository(db),
+		settingsRepo, bookingTimeService, NewBookingQuotaService(bookingRepo, settingsRepo),
+		NewStrikeService(repository.NewStrikeRepository(db), userRepo, settingsRepo, nil),
+		NewWaitlistService(repository.NewWaitlistRepository(db), bookingRepo, userRepo, repository.NewDogRepository(db), settingsRepo, nil))
+
+	userID := testutil.SeedTestUser(t, db, "series@example.com", "Series User", "green")
+	adminID := testutil.SeedTestUser(t, db, "admin@example.com", "Admin", "orange")
+	dogID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+
+	// Dog-specific block on the second Tuesday
+	testutil.SeedTestBlockedDateForDog(t, db, "2030-01-15", "Tierarzt", adminID, dogID)
+
+	return service, seriesRepo, userID, dogID, adminID
+}
+
+// TestBookingSeriesService_CreateWeekly tests creating a weekly series up to the horizon
+func TestBookingSeriesService_CreateWeekly(t *testing.T) {
+	service, seriesRepo, userID, dogID, _ := newTestBookingSeriesService(t, testutil.SetupTestDB(t))
+
+	// Monday 2030-01-07, default horizon 14 days = 2030-01-21
+	today := time.Date(2030, 1, 7, 0, 0, 0, 0, time.UTC)
+
+	series := &models.BookingSeries{
+		UserID:        userID,
+		DogID:         dogID,
+		ScheduledTime: "14:00",
+		IntervalWeeks: 1,
+		StartDate:     "2030-01-08",
+	}
+
+	result, err := service.Create(series, today)
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+
+	if len(result.Bookings) != 1 || result.Bookings[0].Date != "2030-01-08" {
+		t.Fatalf("Expected one booking on 2030-01-08, got %+v", result.Bookings)
+	}
+	if result.Bookings[0].SeriesID == nil || *result.Bookings[0].SeriesID != series.ID {
+		t.Error("Expected booking to reference the series")
+	}
+	if len(result.Skipped) != 1 || result.Skipped[0].Date != "2030-01-15" {
+		t.Fatalf("Expected blocked 2030-01-15 to be skipped, got %+v", result.Skipped)
+	}
+	if result.Skipped[0].Reason == "" {
+		t.Error("Expected a skip reason")
+	}
+
+	stored, err := seriesRepo.FindByID(series.ID)
+	if err != nil || stored == nil {
+		t.Fatalf("FindByID() failed: %v", err)
+	}
+	if stored.LastGeneratedDate != "2030-01-15" {
+		t.Errorf("Expected last generated date 2030-01-15, got %s", stored.LastGeneratedDate)
+	}
+
+	t.Run("extend as horizon moves forward", func(t *testing.T) {
+		result, err := service.Extend(stored, today.AddDate(0, 0, 7))
+		if err != nil {
+			t.Fatalf("Extend() failed: %v", err)
+		}
+		if len(result.Bookings) != 1 || result.Bookings[0].Date != "2030-01-22" {
+			t.Fatalf("Expected one new booking on 2030-01-22, got %+v", result.Bookings)
+		}
+		if len(result.Skipped) != 0 {
+			t.Errorf("Expected no skipped dates, got %+v", result.Skipped)
+		}
+	})
+
+	t.Run("extending again creates nothing", func(t *testing.T) {
+		result, err := service.Extend(stored, today.AddDate(0, 0, 7))
+		if err != nil {
+			t.Fatalf("Extend() failed: %v", err)
+		}
+		if len(result.Bookings) != 0 {
+			t.Errorf("Expected no new bookings, got %d", len(result.Bookings))
+		}
+	})
+}
+
+// TestBookingSeriesService_CreateBiWeekly tests bi-weekly series and time validation
+func TestBookingSeriesService_CreateBiWeekly(t *testing.T) {
+	service, _, userID, dogID, _ := newTestBookingSeriesService(t, testutil.SetupTestDB(t))
+
+	today := time.Date(2030, 1, 7, 0, 0, 0, 0, time.UTC)
+
+	t.Run("every second week", func(t *testing.T) {
+		series := &models.BookingSeries{
+			UserID:        userID,
+			DogID:         dogID,
+			ScheduledTime: "14:00",
+			IntervalWeeks: 2,
+			StartDate:     "2030-01-09",
+		}
+
+		result, err := service.Create(series, today)
+		if err != nil {
+			t.Fatalf("Create() failed: %v", err)
+		}
+		if len(result.Bookings) != 1 || result.Bookings[0].Date != "2030-01-09" {
+			t.Errorf("Expected only 2030-01-09 within horizon, got %+v", result.Bookings)
+		}
+	})
+
+	t.Run("blocked time is skipped", func(t *testing.T) {
+		series := &models.BookingSeries{
+			UserID:        userID,
+			DogID:         dogID,
+			ScheduledTime: "13:30", // Mittagspause
+			IntervalWeeks: 1,
+			StartDate:     "2030-01-10",
+		}
+
+		result, err := service.Create(series, today)
+		if err != nil {
+			t.Fatalf("Create() failed: %v", err)
+		}
+		if len(result.Bookings) != 0 {
+			t.Errorf("Expected no bookings in blocked time, got %d", len(result.Bookings))
+		}
+		if len(result.Skipped) != 2 {
+			t.Errorf("Expected 2 skipped dates, got %+v", result.Skipped)
+		}
+	})
+}
+
+// TestBookingSeriesService_WalkerRules tests that walker rules are checked for every occurrence
+func TestBookingSeriesService_WalkerRules(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	service, _, userID, dogID, adminID := newTestBookingSeriesService(t, db)
+
+	lastDay := "2030-01-09"
+	deny := &models.DogWalkerRule{DogID: dogID, UserID: userID, RuleType: models.DogWalkerRuleDeny, Reason: "Zieht zu stark", ExpiresAt: &lastDay}
+	if err := repository.NewDogWalkerRuleRepository(db).Create(deny); err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+
+	today := time.Date(2030, 1, 7, 0, 0, 0, 0, time.UTC)
+
+	t.Run("occurrences while the walker is denied are skipped", func(t *testing.T) {
+		series := &models.BookingSeries{UserID: userID, DogID: dogID, ScheduledTime: "14:00", IntervalWeeks: 1, StartDate: "2030-01-09"}
+		result, err := service.Create(series, today)
+		if err != nil {
+			t.Fatalf("Create() failed: %v", err)
+		}
+		if len(result.Skipped) != 1 || result.Skipped[0].Date != "2030-01-09" || result.Skipped[0].Reason != models.DogWalkerRuleMessage(models.DogWalkerRuleDeny) {
+			t.Errorf("Expected 2030-01-09 to be skipped for the denylist, got %+v", result.Skipped)
+		}
+		if len(result.Bookings) != 1 || result.Bookings[0].Date != "2030-01-16" {
+			t.Errorf("Expected a booking on 2030-01-16 after the rule expired, got %+v", result.Bookings)
+		}
+	})
+
+	t.Run("admins are exempt", func(t *testing.T) {
+		db.Exec("UPDATE users SET is_admin = 1 WHERE id = ?", adminID)
+		db.Exec("INSERT INTO dog_walker_rules (dog_id, user_id, rule_type, reason, created_at) VALUES (?, ?, 'deny', 'Test', ?)", dogID, adminID, time.Now())
+		series := &models.BookingSeries{UserID: adminID, DogID: dogID, ScheduledTime: "16:00", IntervalWeeks: 1, StartDate: "2030-01-09"}
+		result, err := service.Create(series, today)
+		if err != nil {
+			t.Fatalf("Create() failed: %v", err)
+		}
+		if len(result.Bookings) != 2 {
+			t.Errorf("Expected both occurrences for the admin, got %+v (skipped %+v)", result.Bookings, result.Skipped)
+		}
+	})
+}
+
+// TestBookingSeriesService_Quotas tests that every occurrence counts against the booking quotas
+func TestBookingSeriesService_Quotas(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	service, _, userID, dogID, _ := newTestBookingSeriesService(t, db)
+	db.Exec("UPDATE system_settings SET value = '1' WHERE key = 'max_future_bookings'")
+
+	today := time.Date(2030, 1, 7, 0, 0, 0, 0, time.UTC)
+	series := &models.BookingSeries{UserID: userID, DogID: dogID, ScheduledTime: "14:00", IntervalWeeks: 1, StartDate: "2030-01-09"}
+
+	result, err := service.Create(series, today)
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	if len(result.Bookings) != 1 || result.Bookings[0].Date != "2030-01-09" {
+		t.Fatalf("Expected only the first occurrence to be booked, got %+v", result.Bookings)
+	}
+	want := (&models.BookingQuotaViolation{Reason: models.QuotaMaxFutureBookings, Limit: 1}).Message()
+	if len(result.Skipped) != 1 || result.Skipped[0].Date != "2030-01-16" || result.Skipped[0].Reason != want {
+		t.Errorf("Expected 2030-01-16 to be skipped for the quota, got %+v", result.Skipped)
+	}
+}
+
+// TestBookingSeriesService_WaitlistReservation tests that occurrences leave slots offered to the waitlist alone
+func TestBookingSeriesService_WaitlistReservation(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	service, _, userID, dogID, adminID := newTestBookingSeriesService(t, db)
+
+	waitlistRepo := repository.NewWaitlistRepository(db)
+	entry := &models.WaitlistEntry{UserID: adminID, DogID: dogID, Date: "2030-01-09", ScheduledTime: "14:30"}
+	if err := waitlistRepo.Create(entry); err != nil {
+		t.Fatalf("Create() waitlist entry failed: %v", err)
+	}
+	waitlistRepo.MarkOffered(entry.ID, time.Now(), time.Now().Add(time.Hour))
+
+	today := time.Date(2030, 1, 7, 0, 0, 0, 0, time.UTC)
+	series := &models.BookingSeries{UserID: userID, DogID: dogID, ScheduledTime: "14:00", IntervalWeeks: 1, StartDate: "2030-01-09"}
+	result, err := service.Create(series, today)
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	if len(result.Skipped) != 1 || result.Skipped[0].Date != "2030-01-09" || result.Skipped[0].Reason != "Termin ist für die Warteliste reserviert" {
+		t.Errorf("Expected the offered slot to be skipped, got %+v", result.Skipped)
+	}
+	if len(result.Bookings) != 1 || result.Bookings[0].Date != "2030-01-16" {
+		t.Errorf("Expected only 2030-01-16 to be booked, got %+v", result.Bookings)
+	}
+}
+
+// TestBookingSeriesService_Paused tests that nothing is booked while the walker may not book the dog
+func TestBookingSeriesService_Paused(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	service, seriesRepo, userID, dogID, _ := newTestBookingSeriesService(t, db)
+
+	today := time.Date(2030, 1, 7, 0, 0, 0, 0, time.UTC)
+	series := &models.BookingSeries{
+		UserID:            userID,
+		DogID:             dogID,
+		ScheduledTime:     "14:00",
+		IntervalWeeks:     1,
+		StartDate:         "2030-01-09",
+		LastGeneratedDate: "2030-01-08",
+	}
+	if err := seriesRepo.Create(series); err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+
+	t.Run("restricted by strikes", func(t *testing.T) {
+		strikeService := NewStrikeService(repository.NewStrikeRepository(db), repository.NewUserRepository(db),
+			repository.NewSettingsRepository(db), nil)
+		for i := 0; i < 3; i++ {
+			if _, err := strikeService.AddStrike(userID, nil, models.StrikeReasonNoShow, time.Now()); err != nil {
+				t.Fatalf("AddStrike() failed: %v", err)
+			}
+		}
+		defer db.Exec("DELETE FROM user_strikes WHERE user_id = ?", userID)
+
+		result, err := service.Extend(series, today)
+		if err != nil {
+			t.Fatalf("Extend() failed: %v", err)
+		}
+		if result.PausedReason == "" || len(result.Bookings) != 0 || len(result.Skipped) != 0 {
+			t.Errorf("Expected a paused series without bookings, got %+v", result)
+		}
+	})
+
+	t.Run("without the dog's color", func(t *testing.T) {
+		db.Exec("DELETE FROM user_colors WHERE user_id = ?", userID)
+
+		result, err := service.Extend(series, today)
+		if err != nil {
+			t.Fatalf("Extend() failed: %v", err)
+		}
+		if result.PausedReason != "Du hast nicht die erforderliche Farbkategorie für diesen Hund" || len(result.Bookings) != 0 {
+			t.Errorf("Expected the series to be paused for the color, got %+v", result)
+		}
+	})
+
+	stored, _ := seriesRepo.FindByID(series.ID)
+	if !stored.IsActive || stored.LastGeneratedDate != "2030-01-08" {
+		t.Errorf("Expected the paused series to stay active and unchanged, got %+v", stored)
+	}
+}
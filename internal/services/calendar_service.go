@@ -0,0 +1,222 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tranmh/gassigeher/internal/models"
+	"github.com/tranmh/gassigeher/internal/repository"
+)
+
+// calendarFeedPastDays is how far back feeds list bookings, so recent walks stay in the calendar
+const calendarFeedPastDays = 30
+
+// CalendarService manages calendar feed tokens and builds the iCalendar feeds of walkers and admins
+type CalendarService struct {
+	tokenRepo   *repository.CalendarTokenRepository
+	bookingRepo *repository.BookingRepository
+	dogRepo     *repository.DogRepository
+	userRepo    *repository.UserRepository
+}
+
+// NewCalendarService creates a new calendar service
+func NewCalendarService(
+	tokenRepo *repository.CalendarTokenRepository,
+	bookingRepo *repository.BookingRepository,
+	dogRepo *repository.DogRepository,
+	userRepo *repository.UserRepository,
+) *CalendarService {
+	return &CalendarService{
+		tokenRepo:   tokenRepo,
+		bookingRepo: bookingRepo,
+		dogRepo:     dogRepo,
+		userRepo:    userRepo,
+	}
+}
+
+// GetOrCreateToken returns the user's feed token, creating one on first use
+func (s *CalendarService) GetOrCreateToken(userID int) (string, error) {
+	token, err := s.tokenRepo.FindByUser(userID)
+	if err != nil || token != "" {
+		return token, err
+	}
+	return s.RegenerateToken(userID)
+}
+
+// RegenerateToken replaces the user's feed token; subscriptions using the old token stop working
+func (s *CalendarService) RegenerateToken(userID int) (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	token := hex.EncodeToString(bytes)
+
+	if err := s.tokenRepo.Set(userID, token); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// FindUserByToken returns the active user owning token, or nil if the token is unknown
+// or its owner is deactivated or deleted
+func (s *CalendarService) FindUserByToken(token string) (*models.User, error) {
+	userID, err := s.tokenRepo.FindUserID(token)
+	if err != nil || userID == 0 {
+		return nil, err
+	}
+
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || !user.IsActive || user.IsDeleted {
+		return nil, nil
+	}
+	return user, nil
+}
+
+// UserFeed builds the feed of the user's bookings from calendarFeedPastDays ago on
+func (s *CalendarService) UserFeed(user *models.User, now time.Time) (string, error) {
+	from := now.AddDate(0, 0, -calendarFeedPastDays).Format("2006-01-02")
+	bookings, err := s.bookingRepo.FindAll(&models.BookingFilterRequest{UserID: &user.ID, DateFrom: &from})
+	if err != nil {
+		return "", err
+	}
+
+	dogs, err := s.dogsByID()
+	if err != nil {
+		return "", err
+	}
+
+	events := make([]ICalEvent, 0, len(bookings))
+	for _, booking := range bookings {
+		if event, ok := BookingEvent(booking, dogs[booking.DogID]); ok {
+			events = append(events, event)
+		}
+	}
+
+	return BuildICalendar("Gassigeher – Meine Spaziergänge", events), nil
+}
+
+// AdminFeed builds the feed of all bookings from calendarFeedPastDays ago on, naming the walker
+// of each walk. dogID restricts the feed to one dog; pass nil for all dogs.
+func (s *CalendarService) AdminFeed(dogID *int, now time.Time) (string, error) {
+	from := now.AddDate(0, 0, -calendarFeedPastDays).Format("2006-01-02")
+	bookings, err := s.bookingRepo.FindAll(&models.BookingFilterRequest{DogID: dogID, DateFrom: &from})
+	if err != nil {
+		return "", err
+	}
+
+	dogs, err := s.dogsByID()
+	if err != nil {
+		return "", err
+	}
+
+	users, err := s.userRepo.FindAll(nil)
+	if err != nil {
+		return "", err
+	}
+	walkerNames := make(map[int]string, len(users))
+	for _, user := range users {
+		walkerNames[user.ID] = user.FullName()
+	}
+
+	events := make([]ICalEvent, 0, len(bookings))
+	for _, booking := range bookings {
+		event, ok := BookingEvent(booking, dogs[booking.DogID])
+		if !ok {
+			continue
+		}
+		walker := walkerNames[booking.UserID]
+		if walker == "" {
+			walker = "Gelöschter Nutzer"
+		}
+		event.Summary += " – " + walker
+		events = append(events, event)
+	}
+
+	name := "Gassigeher – Alle Spaziergänge"
+	if dogID != nil && dogs[*dogID] != nil {
+		name = "Gassigeher – " + dogs[*dogID].Name
+	}
+	return BuildICalendar(name, events), nil
+}
+
+// BookingInvite returns the calendar event attached to booking emails,
+// or nil if the booking's date or time cannot be parsed
+func BookingInvite(booking *models.Booking, dog *models.Dog) *ICalEvent {
+	event, ok := BookingEvent(booking, dog)
+	if !ok {
+		return nil
+	}
+	return &event
+}
+
+// BookingEvent converts a booking into a calendar event lasting the dog's walk duration.
+// The pickup location becomes the event location, the walk route part of the description.
+// Cancelled walks and no-shows are CANCELLED, completed walks are marked in the summary.
+// Returns false if the booking's date or time cannot be parsed.
+func BookingEvent(booking *models.Booking, dog *models.Dog) (ICalEvent, bool) {
+	start, err := time.ParseInLocation("2006-01-02 15:04", booking.Date+" "+booking.ScheduledTime, time.Local)
+	if err != nil {
+		return ICalEvent{}, false
+	}
+
+	event := ICalEvent{
+		UID:          fmt.Sprintf("booking-%d@gassigeher", booking.ID),
+		Start:        start,
+		End:          start.Add(time.Duration(models.DefaultWalkDuration) * time.Minute),
+		Summary:      "Gassi",
+		Status:       ICalStatusConfirmed,
+		LastModified: booking.UpdatedAt,
+	}
+
+	var description []string
+	if dog != nil {
+		event.Summary = "Gassi mit " + dog.Name
+		event.End = start.Add(time.Duration(dog.GetWalkDuration()) * time.Minute)
+		if dog.PickupLocation != nil && *dog.PickupLocation != "" {
+			event.Location = *dog.PickupLocation
+			description = append(description, "Abholort: "+*dog.PickupLocation)
+		}
+		if dog.WalkRoute != nil && *dog.WalkRoute != "" {
+			description = append(description, "Route: "+*dog.WalkRoute)
+		}
+	}
+
+	switch {
+	case booking.Status == "cancelled":
+		event.Status = ICalStatusCancelled
+		if booking.AdminCancellationReason != nil && *booking.AdminCancellationReason != "" {
+			description = append(description, "Storniert: "+*booking.AdminCancellationReason)
+		}
+	case booking.Status == "no_show":
+		// The walk did not take place
+		event.Status = ICalStatusCancelled
+		event.Summary += " (nicht erschienen)"
+	case booking.Status == "completed":
+		event.Summary += " (erledigt)"
+	case booking.ApprovalStatus == "pending":
+		event.Status = ICalStatusTentative
+		event.Summary += " (Genehmigung ausstehend)"
+	}
+
+	event.Description = strings.Join(description, "\n")
+	return event, true
+}
+
+// dogsByID loads all dogs keyed by ID
+func (s *CalendarService) dogsByID() (map[int]*models.Dog, error) {
+	dogs, err := s.dogRepo.FindAll(nil)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int]*models.Dog, len(dogs))
+	for _, dog := range dogs {
+		byID[dog.ID] = dog
+	}
+	return byID, nil
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tranmh/gassigeher/internal/repository"
+	"github.com/tranmh/gassigeher/internal/testutil"
+)
+
+// TestCalendarService tests feed tokens and the contents of user and admin feeds
+func TestCalendarService(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	service := NewCalendarService(
+		repository.NewCalendarTokenRepository(db),
+		repository.NewBookingRepository(db),
+		repository.NewDogRepository(db),
+		repository.NewUserRepository(db),
+	)
+
+	userID := testutil.SeedTestUser(t, db, "walker@example.com", "Walker", "green")
+	otherID := testutil.SeedTestUser(t, db, "other@example.com", "Other", "green")
+	bellaID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+	maxID := testutil.SeedTestDog(t, db, "Max", "Beagle", "green")
+	db.Exec("UPDATE dogs SET pickup_location = 'Zwinger 1', walk_route = 'Waldweg', walk_duration = 45 WHERE id = ?", bellaID)
+
+	now := time.Date(2030, 1, 9, 12, 0, 0, 0, time.Local)
+	scheduledID := testutil.SeedTestBooking(t, db, userID, bellaID, "2030-01-10", "09:00", "scheduled")
+	cancelledID := testutil.SeedTestBooking(t, db, userID, maxID, "2030-01-11", "10:00", "cancelled")
+	pendingID := testutil.SeedTestBooking(t, db, userID, maxID, "2030-01-12", "10:00", "scheduled")
+	db.Exec("UPDATE bookings SET approval_status = 'pending' WHERE id = ?", pendingID)
+	oldID := testutil.SeedTestBooking(t, db, userID, bellaID, "2029-11-01", "09:00", "completed")
+	otherBookingID := testutil.SeedTestBooking(t, db, otherID, bellaID, "2030-01-10", "14:00", "scheduled")
+	walkedID := testutil.SeedTestBooking(t, db, userID, bellaID, "2030-01-02", "09:00", "completed")
+	noShowID := testutil.SeedTestBooking(t, db, userID, maxID, "2030-01-03", "09:00", "no_show")
+
+	// eventOf returns the unfolded VEVENT of a booking, or "" if the feed does not contain it
+	eventOf := func(feed string, bookingID int) string {
+		feed = strings.ReplaceAll(feed, "\r\n ", "")
+		for _, event := range strings.Split(feed, "BEGIN:VEVENT")[1:] {
+			if strings.Contains(event, fmt.Sprintf("UID:booking-%d@gassigeher\r\n", bookingID)) {
+				return event
+			}
+		}
+		return ""
+	}
+
+	t.Run("token is created once and can be regenerated", func(t *testing.T) {
+		token, err := service.GetOrCreateToken(userID)
+		if err != nil || len(token) != 64 {
+			t.Fatalf("Expected a 64 character token, got %q (err %v)", token, err)
+		}
+		if again, _ := service.GetOrCreateToken(userID); again != token {
+			t.Error("Expected the same token on the second call")
+		}
+
+		newToken, err := service.RegenerateToken(userID)
+		if err != nil || newToken == token {
+			t.Fatalf("Expected a new token, got %q (err %v)", newToken, err)
+		}
+		if user, _ := service.FindUserByToken(token); user != nil {
+			t.Error("Expected the old token to be revoked")
+		}
+		if user, _ := service.FindUserByToken(newToken); user == nil || user.ID != userID {
+			t.Error("Expected the new token to identify the user")
+		}
+	})
+
+	t.Run("deactivated users have no feed", func(t *testing.T) {
+		token, _ := service.GetOrCreateToken(otherID)
+		db.Exec("UPDATE users SET is_active = 0 WHERE id = ?", otherID)
+		defer db.Exec("UPDATE users SET is_active = 1 WHERE id = ?", otherID)
+
+		if user, _ := service.FindUserByToken(token); user != nil {
+			t.Error("Expected no user for a deactivated account")
+		}
+	})
+
+	t.Run("user feed", func(t *testing.T) {
+		user, _ := repository.NewUserRepository(db).FindByID(userID)
+		feed, err := service.UserFeed(user, now)
+		if err != nil {
+			t.Fatalf("UserFeed failed: %v", err)
+		}
+
+		scheduled := eventOf(feed, scheduledID)
+		for _, want := range []string{"SUMMARY:Gassi mit Bella\r\n", "LOCATION:Zwinger 1\r\n", `Route: Waldweg`, "STATUS:CONFIRMED\r\n"} {
+			if !strings.Contains(scheduled, want) {
+				t.Errorf("Expected scheduled booking to contain %q, got:\n%s", want, scheduled)
+			}
+		}
+		start := time.Date(2030, 1, 10, 9, 0, 0, 0, time.Local)
+		if !strings.Contains(scheduled, "DTEND:"+start.Add(45*time.Minute).UTC().Format(icalTimeFormat)) {
+			t.Errorf("Expected the event to last the dog's walk duration, got:\n%s", scheduled)
+		}
+
+		if event := eventOf(feed, cancelledID); !strings.Contains(event, "STATUS:CANCELLED\r\n") {
+			t.Errorf("Expected cancelled booking with STATUS:CANCELLED, got:\n%s", event)
+		}
+		if event := eventOf(feed, pendingID); !strings.Contains(event, "STATUS:TENTATIVE\r\n") {
+			t.Errorf("Expected pending booking with STATUS:TENTATIVE, got:\n%s", event)
+		}
+		if event := eventOf(feed, walkedID); !strings.Contains(event, "SUMMARY:Gassi mit Bella (erledigt)\r\n") {
+			t.Errorf("Expected completed walk to be marked as done, got:\n%s", event)
+		}
+		if event := eventOf(feed, noShowID); !strings.Contains(event, "STATUS:CANCELLED\r\n") ||
+			!strings.Contains(event, "SUMMARY:Gassi mit Max (nicht erschienen)\r\n") {
+			t.Errorf("Expected no-show with STATUS:CANCELLED, got:\n%s", event)
+		}
+		if eventOf(feed, oldID) != "" {
+			t.Error("Expected bookings older than the feed window to be left out")
+		}
+		if eventOf(feed, otherBookingID) != "" {
+			t.Error("Expected other users' bookings to be left out")
+		}
+	})
+
+	t.Run("admin feed names walkers and filters by dog", func(t *testing.T) {
+		feed, err := service.AdminFeed(nil, now)
+		if err != nil {
+			t.Fatalf("AdminFeed failed: %v", err)
+		}
+		if event := eventOf(feed, otherBookingID); !strings.Contains(event, "SUMMARY:Gassi mit Bella – Other") {
+			t.Errorf("Expected walker name in summary, got:\n%s", event)
+		}
+		if eventOf(feed, cancelledID) == "" {
+			t.Error("Expected bookings of all dogs")
+		}
+
+		feed, _ = service.AdminFeed(&bellaID, now)
+		if eventOf(feed, cancelledID) != "" || eventOf(feed, scheduledID) == "" {
+			t.Error("Expected only Bella's bookings")
+		}
+		if !strings.Contains(feed, "X-WR-CALNAME:Gassigeher – Bella") {
+			t.Error("Expected the calendar to be named after the dog")
+		}
+	})
+}
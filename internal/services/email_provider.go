@@ -1,5 +1,14 @@
 package services
 
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+)
+
 // EmailProvider defines the interface for email sending across different providers
 // Supports Gmail API, SMTP (Strato, Office365, etc.)
 type EmailProvider interface {
@@ -7,6 +16,10 @@ type EmailProvider interface {
 	// Automatically includes BCC if configured in the provider
 	SendEmail(to, subject, body string) error
 
+	// SendEmailWithAttachments sends an email with HTML body and file attachments
+	// as a multipart/mixed message
+	SendEmailWithAttachments(to, subject, body string, attachments []EmailAttachment) error
+
 	// ValidateConfig validates the provider configuration
 	ValidateConfig() error
 
@@ -17,6 +30,65 @@ type EmailProvider interface {
 	GetFromEmail() string
 }
 
+// EmailAttachment is a file attached to an email
+type EmailAttachment struct {
+	Filename    string
+	ContentType string // e.g. "text/calendar; charset=UTF-8; method=REQUEST"
+	Content     []byte
+}
+
+// buildMultipartBody renders an HTML body and attachments as a multipart/mixed body.
+// It returns the Content-Type header value (including the boundary) and the body.
+func buildMultipartBody(htmlBody string, attachments []EmailAttachment) (string, string, error) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	htmlPart, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/html; charset=UTF-8"},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create HTML part: %w", err)
+	}
+	if _, err := htmlPart.Write([]byte(encodeQuotedPrintable(htmlBody))); err != nil {
+		return "", "", fmt.Errorf("failed to write HTML part: %w", err)
+	}
+
+	for _, attachment := range attachments {
+		part, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {attachment.ContentType},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {fmt.Sprintf("attachment; filename=%q", attachment.Filename)},
+		})
+		if err != nil {
+			return "", "", fmt.Errorf("failed to create attachment part: %w", err)
+		}
+		if _, err := part.Write([]byte(wrapBase64(attachment.Content))); err != nil {
+			return "", "", fmt.Errorf("failed to write attachment %s: %w", attachment.Filename, err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return "", "", fmt.Errorf("failed to close multipart body: %w", err)
+	}
+
+	return "multipart/mixed; boundary=" + writer.Boundary(), buf.String(), nil
+}
+
+// wrapBase64 base64-encodes data in lines of 76 characters (RFC 2045)
+func wrapBase64(data []byte) string {
+	encoded := base64.StdEncoding.EncodeToString(data)
+
+	var result strings.Builder
+	for len(encoded) > 76 {
+		result.WriteString(encoded[:76])
+		result.WriteString("\r\n")
+		encoded = encoded[76:]
+	}
+	result.WriteString(encoded)
+	return result.String()
+}
+
 // EmailConfig holds configuration for all email providers
 type EmailConfig struct {
 	// Provider selection
@@ -68,6 +68,11 @@ func NewGmailProvider(config *EmailConfig) (EmailProvider, error) {
 
 // SendEmail sends an email via Gmail API
 func (p *GmailProvider) SendEmail(to, subject, body string) error {
+	return p.SendEmailWithAttachments(to, subject, body, nil)
+}
+
+// SendEmailWithAttachments sends an email with attachments via Gmail API
+func (p *GmailProvider) SendEmailWithAttachments(to, subject, body string, attachments []EmailAttachment) error {
 	var message gmail.Message
 
 	// Build email content with optional BCC
@@ -79,9 +84,20 @@ func (p *GmailProvider) SendEmail(to, subject, body string) error {
 		emailContent += fmt.Sprintf("Bcc: %s\r\n", p.bccAdmin)
 	}
 
-	emailContent += fmt.Sprintf("Subject: %s\r\n"+
-		"Content-Type: text/html; charset=UTF-8\r\n\r\n"+
-		"%s", subject, body)
+	if len(attachments) > 0 {
+		contentType, multipartBody, err := buildMultipartBody(body, attachments)
+		if err != nil {
+			return err
+		}
+		emailContent += fmt.Sprintf("Subject: %s\r\n"+
+			"MIME-Version: 1.0\r\n"+
+			"Content-Type: %s\r\n\r\n"+
+			"%s", subject, contentType, multipartBody)
+	} else {
+		emailContent += fmt.Sprintf("Subject: %s\r\n"+
+			"Content-Type: text/html; charset=UTF-8\r\n\r\n"+
+			"%s", subject, body)
+	}
 
 	// Encode message
 	message.Raw = base64.URLEncoding.EncodeToString([]byte(emailContent))
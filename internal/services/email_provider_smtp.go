@@ -49,6 +49,11 @@ func NewSMTPProvider(config *EmailConfig) (EmailProvider, error) {
 
 // SendEmail sends an email via SMTP
 func (p *SMTPProvider) SendEmail(to, subject, body string) error {
+	return p.SendEmailWithAttachments(to, subject, body, nil)
+}
+
+// SendEmailWithAttachments sends an email with attachments via SMTP
+func (p *SMTPProvider) SendEmailWithAttachments(to, subject, body string, attachments []EmailAttachment) error {
 	// Validate recipient email
 	if _, err := mail.ParseAddress(to); err != nil {
 		return fmt.Errorf("invalid recipient email address: %v", err)
@@ -61,7 +66,10 @@ func (p *SMTPProvider) SendEmail(to, subject, body string) error {
 	}
 
 	// Create MIME message with proper headers
-	message := p.buildMIMEMessage(to, subject, body)
+	message, err := p.buildMIMEMessage(to, subject, body, attachments...)
+	if err != nil {
+		return err
+	}
 
 	// Send email based on SSL/TLS configuration
 	if p.useSSL {
@@ -228,8 +236,9 @@ func (p *SMTPProvider) sendWithSTARTTLS(addr string, auth smtp.Auth, recipients
 	return client.Quit()
 }
 
-// buildMIMEMessage creates a properly formatted MIME email message
-func (p *SMTPProvider) buildMIMEMessage(to, subject, htmlBody string) []byte {
+// buildMIMEMessage creates a properly formatted MIME email message.
+// With attachments the message becomes multipart/mixed.
+func (p *SMTPProvider) buildMIMEMessage(to, subject, htmlBody string, attachments ...EmailAttachment) ([]byte, error) {
 	// Parse from address to get proper format
 	fromAddr, err := mail.ParseAddress(p.fromEmail)
 	if err != nil {
@@ -262,6 +271,18 @@ func (p *SMTPProvider) buildMIMEMessage(to, subject, htmlBody string) []byte {
 		headers["Bcc"] = bccAddr.String()
 	}
 
+	// Attachments turn the message into multipart/mixed with the HTML body as first part
+	body := encodeQuotedPrintable(htmlBody)
+	if len(attachments) > 0 {
+		contentType, multipartBody, err := buildMultipartBody(htmlBody, attachments)
+		if err != nil {
+			return nil, err
+		}
+		headers["Content-Type"] = contentType
+		delete(headers, "Content-Transfer-Encoding")
+		body = multipartBody
+	}
+
 	// Build message
 	var msg strings.Builder
 
@@ -274,9 +295,9 @@ func (p *SMTPProvider) buildMIMEMessage(to, subject, htmlBody string) []byte {
 	msg.WriteString("\r\n")
 
 	// Write body (quoted-printable encoded for UTF-8 support)
-	msg.WriteString(encodeQuotedPrintable(htmlBody))
+	msg.WriteString(body)
 
-	return []byte(msg.String())
+	return []byte(msg.String()), nil
 }
 
 // encodeRFC2047 encodes a string using RFC 2047 for email headers (supports UTF-8)
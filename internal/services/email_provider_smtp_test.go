@@ -1,6 +1,12 @@
 package services
 
 import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
 	"strings"
 	"testing"
 )
@@ -328,7 +334,10 @@ func TestBuildMIMEMessage(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			message := tt.provider.buildMIMEMessage(tt.to, tt.subject, tt.body)
+			message, err := tt.provider.buildMIMEMessage(tt.to, tt.subject, tt.body)
+			if err != nil {
+				t.Fatalf("buildMIMEMessage failed: %v", err)
+			}
 			messageStr := string(message)
 
 			// Check for required headers
@@ -360,6 +369,64 @@ func TestBuildMIMEMessage(t *testing.T) {
 	}
 }
 
+// TestBuildMIMEMessage_WithAttachments tests multipart/mixed messages
+func TestBuildMIMEMessage_WithAttachments(t *testing.T) {
+	provider := &SMTPProvider{fromEmail: "sender@example.com"}
+	ics := "BEGIN:VCALENDAR\r\nMETHOD:REQUEST\r\nEND:VCALENDAR\r\n"
+
+	message, err := provider.buildMIMEMessage("recipient@example.com", "Buchung", "<p>Grüße</p>", EmailAttachment{
+		Filename:    "gassi.ics",
+		ContentType: "text/calendar; charset=UTF-8; method=REQUEST",
+		Content:     []byte(ics),
+	})
+	if err != nil {
+		t.Fatalf("buildMIMEMessage failed: %v", err)
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewReader(message))
+	if err != nil {
+		t.Fatalf("Failed to parse message: %v", err)
+	}
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/mixed" {
+		t.Fatalf("Expected multipart/mixed, got %q (%v)", msg.Header.Get("Content-Type"), err)
+	}
+
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+
+	htmlPart, err := reader.NextPart()
+	if err != nil {
+		t.Fatalf("Missing HTML part: %v", err)
+	}
+	if htmlPart.Header.Get("Content-Type") != "text/html; charset=UTF-8" {
+		t.Errorf("Unexpected HTML part type %q", htmlPart.Header.Get("Content-Type"))
+	}
+	html, _ := io.ReadAll(htmlPart) // multipart.Reader decodes quoted-printable
+	if string(html) != "<p>Grüße</p>" {
+		t.Errorf("Unexpected HTML body %q", html)
+	}
+
+	icsPart, err := reader.NextPart()
+	if err != nil {
+		t.Fatalf("Missing attachment part: %v", err)
+	}
+	if icsPart.FileName() != "gassi.ics" {
+		t.Errorf("Expected filename gassi.ics, got %q", icsPart.FileName())
+	}
+	if icsPart.Header.Get("Content-Type") != "text/calendar; charset=UTF-8; method=REQUEST" {
+		t.Errorf("Unexpected attachment type %q", icsPart.Header.Get("Content-Type"))
+	}
+	encoded, _ := io.ReadAll(icsPart)
+	decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(string(encoded), "\r\n", ""))
+	if err != nil || string(decoded) != ics {
+		t.Errorf("Attachment content not preserved: %q (%v)", decoded, err)
+	}
+
+	if _, err := reader.NextPart(); err != io.EOF {
+		t.Errorf("Expected exactly two parts, got err %v", err)
+	}
+}
+
 // TestEncodeRFC2047 tests RFC 2047 header encoding
 func TestEncodeRFC2047(t *testing.T) {
 	tests := []struct {
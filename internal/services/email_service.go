@@ -5,6 +5,10 @@ import (
 	"fmt"
 	"html/template"
 	"log"
+	"net/mail"
+	"time"
+
+	"github.com/tranmh/gassigeher/internal/models"
 )
 
 // EmailService handles sending emails via any email provider
@@ -70,6 +74,40 @@ func (s *EmailService) SendEmail(to, subject, body string) error {
 	return s.provider.SendEmail(to, subject, body)
 }
 
+// SendEmailWithAttachments sends an email with attachments using the configured provider
+func (s *EmailService) SendEmailWithAttachments(to, subject, body string, attachments []EmailAttachment) error {
+	return s.provider.SendEmailWithAttachments(to, subject, body, attachments)
+}
+
+// icalSequenceEpoch is the reference point of invitation SEQUENCE numbers
+var icalSequenceEpoch = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// sendBookingEmail sends a booking email with the booking's calendar event attached
+// as invitation (method ICalMethodRequest or ICalMethodCancel). Without an event the
+// email is sent without attachment.
+func (s *EmailService) sendBookingEmail(to, subject, body, method string, invite *ICalEvent) error {
+	if invite == nil {
+		return s.SendEmail(to, subject, body)
+	}
+
+	event := *invite
+	event.Attendee = to
+	event.Organizer = s.provider.GetFromEmail()
+	if addr, err := mail.ParseAddress(event.Organizer); err == nil {
+		event.Organizer = addr.Address
+	}
+	// Bookings carry no revision counter; seconds since a fixed epoch always
+	// increase, so a later email wins over an earlier one for the same UID
+	event.Sequence = int(time.Since(icalSequenceEpoch) / time.Second)
+
+	attachment := EmailAttachment{
+		Filename:    "gassi.ics",
+		ContentType: "text/calendar; charset=UTF-8; method=" + method,
+		Content:     []byte(BuildICalendarInvite(method, event)),
+	}
+	return s.SendEmailWithAttachments(to, subject, body, []EmailAttachment{attachment})
+}
+
 // SendVerificationEmail sends an email verification link
 func (s *EmailService) SendVerificationEmail(to, name, token string) error {
 	subject := "Willkommen bei Gassigeher - E-Mail-Adresse bestätigen"
@@ -328,8 +366,8 @@ func (s *EmailService) SendPasswordResetEmail(to, name, token string) error {
 	return s.SendEmail(to, subject, body.String())
 }
 
-// SendBookingConfirmation sends a booking confirmation email
-func (s *EmailService) SendBookingConfirmation(to, name, dogName, date, scheduledTime string) error {
+// SendBookingConfirmation sends a booking confirmation email; invite (optional) is attached as calendar invitation
+func (s *EmailService) SendBookingConfirmation(to, name, dogName, date, scheduledTime string, invite *ICalEvent) error {
 	subject := fmt.Sprintf("Buchungsbestätigung - %s", dogName)
 
 	tmpl := `
@@ -393,11 +431,12 @@ func (s *EmailService) SendBookingConfirmation(to, name, dogName, date, schedule
 		return fmt.Errorf("failed to execute template: %w", err)
 	}
 
-	return s.SendEmail(to, subject, body.String())
+	return s.sendBookingEmail(to, subject, body.String(), ICalMethodRequest, invite)
 }
 
-// SendBookingCancellation sends a booking cancellation confirmation (user-initiated)
-func (s *EmailService) SendBookingCancellation(to, name, dogName, date, scheduledTime string) error {
+// SendBookingCancellation sends a booking cancellation confirmation (user-initiated);
+// invite (optional) is attached as calendar cancellation
+func (s *EmailService) SendBookingCancellation(to, name, dogName, date, scheduledTime string, invite *ICalEvent) error {
 	subject := fmt.Sprintf("Buchung storniert - %s", dogName)
 
 	tmpl := `
@@ -460,11 +499,12 @@ func (s *EmailService) SendBookingCancellation(to, name, dogName, date, schedule
 		return fmt.Errorf("failed to execute template: %w", err)
 	}
 
-	return s.SendEmail(to, subject, body.String())
+	return s.sendBookingEmail(to, subject, body.String(), ICalMethodCancel, invite)
 }
 
-// SendAdminCancellation sends an admin cancellation notification
-func (s *EmailService) SendAdminCancellation(to, name, dogName, date, scheduledTime, reason string) error {
+// SendAdminCancellation sends an admin cancellation notification; invite is the booking's
+// calendar event, attached as cancellation so it is removed from the walker's calendar
+func (s *EmailService) SendAdminCancellation(to, name, dogName, date, scheduledTime, reason string, invite *ICalEvent) error {
 	subject := fmt.Sprintf("Deine Buchung wurde storniert - %s", dogName)
 
 	tmpl := `
@@ -534,11 +574,12 @@ func (s *EmailService) SendAdminCancellation(to, name, dogName, date, scheduledT
 		return fmt.Errorf("failed to execute template: %w", err)
 	}
 
-	return s.SendEmail(to, subject, body.String())
+	return s.sendBookingEmail(to, subject, body.String(), ICalMethodCancel, invite)
 }
 
-// SendBookingReminder sends a reminder 1 hour before the booking
-func (s *EmailService) SendBookingReminder(to, name, dogName, date, scheduledTime string) error {
+// SendBookingReminder sends a reminder 1 hour before the booking; medications lists the
+// doses the walker has to give during the walk
+func (s *EmailService) SendBookingReminder(to, name, dogName, date, scheduledTime string, medications []models.MedicationDose) error {
 	subject := fmt.Sprintf("Erinnerung: Gassirunde mit %s in 1 Stunde", dogName)
 
 	tmpl := `
@@ -554,6 +595,7 @@ func (s *EmailService) SendBookingReminder(to, name, dogName, date, scheduledTim
         .booking-details { background-color: white; padding: 20px; margin: 20px 0; border-radius: 6px; border-left: 4px solid #17a2b8; }
         .detail-row { margin: 10px 0; }
         .label { font-weight: 600; color: #666; }
+        .medication { background-color: #fff3cd; padding: 15px 20px; margin: 20px 0; border-radius: 6px; border-left: 4px solid #ffc107; }
         .footer { text-align: center; margin-top: 20px; color: #666; font-size: 12px; }
     </style>
 </head>
@@ -579,6 +621,18 @@ func (s *EmailService) SendBookingReminder(to, name, dogName, date, scheduledTim
                 </div>
             </div>
 
+            {{if .Medications}}
+            <div class="medication">
+                <h3 style="margin-top: 0;">💊 Medikamente während des Spaziergangs</h3>
+                {{range .Medications}}
+                <div class="detail-row">
+                    <span class="label">{{.Time}} Uhr:</span> {{.Title}}{{if .Dosage}} ({{.Dosage}}){{end}}
+                    {{if .Notes}}<br><small>{{.Notes}}</small>{{end}}
+                </div>
+                {{end}}
+            </div>
+            {{end}}
+
             <p>Viel Spaß beim Spaziergang!</p>
         </div>
         <div class="footer">
@@ -591,11 +645,12 @@ func (s *EmailService) SendBookingReminder(to, name, dogName, date, scheduledTim
 
 	t := template.Must(template.New("reminder").Parse(tmpl))
 	var body bytes.Buffer
-	data := map[string]string{
+	data := map[string]interface{}{
 		"Name":          name,
 		"DogName":       dogName,
 		"Date":          date,
 		"ScheduledTime": scheduledTime,
+		"Medications":   medications,
 	}
 	if err := t.Execute(&body, data); err != nil {
 		return fmt.Errorf("failed to execute template: %w", err)
@@ -604,8 +659,9 @@ func (s *EmailService) SendBookingReminder(to, name, dogName, date, scheduledTim
 	return s.SendEmail(to, subject, body.String())
 }
 
-// SendBookingMoved sends an email when admin moves a booking
-func (s *EmailService) SendBookingMoved(to, name, dogName, oldDate, oldTime, newDate, newTime, reason string) error {
+// SendBookingMoved sends an email when admin moves a booking; invite (optional) carries
+// the new time and updates the existing calendar event
+func (s *EmailService) SendBookingMoved(to, name, dogName, oldDate, oldTime, newDate, newTime, reason string, invite *ICalEvent) error {
 	subject := fmt.Sprintf("Deine Buchung wurde verschoben - %s", dogName)
 
 	tmpl := `
@@ -692,7 +748,7 @@ func (s *EmailService) SendBookingMoved(to, name, dogName, oldDate, oldTime, new
 		return fmt.Errorf("failed to execute template: %w", err)
 	}
 
-	return s.SendEmail(to, subject, body.String())
+	return s.sendBookingEmail(to, subject, body.String(), ICalMethodRequest, invite)
 }
 
 // SendBookingApproved sends a notification when a pending booking is approved by admin
@@ -1072,3 +1128,343 @@ func (s *EmailService) SendExperienceLevelDenied(to, name, level string, message
 
 	return s.SendEmail(to, subject, body.String())
 }
+
+// SendWaitlistSlotAvailable notifies the first waitlisted user that a slot is free and reserved for them
+func (s *EmailService) SendWaitlistSlotAvailable(to, name, dogName, date, scheduledTime, claimUntil string) error {
+	subject := fmt.Sprintf("Termin frei geworden - %s", dogName)
+
+	tmpl := `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <style>
+        body { font-family: Arial, sans-serif; line-height: 1.6; color: #26272b; }
+        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
+        .header { background-color: #82b965; color: white; padding: 20px; text-align: center; border-radius: 6px 6px 0 0; }
+        .content { background-color: #f9f9f9; padding: 30px; border-radius: 0 0 6px 6px; }
+        .booking-details { background-color: white; padding: 20px; margin: 20px 0; border-radius: 6px; border-left: 4px solid #82b965; }
+        .detail-row { margin: 10px 0; }
+        .label { font-weight: 600; color: #666; }
+        .footer { text-align: center; margin-top: 20px; color: #666; font-size: 12px; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>Ein Termin ist frei geworden</h1>
+        </div>
+        <div class="content">
+            <p>Hallo {{.Name}},</p>
+            <p>der Termin, auf dessen Warteliste Sie stehen, ist wieder frei. Wir haben ihn für Sie reserviert.</p>
+
+            <div class="booking-details">
+                <h3 style="margin-top: 0;">Reservierter Termin</h3>
+                <div class="detail-row">
+                    <span class="label">Hund:</span> {{.DogName}}
+                </div>
+                <div class="detail-row">
+                    <span class="label">Datum:</span> {{.Date}}
+                </div>
+                <div class="detail-row">
+                    <span class="label">Uhrzeit:</span> {{.ScheduledTime}} Uhr
+                </div>
+            </div>
+
+            <p><strong>Bitte buchen Sie den Termin bis {{.ClaimUntil}} Uhr.</strong> Danach geht das Angebot an die nächste Person auf der Warteliste.</p>
+        </div>
+        <div class="footer">
+            <p>© 2025 Gassigeher. Alle Rechte vorbehalten.</p>
+        </div>
+    </div>
+</body>
+</html>
+`
+
+	t := template.Must(template.New("waitlist_offer").Parse(tmpl))
+	var body bytes.Buffer
+	data := map[string]string{
+		"Name":          name,
+		"DogName":       dogName,
+		"Date":          date,
+		"ScheduledTime": scheduledTime,
+		"ClaimUntil":    claimUntil,
+	}
+	if err := t.Execute(&body, data); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	return s.SendEmail(to, subject, body.String())
+}
+
+// SendStrikeNotification warns a user about their strikes, or tells them that booking is
+// restricted until restrictedUntil (pass an empty restrictedUntil for a warning)
+func (s *EmailService) SendStrikeNotification(to, name string, strikeCount, windowDays int, restrictedUntil string) error {
+	subject := "Verwarnung - Nicht angetretene oder kurzfristig stornierte Spaziergänge"
+	if restrictedUntil != "" {
+		subject = "Buchungssperre - Nicht angetretene oder kurzfristig stornierte Spaziergänge"
+	}
+
+	tmpl := `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <style>
+        body { font-family: Arial, sans-serif; line-height: 1.6; color: #26272b; }
+        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
+        .header { background-color: #ffc107; color: #26272b; padding: 20px; text-align: center; border-radius: 6px 6px 0 0; }
+        .header.restricted { background-color: #dc3545; color: white; }
+        .content { background-color: #f9f9f9; padding: 30px; border-radius: 0 0 6px 6px; }
+        .footer { text-align: center; margin-top: 20px; color: #666; font-size: 12px; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        {{if .RestrictedUntil}}
+        <div class="header restricted">
+            <h1>Buchungssperre</h1>
+        </div>
+        {{else}}
+        <div class="header">
+            <h1>Verwarnung</h1>
+        </div>
+        {{end}}
+        <div class="content">
+            <p>Hallo {{.Name}},</p>
+            <p>in den letzten {{.WindowDays}} Tagen wurden {{.StrikeCount}} Spaziergänge nicht angetreten oder weniger als die vorgeschriebene Frist vor Beginn storniert.</p>
+            {{if .RestrictedUntil}}
+            <p><strong>Sie können bis zum {{.RestrictedUntil}} keine neuen Buchungen vornehmen.</strong> Bestehende Buchungen bleiben erhalten.</p>
+            {{else}}
+            <p>Bitte stornieren Sie Buchungen rechtzeitig, wenn Sie einen Termin nicht wahrnehmen können. Bei weiteren Verstößen wird Ihr Konto vorübergehend für neue Buchungen gesperrt.</p>
+            {{end}}
+            <p>Wenn Sie glauben, dass es sich um einen Fehler handelt, wenden Sie sich bitte an das Tierheim.</p>
+        </div>
+        <div class="footer">
+            <p>© 2025 Gassigeher. Alle Rechte vorbehalten.</p>
+        </div>
+    </div>
+</body>
+</html>
+`
+
+	t := template.Must(template.New("strike_notification").Parse(tmpl))
+	var body bytes.Buffer
+	data := map[string]interface{}{
+		"Name":            name,
+		"StrikeCount":     strikeCount,
+		"WindowDays":      windowDays,
+		"RestrictedUntil": restrictedUntil,
+	}
+	if err := t.Execute(&body, data); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	return s.SendEmail(to, subject, body.String())
+}
+
+// SendBookingHandedOver tells the previous walker that their booking was taken over;
+// invite is attached as cancellation so the walk leaves their calendar
+func (s *EmailService) SendBookingHandedOver(to, name, dogName, date, scheduledTime, newWalkerName string, invite *ICalEvent) error {
+	subject := fmt.Sprintf("Spaziergang übergeben - %s", dogName)
+
+	tmpl := `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <style>
+        body { font-family: Arial, sans-serif; line-height: 1.6; color: #26272b; }
+        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
+        .header { background-color: #82b965; color: white; padding: 20px; text-align: center; border-radius: 6px 6px 0 0; }
+        .content { background-color: #f9f9f9; padding: 30px; border-radius: 0 0 6px 6px; }
+        .booking-details { background-color: white; padding: 20px; margin: 20px 0; border-radius: 6px; border-left: 4px solid #82b965; }
+        .detail-row { margin: 10px 0; }
+        .label { font-weight: 600; color: #666; }
+        .footer { text-align: center; margin-top: 20px; color: #666; font-size: 12px; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>Spaziergang übergeben</h1>
+        </div>
+        <div class="content">
+            <p>Hallo {{.Name}},</p>
+            <p>{{.NewWalkerName}} hat Ihren Spaziergang übernommen. Die Buchung ist nicht mehr in Ihrer Liste.</p>
+
+            <div class="booking-details">
+                <h3 style="margin-top: 0;">Übergebene Buchung</h3>
+                <div class="detail-row">
+                    <span class="label">Hund:</span> {{.DogName}}
+                </div>
+                <div class="detail-row">
+                    <span class="label">Datum:</span> {{.Date}}
+                </div>
+                <div class="detail-row">
+                    <span class="label">Uhrzeit:</span> {{.ScheduledTime}} Uhr
+                </div>
+            </div>
+
+            <p>Vielen Dank, dass Sie den Spaziergang weitergegeben haben, statt ihn zu stornieren.</p>
+        </div>
+        <div class="footer">
+            <p>© 2025 Gassigeher. Alle Rechte vorbehalten.</p>
+        </div>
+    </div>
+</body>
+</html>
+`
+
+	t := template.Must(template.New("handedOver").Parse(tmpl))
+	var body bytes.Buffer
+	data := map[string]string{
+		"Name":          name,
+		"DogName":       dogName,
+		"Date":          date,
+		"ScheduledTime": scheduledTime,
+		"NewWalkerName": newWalkerName,
+	}
+	if err := t.Execute(&body, data); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	return s.sendBookingEmail(to, subject, body.String(), ICalMethodCancel, invite)
+}
+
+// SendBookingTakenOver confirms a booking to the walker who took it over;
+// invite is attached as invitation so the walk enters their calendar
+func (s *EmailService) SendBookingTakenOver(to, name, dogName, date, scheduledTime, previousWalkerName string, invite *ICalEvent) error {
+	subject := fmt.Sprintf("Spaziergang übernommen - %s", dogName)
+
+	tmpl := `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <style>
+        body { font-family: Arial, sans-serif; line-height: 1.6; color: #26272b; }
+        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
+        .header { background-color: #82b965; color: white; padding: 20px; text-align: center; border-radius: 6px 6px 0 0; }
+        .content { background-color: #f9f9f9; padding: 30px; border-radius: 0 0 6px 6px; }
+        .booking-details { background-color: white; padding: 20px; margin: 20px 0; border-radius: 6px; border-left: 4px solid #82b965; }
+        .detail-row { margin: 10px 0; }
+        .label { font-weight: 600; color: #666; }
+        .footer { text-align: center; margin-top: 20px; color: #666; font-size: 12px; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>Spaziergang übernommen</h1>
+        </div>
+        <div class="content">
+            <p>Hallo {{.Name}},</p>
+            <p>Sie haben den Spaziergang von {{.PreviousWalkerName}} übernommen. Die Buchung gehört jetzt Ihnen.</p>
+
+            <div class="booking-details">
+                <h3 style="margin-top: 0;">Ihre Buchung</h3>
+                <div class="detail-row">
+                    <span class="label">Hund:</span> {{.DogName}}
+                </div>
+                <div class="detail-row">
+                    <span class="label">Datum:</span> {{.Date}}
+                </div>
+                <div class="detail-row">
+                    <span class="label">Uhrzeit:</span> {{.ScheduledTime}} Uhr
+                </div>
+            </div>
+
+            <p>Bitte seien Sie pünktlich im Tierheim.</p>
+        </div>
+        <div class="footer">
+            <p>© 2025 Gassigeher. Alle Rechte vorbehalten.</p>
+        </div>
+    </div>
+</body>
+</html>
+`
+
+	t := template.Must(template.New("takenOver").Parse(tmpl))
+	var body bytes.Buffer
+	data := map[string]string{
+		"Name":               name,
+		"DogName":            dogName,
+		"Date":               date,
+		"ScheduledTime":      scheduledTime,
+		"PreviousWalkerName": previousWalkerName,
+	}
+	if err := t.Execute(&body, data); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	return s.sendBookingEmail(to, subject, body.String(), ICalMethodRequest, invite)
+}
+
+// SendUnderWalkedDogsDigest tells an admin which dogs got fewer walks in the last 7 days than
+// their weekly target
+func (s *EmailService) SendUnderWalkedDogsDigest(to, name string, dogs []*models.UnderWalkedDog) error {
+	subject := fmt.Sprintf("Gassi-Übersicht: %d Hund(e) brauchen Aufmerksamkeit", len(dogs))
+
+	tmpl := `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <style>
+        body { font-family: Arial, sans-serif; line-height: 1.6; color: #26272b; }
+        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
+        .header { background-color: #ffc107; color: #26272b; padding: 20px; text-align: center; border-radius: 6px 6px 0 0; }
+        .content { background-color: #f9f9f9; padding: 30px; border-radius: 0 0 6px 6px; }
+        table { width: 100%; border-collapse: collapse; background-color: white; margin: 20px 0; }
+        th, td { padding: 8px 10px; text-align: left; border-bottom: 1px solid #eee; }
+        th { color: #666; }
+        .footer { text-align: center; margin-top: 20px; color: #666; font-size: 12px; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>🐕 Hunde mit zu wenig Spaziergängen</h1>
+        </div>
+        <div class="content">
+            <p>Hallo {{.Name}},</p>
+            <p>folgende Hunde wurden in den letzten 7 Tagen seltener ausgeführt als vorgesehen:</p>
+
+            <table>
+                <tr>
+                    <th>Hund</th>
+                    <th>Spaziergänge / Ziel</th>
+                    <th>Letzter Spaziergang</th>
+                </tr>
+                {{range .Dogs}}
+                <tr>
+                    <td>{{.DogName}}</td>
+                    <td>{{.WalksLast7Days}} / {{.TargetPerWeek}}</td>
+                    <td>{{if .DaysSinceLastWalk}}vor {{.DaysSinceLastWalk}} Tag(en){{else}}noch nie{{end}}</td>
+                </tr>
+                {{end}}
+            </table>
+
+            <p>Vielleicht können Sie gezielt Gassigeher für diese Hunde ansprechen.</p>
+        </div>
+        <div class="footer">
+            <p>© 2025 Gassigeher. Alle Rechte vorbehalten.</p>
+        </div>
+    </div>
+</body>
+</html>
+`
+
+	t := template.Must(template.New("under_walked_digest").Parse(tmpl))
+	var body bytes.Buffer
+	data := map[string]interface{}{
+		"Name": name,
+		"Dogs": dogs,
+	}
+	if err := t.Execute(&body, data); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	return s.SendEmail(to, subject, body.String())
+}
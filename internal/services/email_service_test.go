@@ -3,6 +3,9 @@ package services
 import (
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/tranmh/gassigeher/internal/models"
 )
 
 // DONE: TestEmailService_VerificationEmail tests verification email formatting
@@ -201,3 +204,146 @@ func TestEmailService_NewUserRegistrationNotification(t *testing.T) {
 // Note: Full EmailService testing requires Gmail API credentials or mocking
 // These tests validate email formatting logic and required parameters
 // Integration/E2E tests should verify actual email delivery in staging environment
+
+// recordingProvider is an EmailProvider that records sent emails
+type recordingProvider struct {
+	bodies      []string
+	attachments [][]EmailAttachment
+}
+
+func (p *recordingProvider) SendEmail(to, subject, body string) error {
+	return p.SendEmailWithAttachments(to, subject, body, nil)
+}
+
+func (p *recordingProvider) SendEmailWithAttachments(to, subject, body string, attachments []EmailAttachment) error {
+	p.bodies = append(p.bodies, body)
+	p.attachments = append(p.attachments, attachments)
+	return nil
+}
+
+func (p *recordingProvider) ValidateConfig() error { return nil }
+func (p *recordingProvider) Close() error          { return nil }
+func (p *recordingProvider) GetFromEmail() string  { return "Gassigeher <noreply@example.com>" }
+
+// TestEmailService_BookingInvites tests the calendar attachments of booking emails
+func TestEmailService_BookingInvites(t *testing.T) {
+	start := time.Date(2030, 1, 9, 9, 0, 0, 0, time.UTC)
+	invite := &ICalEvent{UID: "booking-7@gassigeher", Start: start, End: start.Add(time.Hour), Summary: "Gassi mit Bella"}
+
+	tests := []struct {
+		name   string
+		send   func(s *EmailService) error
+		method string
+	}{
+		{"confirmation", func(s *EmailService) error {
+			return s.SendBookingConfirmation("walker@example.com", "Anna", "Bella", "2030-01-09", "09:00", invite)
+		}, ICalMethodRequest},
+		{"moved", func(s *EmailService) error {
+			return s.SendBookingMoved("walker@example.com", "Anna", "Bella", "2030-01-08", "09:00", "2030-01-09", "09:00", "Tierarzt", invite)
+		}, ICalMethodRequest},
+		{"cancellation", func(s *EmailService) error {
+			return s.SendBookingCancellation("walker@example.com", "Anna", "Bella", "2030-01-09", "09:00", invite)
+		}, ICalMethodCancel},
+		{"admin cancellation", func(s *EmailService) error {
+			return s.SendAdminCancellation("walker@example.com", "Anna", "Bella", "2030-01-09", "09:00", "Tierarzt", invite)
+		}, ICalMethodCancel},
+		{"handed over", func(s *EmailService) error {
+			return s.SendBookingHandedOver("walker@example.com", "Anna", "Bella", "2030-01-09", "09:00", "Tom Taker", invite)
+		}, ICalMethodCancel},
+		{"taken over", func(s *EmailService) error {
+			return s.SendBookingTakenOver("taker@example.com", "Tom", "Bella", "2030-01-09", "09:00", "Anna", invite)
+		}, ICalMethodRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &recordingProvider{}
+			if err := tt.send(&EmailService{provider: provider}); err != nil {
+				t.Fatalf("Send failed: %v", err)
+			}
+
+			if len(provider.attachments) != 1 || len(provider.attachments[0]) != 1 {
+				t.Fatalf("Expected one email with one attachment, got %v", provider.attachments)
+			}
+			attachment := provider.attachments[0][0]
+			if attachment.ContentType != "text/calendar; charset=UTF-8; method="+tt.method {
+				t.Errorf("Unexpected content type %q", attachment.ContentType)
+			}
+			ics := string(attachment.Content)
+			for _, want := range []string{
+				"METHOD:" + tt.method + "\r\n",
+				"UID:booking-7@gassigeher\r\n",
+				"ORGANIZER:mailto:noreply@example.com\r\n",
+			} {
+				if !strings.Contains(ics, want) {
+					t.Errorf("Expected attachment to contain %q, got:\n%s", want, ics)
+				}
+			}
+		})
+	}
+
+	t.Run("without invite", func(t *testing.T) {
+		provider := &recordingProvider{}
+		s := &EmailService{provider: provider}
+		if err := s.SendBookingConfirmation("walker@example.com", "Anna", "Bella", "2030-01-09", "09:00", nil); err != nil {
+			t.Fatalf("Send failed: %v", err)
+		}
+		if len(provider.attachments) != 1 || len(provider.attachments[0]) != 0 {
+			t.Errorf("Expected one email without attachments, got %v", provider.attachments)
+		}
+	})
+}
+
+// TestEmailService_BookingReminderMedication tests the medication section of the reminder
+func TestEmailService_BookingReminderMedication(t *testing.T) {
+	dosage := "1 Tablette"
+	provider := &recordingProvider{}
+	s := &EmailService{provider: provider}
+
+	err := s.SendBookingReminder("walker@example.com", "Anna", "Bella", "09.01.2030", "09:00", []models.MedicationDose{
+		{EntryID: 1, Title: "Rimadyl", Dosage: &dosage, Time: "09:30"},
+	})
+	if err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if err := s.SendBookingReminder("walker@example.com", "Anna", "Bella", "09.01.2030", "09:00", nil); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	if len(provider.bodies) != 2 {
+		t.Fatalf("Expected 2 emails, got %d", len(provider.bodies))
+	}
+	for _, want := range []string{"Medikamente während des Spaziergangs", "09:30 Uhr:", "Rimadyl (1 Tablette)"} {
+		if !strings.Contains(provider.bodies[0], want) {
+			t.Errorf("Expected reminder to contain %q", want)
+		}
+	}
+	if strings.Contains(provider.bodies[1], "Medikamente") {
+		t.Error("Expected no medication section without doses")
+	}
+}
+
+// TestEmailService_UnderWalkedDogsDigest tests the dog rows of the walk target digest
+func TestEmailService_UnderWalkedDogsDigest(t *testing.T) {
+	lastWalk := "2030-01-03"
+	days := 7
+	provider := &recordingProvider{}
+	s := &EmailService{provider: provider}
+
+	err := s.SendUnderWalkedDogsDigest("admin@example.com", "Anna", []*models.UnderWalkedDog{
+		{DogID: 1, DogName: "Rex", TargetPerWeek: 3},
+		{DogID: 2, DogName: "Max", TargetPerWeek: 2, WalksLast7Days: 1, LastWalkDate: &lastWalk, DaysSinceLastWalk: &days},
+	})
+	if err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	if len(provider.bodies) != 1 {
+		t.Fatalf("Expected 1 email, got %d", len(provider.bodies))
+	}
+	for _, want := range []string{"Hallo Anna", "Rex", "0 / 3", "noch nie", "Max", "1 / 2", "vor 7 Tag(en)"} {
+		if !strings.Contains(provider.bodies[0], want) {
+			t.Errorf("Expected digest to contain %q", want)
+		}
+	}
+}
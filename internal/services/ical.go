@@ -0,0 +1,134 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// iCalendar event statuses (RFC 5545)
+const (
+	ICalStatusConfirmed = "CONFIRMED"
+	ICalStatusTentative = "TENTATIVE"
+	ICalStatusCancelled = "CANCELLED"
+)
+
+// iTIP methods (RFC 5546): feeds are published, email invitations request or cancel
+const (
+	ICalMethodPublish = "PUBLISH"
+	ICalMethodRequest = "REQUEST"
+	ICalMethodCancel  = "CANCEL"
+)
+
+const icalTimeFormat = "20060102T150405Z"
+
+// ICalEvent is one VEVENT of an iCalendar document
+type ICalEvent struct {
+	UID          string
+	Start        time.Time
+	End          time.Time
+	Summary      string
+	Description  string
+	Location     string
+	Status       string // ICalStatusConfirmed, ICalStatusTentative or ICalStatusCancelled
+	LastModified time.Time
+
+	// Only used by invitations (BuildICalendarInvite)
+	Sequence  int
+	Organizer string // email address
+	Attendee  string // email address
+}
+
+// BuildICalendar renders events as an iCalendar (RFC 5545) document named calendarName.
+// Times are written in UTC; lines are CRLF-terminated and folded at 75 octets.
+func BuildICalendar(calendarName string, events []ICalEvent) string {
+	return buildICalendar(ICalMethodPublish, calendarName, events)
+}
+
+// BuildICalendarInvite renders a single event as an email invitation with the given
+// iTIP method (ICalMethodRequest or ICalMethodCancel). Clients match updates and
+// cancellations to the original invitation by UID and take the highest SEQUENCE.
+func BuildICalendarInvite(method string, event ICalEvent) string {
+	if method == ICalMethodCancel {
+		event.Status = ICalStatusCancelled
+	}
+	return buildICalendar(method, "", []ICalEvent{event})
+}
+
+func buildICalendar(method, calendarName string, events []ICalEvent) string {
+	var b strings.Builder
+	writeICalLine(&b, "BEGIN:VCALENDAR")
+	writeICalLine(&b, "VERSION:2.0")
+	writeICalLine(&b, "PRODID:-//Gassigeher//Gassigeher//DE")
+	writeICalLine(&b, "CALSCALE:GREGORIAN")
+	writeICalLine(&b, "METHOD:"+method)
+	if calendarName != "" {
+		writeICalLine(&b, "X-WR-CALNAME:"+escapeICalText(calendarName))
+	}
+
+	now := time.Now()
+	for _, event := range events {
+		stamp := event.LastModified
+		if stamp.IsZero() {
+			stamp = now
+		}
+
+		writeICalLine(&b, "BEGIN:VEVENT")
+		writeICalLine(&b, "UID:"+event.UID)
+		writeICalLine(&b, "DTSTAMP:"+stamp.UTC().Format(icalTimeFormat))
+		writeICalLine(&b, "DTSTART:"+event.Start.UTC().Format(icalTimeFormat))
+		writeICalLine(&b, "DTEND:"+event.End.UTC().Format(icalTimeFormat))
+		writeICalLine(&b, "SUMMARY:"+escapeICalText(event.Summary))
+		if event.Location != "" {
+			writeICalLine(&b, "LOCATION:"+escapeICalText(event.Location))
+		}
+		if event.Description != "" {
+			writeICalLine(&b, "DESCRIPTION:"+escapeICalText(event.Description))
+		}
+		if event.Status != "" {
+			writeICalLine(&b, "STATUS:"+event.Status)
+		}
+		writeICalLine(&b, "LAST-MODIFIED:"+stamp.UTC().Format(icalTimeFormat))
+		if method != ICalMethodPublish {
+			writeICalLine(&b, fmt.Sprintf("SEQUENCE:%d", event.Sequence))
+			if event.Organizer != "" {
+				writeICalLine(&b, "ORGANIZER:mailto:"+event.Organizer)
+			}
+			if event.Attendee != "" {
+				writeICalLine(&b, "ATTENDEE;ROLE=REQ-PARTICIPANT;PARTSTAT=ACCEPTED;RSVP=FALSE:mailto:"+event.Attendee)
+			}
+		}
+		writeICalLine(&b, "END:VEVENT")
+	}
+
+	writeICalLine(&b, "END:VCALENDAR")
+	return b.String()
+}
+
+// escapeICalText escapes a TEXT property value
+func escapeICalText(value string) string {
+	value = strings.ReplaceAll(value, "\\", "\\\\")
+	value = strings.ReplaceAll(value, ";", "\\;")
+	value = strings.ReplaceAll(value, ",", "\\,")
+	value = strings.ReplaceAll(value, "\r\n", "\\n")
+	value = strings.ReplaceAll(value, "\n", "\\n")
+	return strings.ReplaceAll(value, "\r", "\\n")
+}
+
+// writeICalLine writes a content line, folding it so no line exceeds 75 octets
+// without splitting UTF-8 characters
+func writeICalLine(b *strings.Builder, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		fmt.Fprintf(b, "%s\r\n ", line[:cut])
+		line = line[cut:]
+		limit = 74 // continuation lines start with a space
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+// TestBuildICalendar tests the iCalendar output format
+func TestBuildICalendar(t *testing.T) {
+	start := time.Date(2030, 1, 9, 9, 0, 0, 0, time.UTC)
+	feed := BuildICalendar("Test", []ICalEvent{{
+		UID:         "booking-1@gassigeher",
+		Start:       start,
+		End:         start.Add(time.Hour),
+		Summary:     "Gassi mit Bella; Max, Rex",
+		Description: "Route: Waldweg\nAbholort: Zwinger 1\\A",
+		Location:    "Zwinger 1",
+		Status:      ICalStatusCancelled,
+	}})
+
+	for _, line := range strings.Split(strings.TrimSuffix(feed, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("Line longer than 75 octets: %q", line)
+		}
+	}
+
+	unfolded := strings.ReplaceAll(feed, "\r\n ", "")
+	expected := []string{
+		"BEGIN:VCALENDAR\r\n",
+		"X-WR-CALNAME:Test\r\n",
+		"DTSTART:20300109T090000Z\r\n",
+		"DTEND:20300109T100000Z\r\n",
+		`SUMMARY:Gassi mit Bella\; Max\, Rex` + "\r\n",
+		`DESCRIPTION:Route: Waldweg\nAbholort: Zwinger 1\\A` + "\r\n",
+		"LOCATION:Zwinger 1\r\n",
+		"STATUS:CANCELLED\r\n",
+		"END:VCALENDAR\r\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(unfolded, want) {
+			t.Errorf("Expected feed to contain %q, got:\n%s", want, unfolded)
+		}
+	}
+}
+
+// TestWriteICalLine_FoldsOnCharacterBoundaries tests that folding never splits UTF-8 characters
+func TestWriteICalLine_FoldsOnCharacterBoundaries(t *testing.T) {
+	var b strings.Builder
+	line := "SUMMARY:" + strings.Repeat("ä", 100)
+	writeICalLine(&b, line)
+
+	folded := b.String()
+	for _, part := range strings.Split(strings.TrimSuffix(folded, "\r\n"), "\r\n") {
+		if len(part) > 75 {
+			t.Errorf("Line longer than 75 octets: %d", len(part))
+		}
+		if !utf8.ValidString(part) {
+			t.Errorf("Line split inside a character: %q", part)
+		}
+	}
+	if unfolded := strings.ReplaceAll(strings.TrimSuffix(folded, "\r\n"), "\r\n ", ""); unfolded != line {
+		t.Errorf("Unfolding did not restore the line")
+	}
+}
+
+// TestBuildICalendarInvite tests REQUEST and CANCEL invitations
+func TestBuildICalendarInvite(t *testing.T) {
+	start := time.Date(2030, 1, 9, 9, 0, 0, 0, time.UTC)
+	event := ICalEvent{
+		UID:       "booking-7@gassigeher",
+		Start:     start,
+		End:       start.Add(time.Hour),
+		Summary:   "Gassi mit Bella",
+		Status:    ICalStatusConfirmed,
+		Sequence:  42,
+		Organizer: "noreply@example.com",
+		Attendee:  "walker@example.com",
+	}
+
+	t.Run("request", func(t *testing.T) {
+		invite := strings.ReplaceAll(BuildICalendarInvite(ICalMethodRequest, event), "\r\n ", "")
+		for _, want := range []string{
+			"METHOD:REQUEST\r\n",
+			"UID:booking-7@gassigeher\r\n",
+			"SEQUENCE:42\r\n",
+			"STATUS:CONFIRMED\r\n",
+			"ORGANIZER:mailto:noreply@example.com\r\n",
+			"mailto:walker@example.com\r\n",
+		} {
+			if !strings.Contains(invite, want) {
+				t.Errorf("Expected invite to contain %q, got:\n%s", want, invite)
+			}
+		}
+		if strings.Contains(invite, "X-WR-CALNAME") {
+			t.Error("Invitations should not carry a calendar name")
+		}
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		invite := BuildICalendarInvite(ICalMethodCancel, event)
+		for _, want := range []string{"METHOD:CANCEL\r\n", "UID:booking-7@gassigeher\r\n", "STATUS:CANCELLED\r\n"} {
+			if !strings.Contains(invite, want) {
+				t.Errorf("Expected cancellation to contain %q, got:\n%s", want, invite)
+			}
+		}
+	})
+
+	t.Run("feeds have no sequence", func(t *testing.T) {
+		feed := BuildICalendar("Test", []ICalEvent{event})
+		if !strings.Contains(feed, "METHOD:PUBLISH\r\n") || strings.Contains(feed, "SEQUENCE:") || strings.Contains(feed, "ORGANIZER") {
+			t.Errorf("Unexpected feed output:\n%s", feed)
+		}
+	})
+}
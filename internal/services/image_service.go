@@ -2,6 +2,8 @@ package services
 
 import (
 	"bytes"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"image"
 	"image/jpeg"
@@ -138,24 +140,134 @@ func (s *ImageService) savePNG(img image.Image, path string) error {
 	return nil
 }
 
-// DeleteDogPhotos deletes both full-size and thumbnail photos for a dog
+// DeleteDogPhotos deletes all photos of a dog: the legacy single photo
+// (dog_{id}_full.jpg, dog_{id}_thumb.jpg) and every gallery photo (dog_{id}_*_full.jpg).
 // Does not return error if files don't exist (idempotent)
 func (s *ImageService) DeleteDogPhotos(dogID int) error {
+	matches, err := filepath.Glob(filepath.Join(s.uploadDir, "dogs", fmt.Sprintf("dog_%d_*.jpg", dogID)))
+	if err != nil {
+		return fmt.Errorf("failed to list dog photos: %w", err)
+	}
+
+	for _, path := range matches {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to delete dog photo: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ProcessDogGalleryPhoto processes an uploaded photo for a dog's gallery. Every photo gets
+// a random name, so replaced or reordered photos are never served from a stale cache.
+// Returns the relative paths (e.g., "dogs/dog_5_3f2a9c1b_full.jpg", "dogs/dog_5_3f2a9c1b_thumb.jpg")
+func (s *ImageService) ProcessDogGalleryPhoto(file multipart.File, dogID int) (fullPath, thumbPath string, err error) {
+	// Reset file pointer to beginning
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", "", fmt.Errorf("failed to seek file: %w", err)
+	}
+
+	// Decode the uploaded image
+	img, err := imaging.Decode(file)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	name, err := dogGalleryPhotoName(dogID)
+	if err != nil {
+		return "", "", err
+	}
+
+	return s.saveFullAndThumbnail(img, "dogs", name)
+}
+
+// CopyToDogGallery copies an already processed photo (e.g. from a walk report) into a
+// dog's gallery under a new name. Returns the relative paths of the copies.
+func (s *ImageService) CopyToDogGallery(srcFullPath, srcThumbPath string, dogID int) (fullPath, thumbPath string, err error) {
+	name, err := dogGalleryPhotoName(dogID)
+	if err != nil {
+		return "", "", err
+	}
+
 	dogsDir := filepath.Join(s.uploadDir, "dogs")
+	if err := os.MkdirAll(dogsDir, 0755); err != nil {
+		return "", "", fmt.Errorf("failed to create dogs directory: %w", err)
+	}
 
-	// Delete full-size image
-	fullPath := filepath.Join(dogsDir, fmt.Sprintf("dog_%d_full.jpg", dogID))
-	if err := os.Remove(fullPath); err != nil && !os.IsNotExist(err) {
-		return fmt.Errorf("failed to delete full-size image: %w", err)
+	fullPath = filepath.Join("dogs", name+"_full.jpg")
+	thumbPath = filepath.Join("dogs", name+"_thumb.jpg")
+
+	if err := s.copyFile(srcFullPath, fullPath); err != nil {
+		return "", "", fmt.Errorf("failed to copy full-size image: %w", err)
+	}
+	if err := s.copyFile(srcThumbPath, thumbPath); err != nil {
+		os.Remove(filepath.Join(s.uploadDir, fullPath))
+		return "", "", fmt.Errorf("failed to copy thumbnail: %w", err)
 	}
 
-	// Delete thumbnail
-	thumbPath := filepath.Join(dogsDir, fmt.Sprintf("dog_%d_thumb.jpg", dogID))
-	if err := os.Remove(thumbPath); err != nil && !os.IsNotExist(err) {
-		return fmt.Errorf("failed to delete thumbnail: %w", err)
+	return fullPath, thumbPath, nil
+}
+
+// DeleteDogPhoto deletes one gallery photo (both full and thumbnail)
+// Does not return error if files don't exist (idempotent)
+func (s *ImageService) DeleteDogPhoto(fullPath, thumbPath string) error {
+	return s.deletePhotoFiles(fullPath, thumbPath)
+}
+
+// dogGalleryPhotoName returns a new random base name for a gallery photo of a dog
+func dogGalleryPhotoName(dogID int) (string, error) {
+	token := make([]byte, 4)
+	if _, err := rand.Read(token); err != nil {
+		return "", fmt.Errorf("failed to generate photo name: %w", err)
 	}
+	return fmt.Sprintf("dog_%d_%s", dogID, hex.EncodeToString(token)), nil
+}
 
-	return nil
+// saveFullAndThumbnail saves the full-size and thumbnail JPEGs of an image as
+// {subdir}/{name}_full.jpg and {subdir}/{name}_thumb.jpg and returns their relative paths
+func (s *ImageService) saveFullAndThumbnail(img image.Image, subdir, name string) (fullPath, thumbPath string, err error) {
+	dir := filepath.Join(s.uploadDir, subdir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", "", fmt.Errorf("failed to create %s directory: %w", subdir, err)
+	}
+
+	fullFilePath := filepath.Join(dir, name+"_full.jpg")
+	if err := s.saveJPEG(s.resizeImage(img, MaxImageWidth, MaxImageHeight), fullFilePath, JPEGQuality); err != nil {
+		return "", "", fmt.Errorf("failed to save full-size image: %w", err)
+	}
+
+	thumbFilePath := filepath.Join(dir, name+"_thumb.jpg")
+	if err := s.saveJPEG(s.resizeImage(img, ThumbnailSize, ThumbnailSize), thumbFilePath, JPEGQuality); err != nil {
+		// Clean up full image if thumbnail fails
+		os.Remove(fullFilePath)
+		return "", "", fmt.Errorf("failed to save thumbnail: %w", err)
+	}
+
+	return filepath.Join(subdir, name+"_full.jpg"), filepath.Join(subdir, name+"_thumb.jpg"), nil
+}
+
+// copyFile copies a file between two paths relative to the upload directory
+func (s *ImageService) copyFile(srcRelPath, dstRelPath string) error {
+	srcPath, err := s.safeJoinPath(srcRelPath)
+	if err != nil {
+		return fmt.Errorf("invalid source path: %w", err)
+	}
+
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.Create(filepath.Join(s.uploadDir, dstRelPath))
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
+	}
+	return dst.Close()
 }
 
 // ResizeAndCompress is a helper function that resizes and compresses an image in memory
@@ -348,6 +460,12 @@ func (s *ImageService) safeJoinPath(relativePath string) (string, error) {
 // Does not return error if files don't exist (idempotent)
 // Includes path traversal protection to prevent deletion of files outside upload directory
 func (s *ImageService) DeleteWalkReportPhoto(fullPath, thumbPath string) error {
+	return s.deletePhotoFiles(fullPath, thumbPath)
+}
+
+// deletePhotoFiles deletes a full-size photo and its thumbnail, both given relative
+// to the upload directory
+func (s *ImageService) deletePhotoFiles(fullPath, thumbPath string) error {
 	// Validate and resolve full-size image path (with path traversal protection)
 	fullAbsPath, err := s.safeJoinPath(fullPath)
 	if err != nil {
@@ -9,6 +9,7 @@ import (
 	"mime/multipart"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/disintegration/imaging"
@@ -431,6 +432,60 @@ func TestImageService_DeleteDogPhotos(t *testing.T) {
 	}
 }
 
+// TestImageService_DogGallery tests gallery photo names, copies and cleanup of all photos of a dog
+func TestImageService_DogGallery(t *testing.T) {
+	tempDir := t.TempDir()
+	service := NewImageService(tempDir)
+
+	buf, err := createTestImage(1200, 900, "jpeg")
+	if err != nil {
+		t.Fatalf("Failed to create test image: %v", err)
+	}
+	data := buf.Bytes()
+
+	first, firstThumb, err := service.ProcessDogGalleryPhoto(createMultipartFile(bytes.NewBuffer(data)), 5)
+	if err != nil {
+		t.Fatalf("ProcessDogGalleryPhoto failed: %v", err)
+	}
+	second, _, err := service.ProcessDogGalleryPhoto(createMultipartFile(bytes.NewBuffer(data)), 5)
+	if err != nil {
+		t.Fatalf("ProcessDogGalleryPhoto failed: %v", err)
+	}
+	if first == second || !strings.HasPrefix(first, filepath.Join("dogs", "dog_5_")) || !strings.HasSuffix(firstThumb, "_thumb.jpg") {
+		t.Errorf("Expected unique gallery names, got %s and %s", first, second)
+	}
+
+	copied, copiedThumb, err := service.CopyToDogGallery(first, firstThumb, 5)
+	if err != nil {
+		t.Fatalf("CopyToDogGallery failed: %v", err)
+	}
+	if copied == first {
+		t.Error("Expected the copy to get a new name")
+	}
+	if _, _, err := service.CopyToDogGallery("../secret.jpg", firstThumb, 5); err == nil {
+		t.Error("Expected path traversal to be rejected")
+	}
+
+	// Photos of dog 55 must survive deleting dog 5
+	other, _, err := service.ProcessDogGalleryPhoto(createMultipartFile(bytes.NewBuffer(data)), 55)
+	if err != nil {
+		t.Fatalf("ProcessDogGalleryPhoto failed: %v", err)
+	}
+	os.WriteFile(filepath.Join(tempDir, "dogs", "dog_5_full.jpg"), []byte("legacy"), 0644)
+
+	if err := service.DeleteDogPhotos(5); err != nil {
+		t.Fatalf("DeleteDogPhotos failed: %v", err)
+	}
+	for _, path := range []string{first, second, copied, copiedThumb, filepath.Join("dogs", "dog_5_full.jpg")} {
+		if _, err := os.Stat(filepath.Join(tempDir, path)); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be deleted", path)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, other)); err != nil {
+		t.Errorf("Photo of another dog was deleted: %v", err)
+	}
+}
+
 // TestImageService_ProcessDogPhoto_InvalidInput tests error cases
 func TestImageService_ProcessDogPhoto_InvalidInput(t *testing.T) {
 	tempDir := t.TempDir()
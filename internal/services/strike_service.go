@@ -0,0 +1,120 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tranmh/gassigeher/internal/models"
+	"github.com/tranmh/gassigeher/internal/repository"
+)
+
+// StrikeService records strikes for no-shows and late cancellations and decides
+// when a user is restricted from booking.
+// Only unforgiven strikes within strike_window_days count. Reaching
+// strike_warning_threshold sends a warning email; reaching strike_restriction_threshold
+// blocks new bookings until enough strikes leave the window or are forgiven.
+// A threshold of 0 disables it.
+type StrikeService struct {
+	strikeRepo   *repository.StrikeRepository
+	userRepo     *repository.UserRepository
+	settingsRepo *repository.SettingsRepository
+	emailService *EmailService
+}
+
+// NewStrikeService creates a new strike service
+func NewStrikeService(
+	strikeRepo *repository.StrikeRepository,
+	userRepo *repository.UserRepository,
+	settingsRepo *repository.SettingsRepository,
+	emailService *EmailService,
+) *StrikeService {
+	return &StrikeService{
+		strikeRepo:   strikeRepo,
+		userRepo:     userRepo,
+		settingsRepo: settingsRepo,
+		emailService: emailService,
+	}
+}
+
+// Summary returns the user's strikes within the strike window and whether they are restricted
+func (s *StrikeService) Summary(userID int, now time.Time) (*models.StrikeSummary, error) {
+	summary := &models.StrikeSummary{
+		WindowDays:           s.settingsRepo.GetInt("strike_window_days", 90),
+		WarningThreshold:     s.settingsRepo.GetInt("strike_warning_threshold", 2),
+		RestrictionThreshold: s.settingsRepo.GetInt("strike_restriction_threshold", 3),
+	}
+
+	window := time.Duration(summary.WindowDays) * 24 * time.Hour
+	strikes, err := s.strikeRepo.FindByUserSince(userID, now.Add(-window))
+	if err != nil {
+		return nil, err
+	}
+	summary.Strikes = strikes
+
+	active := []*models.UserStrike{}
+	for _, strike := range strikes {
+		if strike.IsActive() {
+			active = append(active, strike)
+		}
+	}
+	summary.ActiveCount = len(active)
+
+	if summary.RestrictionThreshold > 0 && summary.ActiveCount >= summary.RestrictionThreshold {
+		summary.IsRestricted = true
+		// The restriction ends when the strike that keeps the count at the threshold leaves the window
+		until := active[summary.ActiveCount-summary.RestrictionThreshold].CreatedAt.Add(window)
+		summary.RestrictedUntil = &until
+	}
+
+	return summary, nil
+}
+
+// AddStrike records a strike and emails the user when it reaches the warning or restriction threshold
+func (s *StrikeService) AddStrike(userID int, bookingID *int, reason string, now time.Time) (*models.StrikeSummary, error) {
+	strike := &models.UserStrike{
+		UserID:    userID,
+		BookingID: bookingID,
+		Reason:    reason,
+		CreatedAt: now,
+	}
+	if err := s.strikeRepo.Create(strike); err != nil {
+		return nil, err
+	}
+
+	summary, err := s.Summary(userID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	// Thresholds are compared with == so each email goes out once when the threshold is reached
+	reachedRestriction := summary.IsRestricted && summary.ActiveCount == summary.RestrictionThreshold
+	reachedWarning := summary.WarningThreshold > 0 && summary.ActiveCount == summary.WarningThreshold
+	if (reachedRestriction || reachedWarning) && s.emailService != nil {
+		user, err := s.userRepo.FindByID(userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+		if user != nil && user.Email != nil {
+			restrictedUntil := ""
+			if reachedRestriction {
+				restrictedUntil = summary.RestrictedUntil.Format("02.01.2006")
+			}
+			go s.emailService.SendStrikeNotification(*user.Email, user.FirstName, summary.ActiveCount, summary.WindowDays, restrictedUntil)
+		}
+	}
+
+	return summary, nil
+}
+
+// Forgive marks one of the user's strikes as forgiven by an admin
+func (s *StrikeService) Forgive(userID, strikeID, adminID int, reason *string) error {
+	strike, err := s.strikeRepo.FindByID(strikeID)
+	if err != nil {
+		return err
+	}
+	if strike == nil || strike.UserID != userID {
+		return fmt.Errorf("strike not found or already forgiven")
+	}
+
+	return s.strikeRepo.Forgive(strikeID, adminID, reason)
+}
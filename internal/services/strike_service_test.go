@@ -0,0 +1,99 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tranmh/gassigeher/internal/models"
+	"github.com/tranmh/gassigeher/internal/repository"
+	"github.com/tranmh/gassigeher/internal/testutil"
+)
+
+// TestStrikeService_Restriction tests that reaching the threshold restricts the user until the window passes
+func TestStrikeService_Restriction(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+
+	service := NewStrikeService(repository.NewStrikeRepository(db), repository.NewUserRepository(db),
+		repository.NewSettingsRepository(db), nil)
+
+	userID := testutil.SeedTestUser(t, db, "walker@example.com", "Walker", "green")
+	otherID := testutil.SeedTestUser(t, db, "other@example.com", "Other", "green")
+
+	now := time.Date(2030, 3, 1, 12, 0, 0, 0, time.UTC)
+	first := now.AddDate(0, 0, -30)
+	second := now.AddDate(0, 0, -20)
+
+	for _, at := range []time.Time{first, second} {
+		if _, err := service.AddStrike(userID, nil, models.StrikeReasonNoShow, at); err != nil {
+			t.Fatalf("AddStrike() failed: %v", err)
+		}
+	}
+
+	t.Run("below threshold", func(t *testing.T) {
+		summary, err := service.Summary(userID, now)
+		if err != nil {
+			t.Fatalf("Summary() failed: %v", err)
+		}
+		if summary.ActiveCount != 2 || summary.IsRestricted {
+			t.Errorf("Expected 2 strikes and no restriction, got %d (restricted %v)", summary.ActiveCount, summary.IsRestricted)
+		}
+	})
+
+	t.Run("restricted at threshold", func(t *testing.T) {
+		summary, err := service.AddStrike(userID, nil, models.StrikeReasonLateCancellation, now)
+		if err != nil {
+			t.Fatalf("AddStrike() failed: %v", err)
+		}
+		if !summary.IsRestricted {
+			t.Fatal("Expected user to be restricted after 3 strikes")
+		}
+		// Restriction ends when the oldest strike leaves the 90 day window
+		if want := first.AddDate(0, 0, 90); !summary.RestrictedUntil.Equal(want) {
+			t.Errorf("Expected restriction until %v, got %v", want, summary.RestrictedUntil)
+		}
+	})
+
+	t.Run("restriction ends when the window passes", func(t *testing.T) {
+		summary, err := service.Summary(userID, first.AddDate(0, 0, 91))
+		if err != nil {
+			t.Fatalf("Summary() failed: %v", err)
+		}
+		if summary.IsRestricted || summary.ActiveCount != 2 {
+			t.Errorf("Expected 2 strikes and no restriction, got %d (restricted %v)", summary.ActiveCount, summary.IsRestricted)
+		}
+	})
+
+	t.Run("forgiving lifts the restriction", func(t *testing.T) {
+		summary, _ := service.Summary(userID, now)
+		strikeID := summary.Strikes[0].ID
+
+		if err := service.Forgive(otherID, strikeID, otherID, nil); err == nil {
+			t.Error("Expected error when forgiving another user's strike")
+		}
+		if err := service.Forgive(userID, strikeID, otherID, nil); err != nil {
+			t.Fatalf("Forgive() failed: %v", err)
+		}
+
+		summary, err := service.Summary(userID, now)
+		if err != nil {
+			t.Fatalf("Summary() failed: %v", err)
+		}
+		if summary.IsRestricted || summary.ActiveCount != 2 || len(summary.Strikes) != 3 {
+			t.Errorf("Expected 2 active of 3 strikes and no restriction, got %d of %d (restricted %v)",
+				summary.ActiveCount, len(summary.Strikes), summary.IsRestricted)
+		}
+	})
+
+	t.Run("threshold of 0 disables the restriction", func(t *testing.T) {
+		db.Exec("UPDATE system_settings SET value = '0' WHERE key = 'strike_restriction_threshold'")
+		service.AddStrike(userID, nil, models.StrikeReasonNoShow, now)
+
+		summary, err := service.Summary(userID, now)
+		if err != nil {
+			t.Fatalf("Summary() failed: %v", err)
+		}
+		if summary.IsRestricted {
+			t.Error("Expected no restriction when threshold is 0")
+		}
+	})
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/tranmh/gassigeher/internal/models"
+	"github.com/tranmh/gassigeher/internal/repository"
+)
+
+// WaitlistService offers freed dog slots to waitlisted users, first come first served.
+// An offer reserves the slot for the offered user for waitlist_claim_hours; unclaimed
+// offers expire and move on to the next user in line.
+type WaitlistService struct {
+	waitlistRepo *repository.WaitlistRepository
+	bookingRepo  *repository.BookingRepository
+	userRepo     *repository.UserRepository
+	dogRepo      *repository.DogRepository
+	settingsRepo *repository.SettingsRepository
+	emailService *EmailService
+}
+
+// NewWaitlistService creates a new waitlist service
+func NewWaitlistService(
+	waitlistRepo *repository.WaitlistRepository,
+	bookingRepo *repository.BookingRepository,
+	userRepo *repository.UserRepository,
+	dogRepo *repository.DogRepository,
+	settingsRepo *repository.SettingsRepository,
+	emailService *EmailService,
+) *WaitlistService {
+	return &WaitlistService{
+		waitlistRepo: waitlistRepo,
+		bookingRepo:  bookingRepo,
+		userRepo:     userRepo,
+		dogRepo:      dogRepo,
+		settingsRepo: settingsRepo,
+		emailService: emailService,
+	}
+}
+
+// OfferSlot offers a freed slot to the next waiting user. Users wait for the time they asked for,
+// which may lie anywhere within the walk duration plus buffer of the freed booking, the same
+// window that made their slot taken when they joined.
+// Entries whose own slot is in the past, still taken or already reserved by an active offer are skipped.
+// Returns the offered entry, or nil if nobody was offered the slot.
+func (s *WaitlistService) OfferSlot(dogID int, date, scheduledTime string, now time.Time) (*models.WaitlistEntry, error) {
+	if _, err := time.ParseInLocation("2006-01-02 15:04", date+" "+scheduledTime, time.Local); err != nil {
+		return nil, fmt.Errorf("invalid slot: %w", err)
+	}
+
+	dog, err := s.dogRepo.FindByID(dogID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dog: %w", err)
+	}
+	if dog == nil {
+		return nil, nil
+	}
+
+	duration := dog.GetWalkDuration()
+	buffer := s.settingsRepo.GetInt("booking_buffer_minutes", 0)
+
+	entries, err := s.waitlistRepo.FindWaiting(dogID, date, scheduledTime, duration, buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		slotStart, err := time.ParseInLocation("2006-01-02 15:04", entry.Date+" "+entry.ScheduledTime, time.Local)
+		if err != nil || !slotStart.After(now) {
+			continue
+		}
+
+		offer, err := s.waitlistRepo.FindActiveOffer(dogID, date, entry.ScheduledTime, duration, buffer, now)
+		if err != nil {
+			return nil, err
+		}
+		if offer != nil {
+			continue
+		}
+
+		conflict, err := s.bookingRepo.FindConflictingBooking(dogID, date, entry.ScheduledTime, duration, buffer, 0)
+		if err != nil {
+			return nil, err
+		}
+		if conflict != nil {
+			continue
+		}
+
+		// Skip users who can no longer book (deactivated or deleted) and offer to the next one
+		user, err := s.userRepo.FindByID(entry.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+		if user == nil || !user.IsActive || user.IsDeleted {
+			if err := s.waitlistRepo.UpdateStatus(entry.ID, "expired"); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		expiresAt := now.Add(time.Duration(s.settingsRepo.GetInt("waitlist_claim_hours", 2)) * time.Hour)
+		// Never reserve the slot beyond its start
+		if expiresAt.After(slotStart) {
+			expiresAt = slotStart
+		}
+
+		if err := s.waitlistRepo.MarkOffered(entry.ID, now, expiresAt); err != nil {
+			return nil, err
+		}
+		entry.Status = "offered"
+		entry.OfferedAt = &now
+		entry.OfferExpiresAt = &expiresAt
+
+		if user.Email != nil && s.emailService != nil {
+			go s.emailService.SendWaitlistSlotAvailable(*user.Email, user.FirstName, dog.Name, date, entry.ScheduledTime, expiresAt.Format("02.01.2006 15:04"))
+		}
+
+		return entry, nil
+	}
+
+	return nil, nil
+}
+
+// CheckReservation reports whether a walk of durationMinutes at the slot would overlap a slot
+// reserved for a different user by an active offer
+func (s *WaitlistService) CheckReservation(userID, dogID int, date, scheduledTime string, durationMinutes int, now time.Time) (bool, error) {
+	offer, err := s.waitlistRepo.FindActiveOffer(dogID, date, scheduledTime, durationMinutes, s.settingsRepo.GetInt("booking_buffer_minutes", 0), now)
+	if err != nil {
+		return false, err
+	}
+
+	return offer != nil && offer.UserID != userID, nil
+}
+
+// Claim marks the user's waitlist entries for a slot as claimed once they booked it
+func (s *WaitlistService) Claim(userID, dogID int, date, scheduledTime string) error {
+	return s.waitlistRepo.MarkClaimed(userID, dogID, date, scheduledTime)
+}
+
+// ExpireOffers expires unclaimed offers and passes each slot on to the next waiting user.
+// Returns the number of expired offers.
+func (s *WaitlistService) ExpireOffers(now time.Time) (int, error) {
+	if _, err := s.waitlistRepo.ExpirePast(now.Format("2006-01-02")); err != nil {
+		return 0, err
+	}
+
+	expired, err := s.waitlistRepo.FindExpiredOffers(now)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, entry := range expired {
+		if err := s.waitlistRepo.UpdateStatus(entry.ID, "expired"); err != nil {
+			return 0, err
+		}
+		if _, err := s.OfferSlot(entry.DogID, entry.Date, entry.ScheduledTime, now); err != nil {
+			log.Printf("Failed to offer waitlist slot for dog %d on %s %s: %v", entry.DogID, entry.Date, entry.ScheduledTime, err)
+		}
+	}
+
+	return len(expired), nil
+}
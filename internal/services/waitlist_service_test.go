@@ -0,0 +1,175 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tranmh/gassigeher/internal/models"
+	"github.com/tranmh/gassigeher/internal/repository"
+	"github.com/tranmh/gassigeher/internal/testutil"
+)
+
+// TestWaitlistService_OfferAndExpire tests that a freed slot is offered in order and passed on after expiry
+func TestWaitlistService_OfferAndExpire(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+
+	waitlistRepo := repository.NewWaitlistRepository(db)
+	bookingRepo := repository.NewBookingRepository(db)
+	service := NewWaitlistService(waitlistRepo, bookingRepo, repository.NewUserRepository(db),
+		repository.NewDogRepository(db), repository.NewSettingsRepository(db), nil)
+
+	ownerID := testutil.SeedTestUser(t, db, "owner@example.com", "Owner", "green")
+	firstID := testutil.SeedTestUser(t, db, "first@example.com", "First", "green")
+	secondID := testutil.SeedTestUser(t, db, "second@example.com", "Second", "green")
+	dogID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+
+	bookingID := testutil.SeedTestBooking(t, db, ownerID, dogID, "2030-01-08", "09:00", "scheduled")
+
+	for _, userID := range []int{firstID, secondID} {
+		entry := &models.WaitlistEntry{UserID: userID, DogID: dogID, Date: "2030-01-08", ScheduledTime: "09:00"}
+		if err := waitlistRepo.Create(entry); err != nil {
+			t.Fatalf("Create() failed: %v", err)
+		}
+	}
+
+	now := time.Date(2030, 1, 7, 10, 0, 0, 0, time.Local)
+
+	t.Run("no offer while the slot is taken", func(t *testing.T) {
+		offer, err := service.OfferSlot(dogID, "2030-01-08", "09:00", now)
+		if err != nil || offer != nil {
+			t.Errorf("Expected no offer, got %+v (err %v)", offer, err)
+		}
+	})
+
+	if err := bookingRepo.Cancel(bookingID, nil); err != nil {
+		t.Fatalf("Cancel() failed: %v", err)
+	}
+
+	t.Run("offer to first waiting user", func(t *testing.T) {
+		offer, err := service.OfferSlot(dogID, "2030-01-08", "09:00", now)
+		if err != nil {
+			t.Fatalf("OfferSlot() failed: %v", err)
+		}
+		if offer == nil || offer.UserID != firstID {
+			t.Fatalf("Expected offer to first user, got %+v", offer)
+		}
+		// Default claim window is 2 hours
+		if offer.OfferExpiresAt == nil || !offer.OfferExpiresAt.Equal(now.Add(2*time.Hour)) {
+			t.Errorf("Expected offer to expire at %v, got %v", now.Add(2*time.Hour), offer.OfferExpiresAt)
+		}
+
+		again, err := service.OfferSlot(dogID, "2030-01-08", "09:00", now)
+		if err != nil || again != nil {
+			t.Errorf("Expected no second offer while one is active, got %+v (err %v)", again, err)
+		}
+	})
+
+	t.Run("slot is reserved for the offered user", func(t *testing.T) {
+		reserved, err := service.CheckReservation(secondID, dogID, "2030-01-08", "09:00", 60, now)
+		if err != nil || !reserved {
+			t.Errorf("Expected slot to be reserved for another user (err %v)", err)
+		}
+		reserved, err = service.CheckReservation(secondID, dogID, "2030-01-08", "09:30", 60, now)
+		if err != nil || !reserved {
+			t.Errorf("Expected an overlapping slot to be reserved for another user (err %v)", err)
+		}
+		reserved, err = service.CheckReservation(firstID, dogID, "2030-01-08", "09:00", 60, now)
+		if err != nil || reserved {
+			t.Errorf("Expected slot to be bookable by the offered user (err %v)", err)
+		}
+	})
+
+	t.Run("expired offer moves to next user", func(t *testing.T) {
+		count, err := service.ExpireOffers(now.Add(3 * time.Hour))
+		if err != nil {
+			t.Fatalf("ExpireOffers() failed: %v", err)
+		}
+		if count != 1 {
+			t.Errorf("Expected 1 expired offer, got %d", count)
+		}
+
+		offer, err := waitlistRepo.FindActiveOffer(dogID, "2030-01-08", "09:00", 60, 0, now.Add(3*time.Hour))
+		if err != nil || offer == nil || offer.UserID != secondID {
+			t.Fatalf("Expected offer to second user, got %+v (err %v)", offer, err)
+		}
+	})
+
+	t.Run("cancelled slot can be rebooked", func(t *testing.T) {
+		booking := &models.Booking{UserID: secondID, DogID: dogID, Date: "2030-01-08", ScheduledTime: "09:00", ApprovalStatus: "approved"}
+		if err := bookingRepo.Create(booking); err != nil {
+			t.Fatalf("Create() on cancelled slot failed: %v", err)
+		}
+		if err := service.Claim(secondID, dogID, "2030-01-08", "09:00"); err != nil {
+			t.Fatalf("Claim() failed: %v", err)
+		}
+
+		reserved, _ := service.CheckReservation(firstID, dogID, "2030-01-08", "09:00", 60, now.Add(3*time.Hour))
+		if reserved {
+			t.Error("Expected no reservation after the slot was claimed")
+		}
+	})
+}
+
+// TestWaitlistService_OfferSkipsPastSlots tests that slots in the past are never offered
+func TestWaitlistService_OfferSkipsPastSlots(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+
+	waitlistRepo := repository.NewWaitlistRepository(db)
+	service := NewWaitlistService(waitlistRepo, repository.NewBookingRepository(db), repository.NewUserRepository(db),
+		repository.NewDogRepository(db), repository.NewSettingsRepository(db), nil)
+
+	userID := testutil.SeedTestUser(t, db, "first@example.com", "First", "green")
+	dogID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+
+	entry := &models.WaitlistEntry{UserID: userID, DogID: dogID, Date: "2030-01-08", ScheduledTime: "09:00"}
+	if err := waitlistRepo.Create(entry); err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+
+	offer, err := service.OfferSlot(dogID, "2030-01-08", "09:00", time.Date(2030, 1, 8, 9, 30, 0, 0, time.Local))
+	if err != nil || offer != nil {
+		t.Errorf("Expected no offer for a past slot, got %+v (err %v)", offer, err)
+	}
+}
+
+// TestWaitlistService_OfferOverlappingSlot tests that users waiting for a time the cancelled walk
+// blocked are offered their own slot, and that a slot still blocked by another walk is skipped
+func TestWaitlistService_OfferOverlappingSlot(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+
+	waitlistRepo := repository.NewWaitlistRepository(db)
+	bookingRepo := repository.NewBookingRepository(db)
+	service := NewWaitlistService(waitlistRepo, bookingRepo, repository.NewUserRepository(db),
+		repository.NewDogRepository(db), repository.NewSettingsRepository(db), nil)
+
+	ownerID := testutil.SeedTestUser(t, db, "owner@example.com", "Owner", "green")
+	firstID := testutil.SeedTestUser(t, db, "first@example.com", "First", "green")
+	secondID := testutil.SeedTestUser(t, db, "second@example.com", "Second", "green")
+	dogID := testutil.SeedTestDog(t, db, "Bella", "Labrador", "green")
+
+	// 09:00 and 10:30 are booked; the first user waits for 09:45 (blocked by both walks),
+	// the second for 09:20 (blocked by the 09:00 walk only)
+	bookingID := testutil.SeedTestBooking(t, db, ownerID, dogID, "2030-01-08", "09:00", "scheduled")
+	testutil.SeedTestBooking(t, db, ownerID, dogID, "2030-01-08", "10:30", "scheduled")
+	for _, entry := range []*models.WaitlistEntry{
+		{UserID: firstID, DogID: dogID, Date: "2030-01-08", ScheduledTime: "09:45"},
+		{UserID: secondID, DogID: dogID, Date: "2030-01-08", ScheduledTime: "09:20"},
+	} {
+		if err := waitlistRepo.Create(entry); err != nil {
+			t.Fatalf("Create() failed: %v", err)
+		}
+	}
+
+	if err := bookingRepo.Cancel(bookingID, nil); err != nil {
+		t.Fatalf("Cancel() failed: %v", err)
+	}
+
+	now := time.Date(2030, 1, 7, 10, 0, 0, 0, time.Local)
+	offer, err := service.OfferSlot(dogID, "2030-01-08", "09:00", now)
+	if err != nil {
+		t.Fatalf("OfferSlot() failed: %v", err)
+	}
+	if offer == nil || offer.UserID != secondID || offer.ScheduledTime != "09:20" {
+		t.Fatalf("Expected the 09:20 slot to be offered to the second user, got %+v", offer)
+	}
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"testing"
+)
+
+// TestXLSXWriter tests that the workbook is a valid package with the written cells
+func TestXLSXWriter(t *testing.T) {
+	var buf bytes.Buffer
+	xw, err := NewXLSXWriter(&buf, "Buchungen & Co")
+	if err != nil {
+		t.Fatalf("NewXLSXWriter() failed: %v", err)
+	}
+	xw.WriteRow([]string{"Hund", "Notiz"})
+	xw.WriteRow([]string{"Bella", "<Tierarzt> & \"Pause\"\nZeile 2"})
+	if err := xw.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("Workbook is not a zip archive: %v", err)
+	}
+	parts := map[string][]byte{}
+	for _, f := range zr.File {
+		rc, _ := f.Open()
+		parts[f.Name], _ = io.ReadAll(rc)
+		rc.Close()
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/worksheets/sheet1.xml"} {
+		if _, ok := parts[name]; !ok {
+			t.Errorf("Missing part %s", name)
+		}
+	}
+
+	var workbook struct {
+		Sheets []struct {
+			Name string `xml:"name,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err := xml.Unmarshal(parts["xl/workbook.xml"], &workbook); err != nil || len(workbook.Sheets) != 1 || workbook.Sheets[0].Name != "Buchungen & Co" {
+		t.Errorf("Unexpected workbook %+v (%v)", workbook, err)
+	}
+
+	var sheet struct {
+		Rows []struct {
+			Cells []struct {
+				Ref  string `xml:"r,attr"`
+				Text string `xml:"is>t"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	if err := xml.Unmarshal(parts["xl/worksheets/sheet1.xml"], &sheet); err != nil {
+		t.Fatalf("Worksheet is not valid XML: %v", err)
+	}
+	if len(sheet.Rows) != 2 {
+		t.Fatalf("Expected 2 rows, got %d", len(sheet.Rows))
+	}
+	cell := sheet.Rows[1].Cells[1]
+	if cell.Ref != "B2" || cell.Text != "<Tierarzt> & \"Pause\"\nZeile 2" {
+		t.Errorf("Unexpected cell %+v", cell)
+	}
+}
+
+// TestXLSXColumn tests column letters
+func TestXLSXColumn(t *testing.T) {
+	for index, want := range map[int]string{0: "A", 11: "L", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"} {
+		if got := xlsxColumn(index); got != want {
+			t.Errorf("xlsxColumn(%d) = %s, want %s", index, got, want)
+		}
+	}
+}
@@ -89,6 +89,31 @@ class API {
         }
     }
 
+    // Download a file (authenticated GET) and save it under the server-provided name
+    async downloadFile(endpoint, fallbackName) {
+        const headers = {};
+
+        if (this.token) {
+            headers['Authorization'] = `Bearer ${this.token}`;
+        }
+
+        const response = await fetch(`${this.baseURL}${endpoint}`, { headers });
+        if (!response.ok) {
+            const responseData = await response.json().catch(() => ({}));
+            throw new Error(responseData.error || 'Download failed');
+        }
+
+        const match = /filename="([^"]+)"/.exec(response.headers.get('Content-Disposition') || '');
+        const url = URL.createObjectURL(await response.blob());
+        const link = document.createElement('a');
+        link.href = url;
+        link.download = match ? match[1] : fallbackName;
+        document.body.appendChild(link);
+        link.click();
+        link.remove();
+        URL.revokeObjectURL(url);
+    }
+
     // AUTH ENDPOINTS
 
     async register(data) {
@@ -148,6 +173,14 @@ class API {
         return this.uploadFile('/users/me/photo', formData);
     }
 
+    async getMyCalendar() {
+        return this.request('GET', '/users/me/calendar');
+    }
+
+    async regenerateMyCalendar() {
+        return this.request('POST', '/users/me/calendar/regenerate');
+    }
+
     // DOG ENDPOINTS
 
     async getDogs(filters = {}) {
@@ -177,12 +210,89 @@ class API {
         return this.request('DELETE', endpoint);
     }
 
+    async changeDogStatus(dogId, data) {
+        return this.request('PUT', `/dogs/${dogId}/status`, data);
+    }
+
+    async restoreDog(dogId, data) {
+        return this.request('POST', `/dogs/${dogId}/restore`, data);
+    }
+
+    async getDogStatusHistory(dogId) {
+        return this.request('GET', `/dogs/${dogId}/status-history`);
+    }
+
     async uploadDogPhoto(dogId, file) {
         const formData = new FormData();
         formData.append('photo', file);
         return this.uploadFile(`/dogs/${dogId}/photo`, formData);
     }
 
+    async addDogPhotos(dogId, files) {
+        const formData = new FormData();
+        for (const file of files) {
+            formData.append('photos', file);
+        }
+        return this.uploadFile(`/dogs/${dogId}/photos`, formData);
+    }
+
+    async exportDogs(format) {
+        return this.downloadFile(`/admin/dogs/export?format=${format}`, `hunde.${format}`);
+    }
+
+    async importDogs(file, photosZip = null, dryRun = false) {
+        const formData = new FormData();
+        formData.append('file', file);
+        if (photosZip) {
+            formData.append('photos', photosZip);
+        }
+        return this.uploadFile(`/admin/dogs/import${dryRun ? '?dry_run=true' : ''}`, formData);
+    }
+
+    async reorderDogPhotos(dogId, photoIds) {
+        return this.request('PUT', `/dogs/${dogId}/photos/order`, { photo_ids: photoIds });
+    }
+
+    async setDogCoverPhoto(dogId, photoId) {
+        return this.request('PUT', `/dogs/${dogId}/photos/${photoId}/cover`);
+    }
+
+    async deleteDogPhoto(dogId, photoId) {
+        return this.request('DELETE', `/dogs/${dogId}/photos/${photoId}`);
+    }
+
+    async promoteWalkReportPhoto(dogId, walkReportPhotoId) {
+        return this.request('POST', `/dogs/${dogId}/photos/from-walk-report`, { walk_report_photo_id: walkReportPhotoId });
+    }
+
+    async getDogCareEntries(dogId) {
+        return this.request('GET', `/dogs/${dogId}/care`);
+    }
+
+    async createDogCareEntry(dogId, data) {
+        return this.request('POST', `/dogs/${dogId}/care`, data);
+    }
+
+    async updateDogCareEntry(dogId, entryId, data) {
+        return this.request('PUT', `/dogs/${dogId}/care/${entryId}`, data);
+    }
+
+    async deleteDogCareEntry(dogId, entryId) {
+        return this.request('DELETE', `/dogs/${dogId}/care/${entryId}`);
+    }
+
+    async getWalkerRules(dogId) {
+        return this.request('GET', `/dogs/${dogId}/walker-rules`);
+    }
+
+    async addWalkerRule(dogId, data) {
+        return this.request('POST', `/dogs/${dogId}/walker-rules`, data);
+    }
+
+    async removeWalkerRule(dogId, ruleId) {
+        return this.request('DELETE', `/dogs/${dogId}/walker-rules/${ruleId}`);
+    }
+
     async toggleDogAvailability(dogId, isAvailable, reason = null) {
         return this.request('PUT', `/dogs/${dogId}/availability`, {
             is_available: isAvailable,
@@ -190,12 +300,26 @@ class API {
         });
     }
 
+    // data: unavailable_reason, unavailable_from, unavailable_until (optional), cancel_bookings
+    async setDogUnavailability(dogId, data) {
+        return this.request('PUT', `/dogs/${dogId}/availability`, {
+            is_available: false,
+            ...data,
+        });
+    }
+
     async setDogFeatured(dogId, isFeatured) {
         return this.request('PUT', `/dogs/${dogId}/featured`, {
             is_featured: isFeatured,
         });
     }
 
+    async setDogPublic(dogId, isPublic) {
+        return this.request('PUT', `/dogs/${dogId}/public`, {
+            is_public: isPublic,
+        });
+    }
+
     async getFeaturedDogs() {
         return this.request('GET', '/dogs/featured');
     }
@@ -216,6 +340,15 @@ class API {
         return this.request('GET', `/bookings/${id}`);
     }
 
+    async getBookingHistory(id) {
+        return this.request('GET', `/bookings/${id}/history`);
+    }
+
+    async exportBookings(format, filters = {}) {
+        const params = new URLSearchParams({ ...filters, format });
+        return this.downloadFile(`/admin/bookings/export?${params.toString()}`, `buchungen.${format}`);
+    }
+
     async cancelBooking(id, reason = null) {
         return this.request('PUT', `/bookings/${id}/cancel`, { reason });
     }
@@ -125,7 +125,7 @@ func cleanMySQLTestDB(t *testing.T, db *sql.DB) {
 	_, _ = db.Exec("SET FOREIGN_KEY_CHECKS = 0")
 
 	// Drop tables if they exist
-	tables := []string{"bookings", "blocked_dates", "experience_requests",
+	tables := []string{"dog_pair_walks", "booking_waitlist", "user_strikes", "booking_transfers", "calendar_tokens", "booking_events", "dog_photos", "dog_care_entries", "dog_walker_rules", "dog_status_events", "bookings", "booking_series", "blocked_dates", "experience_requests",
 		"reactivation_requests", "dogs", "users", "system_settings", "schema_migrations"}
 	for _, table := range tables {
 		_, _ = db.Exec("DROP TABLE IF EXISTS " + table)
@@ -138,7 +138,7 @@ func cleanMySQLTestDB(t *testing.T, db *sql.DB) {
 // cleanPostgreSQLTestDB drops all tables in the test database
 func cleanPostgreSQLTestDB(t *testing.T, db *sql.DB) {
 	// Drop tables if they exist (CASCADE to handle foreign keys)
-	tables := []string{"bookings", "blocked_dates", "experience_requests",
+	tables := []string{"dog_pair_walks", "booking_waitlist", "user_strikes", "booking_transfers", "calendar_tokens", "booking_events", "dog_photos", "dog_care_entries", "dog_walker_rules", "dog_status_events", "bookings", "booking_series", "blocked_dates", "experience_requests",
 		"reactivation_requests", "dogs", "users", "system_settings", "schema_migrations"}
 	for _, table := range tables {
 		_, _ = db.Exec("DROP TABLE IF EXISTS " + table + " CASCADE")